@@ -0,0 +1,2 @@
+alter table `english_word_problem` add column `ngsl_rank` int not null default 0;
+alter table `english_word_problem` add column `cefr_level` varchar(2) character set ascii not null default '';
@@ -0,0 +1,12 @@
+create table `translation_cache` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`cache_key` varchar(300) character set ascii not null
+,`text` varchar(100) not null
+,`not_found` tinyint(1) not null
+,`translations` text not null
+,`expired_at` datetime not null
+,primary key(`id`)
+,unique(`cache_key`)
+,index(`text`)
+);
@@ -0,0 +1,18 @@
+create table `tatoeba_import_job` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp on update current_timestamp
+,`created_by` int not null
+,`organization_id` int not null
+,`kind` varchar(20) character set ascii not null
+,`file_name` varchar(200) not null
+,`file_size` bigint not null
+,`received_size` bigint not null default 0
+,`processed_lines` int not null default 0
+,`status` varchar(20) character set ascii not null
+,`error_message` varchar(400) not null default ''
+,primary key(`id`)
+,index(`organization_id`, `status`)
+,foreign key(`created_by`) references `app_user`(`id`) on delete cascade
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+);
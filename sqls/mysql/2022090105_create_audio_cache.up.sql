@@ -0,0 +1,10 @@
+create table `audio_cache` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`lang2` varchar(2) character set ascii not null
+,`text` varchar(400) not null
+,`audio_id` int not null
+,primary key(`id`)
+,unique(`lang2`, `text`)
+,index(`audio_id`)
+);
@@ -0,0 +1,18 @@
+create table `audio_generation_job` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp on update current_timestamp
+,`created_by` int not null
+,`organization_id` int not null
+,`workbook_id` int not null
+,`total_problems` int not null
+,`processed_problems` int not null default 0
+,`generated_audios` int not null default 0
+,`status` varchar(20) character set ascii not null
+,`error_message` varchar(400) not null default ''
+,primary key(`id`)
+,index(`workbook_id`, `status`)
+,foreign key(`created_by`) references `app_user`(`id`) on delete cascade
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`workbook_id`) references `workbook`(`id`) on delete cascade
+);
@@ -0,0 +1,17 @@
+create table `refresh_token` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp on update current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`token_id` varchar(32) character set ascii not null
+,`family_id` varchar(32) character set ascii not null
+,`used` tinyint(1) not null default 0
+,`revoked` tinyint(1) not null default 0
+,`expires_at` datetime not null
+,primary key(`id`)
+,unique(`token_id`)
+,index(`family_id`)
+,index(`organization_id`, `app_user_id`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+);
@@ -0,0 +1,13 @@
+create table `oidc_auth_request` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`state` varchar(64) character set ascii not null
+,`provider_name` varchar(30) character set ascii not null
+,`organization_name` varchar(20) character set ascii not null
+,`nonce` varchar(64) character set ascii not null
+,`code_verifier` varchar(128) character set ascii not null
+,`expires_at` datetime not null
+,primary key(`id`)
+,unique(`state`)
+,index(`expires_at`)
+);
@@ -0,0 +1,23 @@
+create table `personal_access_token` (
+ `id` int auto_increment
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`name` varchar(40) not null
+,`token_prefix` varchar(12) character set ascii not null
+,`hashed_token` varchar(64) character set ascii not null
+,`scope` varchar(10) character set ascii not null
+,`expires_at` datetime
+,`last_used_at` datetime
+,primary key(`id`)
+,unique(`hashed_token`)
+,index(`organization_id`, `app_user_id`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
+create table `personal_access_token_workbook` (
+ `personal_access_token_id` int not null
+,`workbook_id` int not null
+,primary key(`personal_access_token_id`, `workbook_id`)
+,foreign key(`personal_access_token_id`) references `personal_access_token`(`id`) on delete cascade
+);
@@ -0,0 +1,10 @@
+create table `guest_user` (
+ `app_user_id` int not null
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`expires_at` datetime not null
+,primary key(`app_user_id`)
+,index(`expires_at`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
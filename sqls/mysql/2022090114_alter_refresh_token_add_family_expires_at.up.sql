@@ -0,0 +1,2 @@
+alter table `refresh_token` add column `family_expires_at` datetime not null default '1970-01-01 00:00:00';
+update `refresh_token` set `family_expires_at` = `expires_at`;
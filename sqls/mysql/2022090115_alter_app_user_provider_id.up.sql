@@ -0,0 +1,2 @@
+alter table `app_user` modify `provider_id` varchar(255) character set ascii;
+update `app_user` set `provider_id` = `login_id` where `provider` = 'google' and (`provider_id` is null or `provider_id` = '');
@@ -0,0 +1 @@
+alter table `translation_cache` add index(`expired_at`);
@@ -0,0 +1,300 @@
+update `english_word_problem` set `ngsl_rank` = 41, `cefr_level` = '' where lower(`text`) = 'know';
+update `english_word_problem` set `ngsl_rank` = 42, `cefr_level` = '' where lower(`text`) = 'more';
+update `english_word_problem` set `ngsl_rank` = 43, `cefr_level` = '' where lower(`text`) = 'get';
+update `english_word_problem` set `ngsl_rank` = 44, `cefr_level` = '' where lower(`text`) = 'who';
+update `english_word_problem` set `ngsl_rank` = 45, `cefr_level` = '' where lower(`text`) = 'like';
+update `english_word_problem` set `ngsl_rank` = 46, `cefr_level` = '' where lower(`text`) = 'when';
+update `english_word_problem` set `ngsl_rank` = 47, `cefr_level` = '' where lower(`text`) = 'think';
+update `english_word_problem` set `ngsl_rank` = 48, `cefr_level` = '' where lower(`text`) = 'make';
+update `english_word_problem` set `ngsl_rank` = 49, `cefr_level` = '' where lower(`text`) = 'time';
+update `english_word_problem` set `ngsl_rank` = 50, `cefr_level` = '' where lower(`text`) = 'see';
+update `english_word_problem` set `ngsl_rank` = 51, `cefr_level` = '' where lower(`text`) = 'what';
+update `english_word_problem` set `ngsl_rank` = 52, `cefr_level` = '' where lower(`text`) = 'up';
+update `english_word_problem` set `ngsl_rank` = 53, `cefr_level` = '' where lower(`text`) = 'some';
+update `english_word_problem` set `ngsl_rank` = 54, `cefr_level` = '' where lower(`text`) = 'other';
+update `english_word_problem` set `ngsl_rank` = 55, `cefr_level` = '' where lower(`text`) = 'out';
+update `english_word_problem` set `ngsl_rank` = 56, `cefr_level` = '' where lower(`text`) = 'good';
+update `english_word_problem` set `ngsl_rank` = 57, `cefr_level` = '' where lower(`text`) = 'people';
+update `english_word_problem` set `ngsl_rank` = 58, `cefr_level` = '' where lower(`text`) = 'year';
+update `english_word_problem` set `ngsl_rank` = 59, `cefr_level` = '' where lower(`text`) = 'take';
+update `english_word_problem` set `ngsl_rank` = 60, `cefr_level` = '' where lower(`text`) = 'no';
+update `english_word_problem` set `ngsl_rank` = 61, `cefr_level` = '' where lower(`text`) = 'well';
+update `english_word_problem` set `ngsl_rank` = 62, `cefr_level` = '' where lower(`text`) = 'because';
+update `english_word_problem` set `ngsl_rank` = 63, `cefr_level` = '' where lower(`text`) = 'very';
+update `english_word_problem` set `ngsl_rank` = 64, `cefr_level` = '' where lower(`text`) = 'just';
+update `english_word_problem` set `ngsl_rank` = 65, `cefr_level` = '' where lower(`text`) = 'come';
+update `english_word_problem` set `ngsl_rank` = 66, `cefr_level` = '' where lower(`text`) = 'could';
+update `english_word_problem` set `ngsl_rank` = 67, `cefr_level` = '' where lower(`text`) = 'work';
+update `english_word_problem` set `ngsl_rank` = 68, `cefr_level` = '' where lower(`text`) = 'use';
+update `english_word_problem` set `ngsl_rank` = 69, `cefr_level` = '' where lower(`text`) = 'than';
+update `english_word_problem` set `ngsl_rank` = 70, `cefr_level` = '' where lower(`text`) = 'now';
+update `english_word_problem` set `ngsl_rank` = 71, `cefr_level` = '' where lower(`text`) = 'then';
+update `english_word_problem` set `ngsl_rank` = 72, `cefr_level` = '' where lower(`text`) = 'also';
+update `english_word_problem` set `ngsl_rank` = 73, `cefr_level` = '' where lower(`text`) = 'into';
+update `english_word_problem` set `ngsl_rank` = 74, `cefr_level` = '' where lower(`text`) = 'only';
+update `english_word_problem` set `ngsl_rank` = 75, `cefr_level` = '' where lower(`text`) = 'look';
+update `english_word_problem` set `ngsl_rank` = 76, `cefr_level` = '' where lower(`text`) = 'want';
+update `english_word_problem` set `ngsl_rank` = 77, `cefr_level` = '' where lower(`text`) = 'give';
+update `english_word_problem` set `ngsl_rank` = 78, `cefr_level` = '' where lower(`text`) = 'first';
+update `english_word_problem` set `ngsl_rank` = 79, `cefr_level` = '' where lower(`text`) = 'new';
+update `english_word_problem` set `ngsl_rank` = 80, `cefr_level` = '' where lower(`text`) = 'way';
+update `english_word_problem` set `ngsl_rank` = 81, `cefr_level` = '' where lower(`text`) = 'find';
+update `english_word_problem` set `ngsl_rank` = 82, `cefr_level` = '' where lower(`text`) = 'over';
+update `english_word_problem` set `ngsl_rank` = 83, `cefr_level` = '' where lower(`text`) = 'any';
+update `english_word_problem` set `ngsl_rank` = 84, `cefr_level` = '' where lower(`text`) = 'after';
+update `english_word_problem` set `ngsl_rank` = 85, `cefr_level` = '' where lower(`text`) = 'day';
+update `english_word_problem` set `ngsl_rank` = 86, `cefr_level` = '' where lower(`text`) = 'where';
+update `english_word_problem` set `ngsl_rank` = 87, `cefr_level` = '' where lower(`text`) = 'thing';
+update `english_word_problem` set `ngsl_rank` = 88, `cefr_level` = '' where lower(`text`) = 'most';
+update `english_word_problem` set `ngsl_rank` = 89, `cefr_level` = '' where lower(`text`) = 'should';
+update `english_word_problem` set `ngsl_rank` = 90, `cefr_level` = '' where lower(`text`) = 'need';
+update `english_word_problem` set `ngsl_rank` = 91, `cefr_level` = '' where lower(`text`) = 'much';
+update `english_word_problem` set `ngsl_rank` = 92, `cefr_level` = '' where lower(`text`) = 'right';
+update `english_word_problem` set `ngsl_rank` = 93, `cefr_level` = '' where lower(`text`) = 'how';
+update `english_word_problem` set `ngsl_rank` = 94, `cefr_level` = '' where lower(`text`) = 'back';
+update `english_word_problem` set `ngsl_rank` = 95, `cefr_level` = '' where lower(`text`) = 'mean';
+update `english_word_problem` set `ngsl_rank` = 96, `cefr_level` = '' where lower(`text`) = 'even';
+update `english_word_problem` set `ngsl_rank` = 97, `cefr_level` = '' where lower(`text`) = 'may';
+update `english_word_problem` set `ngsl_rank` = 98, `cefr_level` = '' where lower(`text`) = 'here';
+update `english_word_problem` set `ngsl_rank` = 99, `cefr_level` = '' where lower(`text`) = 'many';
+update `english_word_problem` set `ngsl_rank` = 100, `cefr_level` = '' where lower(`text`) = 'such';
+update `english_word_problem` set `ngsl_rank` = 101, `cefr_level` = '' where lower(`text`) = 'last';
+update `english_word_problem` set `ngsl_rank` = 102, `cefr_level` = '' where lower(`text`) = 'child';
+update `english_word_problem` set `ngsl_rank` = 103, `cefr_level` = '' where lower(`text`) = 'tell';
+update `english_word_problem` set `ngsl_rank` = 104, `cefr_level` = '' where lower(`text`) = 'really';
+update `english_word_problem` set `ngsl_rank` = 105, `cefr_level` = '' where lower(`text`) = 'call';
+update `english_word_problem` set `ngsl_rank` = 106, `cefr_level` = '' where lower(`text`) = 'before';
+update `english_word_problem` set `ngsl_rank` = 107, `cefr_level` = '' where lower(`text`) = 'company';
+update `english_word_problem` set `ngsl_rank` = 108, `cefr_level` = '' where lower(`text`) = 'through';
+update `english_word_problem` set `ngsl_rank` = 109, `cefr_level` = '' where lower(`text`) = 'down';
+update `english_word_problem` set `ngsl_rank` = 110, `cefr_level` = '' where lower(`text`) = 'show';
+update `english_word_problem` set `ngsl_rank` = 111, `cefr_level` = '' where lower(`text`) = 'life';
+update `english_word_problem` set `ngsl_rank` = 112, `cefr_level` = '' where lower(`text`) = 'man';
+update `english_word_problem` set `ngsl_rank` = 113, `cefr_level` = '' where lower(`text`) = 'change';
+update `english_word_problem` set `ngsl_rank` = 114, `cefr_level` = '' where lower(`text`) = 'place';
+update `english_word_problem` set `ngsl_rank` = 115, `cefr_level` = '' where lower(`text`) = 'long';
+update `english_word_problem` set `ngsl_rank` = 116, `cefr_level` = '' where lower(`text`) = 'between';
+update `english_word_problem` set `ngsl_rank` = 117, `cefr_level` = '' where lower(`text`) = 'feel';
+update `english_word_problem` set `ngsl_rank` = 118, `cefr_level` = '' where lower(`text`) = 'too';
+update `english_word_problem` set `ngsl_rank` = 119, `cefr_level` = '' where lower(`text`) = 'still';
+update `english_word_problem` set `ngsl_rank` = 120, `cefr_level` = '' where lower(`text`) = 'problem';
+update `english_word_problem` set `ngsl_rank` = 121, `cefr_level` = '' where lower(`text`) = 'write';
+update `english_word_problem` set `ngsl_rank` = 122, `cefr_level` = '' where lower(`text`) = 'same';
+update `english_word_problem` set `ngsl_rank` = 123, `cefr_level` = '' where lower(`text`) = 'lot';
+update `english_word_problem` set `ngsl_rank` = 124, `cefr_level` = '' where lower(`text`) = 'great';
+update `english_word_problem` set `ngsl_rank` = 125, `cefr_level` = '' where lower(`text`) = 'try';
+update `english_word_problem` set `ngsl_rank` = 126, `cefr_level` = '' where lower(`text`) = 'leave';
+update `english_word_problem` set `ngsl_rank` = 127, `cefr_level` = '' where lower(`text`) = 'number';
+update `english_word_problem` set `ngsl_rank` = 128, `cefr_level` = '' where lower(`text`) = 'both';
+update `english_word_problem` set `ngsl_rank` = 129, `cefr_level` = '' where lower(`text`) = 'own';
+update `english_word_problem` set `ngsl_rank` = 130, `cefr_level` = '' where lower(`text`) = 'part';
+update `english_word_problem` set `ngsl_rank` = 131, `cefr_level` = '' where lower(`text`) = 'point';
+update `english_word_problem` set `ngsl_rank` = 132, `cefr_level` = '' where lower(`text`) = 'little';
+update `english_word_problem` set `ngsl_rank` = 133, `cefr_level` = '' where lower(`text`) = 'help';
+update `english_word_problem` set `ngsl_rank` = 134, `cefr_level` = '' where lower(`text`) = 'ask';
+update `english_word_problem` set `ngsl_rank` = 135, `cefr_level` = '' where lower(`text`) = 'meet';
+update `english_word_problem` set `ngsl_rank` = 136, `cefr_level` = '' where lower(`text`) = 'start';
+update `english_word_problem` set `ngsl_rank` = 137, `cefr_level` = '' where lower(`text`) = 'talk';
+update `english_word_problem` set `ngsl_rank` = 138, `cefr_level` = '' where lower(`text`) = 'something';
+update `english_word_problem` set `ngsl_rank` = 139, `cefr_level` = '' where lower(`text`) = 'put';
+update `english_word_problem` set `ngsl_rank` = 140, `cefr_level` = '' where lower(`text`) = 'another';
+update `english_word_problem` set `ngsl_rank` = 141, `cefr_level` = '' where lower(`text`) = 'become';
+update `english_word_problem` set `ngsl_rank` = 142, `cefr_level` = '' where lower(`text`) = 'interest';
+update `english_word_problem` set `ngsl_rank` = 143, `cefr_level` = '' where lower(`text`) = 'country';
+update `english_word_problem` set `ngsl_rank` = 144, `cefr_level` = '' where lower(`text`) = 'old';
+update `english_word_problem` set `ngsl_rank` = 145, `cefr_level` = '' where lower(`text`) = 'each';
+update `english_word_problem` set `ngsl_rank` = 146, `cefr_level` = '' where lower(`text`) = 'school';
+update `english_word_problem` set `ngsl_rank` = 147, `cefr_level` = '' where lower(`text`) = 'late';
+update `english_word_problem` set `ngsl_rank` = 148, `cefr_level` = '' where lower(`text`) = 'high';
+update `english_word_problem` set `ngsl_rank` = 149, `cefr_level` = '' where lower(`text`) = 'different';
+update `english_word_problem` set `ngsl_rank` = 150, `cefr_level` = '' where lower(`text`) = 'off';
+update `english_word_problem` set `ngsl_rank` = 151, `cefr_level` = '' where lower(`text`) = 'next';
+update `english_word_problem` set `ngsl_rank` = 152, `cefr_level` = '' where lower(`text`) = 'end';
+update `english_word_problem` set `ngsl_rank` = 153, `cefr_level` = '' where lower(`text`) = 'live';
+update `english_word_problem` set `ngsl_rank` = 154, `cefr_level` = '' where lower(`text`) = 'why';
+update `english_word_problem` set `ngsl_rank` = 155, `cefr_level` = '' where lower(`text`) = 'while';
+update `english_word_problem` set `ngsl_rank` = 156, `cefr_level` = '' where lower(`text`) = 'world';
+update `english_word_problem` set `ngsl_rank` = 157, `cefr_level` = '' where lower(`text`) = 'week';
+update `english_word_problem` set `ngsl_rank` = 158, `cefr_level` = '' where lower(`text`) = 'play';
+update `english_word_problem` set `ngsl_rank` = 159, `cefr_level` = '' where lower(`text`) = 'might';
+update `english_word_problem` set `ngsl_rank` = 160, `cefr_level` = '' where lower(`text`) = 'must';
+update `english_word_problem` set `ngsl_rank` = 161, `cefr_level` = '' where lower(`text`) = 'home';
+update `english_word_problem` set `ngsl_rank` = 162, `cefr_level` = '' where lower(`text`) = 'never';
+update `english_word_problem` set `ngsl_rank` = 163, `cefr_level` = '' where lower(`text`) = 'include';
+update `english_word_problem` set `ngsl_rank` = 164, `cefr_level` = '' where lower(`text`) = 'course';
+update `english_word_problem` set `ngsl_rank` = 165, `cefr_level` = '' where lower(`text`) = 'house';
+update `english_word_problem` set `ngsl_rank` = 166, `cefr_level` = '' where lower(`text`) = 'report';
+update `english_word_problem` set `ngsl_rank` = 167, `cefr_level` = '' where lower(`text`) = 'group';
+update `english_word_problem` set `ngsl_rank` = 168, `cefr_level` = '' where lower(`text`) = 'case';
+update `english_word_problem` set `ngsl_rank` = 169, `cefr_level` = '' where lower(`text`) = 'woman';
+update `english_word_problem` set `ngsl_rank` = 170, `cefr_level` = '' where lower(`text`) = 'around';
+update `english_word_problem` set `ngsl_rank` = 171, `cefr_level` = '' where lower(`text`) = 'book';
+update `english_word_problem` set `ngsl_rank` = 172, `cefr_level` = '' where lower(`text`) = 'family';
+update `english_word_problem` set `ngsl_rank` = 173, `cefr_level` = '' where lower(`text`) = 'seem';
+update `english_word_problem` set `ngsl_rank` = 174, `cefr_level` = '' where lower(`text`) = 'let';
+update `english_word_problem` set `ngsl_rank` = 175, `cefr_level` = '' where lower(`text`) = 'again';
+update `english_word_problem` set `ngsl_rank` = 176, `cefr_level` = '' where lower(`text`) = 'kind';
+update `english_word_problem` set `ngsl_rank` = 177, `cefr_level` = '' where lower(`text`) = 'keep';
+update `english_word_problem` set `ngsl_rank` = 178, `cefr_level` = '' where lower(`text`) = 'hear';
+update `english_word_problem` set `ngsl_rank` = 179, `cefr_level` = '' where lower(`text`) = 'system';
+update `english_word_problem` set `ngsl_rank` = 180, `cefr_level` = '' where lower(`text`) = 'every';
+update `english_word_problem` set `ngsl_rank` = 181, `cefr_level` = '' where lower(`text`) = 'question';
+update `english_word_problem` set `ngsl_rank` = 182, `cefr_level` = '' where lower(`text`) = 'during';
+update `english_word_problem` set `ngsl_rank` = 183, `cefr_level` = '' where lower(`text`) = 'always';
+update `english_word_problem` set `ngsl_rank` = 184, `cefr_level` = '' where lower(`text`) = 'big';
+update `english_word_problem` set `ngsl_rank` = 185, `cefr_level` = '' where lower(`text`) = 'set';
+update `english_word_problem` set `ngsl_rank` = 186, `cefr_level` = '' where lower(`text`) = 'small';
+update `english_word_problem` set `ngsl_rank` = 187, `cefr_level` = '' where lower(`text`) = 'study';
+update `english_word_problem` set `ngsl_rank` = 188, `cefr_level` = '' where lower(`text`) = 'follow';
+update `english_word_problem` set `ngsl_rank` = 189, `cefr_level` = '' where lower(`text`) = 'begin';
+update `english_word_problem` set `ngsl_rank` = 190, `cefr_level` = '' where lower(`text`) = 'important';
+update `english_word_problem` set `ngsl_rank` = 191, `cefr_level` = '' where lower(`text`) = 'since';
+update `english_word_problem` set `ngsl_rank` = 192, `cefr_level` = '' where lower(`text`) = 'run';
+update `english_word_problem` set `ngsl_rank` = 193, `cefr_level` = '' where lower(`text`) = 'under';
+update `english_word_problem` set `ngsl_rank` = 194, `cefr_level` = '' where lower(`text`) = 'turn';
+update `english_word_problem` set `ngsl_rank` = 195, `cefr_level` = '' where lower(`text`) = 'few';
+update `english_word_problem` set `ngsl_rank` = 196, `cefr_level` = '' where lower(`text`) = 'bring';
+update `english_word_problem` set `ngsl_rank` = 197, `cefr_level` = '' where lower(`text`) = 'early';
+update `english_word_problem` set `ngsl_rank` = 198, `cefr_level` = '' where lower(`text`) = 'hand';
+update `english_word_problem` set `ngsl_rank` = 199, `cefr_level` = '' where lower(`text`) = 'state';
+update `english_word_problem` set `ngsl_rank` = 200, `cefr_level` = '' where lower(`text`) = 'move';
+update `english_word_problem` set `ngsl_rank` = 201, `cefr_level` = '' where lower(`text`) = 'money';
+update `english_word_problem` set `ngsl_rank` = 202, `cefr_level` = '' where lower(`text`) = 'fact';
+update `english_word_problem` set `ngsl_rank` = 203, `cefr_level` = '' where lower(`text`) = 'however';
+update `english_word_problem` set `ngsl_rank` = 204, `cefr_level` = '' where lower(`text`) = 'area';
+update `english_word_problem` set `ngsl_rank` = 205, `cefr_level` = '' where lower(`text`) = 'provide';
+update `english_word_problem` set `ngsl_rank` = 206, `cefr_level` = '' where lower(`text`) = 'name';
+update `english_word_problem` set `ngsl_rank` = 207, `cefr_level` = '' where lower(`text`) = 'read';
+update `english_word_problem` set `ngsl_rank` = 208, `cefr_level` = '' where lower(`text`) = 'friend';
+update `english_word_problem` set `ngsl_rank` = 209, `cefr_level` = '' where lower(`text`) = 'month';
+update `english_word_problem` set `ngsl_rank` = 210, `cefr_level` = '' where lower(`text`) = 'large';
+update `english_word_problem` set `ngsl_rank` = 211, `cefr_level` = '' where lower(`text`) = 'business';
+update `english_word_problem` set `ngsl_rank` = 212, `cefr_level` = '' where lower(`text`) = 'without';
+update `english_word_problem` set `ngsl_rank` = 213, `cefr_level` = '' where lower(`text`) = 'information';
+update `english_word_problem` set `ngsl_rank` = 214, `cefr_level` = '' where lower(`text`) = 'open';
+update `english_word_problem` set `ngsl_rank` = 215, `cefr_level` = '' where lower(`text`) = 'order';
+update `english_word_problem` set `ngsl_rank` = 216, `cefr_level` = '' where lower(`text`) = 'government';
+update `english_word_problem` set `ngsl_rank` = 217, `cefr_level` = '' where lower(`text`) = 'word';
+update `english_word_problem` set `ngsl_rank` = 218, `cefr_level` = '' where lower(`text`) = 'issue';
+update `english_word_problem` set `ngsl_rank` = 219, `cefr_level` = '' where lower(`text`) = 'market';
+update `english_word_problem` set `ngsl_rank` = 220, `cefr_level` = '' where lower(`text`) = 'pay';
+update `english_word_problem` set `ngsl_rank` = 221, `cefr_level` = '' where lower(`text`) = 'build';
+update `english_word_problem` set `ngsl_rank` = 222, `cefr_level` = '' where lower(`text`) = 'hold';
+update `english_word_problem` set `ngsl_rank` = 223, `cefr_level` = '' where lower(`text`) = 'service';
+update `english_word_problem` set `ngsl_rank` = 224, `cefr_level` = '' where lower(`text`) = 'against';
+update `english_word_problem` set `ngsl_rank` = 225, `cefr_level` = '' where lower(`text`) = 'believe';
+update `english_word_problem` set `ngsl_rank` = 226, `cefr_level` = '' where lower(`text`) = 'second';
+update `english_word_problem` set `ngsl_rank` = 227, `cefr_level` = '' where lower(`text`) = 'though';
+update `english_word_problem` set `ngsl_rank` = 228, `cefr_level` = '' where lower(`text`) = 'yes';
+update `english_word_problem` set `ngsl_rank` = 229, `cefr_level` = '' where lower(`text`) = 'love';
+update `english_word_problem` set `ngsl_rank` = 230, `cefr_level` = '' where lower(`text`) = 'increase';
+update `english_word_problem` set `ngsl_rank` = 231, `cefr_level` = '' where lower(`text`) = 'job';
+update `english_word_problem` set `ngsl_rank` = 232, `cefr_level` = '' where lower(`text`) = 'plan';
+update `english_word_problem` set `ngsl_rank` = 233, `cefr_level` = '' where lower(`text`) = 'result';
+update `english_word_problem` set `ngsl_rank` = 234, `cefr_level` = '' where lower(`text`) = 'away';
+update `english_word_problem` set `ngsl_rank` = 235, `cefr_level` = '' where lower(`text`) = 'example';
+update `english_word_problem` set `ngsl_rank` = 236, `cefr_level` = '' where lower(`text`) = 'happen';
+update `english_word_problem` set `ngsl_rank` = 237, `cefr_level` = '' where lower(`text`) = 'offer';
+update `english_word_problem` set `ngsl_rank` = 238, `cefr_level` = '' where lower(`text`) = 'young';
+update `english_word_problem` set `ngsl_rank` = 239, `cefr_level` = '' where lower(`text`) = 'close';
+update `english_word_problem` set `ngsl_rank` = 240, `cefr_level` = '' where lower(`text`) = 'program';
+update `english_word_problem` set `ngsl_rank` = 241, `cefr_level` = '' where lower(`text`) = 'lead';
+update `english_word_problem` set `ngsl_rank` = 242, `cefr_level` = '' where lower(`text`) = 'buy';
+update `english_word_problem` set `ngsl_rank` = 243, `cefr_level` = '' where lower(`text`) = 'understand';
+update `english_word_problem` set `ngsl_rank` = 244, `cefr_level` = '' where lower(`text`) = 'thank';
+update `english_word_problem` set `ngsl_rank` = 245, `cefr_level` = '' where lower(`text`) = 'far';
+update `english_word_problem` set `ngsl_rank` = 246, `cefr_level` = '' where lower(`text`) = 'today';
+update `english_word_problem` set `ngsl_rank` = 247, `cefr_level` = '' where lower(`text`) = 'hour';
+update `english_word_problem` set `ngsl_rank` = 248, `cefr_level` = '' where lower(`text`) = 'student';
+update `english_word_problem` set `ngsl_rank` = 249, `cefr_level` = '' where lower(`text`) = 'face';
+update `english_word_problem` set `ngsl_rank` = 250, `cefr_level` = '' where lower(`text`) = 'hope';
+update `english_word_problem` set `ngsl_rank` = 251, `cefr_level` = '' where lower(`text`) = 'idea';
+update `english_word_problem` set `ngsl_rank` = 252, `cefr_level` = '' where lower(`text`) = 'cost';
+update `english_word_problem` set `ngsl_rank` = 253, `cefr_level` = '' where lower(`text`) = 'less';
+update `english_word_problem` set `ngsl_rank` = 254, `cefr_level` = '' where lower(`text`) = 'room';
+update `english_word_problem` set `ngsl_rank` = 255, `cefr_level` = '' where lower(`text`) = 'until';
+update `english_word_problem` set `ngsl_rank` = 256, `cefr_level` = '' where lower(`text`) = 'reason';
+update `english_word_problem` set `ngsl_rank` = 257, `cefr_level` = '' where lower(`text`) = 'form';
+update `english_word_problem` set `ngsl_rank` = 258, `cefr_level` = '' where lower(`text`) = 'spend';
+update `english_word_problem` set `ngsl_rank` = 259, `cefr_level` = '' where lower(`text`) = 'head';
+update `english_word_problem` set `ngsl_rank` = 260, `cefr_level` = '' where lower(`text`) = 'car';
+update `english_word_problem` set `ngsl_rank` = 261, `cefr_level` = '' where lower(`text`) = 'learn';
+update `english_word_problem` set `ngsl_rank` = 262, `cefr_level` = '' where lower(`text`) = 'level';
+update `english_word_problem` set `ngsl_rank` = 263, `cefr_level` = '' where lower(`text`) = 'person';
+update `english_word_problem` set `ngsl_rank` = 264, `cefr_level` = '' where lower(`text`) = 'experience';
+update `english_word_problem` set `ngsl_rank` = 265, `cefr_level` = '' where lower(`text`) = 'once';
+update `english_word_problem` set `ngsl_rank` = 266, `cefr_level` = '' where lower(`text`) = 'member';
+update `english_word_problem` set `ngsl_rank` = 267, `cefr_level` = '' where lower(`text`) = 'enough';
+update `english_word_problem` set `ngsl_rank` = 268, `cefr_level` = '' where lower(`text`) = 'bad';
+update `english_word_problem` set `ngsl_rank` = 269, `cefr_level` = '' where lower(`text`) = 'city';
+update `english_word_problem` set `ngsl_rank` = 270, `cefr_level` = '' where lower(`text`) = 'night';
+update `english_word_problem` set `ngsl_rank` = 271, `cefr_level` = '' where lower(`text`) = 'able';
+update `english_word_problem` set `ngsl_rank` = 272, `cefr_level` = '' where lower(`text`) = 'support';
+update `english_word_problem` set `ngsl_rank` = 273, `cefr_level` = '' where lower(`text`) = 'whether';
+update `english_word_problem` set `ngsl_rank` = 274, `cefr_level` = '' where lower(`text`) = 'line';
+update `english_word_problem` set `ngsl_rank` = 275, `cefr_level` = '' where lower(`text`) = 'present';
+update `english_word_problem` set `ngsl_rank` = 276, `cefr_level` = '' where lower(`text`) = 'side';
+update `english_word_problem` set `ngsl_rank` = 277, `cefr_level` = '' where lower(`text`) = 'quite';
+update `english_word_problem` set `ngsl_rank` = 278, `cefr_level` = '' where lower(`text`) = 'although';
+update `english_word_problem` set `ngsl_rank` = 279, `cefr_level` = '' where lower(`text`) = 'sure';
+update `english_word_problem` set `ngsl_rank` = 280, `cefr_level` = '' where lower(`text`) = 'term';
+update `english_word_problem` set `ngsl_rank` = 281, `cefr_level` = '' where lower(`text`) = 'least';
+update `english_word_problem` set `ngsl_rank` = 282, `cefr_level` = '' where lower(`text`) = 'age';
+update `english_word_problem` set `ngsl_rank` = 283, `cefr_level` = '' where lower(`text`) = 'low';
+update `english_word_problem` set `ngsl_rank` = 284, `cefr_level` = '' where lower(`text`) = 'speak';
+update `english_word_problem` set `ngsl_rank` = 285, `cefr_level` = '' where lower(`text`) = 'within';
+update `english_word_problem` set `ngsl_rank` = 286, `cefr_level` = '' where lower(`text`) = 'process';
+update `english_word_problem` set `ngsl_rank` = 287, `cefr_level` = '' where lower(`text`) = 'public';
+update `english_word_problem` set `ngsl_rank` = 288, `cefr_level` = '' where lower(`text`) = 'often';
+update `english_word_problem` set `ngsl_rank` = 289, `cefr_level` = '' where lower(`text`) = 'train';
+update `english_word_problem` set `ngsl_rank` = 290, `cefr_level` = '' where lower(`text`) = 'possible';
+update `english_word_problem` set `ngsl_rank` = 291, `cefr_level` = '' where lower(`text`) = 'actually';
+update `english_word_problem` set `ngsl_rank` = 292, `cefr_level` = '' where lower(`text`) = 'rather';
+update `english_word_problem` set `ngsl_rank` = 293, `cefr_level` = '' where lower(`text`) = 'view';
+update `english_word_problem` set `ngsl_rank` = 294, `cefr_level` = '' where lower(`text`) = 'together';
+update `english_word_problem` set `ngsl_rank` = 295, `cefr_level` = '' where lower(`text`) = 'consider';
+update `english_word_problem` set `ngsl_rank` = 296, `cefr_level` = '' where lower(`text`) = 'price';
+update `english_word_problem` set `ngsl_rank` = 297, `cefr_level` = '' where lower(`text`) = 'parent';
+update `english_word_problem` set `ngsl_rank` = 298, `cefr_level` = '' where lower(`text`) = 'hard';
+update `english_word_problem` set `ngsl_rank` = 299, `cefr_level` = '' where lower(`text`) = 'party';
+update `english_word_problem` set `ngsl_rank` = 300, `cefr_level` = '' where lower(`text`) = 'local';
+update `english_word_problem` set `ngsl_rank` = 301, `cefr_level` = '' where lower(`text`) = 'control';
+update `english_word_problem` set `ngsl_rank` = 302, `cefr_level` = '' where lower(`text`) = 'already';
+update `english_word_problem` set `ngsl_rank` = 303, `cefr_level` = '' where lower(`text`) = 'concern';
+update `english_word_problem` set `ngsl_rank` = 304, `cefr_level` = '' where lower(`text`) = 'product';
+update `english_word_problem` set `ngsl_rank` = 305, `cefr_level` = '' where lower(`text`) = 'lose';
+update `english_word_problem` set `ngsl_rank` = 306, `cefr_level` = '' where lower(`text`) = 'story';
+update `english_word_problem` set `ngsl_rank` = 307, `cefr_level` = '' where lower(`text`) = 'almost';
+update `english_word_problem` set `ngsl_rank` = 308, `cefr_level` = '' where lower(`text`) = 'continue';
+update `english_word_problem` set `ngsl_rank` = 309, `cefr_level` = '' where lower(`text`) = 'stand';
+update `english_word_problem` set `ngsl_rank` = 310, `cefr_level` = '' where lower(`text`) = 'whole';
+update `english_word_problem` set `ngsl_rank` = 311, `cefr_level` = '' where lower(`text`) = 'yet';
+update `english_word_problem` set `ngsl_rank` = 312, `cefr_level` = '' where lower(`text`) = 'rate';
+update `english_word_problem` set `ngsl_rank` = 313, `cefr_level` = '' where lower(`text`) = 'care';
+update `english_word_problem` set `ngsl_rank` = 314, `cefr_level` = '' where lower(`text`) = 'expect';
+update `english_word_problem` set `ngsl_rank` = 315, `cefr_level` = '' where lower(`text`) = 'effect';
+update `english_word_problem` set `ngsl_rank` = 316, `cefr_level` = '' where lower(`text`) = 'sort';
+update `english_word_problem` set `ngsl_rank` = 317, `cefr_level` = '' where lower(`text`) = 'ever';
+update `english_word_problem` set `ngsl_rank` = 318, `cefr_level` = '' where lower(`text`) = 'anything';
+update `english_word_problem` set `ngsl_rank` = 319, `cefr_level` = '' where lower(`text`) = 'cause';
+update `english_word_problem` set `ngsl_rank` = 320, `cefr_level` = '' where lower(`text`) = 'fall';
+update `english_word_problem` set `ngsl_rank` = 321, `cefr_level` = '' where lower(`text`) = 'deal';
+update `english_word_problem` set `ngsl_rank` = 322, `cefr_level` = '' where lower(`text`) = 'water';
+update `english_word_problem` set `ngsl_rank` = 323, `cefr_level` = '' where lower(`text`) = 'send';
+update `english_word_problem` set `ngsl_rank` = 324, `cefr_level` = '' where lower(`text`) = 'allow';
+update `english_word_problem` set `ngsl_rank` = 325, `cefr_level` = '' where lower(`text`) = 'soon';
+update `english_word_problem` set `ngsl_rank` = 326, `cefr_level` = '' where lower(`text`) = 'watch';
+update `english_word_problem` set `ngsl_rank` = 327, `cefr_level` = '' where lower(`text`) = 'base';
+update `english_word_problem` set `ngsl_rank` = 328, `cefr_level` = '' where lower(`text`) = 'probably';
+update `english_word_problem` set `ngsl_rank` = 329, `cefr_level` = '' where lower(`text`) = 'suggest';
+update `english_word_problem` set `ngsl_rank` = 330, `cefr_level` = '' where lower(`text`) = 'past';
+update `english_word_problem` set `ngsl_rank` = 331, `cefr_level` = '' where lower(`text`) = 'power';
+update `english_word_problem` set `ngsl_rank` = 332, `cefr_level` = '' where lower(`text`) = 'test';
+update `english_word_problem` set `ngsl_rank` = 333, `cefr_level` = '' where lower(`text`) = 'visit';
+update `english_word_problem` set `ngsl_rank` = 334, `cefr_level` = '' where lower(`text`) = 'center';
+update `english_word_problem` set `ngsl_rank` = 335, `cefr_level` = '' where lower(`text`) = 'grow';
+update `english_word_problem` set `ngsl_rank` = 336, `cefr_level` = '' where lower(`text`) = 'nothing';
+update `english_word_problem` set `ngsl_rank` = 337, `cefr_level` = '' where lower(`text`) = 'return';
+update `english_word_problem` set `ngsl_rank` = 338, `cefr_level` = '' where lower(`text`) = 'mother';
+update `english_word_problem` set `ngsl_rank` = 339, `cefr_level` = '' where lower(`text`) = 'walk';
+update `english_word_problem` set `ngsl_rank` = 340, `cefr_level` = '' where lower(`text`) = 'matter';
@@ -0,0 +1,11 @@
+create table `translation_cache` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`cache_key` varchar(300) not null
+,`text` varchar(100) not null
+,`not_found` tinyint(1) not null
+,`translations` text not null
+,`expired_at` datetime not null
+,unique(`cache_key`)
+);
+create index `idx_translation_cache_text` on `translation_cache`(`text`);
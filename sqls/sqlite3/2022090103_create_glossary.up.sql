@@ -0,0 +1,16 @@
+create table `glossary` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp
+,`created_by` int not null
+,`updated_by` int not null
+,`organization_id` int not null
+,`lang2` varchar(2) not null
+,`text` varchar(100) not null
+,`pos` int not null
+,`translated` varchar(100) not null
+,unique(`organization_id`, `lang2`, `text`, `pos`)
+,foreign key(`created_by`) references `app_user`(`id`) on delete cascade
+,foreign key(`updated_by`) references `app_user`(`id`) on delete cascade
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+);
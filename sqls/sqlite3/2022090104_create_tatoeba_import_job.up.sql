@@ -0,0 +1,17 @@
+create table `tatoeba_import_job` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp
+,`created_by` int not null
+,`organization_id` int not null
+,`kind` varchar(20) not null
+,`file_name` varchar(200) not null
+,`file_size` bigint not null
+,`received_size` bigint not null default 0
+,`processed_lines` int not null default 0
+,`status` varchar(20) not null
+,`error_message` varchar(400) not null default ''
+,foreign key(`created_by`) references `app_user`(`id`) on delete cascade
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+);
+create index `idx_tatoeba_import_job_organization_id_status` on `tatoeba_import_job`(`organization_id`, `status`);
@@ -0,0 +1,9 @@
+create table `audio_cache` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`lang2` varchar(2) not null
+,`text` varchar(400) not null
+,`audio_id` int not null
+,unique(`lang2`, `text`)
+);
+create index `idx_audio_cache_audio_id` on `audio_cache`(`audio_id`);
@@ -0,0 +1,17 @@
+create table `audio_generation_job` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp
+,`created_by` int not null
+,`organization_id` int not null
+,`workbook_id` int not null
+,`total_problems` int not null
+,`processed_problems` int not null default 0
+,`generated_audios` int not null default 0
+,`status` varchar(20) not null
+,`error_message` varchar(400) not null default ''
+,foreign key(`created_by`) references `app_user`(`id`) on delete cascade
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`workbook_id`) references `workbook`(`id`) on delete cascade
+);
+create index `idx_audio_generation_job_workbook_id_status` on `audio_generation_job`(`workbook_id`, `status`);
@@ -0,0 +1,14 @@
+alter table `app_user` add column `failed_login_count` int not null default 0;
+alter table `app_user` add column `locked_until` datetime;
+create table `app_user_password_reset_token` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`hashed_token` varchar(64) not null
+,`expires_at` datetime not null
+,`used` tinyint(1) not null default 0
+,unique(`hashed_token`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
@@ -0,0 +1,16 @@
+create table `refresh_token` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`token_id` varchar(32) not null
+,`family_id` varchar(32) not null
+,`used` tinyint(1) not null default 0
+,`revoked` tinyint(1) not null default 0
+,`expires_at` datetime not null
+,unique(`token_id`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+);
+create index `idx_refresh_token_family_id` on `refresh_token`(`family_id`);
+create index `idx_refresh_token_organization_id_app_user_id` on `refresh_token`(`organization_id`, `app_user_id`);
@@ -0,0 +1,12 @@
+create table `oidc_auth_request` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`state` varchar(64) not null
+,`provider_name` varchar(30) not null
+,`organization_name` varchar(20) not null
+,`nonce` varchar(64) not null
+,`code_verifier` varchar(128) not null
+,`expires_at` datetime not null
+,unique(`state`)
+);
+create index `idx_oidc_auth_request_expires_at` on `oidc_auth_request`(`expires_at`);
@@ -0,0 +1,22 @@
+create table `personal_access_token` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`name` varchar(40) not null
+,`token_prefix` varchar(12) not null
+,`hashed_token` varchar(64) not null
+,`scope` varchar(10) not null
+,`expires_at` datetime
+,`last_used_at` datetime
+,unique(`hashed_token`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
+create index `idx_personal_access_token_organization_id_app_user_id` on `personal_access_token`(`organization_id`, `app_user_id`);
+create table `personal_access_token_workbook` (
+ `personal_access_token_id` int not null
+,`workbook_id` int not null
+,primary key(`personal_access_token_id`, `workbook_id`)
+,foreign key(`personal_access_token_id`) references `personal_access_token`(`id`) on delete cascade
+);
@@ -0,0 +1,10 @@
+create table `guest_user` (
+ `app_user_id` int not null
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`expires_at` datetime not null
+,primary key(`app_user_id`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
+create index `idx_guest_user_expires_at` on `guest_user`(`expires_at`);
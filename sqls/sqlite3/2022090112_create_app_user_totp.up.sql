@@ -0,0 +1,34 @@
+create table `app_user_totp` (
+ `app_user_id` int not null
+,`created_at` datetime not null default current_timestamp
+,`updated_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`secret` varchar(64) not null
+,`enabled_at` datetime
+,`last_used_step` bigint not null default 0
+,primary key(`app_user_id`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
+create table `app_user_recovery_code` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`hashed_code` varchar(64) not null
+,unique(`app_user_id`, `hashed_code`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
+create table `totp_challenge` (
+ `id` integer primary key autoincrement
+,`created_at` datetime not null default current_timestamp
+,`organization_id` int not null
+,`app_user_id` int not null
+,`hashed_token` varchar(64) not null
+,`expires_at` datetime not null
+,unique(`hashed_token`)
+,foreign key(`organization_id`) references `organization`(`id`) on delete cascade
+,foreign key(`app_user_id`) references `app_user`(`id`) on delete cascade
+);
+create index `idx_totp_challenge_expires_at` on `totp_challenge`(`expires_at`);
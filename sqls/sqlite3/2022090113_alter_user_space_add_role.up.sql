@@ -0,0 +1 @@
+alter table `user_space` add column `role` varchar(10) not null default 'reader';
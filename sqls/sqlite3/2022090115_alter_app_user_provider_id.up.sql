@@ -0,0 +1 @@
+update `app_user` set `provider_id` = `login_id` where `provider` = 'google' and (`provider_id` is null or `provider_id` = '');
@@ -0,0 +1 @@
+create index `idx_translation_cache_expired_at` on `translation_cache`(`expired_at`);
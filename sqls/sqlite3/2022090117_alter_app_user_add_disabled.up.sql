@@ -0,0 +1,2 @@
+alter table `app_user` add column `disabled` tinyint(1) not null default 0;
+update `app_user` set `disabled` = 1, `removed` = 0 where `removed` = 1;
@@ -17,6 +17,8 @@ type AppConfig struct {
 	MetricsPort   int    `yaml:"metricsPort" validate:"required"`
 	OwnerPassword string `yaml:"ownerPassword" validate:"required"`
 	TestUserEmail string `yaml:"testUserEmail" validate:"required"`
+	// GuestQuotaPercent is the percentage of the quotas of the guests to the quotas of the users
+	GuestQuotaPercent int `yaml:"guestQuotaPercent" validate:"gte=1,lte=100"`
 }
 
 type SQLite3Config struct {
@@ -37,22 +39,81 @@ type DBConfig struct {
 	MySQL      *MySQLConfig   `yaml:"mysql"`
 }
 
+// SigningKeyConfig is the key to sign the tokens.
+// The key signs the new tokens from ActiveFrom and verifies the tokens until RetireAt. Both are RFC 3339 and optional
+type SigningKeyConfig struct {
+	KeyID          string `yaml:"kid" validate:"required"`
+	Algorithm      string `yaml:"algorithm" validate:"oneof=RS256 ES256"`
+	PrivateKeyFile string `yaml:"privateKeyFile" validate:"required"`
+	ActiveFrom     string `yaml:"activeFrom"`
+	RetireAt       string `yaml:"retireAt"`
+}
+
+type OIDCProviderConfig struct {
+	// Name is the path parameter of the endpoints. The user is registered with the provider "oidc:<name>"
+	Name         string   `yaml:"name" validate:"required,max=30,alphanum"`
+	Issuer       string   `yaml:"issuer" validate:"required,url"`
+	ClientID     string   `yaml:"clientId" validate:"required"`
+	ClientSecret string   `yaml:"clientSecret"`
+	RedirectURL  string   `yaml:"redirectUrl" validate:"required,url"`
+	Scopes       []string `yaml:"scopes"`
+}
+
 type AuthConfig struct {
-	SigningKey          string `yaml:"signingKey"`
-	AccessTokenTTLMin   int    `yaml:"accessTokenTtlMin" validate:"gte=1"`
-	RefreshTokenTTLHour int    `yaml:"refreshTokenTtlHour" validate:"gte=1"`
-	GoogleCallbackURL   string `yaml:"googleCallbackUrl" validate:"required"`
-	GoogleClientID      string `yaml:"googleClientId" validate:"required"`
-	GoogleClientSecret  string `yaml:"googleClientSecret" validate:"required"`
-	APITimeoutSec       int    `yaml:"apiTimeoutSec" validate:"gte=1"`
+	// SigningKey is the HS256 secret. It verifies the tokens without the kid header
+	SigningKey          string              `yaml:"signingKey"`
+	SigningKeyRetireAt  string              `yaml:"signingKeyRetireAt"`
+	SigningKeys         []*SigningKeyConfig `yaml:"signingKeys" validate:"dive"`
+	AccessTokenTTLMin   int                 `yaml:"accessTokenTtlMin" validate:"gte=1"`
+	RefreshTokenTTLHour int                 `yaml:"refreshTokenTtlHour" validate:"gte=1"`
+	GoogleCallbackURL   string              `yaml:"googleCallbackUrl" validate:"required"`
+	GoogleClientID      string              `yaml:"googleClientId" validate:"required"`
+	GoogleClientSecret  string              `yaml:"googleClientSecret" validate:"required"`
+	APITimeoutSec       int                 `yaml:"apiTimeoutSec" validate:"gte=1"`
+	// the user is locked for PasswordLockMin minutes after PasswordMaxLoginFailures failures
+	PasswordMaxLoginFailures int                   `yaml:"passwordMaxLoginFailures" validate:"gte=1"`
+	PasswordLockMin          int                   `yaml:"passwordLockMin" validate:"gte=1"`
+	PasswordResetTokenTTLMin int                   `yaml:"passwordResetTokenTtlMin" validate:"gte=1"`
+	OIDCProviders            []*OIDCProviderConfig `yaml:"oidcProviders" validate:"dive"`
+	OIDCAuthRequestTTLMin    int                   `yaml:"oidcAuthRequestTtlMin" validate:"gte=1"`
+	// the guests and their data are removed GuestTTLHour hours after they are added unless they register
+	GuestTTLHour       int `yaml:"guestTtlHour" validate:"gte=1"`
+	GuestGCIntervalMin int `yaml:"guestGcIntervalMin" validate:"gte=1"`
+	// the guests aren't added while the organization has GuestMaxCount guests who haven't expired
+	GuestMaxCount int `yaml:"guestMaxCount" validate:"gte=1"`
+	// the users who have TOTPRequiredRoles can't sign in with the password until they enable TOTP
+	TOTPIssuer          string   `yaml:"totpIssuer" validate:"required"`
+	TOTPRequiredRoles   []string `yaml:"totpRequiredRoles" validate:"dive,required"`
+	TOTPChallengeTTLMin int      `yaml:"totpChallengeTtlMin" validate:"gte=1"`
 }
 
 type TranslatorConfig struct {
-	Endpoint   string `yaml:"endpoint" validate:"required"`
-	TimeoutSec int    `yaml:"timeoutSec" validate:"gte=1"`
-	Username   string `yaml:"username" validate:"required"`
-	Password   string `yaml:"password" validate:"required"`
-	GRPCAddr   string `yaml:"grpcAddr" validate:"required"`
+	Endpoint   string                      `yaml:"endpoint" validate:"required"`
+	TimeoutSec int                         `yaml:"timeoutSec" validate:"gte=1"`
+	Username   string                      `yaml:"username" validate:"required"`
+	Password   string                      `yaml:"password" validate:"required"`
+	GRPCAddr   string                      `yaml:"grpcAddr" validate:"required"`
+	Cache      *TranslatorCacheConfig      `yaml:"cache"`
+	Primary    string                      `yaml:"primary" validate:"omitempty,oneof=grpc http"`
+	Resilience *TranslatorResilienceConfig `yaml:"resilience"`
+}
+
+type TranslatorResilienceConfig struct {
+	// Secondary is the transport to fall back on when the primary transport fails. "grpc", "http" or empty
+	Secondary         string `yaml:"secondary" validate:"omitempty,oneof=grpc http"`
+	MaxRetries        int    `yaml:"maxRetries" validate:"gte=0"`
+	RetryIntervalMSec int    `yaml:"retryIntervalMSec" validate:"gte=1"`
+	FailureThreshold  int    `yaml:"failureThreshold" validate:"gte=1"`
+	OpenTimeoutSec    int    `yaml:"openTimeoutSec" validate:"gte=1"`
+}
+
+type TranslatorCacheConfig struct {
+	Size           int  `yaml:"size" validate:"gte=1"`
+	TTLSec         int  `yaml:"ttlSec" validate:"gte=1"`
+	NegativeTTLSec int  `yaml:"negativeTtlSec" validate:"gte=0"`
+	DBEnabled      bool `yaml:"dbEnabled"`
+	// PurgeIntervalMin is the interval to remove the expired caches from the database
+	PurgeIntervalMin int `yaml:"purgeIntervalMin" validate:"gte=1"`
 }
 
 type TatoebaConfig struct {
@@ -60,6 +121,9 @@ type TatoebaConfig struct {
 	TimeoutSec int    `yaml:"timeoutSec" validate:"gte=1"`
 	Username   string `yaml:"username" validate:"required"`
 	Password   string `yaml:"password" validate:"required"`
+	// ImportWorkDir is the directory where the uploaded chunks are stored until they are imported
+	ImportWorkDir    string `yaml:"importWorkDir" validate:"required"`
+	ImportBatchLines int    `yaml:"importBatchLines" validate:"gte=1"`
 }
 
 type SynthesizerConfig struct {
@@ -67,6 +131,12 @@ type SynthesizerConfig struct {
 	TimeoutSec int    `yaml:"timeoutSec" validate:"gte=1"`
 	Username   string `yaml:"username" validate:"required"`
 	Password   string `yaml:"password" validate:"required"`
+	// CacheEnabled makes the same text reuse the existing audio instead of being synthesized again
+	CacheEnabled bool `yaml:"cacheEnabled"`
+	// GenerationIntervalMSec is the interval between the audios which are synthesized by the audio generation jobs
+	GenerationIntervalMSec int `yaml:"generationIntervalMSec" validate:"gte=1"`
+	// GenerationQuotaPerDay is the number of the audios which the audio generation jobs of a user can synthesize a day
+	GenerationQuotaPerDay int `yaml:"generationQuotaPerDay" validate:"gte=1"`
 }
 
 type JaegerConfig struct {
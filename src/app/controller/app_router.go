@@ -4,11 +4,9 @@ import (
 	"context"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
 	ginlog "github.com/onrik/logrus/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
@@ -19,15 +17,22 @@ import (
 	authH "github.com/kujilabo/cocotola-api/src/auth/controller"
 	authM "github.com/kujilabo/cocotola-api/src/auth/controller/middleware"
 	authG "github.com/kujilabo/cocotola-api/src/auth/gateway"
+	authS "github.com/kujilabo/cocotola-api/src/auth/service"
 	authU "github.com/kujilabo/cocotola-api/src/auth/usecase"
 	ginmiddleware "github.com/kujilabo/cocotola-api/src/lib/controller/middleware"
 	pluginCommonController "github.com/kujilabo/cocotola-api/src/plugin/common/controller"
 	pluginCommonService "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	pluginCommonUsecase "github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
+	pluginEnglishController "github.com/kujilabo/cocotola-api/src/plugin/english/controller"
+	pluginEnglishUsecase "github.com/kujilabo/cocotola-api/src/plugin/english/usecase"
+	userH "github.com/kujilabo/cocotola-api/src/user/controller"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userU "github.com/kujilabo/cocotola-api/src/user/usecase"
 )
 
-type NewIteratorFunc func(ctx context.Context, workbookID appD.WorkbookID, problemType string, reader io.Reader) (appS.ProblemAddParameterIterator, error)
+type NewIteratorFunc func(ctx context.Context, organizationID userD.OrganizationID, workbookID appD.WorkbookID, problemType string, reader io.Reader) (appS.ProblemAddParameterIterator, error)
 
-func NewRouter(googleUserUsecase authU.GoogleUserUsecase, guestUserUsecase authU.GuestUserUsecase, studentUsecaseWorkbook studentU.StudentUsecaseWorkbook, studentUsecaseProblem studentU.StudentUsecaseProblem, studentUsecaseAudio studentU.StudentUsecaseAudio, studentUsecaseStudy studentU.StudentUsecaseStudy, translatorClient pluginCommonService.TranslatorClient, tatoebaClient pluginCommonService.TatoebaClient, newIteratorFunc NewIteratorFunc, corsConfig cors.Config, appConfig *config.AppConfig, authConfig *config.AuthConfig, debugConfig *config.DebugConfig) *gin.Engine {
+func NewRouter(signingKeySet *authG.SigningKeySet, authTokenManager authS.AuthTokenManager, googleUserUsecase authU.GoogleUserUsecase, guestUserUsecase authU.GuestUserUsecase, passwordUserUsecase authU.PasswordUserUsecase, totpUsecase authU.TOTPUsecase, oidcUserUsecase authU.OIDCUserUsecase, personalAccessTokenUsecase authU.PersonalAccessTokenUsecase, organizationAdminUsecase userU.OrganizationAdminUsecase, appUserGroupUsecase userU.AppUserGroupUsecase, spaceUsecase userU.SpaceUsecase, studentUsecaseWorkbook studentU.StudentUsecaseWorkbook, studentUsecaseProblem studentU.StudentUsecaseProblem, studentUsecaseAudio studentU.StudentUsecaseAudio, synthesizerCacheClient appS.SynthesizerCacheClient, studentUsecaseStudy studentU.StudentUsecaseStudy, translatorClient pluginCommonService.TranslatorClient, tatoebaClient pluginCommonService.TatoebaClient, tatoebaImportUsecase pluginCommonUsecase.TatoebaImportUsecase, glossaryUsecase pluginCommonUsecase.GlossaryUsecase, studentUsecaseNGSL pluginEnglishUsecase.StudentUsecaseNGSL, studentUsecaseTatoeba pluginEnglishUsecase.StudentUsecaseTatoeba, newIteratorFunc NewIteratorFunc, systemOrganizationID userD.OrganizationID, corsConfig cors.Config, appConfig *config.AppConfig, authConfig *config.AuthConfig, debugConfig *config.DebugConfig) *gin.Engine {
 	if !debugConfig.GinMode {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -48,10 +53,16 @@ func NewRouter(googleUserUsecase authU.GoogleUserUsecase, guestUserUsecase authU
 		c.Status(http.StatusOK)
 	})
 
-	signingKey := []byte(authConfig.SigningKey)
-	signingMethod := jwt.SigningMethodHS256
-	authTokenManager := authG.NewAuthTokenManager(signingKey, signingMethod, time.Duration(authConfig.AccessTokenTTLMin)*time.Minute, time.Duration(authConfig.RefreshTokenTTLHour)*time.Hour)
-	authMiddleware := authM.NewAuthMiddleware(signingKey)
+	router.GET("/.well-known/jwks.json", authH.NewJWKSHandler(signingKeySet).FindJSONWebKeySet)
+
+	authMiddleware := authM.NewAuthMiddleware(signingKeySet, personalAccessTokenUsecase)
+	requireOwner := authM.RequireRole(userD.OwnerRole)
+	// the owners of the system organization administer the whole service
+	requireSystemOwner := authM.RequireOrganizationRole(systemOrganizationID, userD.OwnerRole)
+	workbookPrivilege := NewWorkbookPrivilegeFunc(studentUsecaseWorkbook)
+	requireWorkbookRead := authM.RequirePrivilege(workbookPrivilege, appD.PrivilegeRead)
+	requireWorkbookUpdate := authM.RequirePrivilege(workbookPrivilege, appD.PrivilegeUpdate)
+	requireWorkbookRemove := authM.RequirePrivilege(workbookPrivilege, appD.PrivilegeRemove)
 
 	v1 := router.Group("v1")
 	{
@@ -63,35 +74,106 @@ func NewRouter(googleUserUsecase authU.GoogleUserUsecase, guestUserUsecase authU
 		authHandler := authH.NewAuthHandler(authTokenManager)
 		googleAuthHandler := authH.NewGoogleAuthHandler(googleUserUsecase)
 		guestAuthHandler := authH.NewGuestAuthHandler(guestUserUsecase)
+		passwordAuthHandler := authH.NewPasswordAuthHandler(passwordUserUsecase)
+		totpAuthHandler := authH.NewTOTPAuthHandler(totpUsecase)
+		oidcAuthHandler := authH.NewOIDCAuthHandler(oidcUserUsecase)
 		v1auth.POST("google/authorize", googleAuthHandler.Authorize)
 		v1auth.POST("guest/authorize", guestAuthHandler.Authorize)
+		v1auth.POST("guest/upgrade/password", authMiddleware, authM.RequireRole(userD.GuestRole), guestAuthHandler.UpgradeWithPassword)
+		v1auth.POST("guest/upgrade/google", authMiddleware, authM.RequireRole(userD.GuestRole), guestAuthHandler.UpgradeWithGoogle)
+		v1auth.POST("password/authorize", passwordAuthHandler.Authorize)
+		v1auth.POST("password/reset_request", passwordAuthHandler.RequestPasswordReset)
+		v1auth.POST("password/reset", passwordAuthHandler.ResetPassword)
+		v1auth.POST("password/change", authMiddleware, authM.RequireAuthentication, authM.RequireInteractiveSignIn, passwordAuthHandler.ChangePassword)
+		v1auth.POST("totp/challenge/enroll", totpAuthHandler.StartEnrollmentWithChallenge)
+		v1auth.POST("totp/challenge/verify", totpAuthHandler.VerifyChallenge)
+		v1auth.GET("oidc/:provider/authorization_url", oidcAuthHandler.CreateAuthorizationURL)
+		v1auth.POST("oidc/:provider/authorize", oidcAuthHandler.Authorize)
 		v1auth.POST("refresh_token", authHandler.RefreshToken)
+		v1auth.POST("logout", authHandler.Logout)
+		v1auth.DELETE("user/:appUserID/refresh_token", authMiddleware, requireOwner, authM.RequireInteractiveSignIn, authHandler.RevokeAllRefreshTokens)
+
+		v1PersonalAccessToken := v1auth.Group("personal_access_token")
+		personalAccessTokenHandler := authH.NewPersonalAccessTokenHandler(personalAccessTokenUsecase)
+		v1PersonalAccessToken.Use(authMiddleware, authM.RequireAuthentication, authM.RequireInteractiveSignIn)
+		v1PersonalAccessToken.POST("", personalAccessTokenHandler.AddPersonalAccessToken)
+		v1PersonalAccessToken.GET("", personalAccessTokenHandler.FindPersonalAccessTokens)
+		v1PersonalAccessToken.DELETE(":tokenID", personalAccessTokenHandler.RemovePersonalAccessToken)
+
+		v1TOTP := v1auth.Group("totp")
+		v1TOTP.Use(authMiddleware, authM.RequireAuthentication, authM.RequireInteractiveSignIn)
+		v1TOTP.GET("", totpAuthHandler.FindTOTPStatus)
+		v1TOTP.POST("enroll", totpAuthHandler.StartEnrollment)
+		v1TOTP.POST("confirm", totpAuthHandler.ConfirmEnrollment)
+		v1TOTP.POST("recovery_codes", totpAuthHandler.RegenerateRecoveryCodes)
+		v1TOTP.POST("disable", totpAuthHandler.Disable)
+
+		organizationAdminHandler := userH.NewOrganizationAdminHandler(organizationAdminUsecase)
+		v1.POST("admin/organization", authMiddleware, requireSystemOwner, authM.RequireInteractiveSignIn, organizationAdminHandler.AddOrganization)
+
+		v1AdminUser := v1.Group("admin/user")
+		v1AdminUser.Use(authMiddleware, requireOwner, authM.RequireInteractiveSignIn)
+		v1AdminUser.GET("", organizationAdminHandler.FindAppUsers)
+		v1AdminUser.GET(":appUserID", organizationAdminHandler.FindAppUser)
+		v1AdminUser.POST("", organizationAdminHandler.InviteAppUser)
+		v1AdminUser.POST(":appUserID/disable", organizationAdminHandler.DisableAppUser)
+		v1AdminUser.POST(":appUserID/enable", organizationAdminHandler.EnableAppUser)
+		v1AdminUser.DELETE(":appUserID", organizationAdminHandler.RemoveAppUser)
+
+		v1AdminGroup := v1.Group("admin/group")
+		appUserGroupHandler := userH.NewAppUserGroupHandler(appUserGroupUsecase)
+		v1AdminGroup.Use(authMiddleware, requireOwner, authM.RequireInteractiveSignIn)
+		v1AdminGroup.GET("", appUserGroupHandler.FindAppUserGroups)
+		v1AdminGroup.GET(":appUserGroupID", appUserGroupHandler.FindAppUserGroupByID)
+		v1AdminGroup.POST("", appUserGroupHandler.AddAppUserGroup)
+		v1AdminGroup.PUT(":appUserGroupID", appUserGroupHandler.UpdateAppUserGroup)
+		v1AdminGroup.DELETE(":appUserGroupID", appUserGroupHandler.RemoveAppUserGroup)
+		v1AdminGroup.GET(":appUserGroupID/user", appUserGroupHandler.FindGroupUsers)
+		v1AdminGroup.POST(":appUserGroupID/user/:appUserID", appUserGroupHandler.AddGroupUser)
+		v1AdminGroup.DELETE(":appUserGroupID/user/:appUserID", appUserGroupHandler.RemoveGroupUser)
 
 		v1Workbook := v1.Group("private/workbook")
 		privateWorkbookHandler := NewPrivateWorkbookHandler(studentUsecaseWorkbook)
-		v1Workbook.Use(authMiddleware)
-		v1Workbook.POST(":workbookID", privateWorkbookHandler.FindWorkbooks)
+		v1Workbook.Use(authMiddleware, authM.RequireAuthentication)
+		v1Workbook.POST(":workbookID", authM.ReadOperation, privateWorkbookHandler.FindWorkbooks)
 		v1Workbook.GET(":workbookID", privateWorkbookHandler.FindWorkbookByID)
-		v1Workbook.PUT(":workbookID", privateWorkbookHandler.UpdateWorkbook)
-		v1Workbook.DELETE(":workbookID", privateWorkbookHandler.RemoveWorkbook)
+		v1Workbook.PUT(":workbookID", requireWorkbookUpdate, privateWorkbookHandler.UpdateWorkbook)
+		v1Workbook.DELETE(":workbookID", requireWorkbookRemove, privateWorkbookHandler.RemoveWorkbook)
 		v1Workbook.POST("", privateWorkbookHandler.AddWorkbook)
+		v1Workbook.PUT(":workbookID/space", requireWorkbookRemove, privateWorkbookHandler.MoveWorkbook)
+
+		v1Space := v1.Group("space")
+		spaceHandler := userH.NewSpaceHandler(spaceUsecase)
+		v1Space.Use(authMiddleware, authM.RequireAuthentication)
+		v1Space.GET("", spaceHandler.FindSharedSpaces)
+		v1Space.POST("", spaceHandler.AddSharedSpace)
+		v1Space.GET(":spaceID", spaceHandler.FindSharedSpaceByID)
+		v1Space.GET(":spaceID/member", spaceHandler.FindSpaceMembers)
+		v1Space.POST(":spaceID/member", spaceHandler.AddSpaceMember)
+		v1Space.PUT(":spaceID/member/:appUserID", spaceHandler.UpdateSpaceMember)
+		v1Space.DELETE(":spaceID/member/:appUserID", spaceHandler.RemoveSpaceMember)
+		v1Space.PUT(":spaceID/group/:appUserGroupID", spaceHandler.SetSpaceGroupRole)
+		v1Space.DELETE(":spaceID/group/:appUserGroupID", spaceHandler.RemoveSpaceGroup)
+		v1Space.GET(":spaceID/workbook", privateWorkbookHandler.FindWorkbooksFromSpace)
+		v1Space.POST(":spaceID/workbook", privateWorkbookHandler.AddWorkbookToSpace)
 
 		v1Problem := v1.Group("workbook/:workbookID/problem")
 		problemHandler := NewProblemHandler(studentUsecaseProblem, newIteratorFunc)
-		v1Problem.Use(authMiddleware)
-		v1Problem.POST("", problemHandler.AddProblem)
-		v1Problem.GET(":problemID", problemHandler.FindProblemByID)
-		v1Problem.DELETE(":problemID", problemHandler.RemoveProblem)
-		v1Problem.PUT(":problemID", problemHandler.UpdateProblem)
+		v1Problem.Use(authMiddleware, authM.RequireAuthentication)
+		v1Problem.POST("", requireWorkbookUpdate, problemHandler.AddProblem)
+		v1Problem.GET(":problemID", requireWorkbookRead, problemHandler.FindProblemByID)
+		v1Problem.DELETE(":problemID", requireWorkbookUpdate, problemHandler.RemoveProblem)
+		v1Problem.PUT(":problemID", requireWorkbookUpdate, problemHandler.UpdateProblem)
 		// v1Problem.GET("problem_ids", problemHandler.FindProblemIDs)
-		v1Problem.POST("find", problemHandler.FindProblems)
-		v1Problem.POST("find_all", problemHandler.FindAllProblems)
-		v1Problem.POST("find_by_ids", problemHandler.FindProblemsByProblemIDs)
-		v1Problem.POST("import", problemHandler.ImportProblems)
+		v1Problem.POST("find", authM.ReadOperation, requireWorkbookRead, problemHandler.FindProblems)
+		v1Problem.POST("find_all", authM.ReadOperation, requireWorkbookRead, problemHandler.FindAllProblems)
+		v1Problem.POST("find_by_ids", authM.ReadOperation, requireWorkbookRead, problemHandler.FindProblemsByProblemIDs)
+		v1Problem.POST("import", requireWorkbookUpdate, problemHandler.ImportProblems)
+		v1Problem.POST("attach_sentences", requireWorkbookUpdate, problemHandler.AttachSentences)
 
 		v1Study := v1.Group("study/workbook/:workbookID")
 		recordbookHandler := NewRecordbookHandler(studentUsecaseStudy)
-		v1Study.Use(authMiddleware)
+		v1Study.Use(authMiddleware, authM.RequireAuthentication, requireWorkbookRead)
 		v1Study.GET("study_type/:studyType", recordbookHandler.FindRecordbook)
 		v1Study.POST("study_type/:studyType/problem/:problemID/record", recordbookHandler.SetStudyResult)
 		v1Study.GET("completion_rate", recordbookHandler.GetCompletionRate)
@@ -99,18 +181,37 @@ func NewRouter(googleUserUsecase authU.GoogleUserUsecase, guestUserUsecase authU
 		v1Audio := v1.Group("workbook/:workbookID/problem/:problemID/audio")
 
 		audioHandler := NewAudioHandler(studentUsecaseAudio)
-		v1Audio.Use(authMiddleware)
+		v1Audio.Use(authMiddleware, authM.RequireAuthentication, requireWorkbookRead)
 		v1Audio.GET(":audioID", audioHandler.FindAudioByID)
+		v1Audio.GET(":audioID/content", audioHandler.StreamAudioByID)
+
+		v1AudioGeneration := v1.Group("workbook/:workbookID/audio/generation")
+		v1AudioGeneration.Use(authMiddleware, authM.RequireAuthentication)
+		v1AudioGeneration.POST("", requireWorkbookUpdate, audioHandler.StartAudioGeneration)
+		v1AudioGeneration.GET("", requireWorkbookRead, audioHandler.FindAudioGenerationJob)
+
+		v1AudioBundle := v1.Group("workbook/:workbookID")
+		v1AudioBundle.Use(authMiddleware, authM.RequireAuthentication, requireWorkbookRead)
+		v1AudioBundle.GET("audio.zip", audioHandler.ExportAudioBundle)
+
+		if synthesizerCacheClient != nil {
+			v1AudioCache := v1.Group("audio/cache")
+			audioCacheHandler := NewAudioCacheHandler(synthesizerCacheClient)
+			v1AudioCache.Use(authMiddleware, requireSystemOwner)
+			v1AudioCache.DELETE("", audioCacheHandler.PurgeAudioCache)
+		}
 	}
 
 	plugin := router.Group("plugin")
 	{
 		plugin.Use(otelgin.Middleware(appConfig.Name))
 		plugin.Use(ginmiddleware.NewTraceLogMiddleware(appConfig.Name))
-		plugin.Use(authMiddleware)
+		plugin.Use(authMiddleware, authM.RequireAuthentication)
 
 		InitTranslatorPluginRouter(plugin, translatorClient)
-		InitTatoebaPluginRouter(plugin, tatoebaClient)
+		InitTatoebaPluginRouter(plugin, tatoebaClient, tatoebaImportUsecase)
+		InitGlossaryPluginRouter(plugin, glossaryUsecase)
+		InitEnglishPluginRouter(plugin, studentUsecaseNGSL, studentUsecaseTatoeba)
 	}
 
 	return router
@@ -119,20 +220,53 @@ func NewRouter(googleUserUsecase authU.GoogleUserUsecase, guestUserUsecase authU
 func InitTranslatorPluginRouter(plugin *gin.RouterGroup, translatorClient pluginCommonService.TranslatorClient) {
 
 	pluginTranslation := plugin.Group("translation")
-	translationHandler := pluginCommonController.NewTranslationHandler(translatorClient)
-	pluginTranslation.POST("find", translationHandler.FindTranslations)
+	pluginTranslation.Use(authM.RequireRole(userD.OwnerRole))
+	translationUsecase := pluginCommonUsecase.NewTranslationUsecase(translatorClient)
+	translationHandler := pluginCommonController.NewTranslationHandler(translatorClient, translationUsecase)
+	pluginTranslation.POST("find", authM.ReadOperation, translationHandler.FindTranslations)
 	pluginTranslation.GET("text/:text/pos/:pos", translationHandler.FindTranslationByTextAndPos)
 	pluginTranslation.GET("text/:text", translationHandler.FindTranslationsByText)
 	pluginTranslation.PUT("text/:text/pos/:pos", translationHandler.UpdateTranslation)
 	pluginTranslation.DELETE("text/:text/pos/:pos", translationHandler.RemoveTranslation)
 	pluginTranslation.POST("", translationHandler.AddTranslation)
-	pluginTranslation.POST("export", translationHandler.ExportTranslations)
+	pluginTranslation.GET("export", translationHandler.ExportTranslations)
+	pluginTranslation.POST("export", authM.ReadOperation, translationHandler.ExportTranslations)
+	pluginTranslation.POST("import", translationHandler.ImportTranslations)
+}
+
+func InitGlossaryPluginRouter(plugin *gin.RouterGroup, glossaryUsecase pluginCommonUsecase.GlossaryUsecase) {
+	pluginGlossary := plugin.Group("glossary")
+	glossaryHandler := pluginCommonController.NewGlossaryHandler(glossaryUsecase)
+	pluginGlossary.GET("", glossaryHandler.FindGlossaryEntries)
+	requireOwner := authM.RequireRole(userD.OwnerRole)
+	pluginGlossary.PUT("", requireOwner, glossaryHandler.SetGlossaryEntry)
+	pluginGlossary.DELETE("lang2/:lang2/text/:text/pos/:pos", requireOwner, glossaryHandler.RemoveGlossaryEntry)
+	pluginGlossary.POST("import", requireOwner, glossaryHandler.ImportGlossary)
+	pluginGlossary.GET("export", glossaryHandler.ExportGlossary)
 }
 
-func InitTatoebaPluginRouter(plugin *gin.RouterGroup, tatoebaClient pluginCommonService.TatoebaClient) {
+func InitTatoebaPluginRouter(plugin *gin.RouterGroup, tatoebaClient pluginCommonService.TatoebaClient, tatoebaImportUsecase pluginCommonUsecase.TatoebaImportUsecase) {
 	pluginTatoeba := plugin.Group("tatoeba")
 	tatoebaHandler := pluginCommonController.NewTatoebaHandler(tatoebaClient)
-	pluginTatoeba.POST("find", tatoebaHandler.FindSentencePairs)
-	pluginTatoeba.POST("sentence/import", tatoebaHandler.ImportSentences)
-	pluginTatoeba.POST("link/import", tatoebaHandler.ImportLinks)
+	pluginTatoeba.POST("find", authM.ReadOperation, tatoebaHandler.FindSentencePairs)
+	requireOwner := authM.RequireRole(userD.OwnerRole)
+	pluginTatoeba.POST("sentence/import", requireOwner, tatoebaHandler.ImportSentences)
+	pluginTatoeba.POST("link/import", requireOwner, tatoebaHandler.ImportLinks)
+
+	tatoebaImportHandler := pluginCommonController.NewTatoebaImportHandler(tatoebaImportUsecase)
+	pluginTatoebaImportJob := pluginTatoeba.Group("import/job")
+	pluginTatoebaImportJob.Use(requireOwner)
+	pluginTatoebaImportJob.POST("", tatoebaImportHandler.StartImport)
+	pluginTatoebaImportJob.GET(":jobID", tatoebaImportHandler.FindJob)
+	pluginTatoebaImportJob.PUT(":jobID/chunk", tatoebaImportHandler.UploadChunk)
+	pluginTatoebaImportJob.POST(":jobID/resume", tatoebaImportHandler.ResumeJob)
+}
+
+func InitEnglishPluginRouter(plugin *gin.RouterGroup, studentUsecaseNGSL pluginEnglishUsecase.StudentUsecaseNGSL, studentUsecaseTatoeba pluginEnglishUsecase.StudentUsecaseTatoeba) {
+	pluginEnglish := plugin.Group("english")
+	ngslHandler := pluginEnglishController.NewNGSLHandler(studentUsecaseNGSL)
+	pluginEnglish.POST("ngsl/workbook", ngslHandler.GenerateWorkbook)
+	sentenceHandler := pluginEnglishController.NewSentenceHandler(studentUsecaseTatoeba)
+	pluginEnglish.POST("sentence/find", authM.ReadOperation, sentenceHandler.FindSentencePairs)
+	pluginEnglish.GET("sentence/recommendation", sentenceHandler.RecommendSentences)
 }
@@ -0,0 +1,130 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/app/config"
+	"github.com/kujilabo/cocotola-api/src/app/controller"
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	authG "github.com/kujilabo/cocotola-api/src/auth/gateway"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userU "github.com/kujilabo/cocotola-api/src/user/usecase"
+)
+
+type organizationAdminUsecaseStub struct {
+	userU.OrganizationAdminUsecase
+}
+
+func (s *organizationAdminUsecaseStub) AddOrganization(ctx context.Context, param *userU.OrganizationAddParameter) (userD.OrganizationID, error) {
+	return userD.OrganizationID(3), nil
+}
+
+type synthesizerCacheClientStub struct {
+	appS.SynthesizerCacheClient
+}
+
+func (s *synthesizerCacheClientStub) PurgeCache(ctx context.Context, lang2 appD.Lang2) (int, error) {
+	return 5, nil
+}
+
+func appRouter_newRouter(t *testing.T, signingKeySet *authG.SigningKeySet, systemOrganizationID userD.OrganizationID) *gin.Engine {
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = true
+	return controller.NewRouter(signingKeySet, nil, nil, nil, nil, nil, nil, nil, &organizationAdminUsecaseStub{}, nil, nil, nil, nil, nil, &synthesizerCacheClientStub{}, nil, nil, nil, nil, nil, nil, nil, nil, systemOrganizationID, corsConfig, &config.AppConfig{Name: "test"}, &config.AuthConfig{}, &config.DebugConfig{})
+}
+
+func appRouter_newAccessToken(t *testing.T, signingKeySet *authG.SigningKeySet, organizationID userD.OrganizationID, role string) string {
+	token, err := signingKeySet.Sign(authG.AppUserClaims{
+		AppUserID:      2,
+		OrganizationID: uint(organizationID),
+		Role:           role,
+		TokenType:      "access",
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func Test_NewRouter_addOrganization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	key, err := authG.NewHMACSigningKey("", []byte("SIGNING_KEY"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	signingKeySet, err := authG.NewSigningKeySet([]*authG.SigningKey{key})
+	require.NoError(t, err)
+	router := appRouter_newRouter(t, signingKeySet, userD.OrganizationID(1))
+
+	tests := []struct {
+		name           string
+		organizationID userD.OrganizationID
+		role           string
+		code           int
+	}{
+		{name: "owner of the system organization can add the organization", organizationID: 1, role: "Owner", code: http.StatusOK},
+		{name: "user of the system organization can't add the organization", organizationID: 1, role: "User", code: http.StatusForbidden},
+		{name: "owner of the other organization can't add the organization", organizationID: 2, role: "Owner", code: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"name":"ORG","firstOwner":{"loginId":"OWNER","username":"OWNER","password":"PASSWORD"}}`
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/v1/admin/organization", bytes.NewBufferString(body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+appRouter_newAccessToken(t, signingKeySet, tt.organizationID, tt.role))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.JSONEq(t, `{"id":3}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test_NewRouter_purgeAudioCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	key, err := authG.NewHMACSigningKey("", []byte("SIGNING_KEY"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	signingKeySet, err := authG.NewSigningKeySet([]*authG.SigningKey{key})
+	require.NoError(t, err)
+	router := appRouter_newRouter(t, signingKeySet, userD.OrganizationID(1))
+
+	tests := []struct {
+		name           string
+		organizationID userD.OrganizationID
+		role           string
+		code           int
+	}{
+		{name: "owner of the system organization can purge the audio caches", organizationID: 1, role: "Owner", code: http.StatusOK},
+		{name: "user of the system organization can't purge the audio caches", organizationID: 1, role: "User", code: http.StatusForbidden},
+		{name: "owner of the other organization can't purge the audio caches", organizationID: 2, role: "Owner", code: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodDelete, "/v1/audio/cache", nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+appRouter_newAccessToken(t, signingKeySet, tt.organizationID, tt.role))
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.JSONEq(t, `{"count":5}`, w.Body.String())
+			}
+		})
+	}
+}
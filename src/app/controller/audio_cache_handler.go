@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type AudioCacheHandler interface {
+	PurgeAudioCache(c *gin.Context)
+}
+
+type audioCacheHandler struct {
+	synthesizerCacheClient service.SynthesizerCacheClient
+}
+
+func NewAudioCacheHandler(synthesizerCacheClient service.SynthesizerCacheClient) AudioCacheHandler {
+	return &audioCacheHandler{
+		synthesizerCacheClient: synthesizerCacheClient,
+	}
+}
+
+// PurgeAudioCache godoc
+// @Summary Remove the audio caches of the language, or all the audio caches if lang2 is not specified
+// @Produce json
+// @Param lang2 query string false "lang2"
+// @Success 200 {object} map[string]int
+// @Failure 400
+// @Router /v1/audio/cache [delete]
+func (h *audioCacheHandler) PurgeAudioCache(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		var lang2 domain.Lang2
+		if c.Query("lang2") != "" {
+			l, err := domain.NewLang2(c.Query("lang2"))
+			if err != nil {
+				return err
+			}
+			lang2 = l
+		}
+
+		count, err := h.synthesizerCacheClient.PurgeCache(ctx, lang2)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("audio caches are purged. lang2: %s, count: %d", c.Query("lang2"), count)
+		c.JSON(http.StatusOK, gin.H{"count": count})
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *audioCacheHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("audioCacheHandler err: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	}
+	logger.Errorf("audioCacheHandler err: %+v", err)
+	return false
+}
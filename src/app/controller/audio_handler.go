@@ -1,8 +1,12 @@
 package controller
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/kujilabo/cocotola-api/src/app/domain"
 	"github.com/kujilabo/cocotola-api/src/app/service"
 	studentU "github.com/kujilabo/cocotola-api/src/app/usecase/student"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
@@ -18,6 +23,14 @@ import (
 
 type AudioHandler interface {
 	FindAudioByID(c *gin.Context)
+
+	StreamAudioByID(c *gin.Context)
+
+	StartAudioGeneration(c *gin.Context)
+
+	FindAudioGenerationJob(c *gin.Context)
+
+	ExportAudioBundle(c *gin.Context)
 }
 
 type audioHandler struct {
@@ -67,6 +80,151 @@ func (h *audioHandler) FindAudioByID(c *gin.Context) {
 	}, h.errorHandle)
 }
 
+// StreamAudioByID godoc
+// @Summary Stream the binary audio. Range requests and conditional requests are supported
+// @Produce audio/mpeg,audio/wave
+// @Param workbookID path int true "Workbook ID"
+// @Param problemID path int true "Problem ID"
+// @Param audioID path int true "Audio ID"
+// @Success 200
+// @Success 206
+// @Success 304
+// @Failure 400
+// @Failure 404
+// @Router /v1/workbook/{workbookID}/problem/{problemID}/audio/{audioID}/content [get]
+func (h *audioHandler) StreamAudioByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+		problemID, err := ginhelper.GetUintFromPath(c, "problemID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+		audioID, err := ginhelper.GetUintFromPath(c, "audioID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		result, err := h.studentUsecaseAudio.FindAudioByID(ctx, organizationID, operatorID, domain.WorkbookID(workbookID), domain.ProblemID(problemID), domain.AudioID(audioID))
+		if err != nil {
+			return err
+		}
+
+		content, err := converter.ToAudioContent(ctx, result)
+		if err != nil {
+			return err
+		}
+
+		// the audio of the id never changes, but it is visible only to the users who can read the workbook
+		c.Header("Cache-Control", "private, max-age=31536000, immutable")
+		c.Header("ETag", content.ETag)
+		c.Header("Content-Type", content.ContentType)
+		// ServeContent handles Content-Length, Range and If-None-Match
+		http.ServeContent(c.Writer, c.Request, "", time.Time{}, bytes.NewReader(content.Data))
+		return nil
+	}, h.errorHandle)
+}
+
+// StartAudioGeneration godoc
+// @Summary Start the job which synthesizes the audio of all the problems in the workbook which don't have any audio
+// @Produce json
+// @Param workbookID path int true "Workbook ID"
+// @Success 202 {object} entity.AudioGenerationJobResponse
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Router /v1/workbook/{workbookID}/audio/generation [post]
+func (h *audioHandler) StartAudioGeneration(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		job, err := h.studentUsecaseAudio.StartAudioGeneration(ctx, organizationID, operatorID, domain.WorkbookID(workbookID))
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusAccepted, converter.ToAudioGenerationJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+// FindAudioGenerationJob godoc
+// @Summary Find the latest audio generation job of the workbook
+// @Produce json
+// @Param workbookID path int true "Workbook ID"
+// @Success 200 {object} entity.AudioGenerationJobResponse
+// @Failure 400
+// @Failure 404
+// @Router /v1/workbook/{workbookID}/audio/generation [get]
+func (h *audioHandler) FindAudioGenerationJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		job, err := h.studentUsecaseAudio.FindAudioGenerationJob(ctx, organizationID, operatorID, domain.WorkbookID(workbookID))
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, converter.ToAudioGenerationJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+// ExportAudioBundle godoc
+// @Summary Download the zip file of the audio of all the problems in the workbook with the manifest
+// @Produce application/zip
+// @Param workbookID path int true "Workbook ID"
+// @Success 200
+// @Failure 400
+// @Failure 404
+// @Router /v1/workbook/{workbookID}/audio.zip [get]
+func (h *audioHandler) ExportAudioBundle(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.studentUsecaseAudio.ExportAudioBundle(ctx, organizationID, operatorID, domain.WorkbookID(workbookID), func(workbookModel domain.WorkbookModel) io.Writer {
+			c.Header("Content-Type", "application/zip")
+			c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="workbook-%d-audio.zip"`, workbookModel.GetID()))
+			c.Status(http.StatusOK)
+			return c.Writer
+		}); err != nil {
+			if c.Writer.Written() {
+				// the status can't be changed after the zip file is partially written
+				log.FromContext(ctx).Errorf("failed to ExportAudioBundle. err: %v", err)
+				return nil
+			}
+			return err
+		}
+		return nil
+	}, h.errorHandle)
+}
+
 func (h *audioHandler) errorHandle(c *gin.Context, err error) bool {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
@@ -74,6 +232,26 @@ func (h *audioHandler) errorHandle(c *gin.Context, err error) bool {
 		logger.Warnf("audioHandler err: %+v", err)
 		c.JSON(http.StatusNotFound, gin.H{"message": "Audio not found"})
 		return true
+	} else if errors.Is(err, service.ErrWorkbookNotFound) {
+		logger.Warnf("audioHandler err: %+v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Workbook not found"})
+		return true
+	} else if errors.Is(err, service.ErrAudioGenerationJobNotFound) {
+		logger.Warnf("audioHandler err: %+v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Audio generation job not found"})
+		return true
+	} else if errors.Is(err, service.ErrAudioGenerationJobAlreadyRunning) {
+		logger.Warnf("audioHandler err: %+v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Audio generation job is already running"})
+		return true
+	} else if errors.Is(err, service.ErrWorkbookPermissionDenied) {
+		logger.Warnf("audioHandler err: %+v", err)
+		c.JSON(http.StatusForbidden, gin.H{"message": "Permission denied"})
+		return true
+	} else if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("audioHandler err: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
 	}
 	logger.Errorf("error:%v", err)
 	return false
@@ -12,7 +12,7 @@ import (
 )
 
 func ToProblemSearchCondition(ctx context.Context, param *entity.ProblemFindParameter, workbookID domain.WorkbookID) (service.ProblemSearchCondition, error) {
-	return service.NewProblemSearchCondition(workbookID, param.PageNo, param.PageSize, param.Keyword)
+	return service.NewProblemSearchCondition(workbookID, param.PageNo, param.PageSize, param.Keyword, param.Properties)
 }
 
 func ToProblemFindResponse(ctx context.Context, result service.ProblemSearchResult) (*entity.ProblemFindResponse, error) {
@@ -1,13 +1,21 @@
 package converter
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
 
 	"github.com/kujilabo/cocotola-api/src/app/controller/entity"
 	"github.com/kujilabo/cocotola-api/src/app/service"
 	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 )
 
+const etagHashLength = 16
+
 func ToAudioResponse(ctx context.Context, audio service.Audio) (*entity.AudioResponse, error) {
 	audioModel := audio.GetAudioModel()
 	e := &entity.AudioResponse{
@@ -19,3 +27,46 @@ func ToAudioResponse(ctx context.Context, audio service.Audio) (*entity.AudioRes
 
 	return e, libD.Validator.Struct(e)
 }
+
+// ToAudioContent decodes the base64 encoded content of the audio
+func ToAudioContent(ctx context.Context, audio service.Audio) (*entity.AudioContent, error) {
+	audioModel := audio.GetAudioModel()
+	data, err := base64.StdEncoding.DecodeString(audioModel.GetContent())
+	if err != nil {
+		return nil, liberrors.Errorf("failed to DecodeString. audioID: %d, err: %w", audioModel.GetID(), err)
+	}
+
+	hash := sha256.Sum256(data)
+	e := &entity.AudioContent{
+		Data:        data,
+		ContentType: detectAudioContentType(data),
+		ETag:        `"` + hex.EncodeToString(hash[:])[:etagHashLength] + `"`,
+	}
+
+	return e, libD.Validator.Struct(e)
+}
+
+// detectAudioContentType detects MP3 without ID3 tags in addition to the types http.DetectContentType detects
+func detectAudioContentType(data []byte) string {
+	if len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0 {
+		return "audio/mpeg"
+	}
+	if bytes.HasPrefix(data, []byte("OggS")) {
+		return "audio/ogg"
+	}
+	return http.DetectContentType(data)
+}
+
+func ToAudioGenerationJobResponse(ctx context.Context, job service.AudioGenerationJob) *entity.AudioGenerationJobResponse {
+	return &entity.AudioGenerationJobResponse{
+		ID:                uint(job.GetID()),
+		WorkbookID:        uint(job.GetWorkbookID()),
+		TotalProblems:     job.GetTotalProblems(),
+		ProcessedProblems: job.GetProcessedProblems(),
+		GeneratedAudios:   job.GetGeneratedAudios(),
+		Status:            string(job.GetStatus()),
+		ErrorMessage:      job.GetErrorMessage(),
+		CreatedAt:         job.GetCreatedAt(),
+		UpdatedAt:         job.GetUpdatedAt(),
+	}
+}
@@ -0,0 +1,48 @@
+package converter
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+)
+
+func TestToAudioContent(t *testing.T) {
+	newAudio := func(content string) service.Audio {
+		audioModel, err := domain.NewAudioModel(1, domain.Lang2EN, "hello", content)
+		require.NoError(t, err)
+		audio, err := service.NewAudio(audioModel)
+		require.NoError(t, err)
+		return audio
+	}
+
+	wav := []byte("RIFF\x24\x00\x00\x00WAVEfmt \x10\x00\x00\x00")
+	mp3 := []byte{0xFF, 0xFB, 0x90, 0x64, 0x00}
+
+	tests := []struct {
+		name            string
+		data            []byte
+		wantContentType string
+	}{
+		{name: "wav", data: wav, wantContentType: "audio/wave"},
+		{name: "mp3 without id3 tags", data: mp3, wantContentType: "audio/mpeg"},
+		{name: "mp3 with id3 tags", data: append([]byte("ID3"), mp3...), wantContentType: "audio/mpeg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToAudioContent(context.Background(), newAudio(base64.StdEncoding.EncodeToString(tt.data)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.data, got.Data)
+			assert.Equal(t, tt.wantContentType, got.ContentType)
+			assert.Len(t, got.ETag, etagHashLength+2)
+		})
+	}
+
+	_, err := ToAudioContent(context.Background(), newAudio("not base64!"))
+	assert.Error(t, err)
+}
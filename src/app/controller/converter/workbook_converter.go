@@ -55,5 +55,5 @@ func ToWorkbookAddParameter(param *entity.WorkbookAddParameter) (service.Workboo
 }
 
 func ToWorkbookUpdateParameter(param *entity.WorkbookUpdateParameter) (service.WorkbookUpdateParameter, error) {
-	return service.NewWorkbookUpdateParameter(param.Name, param.QuestionText)
+	return service.NewWorkbookUpdateParameter(param.Name, param.QuestionText, param.Properties)
 }
@@ -3,9 +3,10 @@ package entity
 import "encoding/json"
 
 type ProblemFindParameter struct {
-	PageNo   int    `json:"pageNo" binding:"required,gte=1"`
-	PageSize int    `json:"pageSize" binding:"required,gte=1"`
-	Keyword  string `json:"keyword"`
+	PageNo     int               `json:"pageNo" binding:"required,gte=1"`
+	PageSize   int               `json:"pageSize" binding:"required,gte=1"`
+	Keyword    string            `json:"keyword"`
+	Properties map[string]string `json:"properties"`
 }
 
 type ProblemIDsParameter struct {
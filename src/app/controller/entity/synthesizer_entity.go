@@ -1,8 +1,29 @@
 package entity
 
+import "time"
+
 type AudioResponse struct {
 	ID      int    `json:"id"`
 	Lang2   string `json:"lang2" validate:"len=2"`
 	Text    string `json:"text" validate:"required"`
 	Content string `json:"content" validate:"required"`
 }
+
+// AudioContent is the decoded audio which is streamed as it is
+type AudioContent struct {
+	Data        []byte `validate:"required"`
+	ContentType string `validate:"required"`
+	ETag        string `validate:"required"`
+}
+
+type AudioGenerationJobResponse struct {
+	ID                uint      `json:"id"`
+	WorkbookID        uint      `json:"workbookId"`
+	TotalProblems     int       `json:"totalProblems"`
+	ProcessedProblems int       `json:"processedProblems"`
+	GeneratedAudios   int       `json:"generatedAudios"`
+	Status            string    `json:"status"`
+	ErrorMessage      string    `json:"errorMessage,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
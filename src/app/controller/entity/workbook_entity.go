@@ -47,6 +47,11 @@ type WorkbookWithProblemsHTTPEntity struct {
 	Subscribed   bool       `json:"subscribed"`
 }
 
+type WorkbookFindParameter struct {
+	PageNo   int `form:"pageNo" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
+}
+
 type WorkbookSearchResponse struct {
 	TotalCount int                           `json:"totalCount" validate:"gte=0"`
 	Results    []*WorkbookResponseHTTPEntity `json:"results" validate:"dive"`
@@ -59,6 +64,11 @@ type WorkbookAddParameter struct {
 }
 
 type WorkbookUpdateParameter struct {
-	Name         string `json:"name" binding:"required"`
-	QuestionText string `json:"questionText"`
+	Name         string            `json:"name" binding:"required"`
+	QuestionText string            `json:"questionText"`
+	Properties   map[string]string `json:"properties"`
+}
+
+type WorkbookMoveParameter struct {
+	SpaceID uint `json:"spaceId" binding:"required,gte=1"`
 }
@@ -26,6 +26,9 @@ type PrivateWorkbookHandler interface {
 	AddWorkbook(c *gin.Context)
 	UpdateWorkbook(c *gin.Context)
 	RemoveWorkbook(c *gin.Context)
+	MoveWorkbook(c *gin.Context)
+	FindWorkbooksFromSpace(c *gin.Context)
+	AddWorkbookToSpace(c *gin.Context)
 }
 
 type privateWorkbookHandler struct {
@@ -211,6 +214,137 @@ func (h *privateWorkbookHandler) RemoveWorkbook(c *gin.Context) {
 	}, h.errorHandle)
 }
 
+// MoveWorkbook godoc
+// @Summary     Move the workbook to the space
+// @Tags        private workbook
+// @Accept      json
+// @Param       workbookID path int true "Workbook ID"
+// @Param       version query int true "version of the workbook"
+// @Param       param body entity.WorkbookMoveParameter true "parameter to move the workbook"
+// @Success     204
+// @Failure     400
+// @Failure     403
+// @Failure     404
+// @Router      /v1/private/workbook/{workbookID}/space [put]
+func (h *privateWorkbookHandler) MoveWorkbook(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("MoveWorkbook")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.WorkbookMoveParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("failed to BindJSON. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		version, err := ginhelper.GetIntFromQuery(c, "version")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.studentUsecaseWorkbook.MoveWorkbook(ctx, organizationID, operatorID, domain.WorkbookID(workbookID), version, userD.SpaceID(param.SpaceID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// FindWorkbooksFromSpace godoc
+// @Summary Find the workbooks in the space
+// @Produce json
+// @Param spaceID path int true "Space ID"
+// @Param pageNo query int true "page number"
+// @Param pageSize query int true "page size"
+// @Success 200 {object} entity.WorkbookSearchResponse
+// @Failure 400
+// @Failure 403
+// @Router /v1/space/{spaceID}/workbook [get]
+func (h *privateWorkbookHandler) FindWorkbooksFromSpace(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		param := entity.WorkbookFindParameter{}
+		if err := c.ShouldBindQuery(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		result, err := h.studentUsecaseWorkbook.FindWorkbooksFromSpace(ctx, organizationID, operatorID, userD.SpaceID(spaceID), param.PageNo, param.PageSize)
+		if err != nil {
+			return err
+		}
+
+		response, err := converter.ToWorkbookSearchResponse(result)
+		if err != nil {
+			return err
+		}
+		c.JSON(http.StatusOK, response)
+		return nil
+	}, h.errorHandle)
+}
+
+// AddWorkbookToSpace godoc
+// @Summary Create new workbook in the space
+// @Produce json
+// @Param spaceID path int true "Space ID"
+// @Param param body entity.WorkbookAddParameter true "parameter to create new workbook"
+// @Success 200 {object} controllerhelper.IDResponse
+// @Failure 400
+// @Failure 403
+// @Failure 409
+// @Router /v1/space/{spaceID}/workbook [post]
+func (h *privateWorkbookHandler) AddWorkbookToSpace(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.WorkbookAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("failed to BindJSON. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		parameter, err := converter.ToWorkbookAddParameter(&param)
+		if err != nil {
+			return liberrors.Errorf("failed to ToWorkbookAddParameter. err: %w", err)
+		}
+
+		workbookID, err := h.studentUsecaseWorkbook.AddWorkbookToSpace(ctx, organizationID, operatorID, userD.SpaceID(spaceID), parameter)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, controllerhelper.IDResponse{ID: uint(workbookID)})
+		return nil
+	}, h.errorHandle)
+}
+
 func (h *privateWorkbookHandler) errorHandle(c *gin.Context, err error) bool {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
@@ -223,6 +357,10 @@ func (h *privateWorkbookHandler) errorHandle(c *gin.Context, err error) bool {
 		logger.Warnf("workbookHandler err: %+v", err)
 		c.JSON(http.StatusNotFound, gin.H{"message": "Workbook not found"})
 		return true
+	} else if errors.Is(err, service.ErrWorkbookPermissionDenied) {
+		logger.Warnf("workbookHandler err: %+v", err)
+		c.JSON(http.StatusForbidden, gin.H{"message": http.StatusText(http.StatusForbidden)})
+		return true
 	}
 	logger.Errorf("workbookHandler err: %+v", err)
 	return false
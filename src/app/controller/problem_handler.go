@@ -40,14 +40,16 @@ type ProblemHandler interface {
 	UpdateProblem(c *gin.Context)
 
 	RemoveProblem(c *gin.Context)
+
+	AttachSentences(c *gin.Context)
 }
 
 type problemHandler struct {
 	studentUsecaseProblem studentU.StudentUsecaseProblem
-	newIterator           func(ctx context.Context, workbookID domain.WorkbookID, problemType string, reader io.Reader) (service.ProblemAddParameterIterator, error)
+	newIterator           func(ctx context.Context, organizationID userD.OrganizationID, workbookID domain.WorkbookID, problemType string, reader io.Reader) (service.ProblemAddParameterIterator, error)
 }
 
-func NewProblemHandler(studentUsecaseProblem studentU.StudentUsecaseProblem, newIterator func(ctx context.Context, workbookID domain.WorkbookID, problemType string, reader io.Reader) (service.ProblemAddParameterIterator, error)) ProblemHandler {
+func NewProblemHandler(studentUsecaseProblem studentU.StudentUsecaseProblem, newIterator func(ctx context.Context, organizationID userD.OrganizationID, workbookID domain.WorkbookID, problemType string, reader io.Reader) (service.ProblemAddParameterIterator, error)) ProblemHandler {
 	return &problemHandler{
 		studentUsecaseProblem: studentUsecaseProblem,
 		newIterator:           newIterator,
@@ -335,8 +337,20 @@ func (h *problemHandler) ImportProblems(c *gin.Context) {
 		}
 		defer multipartFile.Close()
 
+		properties := make(map[string]string)
+		if sentenceProvider := c.PostForm("sentenceProvider"); sentenceProvider != "" {
+			properties["sentenceProvider"] = sentenceProvider
+		}
+
 		newIterator := func(workbookID domain.WorkbookID, problemType string) (service.ProblemAddParameterIterator, error) {
-			return h.newIterator(ctx, workbookID, problemType, multipartFile)
+			iterator, err := h.newIterator(ctx, organizationID, workbookID, problemType, multipartFile)
+			if err != nil {
+				return nil, err
+			}
+			if len(properties) == 0 {
+				return iterator, nil
+			}
+			return service.NewProblemAddParameterIteratorWithProperties(iterator, properties), nil
 		}
 
 		if err := h.studentUsecaseProblem.ImportProblems(ctx, organizationID, operatorID, domain.WorkbookID(workbookID), newIterator); err != nil {
@@ -348,6 +362,28 @@ func (h *problemHandler) ImportProblems(c *gin.Context) {
 	}, h.errorHandle)
 }
 
+func (h *problemHandler) AttachSentences(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Infof("AttachSentences")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		updated, err := h.studentUsecaseProblem.AttachSentences(ctx, organizationID, operatorID, domain.WorkbookID(workbookID))
+		if err != nil {
+			return liberrors.Errorf("failed to AttachSentences. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, gin.H{"updated": updated})
+		return nil
+	}, h.errorHandle)
+}
+
 func (h *problemHandler) toProblemSelectParameter1(c *gin.Context) (service.ProblemSelectParameter1, error) {
 	workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
 	if err != nil {
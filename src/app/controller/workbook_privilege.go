@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+	studentU "github.com/kujilabo/cocotola-api/src/app/usecase/student"
+	authM "github.com/kujilabo/cocotola-api/src/auth/controller/middleware"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+// NewWorkbookPrivilegeFunc returns the privileges of the operator on the workbook specified by the workbookID path parameter
+func NewWorkbookPrivilegeFunc(studentUsecaseWorkbook studentU.StudentUsecaseWorkbook) authM.PrivilegeFunc {
+	return func(c *gin.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (userD.Privileges, error) {
+		ctx := c.Request.Context()
+
+		workbookID, err := ginhelper.GetUintFromPath(c, "workbookID")
+		if err != nil {
+			return nil, liberrors.Errorf("invalid workbookID. err: %v, %w", err, libD.ErrInvalidArgument)
+		}
+
+		workbook, err := studentUsecaseWorkbook.FindWorkbookByID(ctx, organizationID, operatorID, domain.WorkbookID(workbookID))
+		if err != nil {
+			if errors.Is(err, service.ErrWorkbookNotFound) {
+				return nil, liberrors.Errorf("workbookID: %d, err: %v, %w", workbookID, err, authM.ErrResourceNotFound)
+			} else if errors.Is(err, service.ErrWorkbookPermissionDenied) {
+				return userD.NewPrivileges([]userD.RBACAction{}), nil
+			}
+			return nil, err
+		}
+
+		return workbook, nil
+	}
+}
@@ -23,3 +23,15 @@ var WorkbookObjectPrefix = "workbook_"
 var PrivilegeRead = userD.RBACAction("read")
 var PrivilegeUpdate = userD.RBACAction("update")
 var PrivilegeRemove = userD.RBACAction("remove")
+
+// NewSpacePrivilege returns the privilege on the space which gives the privilege on the workbooks in the space
+func NewSpacePrivilege(privilege userD.RBACAction) userD.RBACAction {
+	switch privilege {
+	case PrivilegeRemove:
+		return userD.SpacePrivilegeAdmin
+	case PrivilegeUpdate:
+		return userD.SpacePrivilegeWrite
+	default:
+		return userD.SpacePrivilegeRead
+	}
+}
@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+)
+
+type audioCacheEntity struct {
+	ID        uint
+	CreatedAt time.Time
+	Lang2     string
+	Text      string
+	AudioID   uint
+}
+
+func (e *audioCacheEntity) TableName() string {
+	return "audio_cache"
+}
+
+type audioCacheRepository struct {
+	db *gorm.DB
+}
+
+func NewAudioCacheRepository(db *gorm.DB) service.AudioCacheRepository {
+	return &audioCacheRepository{
+		db: db,
+	}
+}
+
+func (r *audioCacheRepository) FindAudioID(ctx context.Context, lang2 domain.Lang2, text string) (domain.AudioID, error) {
+	_, span := tracer.Start(ctx, "audioCacheRepository.FindAudioID")
+	defer span.End()
+
+	entity := audioCacheEntity{}
+	if result := r.db.Where("lang2 = ? and text = ?", lang2.String(), text).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return 0, service.ErrAudioCacheNotFound
+		}
+		return 0, result.Error
+	}
+
+	return domain.AudioID(entity.AudioID), nil
+}
+
+func (r *audioCacheRepository) SetAudioID(ctx context.Context, lang2 domain.Lang2, text string, audioID domain.AudioID) error {
+	_, span := tracer.Start(ctx, "audioCacheRepository.SetAudioID")
+	defer span.End()
+
+	entity := audioCacheEntity{
+		Lang2:   lang2.String(),
+		Text:    text,
+		AudioID: uint(audioID),
+	}
+	if result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "lang2"}, {Name: "text"}},
+		DoUpdates: clause.AssignmentColumns([]string{"audio_id"}),
+	}).Create(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *audioCacheRepository) RemoveAudioID(ctx context.Context, audioID domain.AudioID) error {
+	_, span := tracer.Start(ctx, "audioCacheRepository.RemoveAudioID")
+	defer span.End()
+
+	if result := r.db.Where("audio_id = ?", uint(audioID)).Delete(&audioCacheEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *audioCacheRepository) PurgeAudioCache(ctx context.Context, lang2 domain.Lang2) (int, error) {
+	_, span := tracer.Start(ctx, "audioCacheRepository.PurgeAudioCache")
+	defer span.End()
+
+	db := r.db.Session(&gorm.Session{AllowGlobalUpdate: true})
+	if lang2 != nil {
+		db = db.Where("lang2 = ?", lang2.String())
+	}
+
+	result := db.Delete(&audioCacheEntity{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,146 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+)
+
+// audioGenerationJobStaleDuration is the duration after which the processing job which hasn't made any progress is regarded as stopped.
+// The job stops without updating the status when the server shuts down
+const audioGenerationJobStaleDuration = 10 * time.Minute
+
+type audioGenerationJobEntity struct {
+	ID                uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	CreatedBy         uint
+	OrganizationID    uint
+	WorkbookID        uint
+	TotalProblems     int
+	ProcessedProblems int
+	GeneratedAudios   int
+	Status            string
+	ErrorMessage      string
+}
+
+func (e *audioGenerationJobEntity) TableName() string {
+	return "audio_generation_job"
+}
+
+func (e *audioGenerationJobEntity) toModel() (service.AudioGenerationJob, error) {
+	status := service.AudioGenerationJobStatus(e.Status)
+	errorMessage := e.ErrorMessage
+	if status == service.AudioGenerationJobStatusProcessing && e.UpdatedAt.Before(time.Now().Add(-audioGenerationJobStaleDuration)) {
+		status = service.AudioGenerationJobStatusFailed
+		errorMessage = "the job has stopped"
+	}
+
+	return service.NewAudioGenerationJob(service.AudioGenerationJobID(e.ID), domain.WorkbookID(e.WorkbookID), e.TotalProblems, e.ProcessedProblems, e.GeneratedAudios, status, errorMessage, e.CreatedAt, e.UpdatedAt)
+}
+
+type audioGenerationJobRepository struct {
+	db *gorm.DB
+}
+
+func NewAudioGenerationJobRepository(db *gorm.DB) service.AudioGenerationJobRepository {
+	return &audioGenerationJobRepository{
+		db: db,
+	}
+}
+
+func (r *audioGenerationJobRepository) AddJob(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID, totalProblems int) (service.AudioGenerationJobID, error) {
+	_, span := tracer.Start(ctx, "audioGenerationJobRepository.AddJob")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&audioGenerationJobEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("workbook_id = ?", uint(workbookID)).
+		Where("status = ?", string(service.AudioGenerationJobStatusProcessing)).
+		Where("updated_at > ?", time.Now().Add(-audioGenerationJobStaleDuration)).
+		Count(&count); result.Error != nil {
+		return 0, result.Error
+	}
+	if count > 0 {
+		return 0, service.ErrAudioGenerationJobAlreadyRunning
+	}
+
+	entity := audioGenerationJobEntity{
+		CreatedBy:      operator.GetID(),
+		OrganizationID: uint(operator.GetOrganizationID()),
+		WorkbookID:     uint(workbookID),
+		TotalProblems:  totalProblems,
+		Status:         string(service.AudioGenerationJobStatusProcessing),
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return 0, result.Error
+	}
+
+	return service.AudioGenerationJobID(entity.ID), nil
+}
+
+func (r *audioGenerationJobRepository) FindLatestJob(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID) (service.AudioGenerationJob, error) {
+	_, span := tracer.Start(ctx, "audioGenerationJobRepository.FindLatestJob")
+	defer span.End()
+
+	entity := audioGenerationJobEntity{}
+	if result := r.db.Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("workbook_id = ?", uint(workbookID)).
+		Order("id desc").
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrAudioGenerationJobNotFound
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel()
+}
+
+func (r *audioGenerationJobRepository) UpdateProgress(ctx context.Context, id service.AudioGenerationJobID, processedProblems, generatedAudios int) error {
+	_, span := tracer.Start(ctx, "audioGenerationJobRepository.UpdateProgress")
+	defer span.End()
+
+	result := r.db.Model(&audioGenerationJobEntity{}).
+		Where("id = ?", uint(id)).
+		Updates(map[string]interface{}{
+			"processed_problems": processedProblems,
+			"generated_audios":   generatedAudios,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return service.ErrAudioGenerationJobNotFound
+	}
+
+	return nil
+}
+
+func (r *audioGenerationJobRepository) UpdateStatus(ctx context.Context, id service.AudioGenerationJobID, status service.AudioGenerationJobStatus, errorMessage string) error {
+	_, span := tracer.Start(ctx, "audioGenerationJobRepository.UpdateStatus")
+	defer span.End()
+
+	result := r.db.Model(&audioGenerationJobEntity{}).
+		Where("id = ?", uint(id)).
+		Updates(map[string]interface{}{
+			"status":        string(status),
+			"error_message": errorMessage,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return service.ErrAudioGenerationJobNotFound
+	}
+
+	return nil
+}
@@ -73,3 +73,7 @@ func (f *repositoryFactory) NewRecordbookRepository(ctx context.Context) service
 func (f *repositoryFactory) NewUserQuotaRepository(ctx context.Context) service.UserQuotaRepository {
 	return NewUserQuotaRepository(f.db)
 }
+
+func (f *repositoryFactory) NewAudioGenerationJobRepository(ctx context.Context) service.AudioGenerationJobRepository {
+	return NewAudioGenerationJobRepository(f.db)
+}
@@ -41,6 +41,17 @@ func NewUserQuotaRepository(db *gorm.DB) service.UserQuotaRepository {
 }
 
 func (r *userQuotaRepository) IsExceeded(ctx context.Context, operator domain.StudentModel, name string, unit service.QuotaUnit, limit int) (bool, error) {
+	count, err := r.FindUsage(ctx, operator, name, unit)
+	if err != nil {
+		return false, err
+	}
+	if count > limit {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r *userQuotaRepository) FindUsage(ctx context.Context, operator domain.StudentModel, name string, unit service.QuotaUnit) (int, error) {
 	now := time.Now()
 	var date time.Time
 	if unit == "month" {
@@ -59,14 +70,11 @@ func (r *userQuotaRepository) IsExceeded(ctx context.Context, operator domain.St
 		Name:           name,
 	}).First(&entity); result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return false, nil
+			return 0, nil
 		}
-		return false, result.Error
-	}
-	if entity.Count > limit {
-		return true, nil
+		return 0, result.Error
 	}
-	return false, nil
+	return entity.Count, nil
 }
 
 func (r *userQuotaRepository) Increment(ctx context.Context, operator domain.StudentModel, name string, unit service.QuotaUnit, limit int, count int) (bool, error) {
@@ -183,18 +183,74 @@ func (r *workbookRepository) FindPersonalWorkbooks(ctx context.Context, operator
 	return service.NewWorkbookSearchResult(int(count), results)
 }
 
+func (r *workbookRepository) FindWorkbooksInSpaces(ctx context.Context, operator domain.StudentModel, param service.WorkbookSearchCondition) (service.WorkbookSearchResult, error) {
+	ctx, span := tracer.Start(ctx, "workbookRepository.FindWorkbooksInSpaces")
+	defer span.End()
+
+	if param == nil || len(param.GetSpaceIDs()) == 0 {
+		return nil, libD.ErrInvalidArgument
+	}
+
+	spaceIDs := make([]uint, len(param.GetSpaceIDs()))
+	for i, spaceID := range param.GetSpaceIDs() {
+		spaceIDs[i] = uint(spaceID)
+	}
+
+	limit := param.GetPageSize()
+	offset := (param.GetPageNo() - 1) * param.GetPageSize()
+	workbooks := []workbookEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("space_id in ?", spaceIDs).
+		Order("name").Limit(limit).Offset(offset).
+		Find(&workbooks); result.Error != nil {
+		return nil, result.Error
+	}
+
+	results := make([]domain.WorkbookModel, len(workbooks))
+	for i, e := range workbooks {
+		priv, err := r.getPrivileges(ctx, operator, userD.SpaceID(e.SpaceID), domain.WorkbookID(e.ID))
+		if err != nil {
+			return nil, liberrors.Errorf("failed to getPrivileges. err: %w", err)
+		}
+		w, err := e.toWorkbookModel(r.rf, r.pf, operator, r.toProblemType(e.ProblemTypeID), priv)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to toWorkbook. err: %w", err)
+		}
+		results[i] = w
+	}
+
+	var count int64
+	if result := r.db.Model(&workbookEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("space_id in ?", spaceIDs).
+		Count(&count); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if count > math.MaxInt32 {
+		return nil, errors.New("overflow")
+	}
+
+	return service.NewWorkbookSearchResult(int(count), results)
+}
+
 func (r *workbookRepository) getAllWorkbookRoles(workbookID domain.WorkbookID) []userD.RBACRole {
 	return []userD.RBACRole{domain.NewWorkbookWriter(workbookID), domain.NewWorkbookReader(workbookID)}
 }
 
+func (r *workbookRepository) getAllSpaceRoles(spaceID userD.SpaceID) []userD.RBACRole {
+	return []userD.RBACRole{userD.NewSpaceReaderRole(spaceID), userD.NewSpaceWriterRole(spaceID), userD.NewSpaceAdminRole(spaceID)}
+}
+
 func (r *workbookRepository) getAllWorkbookPrivileges() []userD.RBACAction {
 	return []userD.RBACAction{domain.PrivilegeRead, domain.PrivilegeUpdate, domain.PrivilegeRemove}
 }
 
-func (r *workbookRepository) checkPrivileges(e *casbin.Enforcer, userObject userD.RBACUser, workbookObject userD.RBACObject, privs []userD.RBACAction) (userD.Privileges, error) {
+func (r *workbookRepository) checkPrivileges(e *casbin.Enforcer, userObject userD.RBACUser, object userD.RBACObject, privs []userD.RBACAction) (userD.Privileges, error) {
 	actions := make([]userD.RBACAction, 0)
 	for _, priv := range privs {
-		ok, err := e.Enforce(string(userObject), string(workbookObject), string(priv))
+		ok, err := e.Enforce(string(userObject), string(object), string(priv))
 		if err != nil {
 			return nil, err
 		}
@@ -238,7 +294,7 @@ func (r *workbookRepository) FindWorkbookByID(ctx context.Context, operator doma
 		return nil, result.Error
 	}
 
-	priv, err := r.getPrivileges(ctx, operator, domain.WorkbookID(workbookEntity.ID))
+	priv, err := r.getPrivileges(ctx, operator, userD.SpaceID(workbookEntity.SpaceID), domain.WorkbookID(workbookEntity.ID))
 	if err != nil {
 		return nil, liberrors.Errorf("failed to checkPrivileges. err: %w", err)
 	}
@@ -276,7 +332,7 @@ func (r *workbookRepository) FindWorkbookByName(ctx context.Context, operator us
 	if spaceID == service.GetSystemSpaceID() {
 		priv = userD.NewPrivileges([]userD.RBACAction{domain.PrivilegeRead})
 	} else {
-		privTmp, err := r.getPrivileges(ctx, operator, domain.WorkbookID(workbookEntity.ID))
+		privTmp, err := r.getPrivileges(ctx, operator, spaceID, domain.WorkbookID(workbookEntity.ID))
 		if err != nil {
 			return nil, liberrors.Errorf("failed to checkPrivileges. err: %w", err)
 		}
@@ -296,17 +352,33 @@ func (r *workbookRepository) FindWorkbookByName(ctx context.Context, operator us
 	return service.NewWorkbook(r.rf, r.pf, workbookModel)
 }
 
-func (r *workbookRepository) getPrivileges(ctx context.Context, operator userD.AppUserModel, workbookID domain.WorkbookID) (userD.Privileges, error) {
+// getPrivileges returns the privileges given by the roles of the workbook and the roles of the space which contains the workbook
+func (r *workbookRepository) getPrivileges(ctx context.Context, operator userD.AppUserModel, spaceID userD.SpaceID, workbookID domain.WorkbookID) (userD.Privileges, error) {
 	rbacRepo := r.userRf.NewRBACRepository()
-	workbookRoles := r.getAllWorkbookRoles(workbookID)
+	roles := append(r.getAllWorkbookRoles(workbookID), r.getAllSpaceRoles(spaceID)...)
 	userObject := userD.NewUserObject(userD.AppUserID(operator.GetID()))
-	e, err := rbacRepo.NewEnforcerWithRolesAndUsers(workbookRoles, []userD.RBACUser{userObject})
+	e, err := rbacRepo.NewEnforcerWithRolesAndUsers(roles, []userD.RBACUser{userObject})
 	if err != nil {
 		return nil, liberrors.Errorf("failed to NewEnforcerWithRolesAndUsers. err: %w", err)
 	}
 	workbookObject := domain.NewWorkbookObject(workbookID)
-	privs := r.getAllWorkbookPrivileges()
-	return r.checkPrivileges(e, userObject, workbookObject, privs)
+	workbookPrivs, err := r.checkPrivileges(e, userObject, workbookObject, r.getAllWorkbookPrivileges())
+	if err != nil {
+		return nil, err
+	}
+	spaceObject := userD.NewSpaceObject(spaceID)
+	spacePrivs, err := r.checkPrivileges(e, userObject, spaceObject, userD.SpaceRoleAdmin.GetPrivileges())
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]userD.RBACAction, 0)
+	for _, priv := range r.getAllWorkbookPrivileges() {
+		if workbookPrivs.HasPrivilege(priv) || spacePrivs.HasPrivilege(domain.NewSpacePrivilege(priv)) {
+			actions = append(actions, priv)
+		}
+	}
+	return userD.NewPrivileges(actions), nil
 }
 
 func (r *workbookRepository) AddWorkbook(ctx context.Context, operator userD.AppUserModel, spaceID userD.SpaceID, param service.WorkbookAddParameter) (domain.WorkbookID, error) {
@@ -386,33 +458,47 @@ func (r *workbookRepository) UpdateWorkbook(ctx context.Context, operator domain
 	_, span := tracer.Start(ctx, "workbookRepository.UpdateWorkbook")
 	defer span.End()
 
+	columns := map[string]interface{}{
+		"name":          param.GetName(),
+		"question_text": param.GetQuestionText(),
+		"version":       gorm.Expr("version + 1"),
+	}
+	if param.GetProperties() != nil {
+		propertiesJSON, err := stringMapToJSON(param.GetProperties())
+		if err != nil {
+			return err
+		}
+		columns["properties"] = propertiesJSON
+	}
+
 	if result := r.db.Model(&workbookEntity{}).
 		Where("organization_id = ? and id = ? and version = ?",
 			uint(operator.GetOrganizationID()), uint(id), version).
-		Updates(map[string]interface{}{
-			"name":          param.GetName(),
-			"question_text": param.GetQuestionText(),
-			"version":       gorm.Expr("version + 1"),
-		}); result.Error != nil {
+		Updates(columns); result.Error != nil {
 		return libG.ConvertDuplicatedError(result.Error, service.ErrWorkbookAlreadyExists)
 	}
 
 	return nil
 }
 
-// func (r *workbookRepository) ChangeSpace(ctx context.Context, operator domain.AbstractStudent, id uint, spaceID uint) error {
-// 	result := r.db.Model(&workbookEntity{}).Where(workbookEntity{
-// 		OrganizationID: operator.OrganizationID(),
-// 		ID:             id,
-// 	}).Update(workbookEntity{
-// 		SpaceID: spaceID,
-// 	})
-// 	if result.Error != nil {
-// 		return result.Error
-// 	}
-// 	if result.RowsAffected == 0 {
-// 		return domain.NewWorkbookNotFoundError(id)
-// 	}
+func (r *workbookRepository) ChangeSpace(ctx context.Context, operator domain.StudentModel, id domain.WorkbookID, version int, spaceID userD.SpaceID) error {
+	_, span := tracer.Start(ctx, "workbookRepository.ChangeSpace")
+	defer span.End()
 
-// 	return nil
-// }
+	result := r.db.Model(&workbookEntity{}).
+		Where("organization_id = ? and id = ? and version = ?",
+			uint(operator.GetOrganizationID()), uint(id), version).
+		Updates(map[string]interface{}{
+			"space_id":   uint(spaceID),
+			"updated_by": operator.GetID(),
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrWorkbookNotFound
+	}
+
+	return nil
+}
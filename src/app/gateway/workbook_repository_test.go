@@ -332,3 +332,70 @@ func Test_workbookRepository_FindWorkbookByID_priv(t *testing.T) {
 	}
 
 }
+
+func Test_workbookRepository_ChangeSpace_priv(t *testing.T) {
+	logrus.SetLevel(logrus.DebugLevel)
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (userS.RepositoryFactory, error) {
+		return userG.NewRepositoryFactory(db)
+	}
+
+	userS.InitSystemAdmin(userRfFunc)
+	for driverName, db := range dbList() {
+		logrus.Println(driverName)
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		defer sqlDB.Close()
+		userRepo, err := userG.NewRepositoryFactory(db)
+		assert.NoError(t, err)
+		_, sysOwner, owner := testInitOrganization(t, db)
+
+		rbacRepo := userG.NewRBACRepository(db)
+		err = rbacRepo.Init()
+		assert.NoError(t, err)
+
+		user1, err := userS.NewAppUser(userRepo, testNewAppUser(t, bg, db, owner, "LOGIN_ID_1", "USERNAME_1"))
+		assert.NoError(t, err)
+		user2 := testNewAppUser(t, bg, db, owner, "LOGIN_ID_2", "USERNAME_2")
+
+		englishWord := testNewProblemType(t, "english_word_problem")
+		workbookRepo := gateway.NewWorkbookRepository(bg, driverName, nil, userRepo, nil, db, []domain.ProblemType{englishWord})
+		spaceRepo := userG.NewSpaceRepository(db)
+
+		student1 := testNewStudent(t, user1)
+		spaceID1, err := spaceRepo.AddPersonalSpace(bg, sysOwner, user1)
+		assert.NoError(t, err)
+		workbook11 := testNewWorkbook(t, bg, db, workbookRepo, student1, spaceID1, "WB11")
+		workbookID11 := domain.WorkbookID(workbook11.GetID())
+
+		// user2 cannot read the workbook in user1's personal space
+		student2 := testNewStudent(t, user2)
+		_, err = workbookRepo.FindWorkbookByID(bg, student2, workbookID11)
+		assert.True(t, errors.Is(err, service.ErrWorkbookPermissionDenied))
+
+		// user2 is the reader of the shared space
+		sharedSpaceAddParam, err := userS.NewSharedSpaceAddParameter("team_a", "Team A", "")
+		assert.NoError(t, err)
+		sharedSpaceID, err := user1.AddSharedSpace(bg, sharedSpaceAddParam)
+		assert.NoError(t, err)
+		assert.NoError(t, user1.AddSpaceMember(bg, sharedSpaceID, userD.AppUserID(user2.GetID()), userD.SpaceRoleReader))
+
+		assert.NoError(t, workbookRepo.ChangeSpace(bg, student1, workbookID11, workbook11.GetVersion(), sharedSpaceID))
+		err = workbookRepo.ChangeSpace(bg, student1, workbookID11, workbook11.GetVersion(), sharedSpaceID)
+		assert.True(t, errors.Is(err, service.ErrWorkbookNotFound))
+
+		// user2 can read the workbook in the shared space but cannot update it
+		workbook, err := workbookRepo.FindWorkbookByID(bg, student2, workbookID11)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(sharedSpaceID), uint(workbook.GetSpaceID()))
+		assert.True(t, workbook.HasPrivilege(domain.PrivilegeRead))
+		assert.False(t, workbook.HasPrivilege(domain.PrivilegeUpdate))
+
+		condition, err := service.NewWorkbookSearchCondition(1, 10, []userD.SpaceID{sharedSpaceID})
+		assert.NoError(t, err)
+		result, err := workbookRepo.FindWorkbooksInSpaces(bg, student2, condition)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.GetTotalCount())
+	}
+}
@@ -16,6 +16,7 @@ var (
 	appPropertiesSystemSpaceID     = userD.SpaceID(0)
 	appPropertiesSystemStudentID   = userD.AppUserID(0)
 	appPropertiesTatoebaWorkbookID = domain.WorkbookID(0)
+	appPropertiesGuestQuotaPercent = 100
 	SystemStudentLoginID           = "system-student"
 	TatoebaWorkbookName            = "tatoeba"
 	OrganizationName               = "cocotola"
@@ -49,3 +50,10 @@ func GetTatoebaWorkbookID() domain.WorkbookID {
 func SetTatoebaWorkbookID(propertiesTatoebaWorkbookID domain.WorkbookID) {
 	appPropertiesTatoebaWorkbookID = propertiesTatoebaWorkbookID
 }
+
+func GetGuestQuotaPercent() int {
+	return appPropertiesGuestQuotaPercent
+}
+func SetGuestQuotaPercent(propertiesGuestQuotaPercent int) {
+	appPropertiesGuestQuotaPercent = propertiesGuestQuotaPercent
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+)
+
+const (
+	AudioBundleManifestFileName = "manifest.json"
+
+	// audioBundleFileNameMaxTextLength limits the length of the text in the file names
+	audioBundleFileNameMaxTextLength = 40
+)
+
+var audioBundleFileNameRegexp = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+type AudioBundleManifest struct {
+	WorkbookID uint                      `json:"workbookId"`
+	Name       string                    `json:"name"`
+	Files      []AudioBundleManifestFile `json:"files"`
+	// MissingProblemIDs are the problems whose audio hasn't been generated or has been removed
+	MissingProblemIDs []uint `json:"missingProblemIds"`
+}
+
+type AudioBundleManifestFile struct {
+	ProblemID uint   `json:"problemId"`
+	Number    int    `json:"number"`
+	Text      string `json:"text"`
+	AudioID   uint   `json:"audioId"`
+	File      string `json:"file"`
+}
+
+// WriteAudioBundle writes the zip file which contains the audio of the problems and the manifest.
+// The audio is fetched and written one by one so that only one audio is kept in memory
+func WriteAudioBundle(ctx context.Context, writer io.Writer, synthesizerClient SynthesizerClient, workbookModel domain.WorkbookModel, problems []domain.ProblemModel) error {
+	logger := log.FromContext(ctx)
+
+	zipWriter := zip.NewWriter(writer)
+	manifest := AudioBundleManifest{
+		WorkbookID:        workbookModel.GetID(),
+		Name:              workbookModel.GetName(),
+		Files:             make([]AudioBundleManifestFile, 0, len(problems)),
+		MissingProblemIDs: make([]uint, 0),
+	}
+	fileNames := make(map[string]bool)
+
+	for _, problem := range problems {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		properties := problem.GetProperties(ctx)
+		text, _ := properties["text"].(string)
+		audioID := toAudioID(properties["audioId"])
+		if audioID == 0 {
+			manifest.MissingProblemIDs = append(manifest.MissingProblemIDs, problem.GetID())
+			continue
+		}
+
+		audio, err := synthesizerClient.FindAudioByAudioID(ctx, audioID)
+		if errors.Is(err, ErrAudioNotFound) {
+			logger.Warnf("audio not found. problemID: %d, audioID: %d", problem.GetID(), audioID)
+			manifest.MissingProblemIDs = append(manifest.MissingProblemIDs, problem.GetID())
+			continue
+		} else if err != nil {
+			return liberrors.Errorf("failed to FindAudioByAudioID. audioID: %d, err: %w", audioID, err)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(audio.GetAudioModel().GetContent())
+		if err != nil {
+			return liberrors.Errorf("failed to DecodeString. audioID: %d, err: %w", audioID, err)
+		}
+
+		extension := toAudioFileExtension(data)
+		fileName := toAudioBundleFileName(problem.GetNumber(), text, extension)
+		if fileNames[fileName] {
+			// the problems which have the same number and text are distinguished by the problem id
+			fileName = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(fileName, extension), problem.GetID(), extension)
+		}
+		fileNames[fileName] = true
+
+		// the audio is already compressed
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{Name: fileName, Method: zip.Store})
+		if err != nil {
+			return liberrors.Errorf("failed to CreateHeader. err: %w", err)
+		}
+		if _, err := fileWriter.Write(data); err != nil {
+			return liberrors.Errorf("failed to Write. err: %w", err)
+		}
+
+		manifest.Files = append(manifest.Files, AudioBundleManifestFile{
+			ProblemID: problem.GetID(),
+			Number:    problem.GetNumber(),
+			Text:      text,
+			AudioID:   uint(audioID),
+			File:      fileName,
+		})
+	}
+
+	manifestWriter, err := zipWriter.Create(AudioBundleManifestFileName)
+	if err != nil {
+		return liberrors.Errorf("failed to Create. err: %w", err)
+	}
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(&manifest); err != nil {
+		return liberrors.Errorf("failed to Encode. err: %w", err)
+	}
+
+	return zipWriter.Close()
+}
+
+// toAudioID converts the audioId property. The type of the property depends on the problem type
+func toAudioID(value interface{}) domain.AudioID {
+	switch v := value.(type) {
+	case domain.AudioID:
+		return v
+	case uint:
+		return domain.AudioID(v)
+	case int:
+		return domain.AudioID(v)
+	default:
+		return 0
+	}
+}
+
+// toAudioBundleFileName returns the file name such as "0001_good_morning.mp3"
+func toAudioBundleFileName(number int, text, extension string) string {
+	name := strings.Trim(audioBundleFileNameRegexp.ReplaceAllString(strings.ToLower(text), "_"), "_")
+	if len(name) > audioBundleFileNameMaxTextLength {
+		name = strings.TrimRight(name[:audioBundleFileNameMaxTextLength], "_")
+	}
+	if name == "" {
+		name = "audio"
+	}
+
+	return fmt.Sprintf("%04d_%s%s", number, name, extension)
+}
+
+func toAudioFileExtension(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("RIFF")):
+		return ".wav"
+	case bytes.HasPrefix(data, []byte("OggS")):
+		return ".ogg"
+	default:
+		return ".mp3"
+	}
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	domainM "github.com/kujilabo/cocotola-api/src/app/domain/mock"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+	mocks "github.com/kujilabo/cocotola-api/src/app/service/mock"
+)
+
+func newTestBundleProblem(id uint, number int, text string, audioID interface{}) *domainM.ProblemModel {
+	problem := new(domainM.ProblemModel)
+	problem.On("GetID").Return(id)
+	problem.On("GetNumber").Return(number)
+	problem.On("GetProperties", mock.Anything).Return(map[string]interface{}{
+		"text":    text,
+		"audioId": audioID,
+	})
+	return problem
+}
+
+func TestWriteAudioBundle(t *testing.T) {
+	ctx := context.Background()
+	mp3 := []byte{0xFF, 0xFB, 0x90, 0x00}
+
+	audioModel, err := domain.NewAudioModel(10, domain.Lang2EN, "Good morning!", base64.StdEncoding.EncodeToString(mp3))
+	require.NoError(t, err)
+	audio, err := service.NewAudio(audioModel)
+	require.NoError(t, err)
+
+	synthesizerClient := new(mocks.SynthesizerClient)
+	synthesizerClient.On("FindAudioByAudioID", mock.Anything, domain.AudioID(10)).Return(audio, nil)
+	synthesizerClient.On("FindAudioByAudioID", mock.Anything, domain.AudioID(11)).Return(nil, service.ErrAudioNotFound)
+	workbookModel := new(domainM.WorkbookModel)
+	workbookModel.On("GetID").Return(uint(1))
+	workbookModel.On("GetName").Return("greetings")
+
+	problems := []domain.ProblemModel{
+		newTestBundleProblem(100, 1, "Good morning!", domain.AudioID(10)),
+		newTestBundleProblem(101, 1, "good morning", uint(10)),
+		newTestBundleProblem(102, 2, "good night", uint(0)),
+		newTestBundleProblem(103, 3, "hello", uint(11)),
+	}
+
+	buf := bytes.Buffer{}
+	require.NoError(t, service.WriteAudioBundle(ctx, &buf, synthesizerClient, workbookModel, problems))
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		files[file.Name] = data
+	}
+
+	require.Len(t, files, 3)
+	assert.Equal(t, mp3, files["0001_good_morning.mp3"])
+	assert.Equal(t, mp3, files["0001_good_morning_101.mp3"])
+
+	manifest := service.AudioBundleManifest{}
+	require.NoError(t, json.Unmarshal(files[service.AudioBundleManifestFileName], &manifest))
+	assert.Equal(t, uint(1), manifest.WorkbookID)
+	assert.Equal(t, "greetings", manifest.Name)
+	assert.Equal(t, []service.AudioBundleManifestFile{
+		{ProblemID: 100, Number: 1, Text: "Good morning!", AudioID: 10, File: "0001_good_morning.mp3"},
+		{ProblemID: 101, Number: 1, Text: "good morning", AudioID: 10, File: "0001_good_morning_101.mp3"},
+	}, manifest.Files)
+	assert.Equal(t, []uint{102, 103}, manifest.MissingProblemIDs)
+}
@@ -0,0 +1,25 @@
+//go:generate mockery --output mock --name AudioCacheRepository
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+)
+
+var ErrAudioCacheNotFound = errors.New("audio cache not found")
+
+type AudioCacheRepository interface {
+	// FindAudioID returns the cached audio id of the normalized text. It returns ErrAudioCacheNotFound if nothing is cached
+	FindAudioID(ctx context.Context, lang2 domain.Lang2, text string) (domain.AudioID, error)
+
+	// SetAudioID caches the audio id of the normalized text
+	SetAudioID(ctx context.Context, lang2 domain.Lang2, text string, audioID domain.AudioID) error
+
+	// RemoveAudioID removes the caches which refer to the audio
+	RemoveAudioID(ctx context.Context, audioID domain.AudioID) error
+
+	// PurgeAudioCache removes all the caches of the language, or all the caches if lang2 is nil, and returns the number of the removed caches
+	PurgeAudioCache(ctx context.Context, lang2 domain.Lang2) (int, error)
+}
@@ -0,0 +1,97 @@
+//go:generate mockery --output mock --name AudioGenerationJob
+package service
+
+import (
+	"time"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+)
+
+type AudioGenerationJobID uint
+
+type AudioGenerationJobStatus string
+
+const (
+	AudioGenerationJobStatusProcessing AudioGenerationJobStatus = "processing"
+	AudioGenerationJobStatusCompleted  AudioGenerationJobStatus = "completed"
+	// AudioGenerationJobStatusFailed means the job has stopped. A new job generates only the audio of the problems which don't have any audio
+	AudioGenerationJobStatusFailed AudioGenerationJobStatus = "failed"
+)
+
+type AudioGenerationJob interface {
+	GetID() AudioGenerationJobID
+	GetWorkbookID() domain.WorkbookID
+	GetTotalProblems() int
+	GetProcessedProblems() int
+	// GetGeneratedAudios returns the number of the problems whose audio has been synthesized by the job
+	GetGeneratedAudios() int
+	GetStatus() AudioGenerationJobStatus
+	GetErrorMessage() string
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+}
+
+type audioGenerationJob struct {
+	ID                AudioGenerationJobID
+	WorkbookID        domain.WorkbookID        `validate:"required"`
+	TotalProblems     int                      `validate:"gte=0"`
+	ProcessedProblems int                      `validate:"gte=0"`
+	GeneratedAudios   int                      `validate:"gte=0"`
+	Status            AudioGenerationJobStatus `validate:"required"`
+	ErrorMessage      string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewAudioGenerationJob(id AudioGenerationJobID, workbookID domain.WorkbookID, totalProblems, processedProblems, generatedAudios int, status AudioGenerationJobStatus, errorMessage string, createdAt, updatedAt time.Time) (AudioGenerationJob, error) {
+	m := &audioGenerationJob{
+		ID:                id,
+		WorkbookID:        workbookID,
+		TotalProblems:     totalProblems,
+		ProcessedProblems: processedProblems,
+		GeneratedAudios:   generatedAudios,
+		Status:            status,
+		ErrorMessage:      errorMessage,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (m *audioGenerationJob) GetID() AudioGenerationJobID {
+	return m.ID
+}
+
+func (m *audioGenerationJob) GetWorkbookID() domain.WorkbookID {
+	return m.WorkbookID
+}
+
+func (m *audioGenerationJob) GetTotalProblems() int {
+	return m.TotalProblems
+}
+
+func (m *audioGenerationJob) GetProcessedProblems() int {
+	return m.ProcessedProblems
+}
+
+func (m *audioGenerationJob) GetGeneratedAudios() int {
+	return m.GeneratedAudios
+}
+
+func (m *audioGenerationJob) GetStatus() AudioGenerationJobStatus {
+	return m.Status
+}
+
+func (m *audioGenerationJob) GetErrorMessage() string {
+	return m.ErrorMessage
+}
+
+func (m *audioGenerationJob) GetCreatedAt() time.Time {
+	return m.CreatedAt
+}
+
+func (m *audioGenerationJob) GetUpdatedAt() time.Time {
+	return m.UpdatedAt
+}
@@ -0,0 +1,26 @@
+//go:generate mockery --output mock --name AudioGenerationJobRepository
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+)
+
+var ErrAudioGenerationJobNotFound = errors.New("audio generation job not found")
+
+// ErrAudioGenerationJobAlreadyRunning is returned when another job of the workbook is processing
+var ErrAudioGenerationJobAlreadyRunning = errors.New("audio generation job already running")
+
+type AudioGenerationJobRepository interface {
+	// AddJob returns ErrAudioGenerationJobAlreadyRunning if another job of the workbook is processing
+	AddJob(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID, totalProblems int) (AudioGenerationJobID, error)
+
+	// FindLatestJob returns the latest job of the workbook
+	FindLatestJob(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID) (AudioGenerationJob, error)
+
+	UpdateProgress(ctx context.Context, id AudioGenerationJobID, processedProblems, generatedAudios int) error
+
+	UpdateStatus(ctx context.Context, id AudioGenerationJobID, status AudioGenerationJobStatus, errorMessage string) error
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/app/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+)
+
+// AudioCacheRepository is an autogenerated mock type for the AudioCacheRepository type
+type AudioCacheRepository struct {
+	mock.Mock
+}
+
+// FindAudioID provides a mock function with given fields: ctx, lang2, text
+func (_m *AudioCacheRepository) FindAudioID(ctx context.Context, lang2 domain.Lang2, text string) (domain.AudioID, error) {
+	ret := _m.Called(ctx, lang2, text)
+
+	var r0 domain.AudioID
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Lang2, string) domain.AudioID); ok {
+		r0 = rf(ctx, lang2, text)
+	} else {
+		r0 = ret.Get(0).(domain.AudioID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Lang2, string) error); ok {
+		r1 = rf(ctx, lang2, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeAudioCache provides a mock function with given fields: ctx, lang2
+func (_m *AudioCacheRepository) PurgeAudioCache(ctx context.Context, lang2 domain.Lang2) (int, error) {
+	ret := _m.Called(ctx, lang2)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Lang2) int); ok {
+		r0 = rf(ctx, lang2)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Lang2) error); ok {
+		r1 = rf(ctx, lang2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveAudioID provides a mock function with given fields: ctx, audioID
+func (_m *AudioCacheRepository) RemoveAudioID(ctx context.Context, audioID domain.AudioID) error {
+	ret := _m.Called(ctx, audioID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.AudioID) error); ok {
+		r0 = rf(ctx, audioID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAudioID provides a mock function with given fields: ctx, lang2, text, audioID
+func (_m *AudioCacheRepository) SetAudioID(ctx context.Context, lang2 domain.Lang2, text string, audioID domain.AudioID) error {
+	ret := _m.Called(ctx, lang2, text, audioID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Lang2, string, domain.AudioID) error); ok {
+		r0 = rf(ctx, lang2, text, audioID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAudioCacheRepository creates a new instance of AudioCacheRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewAudioCacheRepository(t testing.TB) *AudioCacheRepository {
+	mock := &AudioCacheRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "github.com/kujilabo/cocotola-api/src/app/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/kujilabo/cocotola-api/src/app/service"
+
+	testing "testing"
+
+	time "time"
+)
+
+// AudioGenerationJob is an autogenerated mock type for the AudioGenerationJob type
+type AudioGenerationJob struct {
+	mock.Mock
+}
+
+// GetCreatedAt provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetCreatedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// GetErrorMessage provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetErrorMessage() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetGeneratedAudios provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetGeneratedAudios() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetID provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetID() service.AudioGenerationJobID {
+	ret := _m.Called()
+
+	var r0 service.AudioGenerationJobID
+	if rf, ok := ret.Get(0).(func() service.AudioGenerationJobID); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.AudioGenerationJobID)
+	}
+
+	return r0
+}
+
+// GetProcessedProblems provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetProcessedProblems() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetStatus provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetStatus() service.AudioGenerationJobStatus {
+	ret := _m.Called()
+
+	var r0 service.AudioGenerationJobStatus
+	if rf, ok := ret.Get(0).(func() service.AudioGenerationJobStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.AudioGenerationJobStatus)
+	}
+
+	return r0
+}
+
+// GetTotalProblems provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetTotalProblems() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetUpdatedAt provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetUpdatedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// GetWorkbookID provides a mock function with given fields:
+func (_m *AudioGenerationJob) GetWorkbookID() domain.WorkbookID {
+	ret := _m.Called()
+
+	var r0 domain.WorkbookID
+	if rf, ok := ret.Get(0).(func() domain.WorkbookID); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.WorkbookID)
+	}
+
+	return r0
+}
+
+// NewAudioGenerationJob creates a new instance of AudioGenerationJob. It also registers a cleanup function to assert the mocks expectations.
+func NewAudioGenerationJob(t testing.TB) *AudioGenerationJob {
+	mock := &AudioGenerationJob{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
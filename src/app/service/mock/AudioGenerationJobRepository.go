@@ -0,0 +1,100 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/app/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/kujilabo/cocotola-api/src/app/service"
+
+	testing "testing"
+)
+
+// AudioGenerationJobRepository is an autogenerated mock type for the AudioGenerationJobRepository type
+type AudioGenerationJobRepository struct {
+	mock.Mock
+}
+
+// AddJob provides a mock function with given fields: ctx, operator, workbookID, totalProblems
+func (_m *AudioGenerationJobRepository) AddJob(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID, totalProblems int) (service.AudioGenerationJobID, error) {
+	ret := _m.Called(ctx, operator, workbookID, totalProblems)
+
+	var r0 service.AudioGenerationJobID
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, domain.WorkbookID, int) service.AudioGenerationJobID); ok {
+		r0 = rf(ctx, operator, workbookID, totalProblems)
+	} else {
+		r0 = ret.Get(0).(service.AudioGenerationJobID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StudentModel, domain.WorkbookID, int) error); ok {
+		r1 = rf(ctx, operator, workbookID, totalProblems)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestJob provides a mock function with given fields: ctx, operator, workbookID
+func (_m *AudioGenerationJobRepository) FindLatestJob(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID) (service.AudioGenerationJob, error) {
+	ret := _m.Called(ctx, operator, workbookID)
+
+	var r0 service.AudioGenerationJob
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, domain.WorkbookID) service.AudioGenerationJob); ok {
+		r0 = rf(ctx, operator, workbookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.AudioGenerationJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StudentModel, domain.WorkbookID) error); ok {
+		r1 = rf(ctx, operator, workbookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProgress provides a mock function with given fields: ctx, id, processedProblems, generatedAudios
+func (_m *AudioGenerationJobRepository) UpdateProgress(ctx context.Context, id service.AudioGenerationJobID, processedProblems int, generatedAudios int) error {
+	ret := _m.Called(ctx, id, processedProblems, generatedAudios)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.AudioGenerationJobID, int, int) error); ok {
+		r0 = rf(ctx, id, processedProblems, generatedAudios)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status, errorMessage
+func (_m *AudioGenerationJobRepository) UpdateStatus(ctx context.Context, id service.AudioGenerationJobID, status service.AudioGenerationJobStatus, errorMessage string) error {
+	ret := _m.Called(ctx, id, status, errorMessage)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.AudioGenerationJobID, service.AudioGenerationJobStatus, string) error); ok {
+		r0 = rf(ctx, id, status, errorMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAudioGenerationJobRepository creates a new instance of AudioGenerationJobRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewAudioGenerationJobRepository(t testing.TB) *AudioGenerationJobRepository {
+	mock := &AudioGenerationJobRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,49 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/app/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/kujilabo/cocotola-api/src/app/service"
+
+	testing "testing"
+)
+
+// ProblemAudioProcessor is an autogenerated mock type for the ProblemAudioProcessor type
+type ProblemAudioProcessor struct {
+	mock.Mock
+}
+
+// GenerateAudio provides a mock function with given fields: ctx, repo, operator, workbookModel, problemID
+func (_m *ProblemAudioProcessor) GenerateAudio(ctx context.Context, repo service.RepositoryFactory, operator domain.StudentModel, workbookModel domain.WorkbookModel, problemID domain.ProblemID) (bool, error) {
+	ret := _m.Called(ctx, repo, operator, workbookModel, problemID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, service.RepositoryFactory, domain.StudentModel, domain.WorkbookModel, domain.ProblemID) bool); ok {
+		r0 = rf(ctx, repo, operator, workbookModel, problemID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.RepositoryFactory, domain.StudentModel, domain.WorkbookModel, domain.ProblemID) error); ok {
+		r1 = rf(ctx, repo, operator, workbookModel, problemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProblemAudioProcessor creates a new instance of ProblemAudioProcessor. It also registers a cleanup function to assert the mocks expectations.
+func NewProblemAudioProcessor(t testing.TB) *ProblemAudioProcessor {
+	mock := &ProblemAudioProcessor{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
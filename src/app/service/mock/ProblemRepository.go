@@ -212,6 +212,20 @@ func (_m *ProblemRepository) RemoveProblem(ctx context.Context, operator domain.
 	return r0
 }
 
+// UpdateAudioID provides a mock function with given fields: ctx, operator, id, audioID
+func (_m *ProblemRepository) UpdateAudioID(ctx context.Context, operator domain.StudentModel, id service.ProblemSelectParameter1, audioID domain.AudioID) error {
+	ret := _m.Called(ctx, operator, id, audioID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, service.ProblemSelectParameter1, domain.AudioID) error); ok {
+		r0 = rf(ctx, operator, id, audioID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateProblem provides a mock function with given fields: ctx, operator, id, param
 func (_m *ProblemRepository) UpdateProblem(ctx context.Context, operator domain.StudentModel, id service.ProblemSelectParameter2, param service.ProblemUpdateParameter) error {
 	ret := _m.Called(ctx, operator, id, param)
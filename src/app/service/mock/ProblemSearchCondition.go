@@ -56,6 +56,22 @@ func (_m *ProblemSearchCondition) GetPageSize() int {
 	return r0
 }
 
+// GetProperties provides a mock function with given fields:
+func (_m *ProblemSearchCondition) GetProperties() map[string]string {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
 // GetWorkbookID provides a mock function with given fields:
 func (_m *ProblemSearchCondition) GetWorkbookID() domain.WorkbookID {
 	ret := _m.Called()
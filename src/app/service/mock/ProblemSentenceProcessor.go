@@ -0,0 +1,95 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/app/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/kujilabo/cocotola-api/src/app/service"
+
+	testing "testing"
+)
+
+// ProblemSentenceProcessor is an autogenerated mock type for the ProblemSentenceProcessor type
+type ProblemSentenceProcessor struct {
+	mock.Mock
+}
+
+// AttachSentences provides a mock function with given fields: ctx, repo, operator, workbookModel, sentences
+func (_m *ProblemSentenceProcessor) AttachSentences(ctx context.Context, repo service.RepositoryFactory, operator domain.StudentModel, workbookModel domain.WorkbookModel, sentences []service.ProblemSentences) (service.Updated, error) {
+	ret := _m.Called(ctx, repo, operator, workbookModel, sentences)
+
+	var r0 service.Updated
+	if rf, ok := ret.Get(0).(func(context.Context, service.RepositoryFactory, domain.StudentModel, domain.WorkbookModel, []service.ProblemSentences) service.Updated); ok {
+		r0 = rf(ctx, repo, operator, workbookModel, sentences)
+	} else {
+		r0 = ret.Get(0).(service.Updated)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.RepositoryFactory, domain.StudentModel, domain.WorkbookModel, []service.ProblemSentences) error); ok {
+		r1 = rf(ctx, repo, operator, workbookModel, sentences)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchSentences provides a mock function with given fields: ctx, problems
+func (_m *ProblemSentenceProcessor) FetchSentences(ctx context.Context, problems []domain.ProblemModel) ([]service.ProblemSentences, error) {
+	ret := _m.Called(ctx, problems)
+
+	var r0 []service.ProblemSentences
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ProblemModel) []service.ProblemSentences); ok {
+		r0 = rf(ctx, problems)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ProblemSentences)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.ProblemModel) error); ok {
+		r1 = rf(ctx, problems)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindProblemsWithoutSentences provides a mock function with given fields: ctx, repo, operator, workbookModel, size
+func (_m *ProblemSentenceProcessor) FindProblemsWithoutSentences(ctx context.Context, repo service.RepositoryFactory, operator domain.StudentModel, workbookModel domain.WorkbookModel, size int) ([]domain.ProblemModel, error) {
+	ret := _m.Called(ctx, repo, operator, workbookModel, size)
+
+	var r0 []domain.ProblemModel
+	if rf, ok := ret.Get(0).(func(context.Context, service.RepositoryFactory, domain.StudentModel, domain.WorkbookModel, int) []domain.ProblemModel); ok {
+		r0 = rf(ctx, repo, operator, workbookModel, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProblemModel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, service.RepositoryFactory, domain.StudentModel, domain.WorkbookModel, int) error); ok {
+		r1 = rf(ctx, repo, operator, workbookModel, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProblemSentenceProcessor creates a new instance of ProblemSentenceProcessor. It also registers a cleanup function to assert the mocks expectations.
+func NewProblemSentenceProcessor(t testing.TB) *ProblemSentenceProcessor {
+	mock := &ProblemSentenceProcessor{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
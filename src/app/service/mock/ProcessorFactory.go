@@ -37,6 +37,29 @@ func (_m *ProcessorFactory) NewProblemAddProcessor(processorType string) (servic
 	return r0, r1
 }
 
+// NewProblemAudioProcessor provides a mock function with given fields: processorType
+func (_m *ProcessorFactory) NewProblemAudioProcessor(processorType string) (service.ProblemAudioProcessor, error) {
+	ret := _m.Called(processorType)
+
+	var r0 service.ProblemAudioProcessor
+	if rf, ok := ret.Get(0).(func(string) service.ProblemAudioProcessor); ok {
+		r0 = rf(processorType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.ProblemAudioProcessor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(processorType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewProblemImportProcessor provides a mock function with given fields: processorType
 func (_m *ProcessorFactory) NewProblemImportProcessor(processorType string) (service.ProblemImportProcessor, error) {
 	ret := _m.Called(processorType)
@@ -106,6 +129,29 @@ func (_m *ProcessorFactory) NewProblemRemoveProcessor(processorType string) (ser
 	return r0, r1
 }
 
+// NewProblemSentenceProcessor provides a mock function with given fields: processorType
+func (_m *ProcessorFactory) NewProblemSentenceProcessor(processorType string) (service.ProblemSentenceProcessor, error) {
+	ret := _m.Called(processorType)
+
+	var r0 service.ProblemSentenceProcessor
+	if rf, ok := ret.Get(0).(func(string) service.ProblemSentenceProcessor); ok {
+		r0 = rf(processorType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.ProblemSentenceProcessor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(processorType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewProblemUpdateProcessor provides a mock function with given fields: processorType
 func (_m *ProcessorFactory) NewProblemUpdateProcessor(processorType string) (service.ProblemUpdateProcessor, error) {
 	ret := _m.Called(processorType)
@@ -16,6 +16,22 @@ type RepositoryFactory struct {
 	mock.Mock
 }
 
+// NewAudioGenerationJobRepository provides a mock function with given fields: ctx
+func (_m *RepositoryFactory) NewAudioGenerationJobRepository(ctx context.Context) service.AudioGenerationJobRepository {
+	ret := _m.Called(ctx)
+
+	var r0 service.AudioGenerationJobRepository
+	if rf, ok := ret.Get(0).(func(context.Context) service.AudioGenerationJobRepository); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.AudioGenerationJobRepository)
+		}
+	}
+
+	return r0
+}
+
 // NewProblemRepository provides a mock function with given fields: ctx, problemType
 func (_m *RepositoryFactory) NewProblemRepository(ctx context.Context, problemType string) (service.ProblemRepository, error) {
 	ret := _m.Called(ctx, problemType)
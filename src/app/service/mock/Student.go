@@ -43,6 +43,27 @@ func (_m *Student) AddWorkbookToPersonalSpace(ctx context.Context, parameter ser
 	return r0, r1
 }
 
+// AddWorkbookToSpace provides a mock function with given fields: ctx, spaceID, parameter
+func (_m *Student) AddWorkbookToSpace(ctx context.Context, spaceID userdomain.SpaceID, parameter service.WorkbookAddParameter) (domain.WorkbookID, error) {
+	ret := _m.Called(ctx, spaceID, parameter)
+
+	var r0 domain.WorkbookID
+	if rf, ok := ret.Get(0).(func(context.Context, userdomain.SpaceID, service.WorkbookAddParameter) domain.WorkbookID); ok {
+		r0 = rf(ctx, spaceID, parameter)
+	} else {
+		r0 = ret.Get(0).(domain.WorkbookID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, userdomain.SpaceID, service.WorkbookAddParameter) error); ok {
+		r1 = rf(ctx, spaceID, parameter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckQuota provides a mock function with given fields: ctx, problemType, name
 func (_m *Student) CheckQuota(ctx context.Context, problemType string, name service.QuotaName) error {
 	ret := _m.Called(ctx, problemType, name)
@@ -117,6 +138,27 @@ func (_m *Student) FindRecordbookSummary(ctx context.Context, workbookID domain.
 	return r0, r1
 }
 
+// FindRemainingQuota provides a mock function with given fields: ctx, problemType, name
+func (_m *Student) FindRemainingQuota(ctx context.Context, problemType string, name service.QuotaName) (int, error) {
+	ret := _m.Called(ctx, problemType, name)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string, service.QuotaName) int); ok {
+		r0 = rf(ctx, problemType, name)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, service.QuotaName) error); ok {
+		r1 = rf(ctx, problemType, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindWorkbookByID provides a mock function with given fields: ctx, id
 func (_m *Student) FindWorkbookByID(ctx context.Context, id domain.WorkbookID) (service.Workbook, error) {
 	ret := _m.Called(ctx, id)
@@ -186,6 +228,29 @@ func (_m *Student) FindWorkbooksFromPersonalSpace(ctx context.Context, condition
 	return r0, r1
 }
 
+// FindWorkbooksFromSpace provides a mock function with given fields: ctx, spaceID, condition
+func (_m *Student) FindWorkbooksFromSpace(ctx context.Context, spaceID userdomain.SpaceID, condition service.WorkbookSearchCondition) (service.WorkbookSearchResult, error) {
+	ret := _m.Called(ctx, spaceID, condition)
+
+	var r0 service.WorkbookSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, userdomain.SpaceID, service.WorkbookSearchCondition) service.WorkbookSearchResult); ok {
+		r0 = rf(ctx, spaceID, condition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.WorkbookSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, userdomain.SpaceID, service.WorkbookSearchCondition) error); ok {
+		r1 = rf(ctx, spaceID, condition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDefaultSpace provides a mock function with given fields: ctx
 func (_m *Student) GetDefaultSpace(ctx context.Context) (userservice.Space, error) {
 	ret := _m.Called(ctx)
@@ -334,6 +399,20 @@ func (_m *Student) IncrementQuotaUsage(ctx context.Context, problemType string,
 	return r0
 }
 
+// MoveWorkbook provides a mock function with given fields: ctx, workbookID, version, spaceID
+func (_m *Student) MoveWorkbook(ctx context.Context, workbookID domain.WorkbookID, version int, spaceID userdomain.SpaceID) error {
+	ret := _m.Called(ctx, workbookID, version, spaceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.WorkbookID, int, userdomain.SpaceID) error); ok {
+		r0 = rf(ctx, workbookID, version, spaceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveWorkbook provides a mock function with given fields: ctx, id, version
 func (_m *Student) RemoveWorkbook(ctx context.Context, id domain.WorkbookID, version int) error {
 	ret := _m.Called(ctx, id, version)
@@ -18,6 +18,27 @@ type UserQuotaRepository struct {
 	mock.Mock
 }
 
+// FindUsage provides a mock function with given fields: ctx, operator, name, unit
+func (_m *UserQuotaRepository) FindUsage(ctx context.Context, operator domain.StudentModel, name string, unit service.QuotaUnit) (int, error) {
+	ret := _m.Called(ctx, operator, name, unit)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, string, service.QuotaUnit) int); ok {
+		r0 = rf(ctx, operator, name, unit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StudentModel, string, service.QuotaUnit) error); ok {
+		r1 = rf(ctx, operator, name, unit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Increment provides a mock function with given fields: ctx, operator, name, unit, limit, count
 func (_m *UserQuotaRepository) Increment(ctx context.Context, operator domain.StudentModel, name string, unit service.QuotaUnit, limit int, count int) (bool, error) {
 	ret := _m.Called(ctx, operator, name, unit, limit, count)
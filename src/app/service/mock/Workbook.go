@@ -45,6 +45,27 @@ func (_m *Workbook) AddProblem(ctx context.Context, operator domain.StudentModel
 	return r0, r1
 }
 
+// AttachSentences provides a mock function with given fields: ctx, operator, sentences
+func (_m *Workbook) AttachSentences(ctx context.Context, operator domain.StudentModel, sentences []service.ProblemSentences) (service.Updated, error) {
+	ret := _m.Called(ctx, operator, sentences)
+
+	var r0 service.Updated
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, []service.ProblemSentences) service.Updated); ok {
+		r0 = rf(ctx, operator, sentences)
+	} else {
+		r0 = ret.Get(0).(service.Updated)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StudentModel, []service.ProblemSentences) error); ok {
+		r1 = rf(ctx, operator, sentences)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountProblems provides a mock function with given fields: ctx, operator
 func (_m *Workbook) CountProblems(ctx context.Context, operator domain.StudentModel) (int, error) {
 	ret := _m.Called(ctx, operator)
@@ -181,6 +202,50 @@ func (_m *Workbook) FindProblemsByProblemIDs(ctx context.Context, operator domai
 	return r0, r1
 }
 
+// FindProblemsWithoutSentences provides a mock function with given fields: ctx, operator, size
+func (_m *Workbook) FindProblemsWithoutSentences(ctx context.Context, operator domain.StudentModel, size int) ([]domain.ProblemModel, error) {
+	ret := _m.Called(ctx, operator, size)
+
+	var r0 []domain.ProblemModel
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, int) []domain.ProblemModel); ok {
+		r0 = rf(ctx, operator, size)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.ProblemModel)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StudentModel, int) error); ok {
+		r1 = rf(ctx, operator, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateAudio provides a mock function with given fields: ctx, operator, problemID
+func (_m *Workbook) GenerateAudio(ctx context.Context, operator domain.StudentModel, problemID domain.ProblemID) (bool, error) {
+	ret := _m.Called(ctx, operator, problemID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, domain.StudentModel, domain.ProblemID) bool); ok {
+		r0 = rf(ctx, operator, problemID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.StudentModel, domain.ProblemID) error); ok {
+		r1 = rf(ctx, operator, problemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCreatedAt provides a mock function with given fields:
 func (_m *Workbook) GetCreatedAt() time.Time {
 	ret := _m.Called()
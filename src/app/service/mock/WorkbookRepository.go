@@ -42,6 +42,20 @@ func (_m *WorkbookRepository) AddWorkbook(ctx context.Context, operator domain.A
 	return r0, r1
 }
 
+// ChangeSpace provides a mock function with given fields: ctx, operator, workbookID, version, spaceID
+func (_m *WorkbookRepository) ChangeSpace(ctx context.Context, operator appdomain.StudentModel, workbookID appdomain.WorkbookID, version int, spaceID domain.SpaceID) error {
+	ret := _m.Called(ctx, operator, workbookID, version, spaceID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, appdomain.StudentModel, appdomain.WorkbookID, int, domain.SpaceID) error); ok {
+		r0 = rf(ctx, operator, workbookID, version, spaceID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindPersonalWorkbooks provides a mock function with given fields: ctx, operator, param
 func (_m *WorkbookRepository) FindPersonalWorkbooks(ctx context.Context, operator appdomain.StudentModel, param service.WorkbookSearchCondition) (service.WorkbookSearchResult, error) {
 	ret := _m.Called(ctx, operator, param)
@@ -111,6 +125,29 @@ func (_m *WorkbookRepository) FindWorkbookByName(ctx context.Context, operator d
 	return r0, r1
 }
 
+// FindWorkbooksInSpaces provides a mock function with given fields: ctx, operator, param
+func (_m *WorkbookRepository) FindWorkbooksInSpaces(ctx context.Context, operator appdomain.StudentModel, param service.WorkbookSearchCondition) (service.WorkbookSearchResult, error) {
+	ret := _m.Called(ctx, operator, param)
+
+	var r0 service.WorkbookSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, appdomain.StudentModel, service.WorkbookSearchCondition) service.WorkbookSearchResult); ok {
+		r0 = rf(ctx, operator, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.WorkbookSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, appdomain.StudentModel, service.WorkbookSearchCondition) error); ok {
+		r1 = rf(ctx, operator, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveWorkbook provides a mock function with given fields: ctx, operator, workbookID, version
 func (_m *WorkbookRepository) RemoveWorkbook(ctx context.Context, operator appdomain.StudentModel, workbookID appdomain.WorkbookID, version int) error {
 	ret := _m.Called(ctx, operator, workbookID, version)
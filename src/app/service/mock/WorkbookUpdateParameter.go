@@ -27,6 +27,22 @@ func (_m *WorkbookUpdateParameter) GetName() string {
 	return r0
 }
 
+// GetProperties provides a mock function with given fields:
+func (_m *WorkbookUpdateParameter) GetProperties() map[string]string {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
 // GetQuestionText provides a mock function with given fields:
 func (_m *WorkbookUpdateParameter) GetQuestionText() string {
 	ret := _m.Called()
@@ -3,3 +3,36 @@ package service
 type ProblemAddParameterIterator interface {
 	Next() (ProblemAddParameter, error)
 }
+
+type problemAddParameterIteratorWithProperties struct {
+	iterator   ProblemAddParameterIterator
+	properties map[string]string
+}
+
+// NewProblemAddParameterIteratorWithProperties returns the iterator which adds the properties to every parameter returned by the iterator.
+func NewProblemAddParameterIteratorWithProperties(iterator ProblemAddParameterIterator, properties map[string]string) ProblemAddParameterIterator {
+	return &problemAddParameterIteratorWithProperties{
+		iterator:   iterator,
+		properties: properties,
+	}
+}
+
+func (r *problemAddParameterIteratorWithProperties) Next() (ProblemAddParameter, error) {
+	param, err := r.iterator.Next()
+	if err != nil {
+		return nil, err
+	}
+	if param == nil {
+		return nil, nil
+	}
+
+	properties := make(map[string]string)
+	for k, v := range param.GetProperties() {
+		properties[k] = v
+	}
+	for k, v := range r.properties {
+		properties[k] = v
+	}
+
+	return NewProblemAddParameter(param.GetWorkbookID(), param.GetNumber(), properties)
+}
@@ -1,4 +1,6 @@
 //go:generate mockery --output mock --name ProblemQuotaProcessor
+//go:generate mockery --output mock --name ProblemSentenceProcessor
+//go:generate mockery --output mock --name ProblemAudioProcessor
 package service
 
 import (
@@ -6,6 +8,7 @@ import (
 	"io"
 
 	"github.com/kujilabo/cocotola-api/src/app/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 	// pluginCommon "github.com/kujilabo/cocotola-api/src/plugin/common/domain"
 )
 
@@ -25,7 +28,29 @@ type ProblemRemoveProcessor interface {
 }
 
 type ProblemImportProcessor interface {
-	CreateCSVReader(ctx context.Context, workbookID domain.WorkbookID, reader io.Reader) (ProblemAddParameterIterator, error)
+	CreateCSVReader(ctx context.Context, organizationID userD.OrganizationID, workbookID domain.WorkbookID, reader io.Reader) (ProblemAddParameterIterator, error)
+}
+
+// ProblemSentences is the example sentences which are fetched for the problem. The content depends on the problem type
+type ProblemSentences interface {
+	GetProblemID() domain.ProblemID
+}
+
+// ProblemSentenceProcessor attaches the example sentences in three steps so that the external service is called outside of the transactions
+type ProblemSentenceProcessor interface {
+	// FindProblemsWithoutSentences returns at most `size` problems which don't have any sentences
+	FindProblemsWithoutSentences(ctx context.Context, repo RepositoryFactory, operator domain.StudentModel, workbookModel domain.WorkbookModel, size int) ([]domain.ProblemModel, error)
+
+	// FetchSentences fetches the example sentences of the problems. The problems whose sentences aren't found are omitted
+	FetchSentences(ctx context.Context, problems []domain.ProblemModel) ([]ProblemSentences, error)
+
+	// AttachSentences attaches the sentences to the problems. The problems which have been changed since they were found are skipped
+	AttachSentences(ctx context.Context, repo RepositoryFactory, operator domain.StudentModel, workbookModel domain.WorkbookModel, sentences []ProblemSentences) (Updated, error)
+}
+
+type ProblemAudioProcessor interface {
+	// GenerateAudio synthesizes the audio of the problem which doesn't have any audio. It returns false if the problem already has the audio
+	GenerateAudio(ctx context.Context, repo RepositoryFactory, operator domain.StudentModel, workbookModel domain.WorkbookModel, problemID domain.ProblemID) (bool, error)
 }
 
 type ProblemQuotaProcessor interface {
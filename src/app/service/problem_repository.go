@@ -158,6 +158,7 @@ type ProblemSearchCondition interface {
 	GetPageNo() int
 	GetPageSize() int
 	GetKeyword() string
+	GetProperties() map[string]string
 }
 
 type problemSearchCondition struct {
@@ -165,14 +166,20 @@ type problemSearchCondition struct {
 	PageNo     int `validate:"required,gte=1"`
 	PageSize   int `validate:"required,gte=1,lte=1000"`
 	Keyword    string
+	Properties map[string]string
 }
 
-func NewProblemSearchCondition(workbookID domain.WorkbookID, pageNo, pageSize int, keyword string) (ProblemSearchCondition, error) {
+func NewProblemSearchCondition(workbookID domain.WorkbookID, pageNo, pageSize int, keyword string, properties map[string]string) (ProblemSearchCondition, error) {
+	if properties == nil {
+		properties = map[string]string{}
+	}
+
 	m := &problemSearchCondition{
 		WorkbookID: workbookID,
 		PageNo:     pageNo,
 		PageSize:   pageSize,
 		Keyword:    keyword,
+		Properties: properties,
 	}
 
 	return m, libD.Validator.Struct(m)
@@ -194,6 +201,10 @@ func (c *problemSearchCondition) GetKeyword() string {
 	return c.Keyword
 }
 
+func (c *problemSearchCondition) GetProperties() map[string]string {
+	return c.Properties
+}
+
 type ProblemIDsCondition interface {
 	GetWorkbookID() domain.WorkbookID
 	GetIDs() []domain.ProblemID
@@ -265,6 +276,9 @@ type ProblemRepository interface {
 
 	UpdateProblem(ctx context.Context, operator domain.StudentModel, id ProblemSelectParameter2, param ProblemUpdateParameter) error
 
+	// UpdateAudioID updates only the audio id of the problem. The version of the problem is not changed
+	UpdateAudioID(ctx context.Context, operator domain.StudentModel, id ProblemSelectParameter1, audioID domain.AudioID) error
+
 	RemoveProblem(ctx context.Context, operator domain.StudentModel, id ProblemSelectParameter2) error
 
 	CountProblems(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID) (int, error)
@@ -13,23 +13,31 @@ type ProcessorFactory interface {
 	NewProblemImportProcessor(processorType string) (ProblemImportProcessor, error)
 
 	NewProblemQuotaProcessor(processorType string) (ProblemQuotaProcessor, error)
+
+	NewProblemSentenceProcessor(processorType string) (ProblemSentenceProcessor, error)
+
+	NewProblemAudioProcessor(processorType string) (ProblemAudioProcessor, error)
 }
 
 type processorFactrory struct {
-	addProcessors    map[string]ProblemAddProcessor
-	updateProcessors map[string]ProblemUpdateProcessor
-	removeProcessors map[string]ProblemRemoveProcessor
-	importProcessors map[string]ProblemImportProcessor
-	quotaProcessors  map[string]ProblemQuotaProcessor
+	addProcessors      map[string]ProblemAddProcessor
+	updateProcessors   map[string]ProblemUpdateProcessor
+	removeProcessors   map[string]ProblemRemoveProcessor
+	importProcessors   map[string]ProblemImportProcessor
+	quotaProcessors    map[string]ProblemQuotaProcessor
+	sentenceProcessors map[string]ProblemSentenceProcessor
+	audioProcessors    map[string]ProblemAudioProcessor
 }
 
-func NewProcessorFactory(addProcessors map[string]ProblemAddProcessor, updateProcessors map[string]ProblemUpdateProcessor, removeProcessors map[string]ProblemRemoveProcessor, importProcessors map[string]ProblemImportProcessor, quotaProcessors map[string]ProblemQuotaProcessor) ProcessorFactory {
+func NewProcessorFactory(addProcessors map[string]ProblemAddProcessor, updateProcessors map[string]ProblemUpdateProcessor, removeProcessors map[string]ProblemRemoveProcessor, importProcessors map[string]ProblemImportProcessor, quotaProcessors map[string]ProblemQuotaProcessor, sentenceProcessors map[string]ProblemSentenceProcessor, audioProcessors map[string]ProblemAudioProcessor) ProcessorFactory {
 	return &processorFactrory{
-		addProcessors:    addProcessors,
-		updateProcessors: updateProcessors,
-		removeProcessors: removeProcessors,
-		importProcessors: importProcessors,
-		quotaProcessors:  quotaProcessors,
+		addProcessors:      addProcessors,
+		updateProcessors:   updateProcessors,
+		removeProcessors:   removeProcessors,
+		importProcessors:   importProcessors,
+		quotaProcessors:    quotaProcessors,
+		sentenceProcessors: sentenceProcessors,
+		audioProcessors:    audioProcessors,
 	}
 }
 
@@ -72,3 +80,19 @@ func (f *processorFactrory) NewProblemQuotaProcessor(processorType string) (Prob
 	}
 	return processor, nil
 }
+
+func (f *processorFactrory) NewProblemSentenceProcessor(processorType string) (ProblemSentenceProcessor, error) {
+	processor, ok := f.sentenceProcessors[processorType]
+	if !ok {
+		return nil, liberrors.Errorf("NewProblemSentenceProcessor not found. processorType: %s", processorType)
+	}
+	return processor, nil
+}
+
+func (f *processorFactrory) NewProblemAudioProcessor(processorType string) (ProblemAudioProcessor, error) {
+	processor, ok := f.audioProcessors[processorType]
+	if !ok {
+		return nil, liberrors.Errorf("NewProblemAudioProcessor not found. processorType: %s", processorType)
+	}
+	return processor, nil
+}
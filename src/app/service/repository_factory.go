@@ -17,4 +17,6 @@ type RepositoryFactory interface {
 	NewRecordbookRepository(ctx context.Context) RecordbookRepository
 
 	NewUserQuotaRepository(ctx context.Context) UserQuotaRepository
+
+	NewAudioGenerationJobRepository(ctx context.Context) AudioGenerationJobRepository
 }
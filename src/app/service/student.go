@@ -29,8 +29,20 @@ type Student interface {
 
 	RemoveWorkbook(ctx context.Context, id domain.WorkbookID, version int) error
 
+	// FindWorkbooksFromSpace returns the workbooks in the space. The student must be able to read the space
+	FindWorkbooksFromSpace(ctx context.Context, spaceID userD.SpaceID, condition WorkbookSearchCondition) (WorkbookSearchResult, error)
+
+	// AddWorkbookToSpace adds the workbook to the space. The student must be able to write to the space
+	AddWorkbookToSpace(ctx context.Context, spaceID userD.SpaceID, parameter WorkbookAddParameter) (domain.WorkbookID, error)
+
+	// MoveWorkbook moves the workbook to the space. The student must be able to remove the workbook and write to the space
+	MoveWorkbook(ctx context.Context, workbookID domain.WorkbookID, version int, spaceID userD.SpaceID) error
+
 	CheckQuota(ctx context.Context, problemType string, name QuotaName) error
 
+	// FindRemainingQuota returns the number which the student can use until the quota is exceeded
+	FindRemainingQuota(ctx context.Context, problemType string, name QuotaName) (int, error)
+
 	IncrementQuotaUsage(ctx context.Context, problemType string, name QuotaName, value int) error
 
 	DecrementQuotaUsage(ctx context.Context, problemType string, name QuotaName, value int) error
@@ -146,6 +158,80 @@ func (s *student) RemoveWorkbook(ctx context.Context, workbookID domain.Workbook
 	return workbook.RemoveWorkbook(ctx, s, version)
 }
 
+func (s *student) FindWorkbooksFromSpace(ctx context.Context, spaceID userD.SpaceID, condition WorkbookSearchCondition) (WorkbookSearchResult, error) {
+	if err := s.checkSpacePrivilege(ctx, spaceID, userD.SpacePrivilegeRead); err != nil {
+		return nil, err
+	}
+
+	// specify space
+	newCondition, err := NewWorkbookSearchCondition(condition.GetPageNo(), condition.GetPageSize(), []userD.SpaceID{spaceID})
+	if err != nil {
+		return nil, liberrors.Errorf("failed to NewWorkbookSearchCondition. err: %w", err)
+	}
+
+	workbookRepo, err := s.rf.NewWorkbookRepository(ctx)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to NewWorkbookRepository. err: %w", err)
+	}
+
+	return workbookRepo.FindWorkbooksInSpaces(ctx, s, newCondition)
+}
+
+func (s *student) AddWorkbookToSpace(ctx context.Context, spaceID userD.SpaceID, parameter WorkbookAddParameter) (domain.WorkbookID, error) {
+	if err := s.checkSpacePrivilege(ctx, spaceID, userD.SpacePrivilegeWrite); err != nil {
+		return 0, err
+	}
+
+	workbookRepo, err := s.rf.NewWorkbookRepository(ctx)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to NewWorkbookRepository. err: %w", err)
+	}
+
+	workbookID, err := workbookRepo.AddWorkbook(ctx, s, spaceID, parameter)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to AddWorkbook. err: %w", err)
+	}
+
+	return workbookID, nil
+}
+
+func (s *student) MoveWorkbook(ctx context.Context, workbookID domain.WorkbookID, version int, spaceID userD.SpaceID) error {
+	workbook, err := s.FindWorkbookByID(ctx, workbookID)
+	if err != nil {
+		return liberrors.Errorf("s.FindWorkbookByID. err: %w", err)
+	}
+	if !workbook.HasPrivilege(domain.PrivilegeRemove) {
+		return ErrWorkbookPermissionDenied
+	}
+
+	if err := s.checkSpacePrivilege(ctx, spaceID, userD.SpacePrivilegeWrite); err != nil {
+		return err
+	}
+
+	workbookRepo, err := s.rf.NewWorkbookRepository(ctx)
+	if err != nil {
+		return liberrors.Errorf("failed to NewWorkbookRepository. err: %w", err)
+	}
+
+	return workbookRepo.ChangeSpace(ctx, s, workbookID, version, spaceID)
+}
+
+func (s *student) checkSpacePrivilege(ctx context.Context, spaceID userD.SpaceID, privilege userD.RBACAction) error {
+	appUser, err := userS.NewAppUser(s.userRf, s)
+	if err != nil {
+		return liberrors.Errorf("failed to NewAppUser. err: %w", err)
+	}
+
+	privs, err := appUser.GetSpacePrivileges(ctx, spaceID)
+	if err != nil {
+		return liberrors.Errorf("failed to GetSpacePrivileges. err: %w", err)
+	}
+	if !privs.HasPrivilege(privilege) {
+		return liberrors.Errorf("the student doesn't have the privilege on the space. spaceID: %d, privilege: %s, err: %w", spaceID, privilege, ErrWorkbookPermissionDenied)
+	}
+	return nil
+}
+
 func (s *student) CheckQuota(ctx context.Context, problemType string, name QuotaName) error {
 	processor, err := s.pf.NewProblemQuotaProcessor(problemType)
 	if err != nil {
@@ -157,7 +243,7 @@ func (s *student) CheckQuota(ctx context.Context, problemType string, name Quota
 	switch name {
 	case QuotaNameSize:
 		unit := processor.GetUnitForSizeQuota()
-		limit := processor.GetLimitForSizeQuota()
+		limit := QuotaLimit(s, processor.GetLimitForSizeQuota())
 		isExceeded, err := userQuotaRepo.IsExceeded(ctx, s, problemType+"_size", unit, limit)
 		if err != nil {
 			return liberrors.Errorf("userQuotaRepo.IsExceeded(size). err: %w", err)
@@ -170,7 +256,7 @@ func (s *student) CheckQuota(ctx context.Context, problemType string, name Quota
 		return nil
 	case QuotaNameUpdate:
 		unit := processor.GetUnitForUpdateQuota()
-		limit := processor.GetLimitForUpdateQuota()
+		limit := QuotaLimit(s, processor.GetLimitForUpdateQuota())
 		isExceeded, err := userQuotaRepo.IsExceeded(ctx, s, problemType+"_update", unit, limit)
 		if err != nil {
 			return liberrors.Errorf("userQuotaRepo.IsExceeded(update). err: %w", err)
@@ -186,6 +272,39 @@ func (s *student) CheckQuota(ctx context.Context, problemType string, name Quota
 	}
 }
 
+func (s *student) FindRemainingQuota(ctx context.Context, problemType string, name QuotaName) (int, error) {
+	processor, err := s.pf.NewProblemQuotaProcessor(problemType)
+	if err != nil {
+		return 0, liberrors.Errorf("s.pf.NewProblemQuotaProcessor. err: %w", err)
+	}
+
+	var quotaName string
+	var unit QuotaUnit
+	var limit int
+	switch name {
+	case QuotaNameSize:
+		quotaName = problemType + "_size"
+		unit = processor.GetUnitForSizeQuota()
+		limit = QuotaLimit(s, processor.GetLimitForSizeQuota())
+	case QuotaNameUpdate:
+		quotaName = problemType + "_update"
+		unit = processor.GetUnitForUpdateQuota()
+		limit = QuotaLimit(s, processor.GetLimitForUpdateQuota())
+	default:
+		return 0, liberrors.Errorf("invalid name. name: %s", name)
+	}
+
+	usage, err := s.rf.NewUserQuotaRepository(ctx).FindUsage(ctx, s, quotaName, unit)
+	if err != nil {
+		return 0, liberrors.Errorf("userQuotaRepo.FindUsage. err: %w", err)
+	}
+
+	if usage >= limit {
+		return 0, nil
+	}
+	return limit - usage, nil
+}
+
 func (s *student) IncrementQuotaUsage(ctx context.Context, problemType string, name QuotaName, value int) error {
 	processor, err := s.pf.NewProblemQuotaProcessor(problemType)
 	if err != nil {
@@ -197,7 +316,7 @@ func (s *student) IncrementQuotaUsage(ctx context.Context, problemType string, n
 	switch name {
 	case QuotaNameSize:
 		unit := processor.GetUnitForSizeQuota()
-		limit := processor.GetLimitForSizeQuota()
+		limit := QuotaLimit(s, processor.GetLimitForSizeQuota())
 		isExceeded, err := userQuotaRepo.Increment(ctx, s, problemType+"_size", unit, limit, value)
 		if err != nil {
 			return err
@@ -210,7 +329,7 @@ func (s *student) IncrementQuotaUsage(ctx context.Context, problemType string, n
 		return nil
 	case QuotaNameUpdate:
 		unit := processor.GetUnitForUpdateQuota()
-		limit := processor.GetLimitForUpdateQuota()
+		limit := QuotaLimit(s, processor.GetLimitForUpdateQuota())
 		isExceeded, err := userQuotaRepo.Increment(ctx, s, problemType+"_update", unit, limit, value)
 		if err != nil {
 			return err
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kujilabo/cocotola-api/src/app/domain"
+	domain_mock "github.com/kujilabo/cocotola-api/src/app/domain/mock"
 	"github.com/kujilabo/cocotola-api/src/app/service"
 	mocks "github.com/kujilabo/cocotola-api/src/app/service/mock"
 	user_mock "github.com/kujilabo/cocotola-api/src/user/domain/mock"
@@ -147,10 +148,12 @@ func Test_student_CheckQuota(t *testing.T) {
 	}
 	tests := []struct {
 		name              string
+		roles             []string
 		isExceeded        bool
 		problemTypeSuffix string
 		quotaUnit         service.QuotaUnit
 		quotaLimit        int
+		expectedLimit     int
 		args              args
 		err               error
 	}{
@@ -160,6 +163,7 @@ func Test_student_CheckQuota(t *testing.T) {
 			problemTypeSuffix: "_size",
 			quotaUnit:         service.QuotaUnitPersitance,
 			quotaLimit:        234,
+			expectedLimit:     234,
 			args: args{
 				problemType: problemType1,
 				name:        service.QuotaNameSize,
@@ -172,6 +176,7 @@ func Test_student_CheckQuota(t *testing.T) {
 			problemTypeSuffix: "_size",
 			quotaUnit:         service.QuotaUnitPersitance,
 			quotaLimit:        234,
+			expectedLimit:     234,
 			args: args{
 				problemType: problemType2,
 				name:        service.QuotaNameSize,
@@ -184,6 +189,7 @@ func Test_student_CheckQuota(t *testing.T) {
 			problemTypeSuffix: "_update",
 			quotaUnit:         service.QuotaUnitDay,
 			quotaLimit:        345,
+			expectedLimit:     345,
 			args: args{
 				problemType: problemType1,
 				name:        service.QuotaNameUpdate,
@@ -196,23 +202,42 @@ func Test_student_CheckQuota(t *testing.T) {
 			problemTypeSuffix: "_update",
 			quotaUnit:         service.QuotaUnitDay,
 			quotaLimit:        345,
+			expectedLimit:     345,
 			args: args{
 				problemType: problemType2,
 				name:        service.QuotaNameUpdate,
 			},
 			err: service.ErrQuotaExceeded,
 		},
+		{
+			name:              "QuotaNameSize,guest",
+			roles:             []string{"Guest"},
+			isExceeded:        false,
+			problemTypeSuffix: "_size",
+			quotaUnit:         service.QuotaUnitPersitance,
+			quotaLimit:        200,
+			expectedLimit:     20,
+			args: args{
+				problemType: problemType1,
+				name:        service.QuotaNameSize,
+			},
+			err: nil,
+		},
 	}
+	service.SetGuestQuotaPercent(10)
+	defer service.SetGuestQuotaPercent(100)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			_, userRf, _, userQuotaRepo, rf, problemQuotaProcessor, pf := student_Init(t, ctx)
-			userQuotaRepo.On("IsExceeded", mock.Anything, mock.Anything, tt.args.problemType+tt.problemTypeSuffix, tt.quotaUnit, tt.quotaLimit).Return(tt.isExceeded, nil)
+			userQuotaRepo.On("IsExceeded", mock.Anything, mock.Anything, tt.args.problemType+tt.problemTypeSuffix, tt.quotaUnit, tt.expectedLimit).Return(tt.isExceeded, nil)
 			problemQuotaProcessor.On("GetUnitForSizeQuota").Return(service.QuotaUnitPersitance)
 			problemQuotaProcessor.On("GetLimitForSizeQuota").Return(tt.quotaLimit)
 			problemQuotaProcessor.On("GetUnitForUpdateQuota").Return(service.QuotaUnitDay)
 			problemQuotaProcessor.On("GetLimitForUpdateQuota").Return(tt.quotaLimit)
 
-			s, err := service.NewStudent(pf, rf, userRf, nil)
+			studentModel := new(domain_mock.StudentModel)
+			studentModel.On("GetRoles").Return(tt.roles)
+			s, err := service.NewStudent(pf, rf, userRf, studentModel)
 			require.NoError(t, err)
 			err = s.CheckQuota(ctx, tt.args.problemType, tt.args.name)
 			if err == nil && tt.err != nil {
@@ -222,8 +247,50 @@ func Test_student_CheckQuota(t *testing.T) {
 			} else if err != nil && tt.err != nil && !errors.Is(err, tt.err) {
 				t.Errorf("student.CheckQuota() error = %v, err %v", err, tt.err)
 			}
-			userQuotaRepo.AssertCalled(t, "IsExceeded", mock.Anything, mock.Anything, tt.args.problemType+tt.problemTypeSuffix, tt.quotaUnit, tt.quotaLimit)
+			userQuotaRepo.AssertCalled(t, "IsExceeded", mock.Anything, mock.Anything, tt.args.problemType+tt.problemTypeSuffix, tt.quotaUnit, tt.expectedLimit)
 			userQuotaRepo.AssertNumberOfCalls(t, "IsExceeded", 1)
 		})
 	}
 }
+
+func Test_student_FindRemainingQuota(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		usage    int
+		expected int
+	}{
+		{
+			name:     "remaining",
+			usage:    300,
+			expected: 45,
+		},
+		{
+			name:     "used up",
+			usage:    345,
+			expected: 0,
+		},
+		{
+			name:     "overused",
+			usage:    400,
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, userRf, _, userQuotaRepo, rf, problemQuotaProcessor, pf := student_Init(t, ctx)
+			userQuotaRepo.On("FindUsage", mock.Anything, mock.Anything, problemType1+"_update", service.QuotaUnitDay).Return(tt.usage, nil)
+			problemQuotaProcessor.On("GetUnitForUpdateQuota").Return(service.QuotaUnitDay)
+			problemQuotaProcessor.On("GetLimitForUpdateQuota").Return(345)
+
+			studentModel := new(domain_mock.StudentModel)
+			studentModel.On("GetRoles").Return([]string{})
+			s, err := service.NewStudent(pf, rf, userRf, studentModel)
+			require.NoError(t, err)
+			actual, err := s.FindRemainingQuota(ctx, problemType1, service.QuotaNameUpdate)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+)
+
+const (
+	audioCacheResultHit   = "hit"
+	audioCacheResultMiss  = "miss"
+	audioCacheResultStale = "stale"
+)
+
+var audioCacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "cocotola_synthesizer_cache_requests_total",
+	Help: "The total number of the synthesize requests by the result of the audio cache",
+}, []string{"lang2", "result"})
+
+type SynthesizerCacheClient interface {
+	SynthesizerClient
+
+	// PurgeCache removes all the caches of the language, or all the caches if lang2 is nil
+	PurgeCache(ctx context.Context, lang2 domain.Lang2) (int, error)
+}
+
+type synthesizerCacheClient struct {
+	client    SynthesizerClient
+	cacheRepo AudioCacheRepository
+	group     singleflight.Group
+}
+
+// NewSynthesizerCacheClient returns the SynthesizerClient which returns the existing audio instead of synthesizing the same text again.
+// The texts are compared after they are normalized by NormalizeAudioText.
+// The concurrent requests of the same text are synthesized only once
+func NewSynthesizerCacheClient(client SynthesizerClient, cacheRepo AudioCacheRepository) SynthesizerCacheClient {
+	return &synthesizerCacheClient{
+		client:    client,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (c *synthesizerCacheClient) Synthesize(ctx context.Context, lang2 domain.Lang2, text string) (Audio, error) {
+	normalizedText := NormalizeAudioText(text)
+	key := lang2.String() + ":" + normalizedText
+
+	result, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.synthesize(ctx, lang2, text, normalizedText)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(Audio), nil
+}
+
+func (c *synthesizerCacheClient) FindAudioByAudioID(ctx context.Context, audioID domain.AudioID) (Audio, error) {
+	return c.client.FindAudioByAudioID(ctx, audioID)
+}
+
+func (c *synthesizerCacheClient) PurgeCache(ctx context.Context, lang2 domain.Lang2) (int, error) {
+	count, err := c.cacheRepo.PurgeAudioCache(ctx, lang2)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to PurgeAudioCache. err: %w", err)
+	}
+	return count, nil
+}
+
+func (c *synthesizerCacheClient) synthesize(ctx context.Context, lang2 domain.Lang2, text, normalizedText string) (Audio, error) {
+	logger := log.FromContext(ctx)
+
+	audioID, err := c.cacheRepo.FindAudioID(ctx, lang2, normalizedText)
+	if err == nil {
+		audio, err := c.client.FindAudioByAudioID(ctx, audioID)
+		if err == nil {
+			audioCacheRequestsTotal.WithLabelValues(lang2.String(), audioCacheResultHit).Inc()
+			return audio, nil
+		} else if !errors.Is(err, ErrAudioNotFound) {
+			return nil, liberrors.Errorf("failed to FindAudioByAudioID. audioID: %d, err: %w", audioID, err)
+		}
+
+		// the audio has been removed from the synthesizer
+		audioCacheRequestsTotal.WithLabelValues(lang2.String(), audioCacheResultStale).Inc()
+		if err := c.cacheRepo.RemoveAudioID(ctx, audioID); err != nil {
+			logger.Warnf("failed to RemoveAudioID. audioID: %d, err: %v", audioID, err)
+		}
+	} else if errors.Is(err, ErrAudioCacheNotFound) {
+		audioCacheRequestsTotal.WithLabelValues(lang2.String(), audioCacheResultMiss).Inc()
+	} else {
+		// the audio should be synthesized even if the cache is unavailable
+		audioCacheRequestsTotal.WithLabelValues(lang2.String(), audioCacheResultMiss).Inc()
+		logger.Warnf("failed to FindAudioID. text: %s, err: %v", normalizedText, err)
+	}
+
+	audio, err := c.client.Synthesize(ctx, lang2, text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.cacheRepo.SetAudioID(ctx, lang2, normalizedText, domain.AudioID(audio.GetAudioModel().GetID())); err != nil {
+		logger.Warnf("failed to SetAudioID. text: %s, err: %v", normalizedText, err)
+	}
+
+	return audio, nil
+}
+
+// NormalizeAudioText returns the lower case text whose consecutive spaces are replaced with a space
+func NormalizeAudioText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,79 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/app/service"
+	mocks "github.com/kujilabo/cocotola-api/src/app/service/mock"
+)
+
+func newTestAudio(t *testing.T, id uint, text string) service.Audio {
+	audioModel, err := domain.NewAudioModel(id, domain.Lang2EN, text, "content")
+	require.NoError(t, err)
+	audio, err := service.NewAudio(audioModel)
+	require.NoError(t, err)
+	return audio
+}
+
+func TestSynthesizerCacheClient_Synthesize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cache miss", func(t *testing.T) {
+		client := new(mocks.SynthesizerClient)
+		cacheRepo := new(mocks.AudioCacheRepository)
+		cacheRepo.On("FindAudioID", mock.Anything, domain.Lang2EN, "hello world").Return(domain.AudioID(0), service.ErrAudioCacheNotFound)
+		cacheRepo.On("SetAudioID", mock.Anything, domain.Lang2EN, "hello world", domain.AudioID(1)).Return(nil)
+		client.On("Synthesize", mock.Anything, domain.Lang2EN, " Hello  World").Return(newTestAudio(t, 1, "Hello World"), nil)
+
+		audio, err := service.NewSynthesizerCacheClient(client, cacheRepo).Synthesize(ctx, domain.Lang2EN, " Hello  World")
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), audio.GetAudioModel().GetID())
+		cacheRepo.AssertCalled(t, "SetAudioID", mock.Anything, domain.Lang2EN, "hello world", domain.AudioID(1))
+	})
+
+	t.Run("cache hit", func(t *testing.T) {
+		client := new(mocks.SynthesizerClient)
+		cacheRepo := new(mocks.AudioCacheRepository)
+		cacheRepo.On("FindAudioID", mock.Anything, domain.Lang2EN, "hello").Return(domain.AudioID(2), nil)
+		client.On("FindAudioByAudioID", mock.Anything, domain.AudioID(2)).Return(newTestAudio(t, 2, "hello"), nil)
+
+		audio, err := service.NewSynthesizerCacheClient(client, cacheRepo).Synthesize(ctx, domain.Lang2EN, "Hello")
+		require.NoError(t, err)
+		assert.Equal(t, uint(2), audio.GetAudioModel().GetID())
+		client.AssertNotCalled(t, "Synthesize", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("the cached audio has been removed", func(t *testing.T) {
+		client := new(mocks.SynthesizerClient)
+		cacheRepo := new(mocks.AudioCacheRepository)
+		cacheRepo.On("FindAudioID", mock.Anything, domain.Lang2EN, "hello").Return(domain.AudioID(2), nil)
+		cacheRepo.On("RemoveAudioID", mock.Anything, domain.AudioID(2)).Return(nil)
+		cacheRepo.On("SetAudioID", mock.Anything, domain.Lang2EN, "hello", domain.AudioID(3)).Return(nil)
+		client.On("FindAudioByAudioID", mock.Anything, domain.AudioID(2)).Return(nil, service.ErrAudioNotFound)
+		client.On("Synthesize", mock.Anything, domain.Lang2EN, "hello").Return(newTestAudio(t, 3, "hello"), nil)
+
+		audio, err := service.NewSynthesizerCacheClient(client, cacheRepo).Synthesize(ctx, domain.Lang2EN, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, uint(3), audio.GetAudioModel().GetID())
+		cacheRepo.AssertCalled(t, "RemoveAudioID", mock.Anything, domain.AudioID(2))
+	})
+
+	t.Run("the cache is unavailable", func(t *testing.T) {
+		client := new(mocks.SynthesizerClient)
+		cacheRepo := new(mocks.AudioCacheRepository)
+		cacheRepo.On("FindAudioID", mock.Anything, domain.Lang2EN, "hello").Return(domain.AudioID(0), errors.New("connection refused"))
+		cacheRepo.On("SetAudioID", mock.Anything, domain.Lang2EN, "hello", domain.AudioID(1)).Return(errors.New("connection refused"))
+		client.On("Synthesize", mock.Anything, domain.Lang2EN, "hello").Return(newTestAudio(t, 1, "hello"), nil)
+
+		audio, err := service.NewSynthesizerCacheClient(client, cacheRepo).Synthesize(ctx, domain.Lang2EN, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, uint(1), audio.GetAudioModel().GetID())
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/kujilabo/cocotola-api/src/app/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
 type QuotaUnit string
@@ -23,5 +24,22 @@ var (
 type UserQuotaRepository interface {
 	IsExceeded(ctx context.Context, operator domain.StudentModel, name string, unit QuotaUnit, limit int) (bool, error)
 
+	// FindUsage returns the usage in the current unit
+	FindUsage(ctx context.Context, operator domain.StudentModel, name string, unit QuotaUnit) (int, error)
+
 	Increment(ctx context.Context, operator domain.StudentModel, name string, unit QuotaUnit, limit int, count int) (bool, error)
 }
+
+// QuotaLimit returns the limit of the student. The limits of the guests are reduced to GetGuestQuotaPercent percent
+func QuotaLimit(student domain.StudentModel, limit int) int {
+	for _, role := range student.GetRoles() {
+		if role == userD.GuestRole.GetName() {
+			guestLimit := limit * GetGuestQuotaPercent() / 100
+			if guestLimit < 1 {
+				return 1
+			}
+			return guestLimit
+		}
+	}
+	return limit
+}
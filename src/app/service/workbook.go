@@ -32,6 +32,15 @@ type Workbook interface {
 
 	RemoveProblem(ctx context.Context, operator domain.StudentModel, id ProblemSelectParameter2) error
 
+	// FindProblemsWithoutSentences returns at most `size` problems which don't have any sentences
+	FindProblemsWithoutSentences(ctx context.Context, operator domain.StudentModel, size int) ([]domain.ProblemModel, error)
+
+	// AttachSentences attaches the example sentences which are fetched by the processor to the problems
+	AttachSentences(ctx context.Context, operator domain.StudentModel, sentences []ProblemSentences) (Updated, error)
+
+	// GenerateAudio synthesizes the audio of the problem which doesn't have any audio. It returns false if the problem already has the audio
+	GenerateAudio(ctx context.Context, operator domain.StudentModel, problemID domain.ProblemID) (bool, error)
+
 	UpdateWorkbook(ctx context.Context, operator domain.StudentModel, version int, parameter WorkbookUpdateParameter) error
 
 	RemoveWorkbook(ctx context.Context, operator domain.StudentModel, version int) error
@@ -152,6 +161,48 @@ func (m *workbook) RemoveProblem(ctx context.Context, operator domain.StudentMod
 	return processor.RemoveProblem(ctx, m.rf, operator, id)
 }
 
+func (m *workbook) FindProblemsWithoutSentences(ctx context.Context, operator domain.StudentModel, size int) ([]domain.ProblemModel, error) {
+	if !m.GetWorkbookModel().HasPrivilege(domain.PrivilegeUpdate) {
+		return nil, errors.New("no update privilege")
+	}
+
+	processor, err := m.pf.NewProblemSentenceProcessor(m.GetWorkbookModel().GetProblemType())
+	if err != nil {
+		return nil, liberrors.Errorf("processor not found. problemType: %s, err: %w", m.GetWorkbookModel().GetProblemType(), err)
+	}
+
+	return processor.FindProblemsWithoutSentences(ctx, m.rf, operator, m.GetWorkbookModel(), size)
+}
+
+func (m *workbook) AttachSentences(ctx context.Context, operator domain.StudentModel, sentences []ProblemSentences) (Updated, error) {
+	logger := log.FromContext(ctx)
+	logger.Infof("workbook.AttachSentences")
+
+	if !m.GetWorkbookModel().HasPrivilege(domain.PrivilegeUpdate) {
+		return 0, errors.New("no update privilege")
+	}
+
+	processor, err := m.pf.NewProblemSentenceProcessor(m.GetWorkbookModel().GetProblemType())
+	if err != nil {
+		return 0, liberrors.Errorf("processor not found. problemType: %s, err: %w", m.GetWorkbookModel().GetProblemType(), err)
+	}
+
+	return processor.AttachSentences(ctx, m.rf, operator, m.GetWorkbookModel(), sentences)
+}
+
+func (m *workbook) GenerateAudio(ctx context.Context, operator domain.StudentModel, problemID domain.ProblemID) (bool, error) {
+	if !m.GetWorkbookModel().HasPrivilege(domain.PrivilegeUpdate) {
+		return false, ErrWorkbookPermissionDenied
+	}
+
+	processor, err := m.pf.NewProblemAudioProcessor(m.GetWorkbookModel().GetProblemType())
+	if err != nil {
+		return false, liberrors.Errorf("processor not found. problemType: %s, err: %w", m.GetWorkbookModel().GetProblemType(), err)
+	}
+
+	return processor.GenerateAudio(ctx, m.rf, operator, m.GetWorkbookModel(), problemID)
+}
+
 func (m *workbook) UpdateWorkbook(ctx context.Context, operator domain.StudentModel, version int, parameter WorkbookUpdateParameter) error {
 	if !m.GetWorkbookModel().HasPrivilege(domain.PrivilegeUpdate) {
 		return ErrWorkbookPermissionDenied
@@ -162,6 +213,23 @@ func (m *workbook) UpdateWorkbook(ctx context.Context, operator domain.StudentMo
 		return liberrors.Errorf("failed to NewWorkbookRepository. err: %w", err)
 	}
 
+	// the properties which are not in the parameter are kept
+	if parameter.GetProperties() != nil {
+		properties := make(map[string]string)
+		for k, v := range m.GetWorkbookModel().GetProperties() {
+			properties[k] = v
+		}
+		for k, v := range parameter.GetProperties() {
+			properties[k] = v
+		}
+
+		mergedParameter, err := NewWorkbookUpdateParameter(parameter.GetName(), parameter.GetQuestionText(), properties)
+		if err != nil {
+			return liberrors.Errorf("failed to NewWorkbookUpdateParameter. err: %w", err)
+		}
+		parameter = mergedParameter
+	}
+
 	return workbookRepo.UpdateWorkbook(ctx, operator, domain.WorkbookID(m.GetWorkbookModel().GetID()), version, parameter)
 }
 
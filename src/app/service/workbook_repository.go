@@ -129,17 +129,21 @@ func (p *workbookAddParameter) GetProperties() map[string]string {
 type WorkbookUpdateParameter interface {
 	GetName() string
 	GetQuestionText() string
+	// GetProperties returns the properties to be updated. The properties are not updated if it is nil
+	GetProperties() map[string]string
 }
 
 type workbookUpdateParameter struct {
 	Name         string
 	QuestionText string
+	Properties   map[string]string
 }
 
-func NewWorkbookUpdateParameter(name, questionText string) (WorkbookUpdateParameter, error) {
+func NewWorkbookUpdateParameter(name, questionText string, properties map[string]string) (WorkbookUpdateParameter, error) {
 	m := &workbookUpdateParameter{
 		Name:         name,
 		QuestionText: questionText,
+		Properties:   properties,
 	}
 
 	return m, libD.Validator.Struct(m)
@@ -153,9 +157,16 @@ func (p *workbookUpdateParameter) GetQuestionText() string {
 	return p.QuestionText
 }
 
+func (p *workbookUpdateParameter) GetProperties() map[string]string {
+	return p.Properties
+}
+
 type WorkbookRepository interface {
 	FindPersonalWorkbooks(ctx context.Context, operator domain.StudentModel, param WorkbookSearchCondition) (WorkbookSearchResult, error)
 
+	// FindWorkbooksInSpaces returns the workbooks in the spaces of the condition. The privileges on the spaces are checked by the caller
+	FindWorkbooksInSpaces(ctx context.Context, operator domain.StudentModel, param WorkbookSearchCondition) (WorkbookSearchResult, error)
+
 	FindWorkbookByID(ctx context.Context, operator domain.StudentModel, id domain.WorkbookID) (Workbook, error)
 
 	FindWorkbookByName(ctx context.Context, operator userD.AppUserModel, spaceID userD.SpaceID, name string) (Workbook, error)
@@ -165,4 +176,7 @@ type WorkbookRepository interface {
 	UpdateWorkbook(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID, version int, param WorkbookUpdateParameter) error
 
 	RemoveWorkbook(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID, version int) error
+
+	// ChangeSpace moves the workbook to the space
+	ChangeSpace(ctx context.Context, operator domain.StudentModel, workbookID domain.WorkbookID, version int, spaceID userD.SpaceID) error
 }
@@ -3,6 +3,9 @@ package student
 import (
 	"context"
 	"errors"
+	"io"
+	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -16,25 +19,47 @@ import (
 	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
 
+const (
+	// audioQuotaName is the name of the quota of the audio which is synthesized by the audio generation jobs
+	audioQuotaName = "audio"
+
+	audioGenerationErrorMessageMaxLength = 400
+)
+
 type StudentUsecaseAudio interface {
 	FindAudioByID(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, problemID domain.ProblemID, audioID domain.AudioID) (service.Audio, error)
+
+	// StartAudioGeneration starts the background job which synthesizes the audio of all the problems in the workbook which don't have any audio
+	StartAudioGeneration(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.AudioGenerationJob, error)
+
+	// FindAudioGenerationJob returns the latest audio generation job of the workbook
+	FindAudioGenerationJob(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.AudioGenerationJob, error)
+
+	// ExportAudioBundle writes the zip file of the audio of all the problems in the workbook.
+	// `newWriter` is called after the workbook is found so that nothing is written if the workbook can't be read
+	ExportAudioBundle(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, newWriter func(workbookModel domain.WorkbookModel) io.Writer) error
 }
 
 type studentUsecaseAudio struct {
-	db                *gorm.DB
-	pf                service.ProcessorFactory
-	rfFunc            service.RepositoryFactoryFunc
-	userRfFunc        userS.RepositoryFactoryFunc
-	synthesizerClient service.SynthesizerClient
+	db                 *gorm.DB
+	pf                 service.ProcessorFactory
+	rfFunc             service.RepositoryFactoryFunc
+	userRfFunc         userS.RepositoryFactoryFunc
+	synthesizerClient  service.SynthesizerClient
+	generationInterval time.Duration
+	audioQuotaPerDay   int
 }
 
-func NewStudentUsecaseAudio(db *gorm.DB, pf service.ProcessorFactory, rfFunc service.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, synthesizerClient service.SynthesizerClient) StudentUsecaseAudio {
+// NewStudentUsecaseAudio returns the usecase whose audio generation jobs synthesize an audio at most every `generationInterval`, and at most `audioQuotaPerDay` audios per user a day
+func NewStudentUsecaseAudio(db *gorm.DB, pf service.ProcessorFactory, rfFunc service.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, synthesizerClient service.SynthesizerClient, generationInterval time.Duration, audioQuotaPerDay int) StudentUsecaseAudio {
 	return &studentUsecaseAudio{
-		db:                db,
-		pf:                pf,
-		rfFunc:            rfFunc,
-		userRfFunc:        userRfFunc,
-		synthesizerClient: synthesizerClient,
+		db:                 db,
+		pf:                 pf,
+		rfFunc:             rfFunc,
+		userRfFunc:         userRfFunc,
+		synthesizerClient:  synthesizerClient,
+		generationInterval: generationInterval,
+		audioQuotaPerDay:   audioQuotaPerDay,
 	}
 }
 
@@ -78,6 +103,205 @@ func (s *studentUsecaseAudio) FindAudioByID(ctx context.Context, organizationID
 	return result, nil
 }
 
+func (s *studentUsecaseAudio) StartAudioGeneration(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.AudioGenerationJob, error) {
+	var job service.AudioGenerationJob
+	var problemIDs []domain.ProblemID
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		student, workbook, err := s.findStudentAndWorkbook(ctx, tx, organizationID, operatorID, workbookID)
+		if err != nil {
+			return err
+		}
+
+		if !workbook.HasPrivilege(domain.PrivilegeUpdate) {
+			return service.ErrWorkbookPermissionDenied
+		}
+
+		if _, err := s.pf.NewProblemAudioProcessor(workbook.GetProblemType()); err != nil {
+			return liberrors.Errorf("audio is not supported. problemType: %s, err: %w", workbook.GetProblemType(), libD.ErrInvalidArgument)
+		}
+
+		tmpProblemIDs, err := workbook.FindProblemIDs(ctx, student)
+		if err != nil {
+			return liberrors.Errorf("failed to FindProblemIDs. err: %w", err)
+		}
+
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		jobRepo := rf.NewAudioGenerationJobRepository(ctx)
+		if _, err := jobRepo.AddJob(ctx, student, workbookID, len(tmpProblemIDs)); err != nil {
+			return liberrors.Errorf("failed to AddJob. err: %w", err)
+		}
+
+		tmpJob, err := jobRepo.FindLatestJob(ctx, student, workbookID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindLatestJob. err: %w", err)
+		}
+
+		job = tmpJob
+		problemIDs = tmpProblemIDs
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// the request context is canceled when the response is written
+	bgCtx := log.With(context.Background(), log.Str("audioGenerationJobID", strconv.Itoa(int(job.GetID()))))
+	go func() {
+		logger := log.FromContext(bgCtx)
+		status := service.AudioGenerationJobStatusCompleted
+		errorMessage := ""
+		if err := s.generateAudios(bgCtx, organizationID, operatorID, workbookID, job.GetID(), problemIDs); err != nil {
+			logger.Errorf("failed to generate audios. jobID: %d, err: %v", job.GetID(), err)
+			status = service.AudioGenerationJobStatusFailed
+			errorMessage = err.Error()
+			if errors.Is(err, service.ErrQuotaExceeded) {
+				errorMessage = service.ErrQuotaExceeded.Error()
+			}
+		}
+
+		if err := s.updateAudioGenerationJob(bgCtx, func(jobRepo service.AudioGenerationJobRepository) error {
+			return jobRepo.UpdateStatus(bgCtx, job.GetID(), status, truncateAudioGenerationErrorMessage(errorMessage))
+		}); err != nil {
+			logger.Errorf("failed to UpdateStatus. jobID: %d, err: %v", job.GetID(), err)
+		}
+	}()
+
+	return job, nil
+}
+
+func (s *studentUsecaseAudio) FindAudioGenerationJob(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.AudioGenerationJob, error) {
+	var result service.AudioGenerationJob
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		student, _, err := s.findStudentAndWorkbook(ctx, tx, organizationID, operatorID, workbookID)
+		if err != nil {
+			return err
+		}
+
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		tmpResult, err := rf.NewAudioGenerationJobRepository(ctx).FindLatestJob(ctx, student, workbookID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindLatestJob. err: %w", err)
+		}
+
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *studentUsecaseAudio) ExportAudioBundle(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, newWriter func(workbookModel domain.WorkbookModel) io.Writer) error {
+	var workbookModel domain.WorkbookModel
+	var problems []domain.ProblemModel
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		student, workbook, err := s.findStudentAndWorkbook(ctx, tx, organizationID, operatorID, workbookID)
+		if err != nil {
+			return err
+		}
+
+		result, err := workbook.FindAllProblems(ctx, student)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAllProblems. err: %w", err)
+		}
+
+		workbookModel = workbook
+		problems = result.GetResults()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// the audio is fetched outside the transaction because it takes long for large workbooks
+	if err := service.WriteAudioBundle(ctx, newWriter(workbookModel), s.synthesizerClient, workbookModel, problems); err != nil {
+		return liberrors.Errorf("failed to WriteAudioBundle. err: %w", err)
+	}
+
+	return nil
+}
+
+// generateAudios synthesizes the audio of the problems one by one so that the problems which are processed are kept even if the job fails
+func (s *studentUsecaseAudio) generateAudios(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, jobID service.AudioGenerationJobID, problemIDs []domain.ProblemID) error {
+	logger := log.FromContext(ctx)
+	generatedAudios := 0
+	for i, problemID := range problemIDs {
+		generated := false
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			// the student and the workbook are found for each problem because the privileges may be changed while the job is running
+			student, workbook, err := s.findStudentAndWorkbook(ctx, tx, organizationID, operatorID, workbookID)
+			if err != nil {
+				return err
+			}
+
+			rf, err := s.rfFunc(ctx, tx)
+			if err != nil {
+				return err
+			}
+			userQuotaRepo := rf.NewUserQuotaRepository(ctx)
+			audioQuotaPerDay := service.QuotaLimit(student, s.audioQuotaPerDay)
+
+			isExceeded, err := userQuotaRepo.IsExceeded(ctx, student, audioQuotaName, service.QuotaUnitDay, audioQuotaPerDay)
+			if err != nil {
+				return liberrors.Errorf("failed to IsExceeded. err: %w", err)
+			}
+			if isExceeded {
+				return service.ErrQuotaExceeded
+			}
+
+			tmpGenerated, err := workbook.GenerateAudio(ctx, student, problemID)
+			if errors.Is(err, service.ErrProblemNotFound) {
+				logger.Infof("the problem has been removed. problemID: %d", problemID)
+				return nil
+			} else if err != nil {
+				return liberrors.Errorf("failed to GenerateAudio. problemID: %d, err: %w", problemID, err)
+			}
+
+			if tmpGenerated {
+				if _, err := userQuotaRepo.Increment(ctx, student, audioQuotaName, service.QuotaUnitDay, audioQuotaPerDay, 1); err != nil {
+					return liberrors.Errorf("failed to Increment. err: %w", err)
+				}
+			}
+
+			generated = tmpGenerated
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		if generated {
+			generatedAudios++
+		}
+
+		if err := s.updateAudioGenerationJob(ctx, func(jobRepo service.AudioGenerationJobRepository) error {
+			return jobRepo.UpdateProgress(ctx, jobID, i+1, generatedAudios)
+		}); err != nil {
+			return liberrors.Errorf("failed to UpdateProgress. err: %w", err)
+		}
+
+		// the problems which already have the audio are not throttled
+		if generated && i < len(problemIDs)-1 {
+			time.Sleep(s.generationInterval)
+		}
+	}
+
+	return nil
+}
+
+func (s *studentUsecaseAudio) updateAudioGenerationJob(ctx context.Context, fn func(jobRepo service.AudioGenerationJobRepository) error) error {
+	rf, err := s.rfFunc(ctx, s.db)
+	if err != nil {
+		return err
+	}
+	return fn(rf.NewAudioGenerationJobRepository(ctx))
+}
+
 func (s *studentUsecaseAudio) findStudentAndWorkbook(ctx context.Context, tx *gorm.DB, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.Student, service.Workbook, error) {
 	repo, err := s.rfFunc(ctx, tx)
 	if err != nil {
@@ -97,3 +321,11 @@ func (s *studentUsecaseAudio) findStudentAndWorkbook(ctx context.Context, tx *go
 	}
 	return studentService, workbookService, nil
 }
+
+func truncateAudioGenerationErrorMessage(message string) string {
+	runes := []rune(message)
+	if len(runes) > audioGenerationErrorMessageMaxLength {
+		return string(runes[:audioGenerationErrorMessageMaxLength])
+	}
+	return message
+}
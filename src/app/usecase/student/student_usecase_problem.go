@@ -35,6 +35,8 @@ type StudentUsecaseProblem interface {
 	RemoveProblem(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, id service.ProblemSelectParameter2) error
 
 	ImportProblems(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, newIterator func(workbookID domain.WorkbookID, problemType string) (service.ProblemAddParameterIterator, error)) error
+
+	AttachSentences(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.Updated, error)
 }
 
 type studentUsecaseProblem struct {
@@ -265,6 +267,86 @@ func (s *studentUsecaseProblem) ImportProblems(ctx context.Context, organization
 	return nil
 }
 
+func (s *studentUsecaseProblem) AttachSentences(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.Updated, error) {
+	logger := log.FromContext(ctx)
+	logger.Debug("ProblemService.AttachSentences")
+
+	// the problems are only as many as the remaining quota so that the usage doesn't exceed the quota
+	var problemType string
+	var problems []domain.ProblemModel
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		student, workbook, err := s.findStudentAndWorkbook(ctx, tx, organizationID, operatorID, workbookID)
+		if err != nil {
+			return liberrors.Errorf("s.findStudentAndWorkbook. err: %w", err)
+		}
+		problemType = workbook.GetProblemType()
+		remaining, err := student.FindRemainingQuota(ctx, problemType, service.QuotaNameUpdate)
+		if err != nil {
+			return liberrors.Errorf("student.FindRemainingQuota. err: %w", err)
+		}
+		if remaining == 0 {
+			return service.ErrQuotaExceeded
+		}
+		tmpProblems, err := workbook.FindProblemsWithoutSentences(ctx, student, remaining)
+		if err != nil {
+			return liberrors.Errorf("workbook.FindProblemsWithoutSentences. err: %w", err)
+		}
+		problems = tmpProblems
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	if len(problems) == 0 {
+		return 0, nil
+	}
+
+	// the sentences are fetched outside of the transaction because the external service is slow
+	processor, err := s.pf.NewProblemSentenceProcessor(problemType)
+	if err != nil {
+		return 0, liberrors.Errorf("s.pf.NewProblemSentenceProcessor. err: %w", err)
+	}
+	sentences, err := processor.FetchSentences(ctx, problems)
+	if err != nil {
+		return 0, liberrors.Errorf("processor.FetchSentences. err: %w", err)
+	}
+	if len(sentences) == 0 {
+		return 0, nil
+	}
+
+	var result service.Updated
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		student, workbook, err := s.findStudentAndWorkbook(ctx, tx, organizationID, operatorID, workbookID)
+		if err != nil {
+			return liberrors.Errorf("s.findStudentAndWorkbook. err: %w", err)
+		}
+		// the quota may have been used while the sentences were fetched
+		remaining, err := student.FindRemainingQuota(ctx, problemType, service.QuotaNameUpdate)
+		if err != nil {
+			return liberrors.Errorf("student.FindRemainingQuota. err: %w", err)
+		}
+		if remaining == 0 {
+			return service.ErrQuotaExceeded
+		}
+		if len(sentences) > remaining {
+			sentences = sentences[:remaining]
+		}
+		updated, err := workbook.AttachSentences(ctx, student, sentences)
+		if err != nil {
+			return liberrors.Errorf("workbook.AttachSentences. err: %w", err)
+		}
+		if updated > 0 {
+			if err := student.IncrementQuotaUsage(ctx, problemType, service.QuotaNameUpdate, int(updated)); err != nil {
+				return liberrors.Errorf("student.IncrementQuotaUsage(Update). err: %w", err)
+			}
+		}
+		result = updated
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
 func (s *studentUsecaseProblem) findStudentAndWorkbook(ctx context.Context, tx *gorm.DB, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID) (service.Student, service.Workbook, error) {
 	repo, err := s.rfFunc(ctx, tx)
 	if err != nil {
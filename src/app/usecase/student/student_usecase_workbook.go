@@ -9,6 +9,7 @@ import (
 	"github.com/kujilabo/cocotola-api/src/app/service"
 	"github.com/kujilabo/cocotola-api/src/app/usecase"
 	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
@@ -26,21 +27,30 @@ type StudentUsecaseWorkbook interface {
 	UpdateWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, version int, parameter service.WorkbookUpdateParameter) error
 
 	RemoveWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, version int) error
+
+	FindWorkbooksFromSpace(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, spaceID userD.SpaceID, pageNo, pageSize int) (service.WorkbookSearchResult, error)
+
+	AddWorkbookToSpace(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, spaceID userD.SpaceID, parameter service.WorkbookAddParameter) (domain.WorkbookID, error)
+
+	// MoveWorkbook moves the workbook to the space, e.g. from the personal space to the shared space
+	MoveWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, version int, spaceID userD.SpaceID) error
 }
 
 type studentUsecaseWorkbook struct {
-	db         *gorm.DB
-	pf         service.ProcessorFactory
-	rfFunc     service.RepositoryFactoryFunc
-	userRfFunc userS.RepositoryFactoryFunc
+	db                  *gorm.DB
+	pf                  service.ProcessorFactory
+	rfFunc              service.RepositoryFactoryFunc
+	userRfFunc          userS.RepositoryFactoryFunc
+	studentUsecaseAudio StudentUsecaseAudio
 }
 
-func NewStudentUsecaseWorkbook(db *gorm.DB, pf service.ProcessorFactory, rfFunc service.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc) StudentUsecaseWorkbook {
+func NewStudentUsecaseWorkbook(db *gorm.DB, pf service.ProcessorFactory, rfFunc service.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, studentUsecaseAudio StudentUsecaseAudio) StudentUsecaseWorkbook {
 	return &studentUsecaseWorkbook{
-		db:         db,
-		pf:         pf,
-		rfFunc:     rfFunc,
-		userRfFunc: userRfFunc,
+		db:                  db,
+		pf:                  pf,
+		rfFunc:              rfFunc,
+		userRfFunc:          userRfFunc,
+		studentUsecaseAudio: studentUsecaseAudio,
 	}
 }
 
@@ -137,6 +147,7 @@ func (s *studentUsecaseWorkbook) AddWorkbook(ctx context.Context, organizationID
 }
 
 func (s *studentUsecaseWorkbook) UpdateWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, version int, parameter service.WorkbookUpdateParameter) error {
+	audioTurnedOn := false
 	if err := s.db.Transaction(func(tx *gorm.DB) error {
 		rf, err := s.rfFunc(ctx, tx)
 		if err != nil {
@@ -151,10 +162,29 @@ func (s *studentUsecaseWorkbook) UpdateWorkbook(ctx context.Context, organizatio
 			return liberrors.Errorf("failed to findStudent. err: %w", err)
 		}
 
-		return student.UpdateWorkbook(ctx, workbookID, version, parameter)
+		workbook, err := student.FindWorkbookByID(ctx, workbookID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindWorkbookByID. err: %w", err)
+		}
+
+		if err := student.UpdateWorkbook(ctx, workbookID, version, parameter); err != nil {
+			return err
+		}
+
+		audioTurnedOn = workbook.GetProperties()["audioEnabled"] != "true" && parameter.GetProperties()["audioEnabled"] == "true"
+		return nil
 	}); err != nil {
 		return err
 	}
+
+	// the audio of the existing problems is generated in the background when audioEnabled is turned on.
+	// The workbook has been updated even if the job can't be started
+	if audioTurnedOn && s.studentUsecaseAudio != nil {
+		if _, err := s.studentUsecaseAudio.StartAudioGeneration(ctx, organizationID, operatorID, workbookID); err != nil {
+			log.FromContext(ctx).Warnf("failed to StartAudioGeneration. workbookID: %d, err: %v", workbookID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -179,3 +209,88 @@ func (s *studentUsecaseWorkbook) RemoveWorkbook(ctx context.Context, organizatio
 	}
 	return nil
 }
+
+func (s *studentUsecaseWorkbook) FindWorkbooksFromSpace(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, spaceID userD.SpaceID, pageNo, pageSize int) (service.WorkbookSearchResult, error) {
+	var result service.WorkbookSearchResult
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		student, err := usecase.FindStudent(ctx, s.pf, rf, userRf, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to findStudent. err: %w", err)
+		}
+
+		condition, err := service.NewWorkbookSearchCondition(pageNo, pageSize, []userD.SpaceID{})
+		if err != nil {
+			return liberrors.Errorf("failed to NewWorkbookSearchCondition. err: %w", err)
+		}
+
+		tmpResult, err := student.FindWorkbooksFromSpace(ctx, spaceID, condition)
+		if err != nil {
+			return liberrors.Errorf("failed to FindWorkbooksFromSpace. err: %w", err)
+		}
+
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *studentUsecaseWorkbook) AddWorkbookToSpace(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, spaceID userD.SpaceID, parameter service.WorkbookAddParameter) (domain.WorkbookID, error) {
+	var result domain.WorkbookID
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		student, err := usecase.FindStudent(ctx, s.pf, rf, userRf, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to findStudent. err: %w", err)
+		}
+
+		tmpResult, err := student.AddWorkbookToSpace(ctx, spaceID, parameter)
+		if err != nil {
+			return liberrors.Errorf("failed to AddWorkbookToSpace. err: %w", err)
+		}
+
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func (s *studentUsecaseWorkbook) MoveWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, workbookID domain.WorkbookID, version int, spaceID userD.SpaceID) error {
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		student, err := usecase.FindStudent(ctx, s.pf, rf, userRf, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to findStudent. err: %w", err)
+		}
+
+		return student.MoveWorkbook(ctx, workbookID, version, spaceID)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
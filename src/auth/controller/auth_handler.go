@@ -1,17 +1,26 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
 	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
 type AuthHandler interface {
 	RefreshToken(c *gin.Context)
+
+	Logout(c *gin.Context)
+
+	RevokeAllRefreshTokens(c *gin.Context)
 }
 
 type authHandler struct {
@@ -24,6 +33,13 @@ func NewAuthHandler(authTokenManager service.AuthTokenManager) AuthHandler {
 	}
 }
 
+// RefreshToken godoc
+// @Summary Rotate the refresh token. The refresh token can be used only once
+// @Produce json
+// @Param param body entity.RefreshTokenParameter true "parameter to refresh the token"
+// @Success 200 {object} entity.AuthResponse
+// @Failure 400
+// @Router /v1/auth/refresh_token [post]
 func (h *authHandler) RefreshToken(c *gin.Context) {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
@@ -33,14 +49,107 @@ func (h *authHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authTokenManager.RefreshToken(ctx, refreshTokenParameter.RefreshToken)
+	tokenSet, err := h.authTokenManager.RefreshToken(ctx, refreshTokenParameter.RefreshToken)
 	if err != nil {
+		if !h.isUnauthorizedError(err) {
+			logger.Errorf("failed to RefreshToken. err: %+v", err)
+		}
 		c.Status(http.StatusBadRequest)
 		return
 	}
 
 	logger.Info("Authorize OK")
 	c.JSON(http.StatusOK, entity.AuthResponse{
-		AccessToken: token,
+		AccessToken:  tokenSet.AccessToken,
+		RefreshToken: tokenSet.RefreshToken,
 	})
 }
+
+// Logout godoc
+// @Summary Revoke the refresh token and the tokens rotated from the same sign-in
+// @Param param body entity.RefreshTokenParameter true "refresh token to revoke"
+// @Success 204
+// @Failure 400
+// @Router /v1/auth/logout [post]
+func (h *authHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("Logout")
+	refreshTokenParameter := entity.RefreshTokenParameter{}
+	if err := c.BindJSON(&refreshTokenParameter); err != nil {
+		return
+	}
+
+	if err := h.authTokenManager.RevokeRefreshToken(ctx, refreshTokenParameter.RefreshToken); err != nil {
+		if h.isUnauthorizedError(err) {
+			logger.Warnf("failed to RevokeRefreshToken. err: %v", err)
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		logger.Errorf("failed to RevokeRefreshToken. err: %+v", err)
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeAllRefreshTokens godoc
+// @Summary Revoke all the refresh tokens of the user. Only the owner of the organization can call this
+// @Produce json
+// @Param appUserID path int true "App user ID"
+// @Success 200 {object} entity.RevokeRefreshTokensResponse
+// @Failure 400
+// @Failure 403
+// @Router /v1/auth/user/{appUserID}/refresh_token [delete]
+func (h *authHandler) RevokeAllRefreshTokens(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("RevokeAllRefreshTokens")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		appUserID, err := ginhelper.GetUintFromPath(c, "appUserID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		count, err := h.authTokenManager.RevokeAllRefreshTokens(ctx, organizationID, userD.AppUserID(appUserID))
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("revoked refresh tokens. appUserID: %d, count: %d", appUserID, count)
+		c.JSON(http.StatusOK, entity.RevokeRefreshTokensResponse{
+			Count: count,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *authHandler) isUnauthorizedError(err error) bool {
+	var unauthorizedError *service.UnauthorizedError
+	return errors.As(err, &unauthorizedError)
+}
+
+func (h *authHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Errorf("authHandler err: %+v", err)
+	return false
+}
+
+func toAuthResultEntity(authResult *usecase.AuthResult) entity.AuthResultResponse {
+	if authResult.TOTPChallenge != nil {
+		return entity.AuthResultResponse{
+			TOTPRequired:           true,
+			TOTPEnrollmentRequired: authResult.TOTPChallenge.EnrollmentRequired,
+			ChallengeToken:         authResult.TOTPChallenge.Token,
+		}
+	}
+
+	return entity.AuthResultResponse{
+		AccessToken:  authResult.TokenSet.AccessToken,
+		RefreshToken: authResult.TokenSet.RefreshToken,
+	}
+}
@@ -5,6 +5,19 @@ type AuthResponse struct {
 	RefreshToken string `json:"refreshToken"`
 }
 
+// AuthResultResponse has the challenge token instead of the tokens when the user needs to enter the TOTP code
+type AuthResultResponse struct {
+	AccessToken            string `json:"accessToken,omitempty"`
+	RefreshToken           string `json:"refreshToken,omitempty"`
+	TOTPRequired           bool   `json:"totpRequired"`
+	TOTPEnrollmentRequired bool   `json:"totpEnrollmentRequired"`
+	ChallengeToken         string `json:"challengeToken,omitempty"`
+}
+
 type RefreshTokenParameter struct {
 	RefreshToken string `json:"refreshToken"`
 }
+
+type RevokeRefreshTokensResponse struct {
+	Count int `json:"count"`
+}
@@ -3,3 +3,13 @@ package entity
 type GuestAuthParameter struct {
 	OrganizationName string `json:"organizationName"`
 }
+
+type GuestPasswordUpgradeParameter struct {
+	LoginID  string `json:"loginId" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type GuestGoogleUpgradeParameter struct {
+	Code string `json:"code" binding:"required"`
+}
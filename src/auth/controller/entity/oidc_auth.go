@@ -0,0 +1,14 @@
+package entity
+
+type OIDCAuthorizationURLParameter struct {
+	OrganizationName string `form:"organizationName" binding:"required"`
+}
+
+type OIDCAuthorizationURLResponse struct {
+	URL string `json:"url"`
+}
+
+type OIDCAuthParameter struct {
+	State string `json:"state" binding:"required"`
+	Code  string `json:"code" binding:"required"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+type PasswordAuthParameter struct {
+	OrganizationName string `json:"organizationName" binding:"required"`
+	LoginID          string `json:"loginId" binding:"required"`
+	Password         string `json:"password" binding:"required"`
+}
+
+type PasswordChangeParameter struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+type PasswordResetRequestParameter struct {
+	OrganizationName string `json:"organizationName" binding:"required"`
+	LoginID          string `json:"loginId" binding:"required"`
+}
+
+type PasswordResetParameter struct {
+	OrganizationName string `json:"organizationName" binding:"required"`
+	Token            string `json:"token" binding:"required"`
+	NewPassword      string `json:"newPassword" binding:"required"`
+}
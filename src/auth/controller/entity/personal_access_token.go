@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+type PersonalAccessTokenAddParameter struct {
+	Name          string `json:"name" binding:"required"`
+	Scope         string `json:"scope" binding:"required"`
+	WorkbookIDs   []uint `json:"workbookIds"`
+	ExpiresInDays int    `json:"expiresInDays"`
+}
+
+type PersonalAccessToken struct {
+	ID          uint       `json:"id"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	Scope       string     `json:"scope"`
+	WorkbookIDs []uint     `json:"workbookIds"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+}
+
+type PersonalAccessTokenAddResponse struct {
+	PersonalAccessToken
+	// Token is returned only once
+	Token string `json:"token"`
+}
+
+type PersonalAccessTokensResponse struct {
+	Results []PersonalAccessToken `json:"results"`
+}
@@ -0,0 +1,38 @@
+package entity
+
+type TOTPStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RemainingRecoveryCodes int  `json:"remainingRecoveryCodes"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type TOTPCodeParameter struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TOTPRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type TOTPChallengeParameter struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+// TOTPVerifyParameter has either the code or the recovery code
+type TOTPVerifyParameter struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recoveryCode"`
+}
+
+// TOTPVerifyResponse has the recovery codes only when TOTP is enabled by the verification
+type TOTPVerifyResponse struct {
+	AccessToken   string   `json:"accessToken"`
+	RefreshToken  string   `json:"refreshToken"`
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
@@ -1,13 +1,16 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
 	"github.com/kujilabo/cocotola-api/src/auth/usecase"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
 
 type GoogleUserHandler interface {
@@ -54,15 +57,20 @@ func (h *googleUserHandler) Authorize(c *gin.Context) {
 
 	logger.Info("RegisterAppUser")
 	authResult, err := h.googleUserUsecase.RegisterAppUser(ctx, userInfo, googleAuthResponse, googleAuthParameter.OrganizationName)
-	if err != nil {
+	if errors.Is(err, service.ErrAppUserProviderMismatch) {
+		logger.Warnf("failed to RegisterStudent. err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "The login ID is used by the other sign-in method"})
+		return
+	} else if errors.Is(err, userS.ErrAppUserDisabled) {
+		logger.Warnf("failed to RegisterStudent. err: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"message": http.StatusText(http.StatusUnauthorized)})
+		return
+	} else if err != nil {
 		logger.Warnf("failed to RegisterStudent. err: %+v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusBadRequest)})
 		return
 	}
 
-	logger.Info("Authorize OK")
-	c.JSON(http.StatusOK, entity.AuthResponse{
-		AccessToken:  authResult.AccessToken,
-		RefreshToken: authResult.RefreshToken,
-	})
+	logger.Infof("Authorize OK. TOTP required: %t", authResult.TOTPChallenge != nil)
+	c.JSON(http.StatusOK, toAuthResultEntity(authResult))
 }
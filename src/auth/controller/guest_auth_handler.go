@@ -1,17 +1,27 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
 	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
 
 type GuestUserHandler interface {
 	Authorize(c *gin.Context)
+
+	UpgradeWithPassword(c *gin.Context)
+
+	UpgradeWithGoogle(c *gin.Context)
 }
 
 type guestUserHandler struct {
@@ -38,8 +48,9 @@ func (h *guestUserHandler) Authorize(c *gin.Context) {
 
 	authResult, err := h.guestUserUsecase.RetrieveGuestToken(ctx, guestAuthParameter.OrganizationName)
 	if err != nil {
-		logger.Warnf("failed to RetrieveGuestToken. err: %+v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
 		return
 	}
 
@@ -49,3 +60,109 @@ func (h *guestUserHandler) Authorize(c *gin.Context) {
 		RefreshToken: authResult.RefreshToken,
 	})
 }
+
+// UpgradeWithPassword godoc
+// @Summary Register the login id and the password of the guest. The workbooks and the recordbooks are kept
+// @Produce json
+// @Param param body entity.GuestPasswordUpgradeParameter true "parameter to register the guest"
+// @Success 200 {object} entity.AuthResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Router /v1/auth/guest/upgrade/password [post]
+func (h *guestUserHandler) UpgradeWithPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("UpgradeWithPassword")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.GuestPasswordUpgradeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		authResult, err := h.guestUserUsecase.UpgradeWithPassword(ctx, organizationID, operatorID, &usecase.GuestUserPasswordUpgradeParameter{
+			LoginID:  param.LoginID,
+			Username: param.Username,
+			Password: param.Password,
+		})
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.AuthResponse{
+			AccessToken:  authResult.AccessToken,
+			RefreshToken: authResult.RefreshToken,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// UpgradeWithGoogle godoc
+// @Summary Link the Google account to the guest. The workbooks and the recordbooks are kept
+// @Produce json
+// @Param param body entity.GuestGoogleUpgradeParameter true "parameter to register the guest"
+// @Success 200 {object} entity.AuthResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Router /v1/auth/guest/upgrade/google [post]
+func (h *guestUserHandler) UpgradeWithGoogle(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("UpgradeWithGoogle")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.GuestGoogleUpgradeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		authResult, err := h.guestUserUsecase.UpgradeWithGoogle(ctx, organizationID, operatorID, param.Code)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.AuthResponse{
+			AccessToken:  authResult.AccessToken,
+			RefreshToken: authResult.RefreshToken,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *guestUserHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, userS.ErrSystemOwnerNotFound) || errors.Is(err, userS.ErrGuestUserNotFound) {
+		logger.Warnf("guestUserHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": http.StatusText(http.StatusNotFound)})
+		return true
+	} else if errors.Is(err, userS.ErrAppUserAlreadyExists) {
+		logger.Warnf("guestUserHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "The user already exists"})
+		return true
+	} else if errors.Is(err, userS.ErrGuestUserLimitExceeded) {
+		logger.Warnf("guestUserHandler err: %v", err)
+		c.JSON(http.StatusTooManyRequests, gin.H{"message": "Too many guests"})
+		return true
+	} else if errors.Is(err, service.ErrInvalidAuthorizationCode) {
+		logger.Warnf("guestUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return true
+	} else if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("guestUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	}
+	logger.Errorf("guestUserHandler err: %+v", err)
+	return false
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+)
+
+type JWKSHandler interface {
+	FindJSONWebKeySet(c *gin.Context)
+}
+
+type jwksHandler struct {
+	jsonWebKeyProvider service.JSONWebKeyProvider
+}
+
+func NewJWKSHandler(jsonWebKeyProvider service.JSONWebKeyProvider) JWKSHandler {
+	return &jwksHandler{
+		jsonWebKeyProvider: jsonWebKeyProvider,
+	}
+}
+
+// FindJSONWebKeySet godoc
+// @Summary Get the public keys to verify the tokens
+// @Produce json
+// @Success 200 {object} entity.JSONWebKeySet
+// @Router /.well-known/jwks.json [get]
+func (h *jwksHandler) FindJSONWebKeySet(c *gin.Context) {
+	keys := h.jsonWebKeyProvider.JSONWebKeys()
+	response := entity.JSONWebKeySet{
+		Keys: make([]entity.JSONWebKey, len(keys)),
+	}
+	for i, key := range keys {
+		response.Keys[i] = entity.JSONWebKey{
+			KeyType:   key.KeyType,
+			Use:       key.Use,
+			KeyID:     key.KeyID,
+			Algorithm: key.Algorithm,
+			N:         key.N,
+			E:         key.E,
+			Curve:     key.Curve,
+			X:         key.X,
+			Y:         key.Y,
+		}
+	}
+
+	// the verifiers cache the keys. the next key is published before it becomes active
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, response)
+}
@@ -1,16 +1,41 @@
 package middleware
 
 import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 
 	"github.com/kujilabo/cocotola-api/src/auth/gateway"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 )
 
-func NewAuthMiddleware(signingKey []byte) gin.HandlerFunc {
+const (
+	AuthMethodKey                 = "AuthMethod"
+	AuthMethodJWT                 = "jwt"
+	AuthMethodPersonalAccessToken = "personal_access_token"
+)
+
+var readOperationName = runtime.FuncForPC(reflect.ValueOf(ReadOperation).Pointer()).Name()
+
+// ReadOperation marks the route which doesn't modify the resources although its method isn't GET.
+// The personal access tokens with the read scope can call the route
+func ReadOperation(c *gin.Context) {}
+
+// RequireInteractiveSignIn rejects the personal access tokens so that the leaked token can't be used to manage the credentials
+func RequireInteractiveSignIn(c *gin.Context) {
+	if c.GetString(AuthMethodKey) == AuthMethodPersonalAccessToken {
+		abortWithForbidden(c, "The personal access token can't be used for this operation")
+	}
+}
+
+func NewAuthMiddleware(signingKeySet *gateway.SigningKeySet, personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 		logger := log.FromContext(ctx)
@@ -21,23 +46,92 @@ func NewAuthMiddleware(signingKey []byte) gin.HandlerFunc {
 		}
 
 		tokenString := authorization[len("Bearer "):]
-		token, err := jwt.ParseWithClaims(tokenString, &gateway.AppUserClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return signingKey, nil
-		})
+		if strings.HasPrefix(tokenString, usecase.PersonalAccessTokenPrefix) {
+			handlePersonalAccessToken(c, personalAccessTokenUsecase, tokenString)
+			return
+		}
+
+		token, err := jwt.ParseWithClaims(tokenString, &gateway.AppUserClaims{}, signingKeySet.Keyfunc)
 		if err != nil {
 			logger.WithError(err).Warnf("invalid token. err: %v", err)
 			return
 		}
 
-		if claims, ok := token.Claims.(*gateway.AppUserClaims); ok && token.Valid {
-			c.Set("AuthorizedUser", int(claims.AppUserID))
-			c.Set("OrganizationID", int(claims.OrganizationID))
-			c.Set("Role", claims.Role)
-
-			logger.Infof("uri: %s, user: %d, role: %s", c.Request.RequestURI, int(claims.AppUserID), claims.Role)
-		} else {
+		claims, ok := token.Claims.(*gateway.AppUserClaims)
+		if !ok || !token.Valid {
 			logger.Warnf("invalid token")
 			return
 		}
+
+		// the refresh token lives longer than the access token, so it can only be exchanged for a new token set
+		if claims.TokenType != "access" {
+			logger.Warnf("invalid token type. tokenType: %s", claims.TokenType)
+			return
+		}
+
+		c.Set("AuthorizedUser", int(claims.AppUserID))
+		c.Set("OrganizationID", int(claims.OrganizationID))
+		c.Set("Role", claims.Role)
+		c.Set(AuthMethodKey, AuthMethodJWT)
+
+		logger.Infof("uri: %s, user: %d, role: %s", c.Request.RequestURI, int(claims.AppUserID), claims.Role)
+	}
+}
+
+func handlePersonalAccessToken(c *gin.Context, personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase, tokenString string) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	result, err := personalAccessTokenUsecase.Authenticate(ctx, tokenString)
+	if err != nil {
+		logger.Warnf("invalid personal access token. err: %v", err)
+		return
+	}
+
+	if result.Scope != service.PersonalAccessTokenScopeWrite && !isReadOperation(c) {
+		logger.Warnf("personal access token with the read scope. uri: %s", c.Request.RequestURI)
+		abortWithForbidden(c, "The token doesn't have the write scope")
+		return
+	}
+
+	if len(result.WorkbookIDs) > 0 && !containsWorkbookID(c, result.WorkbookIDs) {
+		logger.Warnf("personal access token limited to the workbooks. uri: %s", c.Request.RequestURI)
+		abortWithForbidden(c, "The token can't access the workbook")
+		return
+	}
+
+	c.Set("AuthorizedUser", int(result.AppUserID))
+	c.Set("OrganizationID", int(result.OrganizationID))
+	c.Set("Role", result.Role)
+	c.Set(AuthMethodKey, AuthMethodPersonalAccessToken)
+
+	logger.Infof("uri: %s, user: %d, role: %s, scope: %s", c.Request.RequestURI, int(result.AppUserID), result.Role, result.Scope)
+}
+
+func isReadOperation(c *gin.Context) bool {
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		return true
+	}
+
+	for _, name := range c.HandlerNames() {
+		if name == readOperationName {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWorkbookID returns false for the routes without the workbook id because they aren't limited to the workbook
+func containsWorkbookID(c *gin.Context, workbookIDs []uint) bool {
+	workbookID, err := strconv.ParseUint(c.Param("workbookID"), 10, 32)
+	if err != nil {
+		return false
+	}
+
+	for _, id := range workbookIDs {
+		if uint64(id) == workbookID {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,139 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/middleware"
+	"github.com/kujilabo/cocotola-api/src/auth/gateway"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type personalAccessTokenUsecaseStub struct {
+	usecase.PersonalAccessTokenUsecase
+	results map[string]*usecase.PersonalAccessTokenAuthResult
+}
+
+func (s *personalAccessTokenUsecaseStub) Authenticate(ctx context.Context, token string) (*usecase.PersonalAccessTokenAuthResult, error) {
+	result, ok := s.results[token]
+	if !ok {
+		return nil, service.ErrPersonalAccessTokenNotFound
+	}
+	return result, nil
+}
+
+func Test_NewAuthMiddleware_personalAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	key, err := gateway.NewHMACSigningKey("", []byte("SIGNING_KEY"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	signingKeySet, err := gateway.NewSigningKeySet([]*gateway.SigningKey{key})
+	require.NoError(t, err)
+
+	stub := &personalAccessTokenUsecaseStub{results: map[string]*usecase.PersonalAccessTokenAuthResult{
+		"cct_READ": {OrganizationID: userD.OrganizationID(1), AppUserID: userD.AppUserID(2), Role: "Student", Scope: service.PersonalAccessTokenScopeRead},
+		"cct_WRITE": {OrganizationID: userD.OrganizationID(1), AppUserID: userD.AppUserID(2), Role: "Student", Scope: service.PersonalAccessTokenScopeWrite,
+			WorkbookIDs: []uint{10}},
+	}}
+
+	handler := func(c *gin.Context) {
+		c.String(http.StatusOK, "%d:%s", c.GetInt("AuthorizedUser"), c.GetString(middleware.AuthMethodKey))
+	}
+	router := gin.New()
+	router.Use(middleware.NewAuthMiddleware(signingKeySet, stub))
+	router.GET("/workbook/:workbookID", handler)
+	router.PUT("/workbook/:workbookID", handler)
+	router.POST("/workbook/:workbookID/find", middleware.ReadOperation, handler)
+	router.POST("/workbook", handler)
+	router.POST("/auth/personal_access_token", middleware.RequireInteractiveSignIn, handler)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		body   string
+	}{
+		{name: "read scope can GET", method: http.MethodGet, path: "/workbook/10", token: "cct_READ", code: http.StatusOK, body: "2:personal_access_token"},
+		{name: "read scope can call the read operation", method: http.MethodPost, path: "/workbook/10/find", token: "cct_READ", code: http.StatusOK},
+		{name: "read scope can't PUT", method: http.MethodPut, path: "/workbook/10", token: "cct_READ", code: http.StatusForbidden},
+		{name: "write scope can PUT the workbook", method: http.MethodPut, path: "/workbook/10", token: "cct_WRITE", code: http.StatusOK},
+		{name: "write scope can't PUT the other workbook", method: http.MethodPut, path: "/workbook/11", token: "cct_WRITE", code: http.StatusForbidden},
+		{name: "limited token can't call the route without the workbook", method: http.MethodPost, path: "/workbook", token: "cct_WRITE", code: http.StatusForbidden},
+		{name: "unknown token isn't authorized", method: http.MethodGet, path: "/workbook/10", token: "cct_UNKNOWN", code: http.StatusOK, body: "0:"},
+		{name: "token can't manage the tokens", method: http.MethodPost, path: "/auth/personal_access_token", token: "cct_READ", code: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.method, tt.path, http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test_NewAuthMiddleware_tokenType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	key, err := gateway.NewHMACSigningKey("", []byte("SIGNING_KEY"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	signingKeySet, err := gateway.NewSigningKeySet([]*gateway.SigningKey{key})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middleware.NewAuthMiddleware(signingKeySet, &personalAccessTokenUsecaseStub{}))
+	router.GET("/workbook", func(c *gin.Context) {
+		c.String(http.StatusOK, "%d:%s", c.GetInt("AuthorizedUser"), c.GetString(middleware.AuthMethodKey))
+	})
+
+	tests := []struct {
+		name      string
+		tokenType string
+		body      string
+	}{
+		{name: "access token is authorized", tokenType: "access", body: "2:jwt"},
+		{name: "refresh token isn't authorized", tokenType: "refresh", body: "0:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := signingKeySet.Sign(gateway.AppUserClaims{
+				AppUserID:      2,
+				OrganizationID: 1,
+				Role:           "Student",
+				TokenType:      tt.tokenType,
+				FamilyID:       "FAMILY_ID",
+				StandardClaims: jwt.StandardClaims{
+					Id:        "TOKEN_ID",
+					IssuedAt:  time.Now().Unix(),
+					ExpiresAt: time.Now().Add(time.Hour).Unix(),
+				},
+			})
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/workbook", http.NoBody)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.body, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+// ErrResourceNotFound is returned by PrivilegeFunc when the resource in the path doesn't exist
+var ErrResourceNotFound = errors.New("resource not found")
+
+// PrivilegeFunc returns the privileges of the operator on the resource specified by the request
+type PrivilegeFunc func(c *gin.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (userD.Privileges, error)
+
+// RequireAuthentication rejects the request which doesn't have the valid token.
+// NewAuthMiddleware must be applied before
+func RequireAuthentication(c *gin.Context) {
+	if c.GetInt("AuthorizedUser") == 0 {
+		abortWithUnauthorized(c)
+	}
+}
+
+// RequireRole rejects the user who has none of the roles
+func RequireRole(roles ...userD.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		logger := log.FromContext(ctx)
+
+		if c.GetInt("AuthorizedUser") == 0 {
+			abortWithUnauthorized(c)
+			return
+		}
+
+		role := c.GetString("Role")
+		for _, r := range roles {
+			if r.GetName() == role {
+				return
+			}
+		}
+
+		logger.Warnf("role not allowed. uri: %s, role: %s", c.Request.RequestURI, role)
+		abortWithForbidden(c, "The role isn't allowed to perform this operation")
+	}
+}
+
+// RequireOrganizationRole rejects the user who doesn't belong to the organization or has none of the roles.
+// The owners of the system organization use it to manage the whole service, such as adding the organizations
+func RequireOrganizationRole(organizationID userD.OrganizationID, roles ...userD.Role) gin.HandlerFunc {
+	requireRole := RequireRole(roles...)
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		logger := log.FromContext(ctx)
+
+		if requireRole(c); c.IsAborted() {
+			return
+		}
+
+		if userD.OrganizationID(c.GetInt("OrganizationID")) != organizationID {
+			logger.Warnf("organization not allowed. uri: %s, organizationID: %d", c.Request.RequestURI, c.GetInt("OrganizationID"))
+			abortWithForbidden(c, "The organization isn't allowed to perform this operation")
+		}
+	}
+}
+
+// RequirePrivilege rejects the user who doesn't have the privilege on the resource
+func RequirePrivilege(privilegeFunc PrivilegeFunc, privilege userD.RBACAction) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		logger := log.FromContext(ctx)
+
+		organizationID := userD.OrganizationID(c.GetInt("OrganizationID"))
+		operatorID := userD.AppUserID(c.GetInt("AuthorizedUser"))
+		if operatorID == 0 {
+			abortWithUnauthorized(c)
+			return
+		}
+
+		privileges, err := privilegeFunc(c, organizationID, operatorID)
+		if err != nil {
+			if errors.Is(err, libD.ErrInvalidArgument) {
+				logger.Warnf("RequirePrivilege err: %v", err)
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+				return
+			} else if errors.Is(err, ErrResourceNotFound) {
+				logger.Warnf("RequirePrivilege err: %v", err)
+				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"message": http.StatusText(http.StatusNotFound)})
+				return
+			}
+			logger.Errorf("RequirePrivilege err: %+v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+			return
+		}
+
+		if !privileges.HasPrivilege(privilege) {
+			logger.Warnf("privilege not found. uri: %s, user: %d, privilege: %s", c.Request.RequestURI, operatorID, privilege)
+			abortWithForbidden(c, "The user doesn't have the privilege to perform this operation")
+			return
+		}
+	}
+}
+
+func abortWithUnauthorized(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": http.StatusText(http.StatusUnauthorized)})
+}
+
+func abortWithForbidden(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": message})
+}
@@ -0,0 +1,88 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/middleware"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+func Test_RequireRoleAndPrivilege(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	privilegeFunc := func(c *gin.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (userD.Privileges, error) {
+		switch c.Param("workbookID") {
+		case "1":
+			return userD.NewPrivileges([]userD.RBACAction{"read", "update"}), nil
+		case "2":
+			return userD.NewPrivileges([]userD.RBACAction{"read"}), nil
+		case "3":
+			return nil, middleware.ErrResourceNotFound
+		case "4":
+			return nil, errors.New("unexpected")
+		}
+		return nil, libD.ErrInvalidArgument
+	}
+
+	ok := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if role := c.GetHeader("X-Role"); role != "" {
+			c.Set("AuthorizedUser", 2)
+			c.Set("OrganizationID", 1)
+			c.Set("Role", role)
+		}
+	})
+	router.GET("/authenticated", middleware.RequireAuthentication, ok)
+	router.GET("/owner", middleware.RequireRole(userD.OwnerRole), ok)
+	router.GET("/system/owner", middleware.RequireOrganizationRole(userD.OrganizationID(1), userD.OwnerRole), ok)
+	router.GET("/other/owner", middleware.RequireOrganizationRole(userD.OrganizationID(2), userD.OwnerRole), ok)
+	router.GET("/workbook/:workbookID", middleware.RequirePrivilege(privilegeFunc, "update"), ok)
+
+	tests := []struct {
+		name string
+		path string
+		role string
+		code int
+	}{
+		{name: "anonymous user isn't authenticated", path: "/authenticated", code: http.StatusUnauthorized},
+		{name: "user is authenticated", path: "/authenticated", role: "User", code: http.StatusOK},
+		{name: "anonymous user doesn't have the role", path: "/owner", code: http.StatusUnauthorized},
+		{name: "user doesn't have the role", path: "/owner", role: "User", code: http.StatusForbidden},
+		{name: "owner has the role", path: "/owner", role: "Owner", code: http.StatusOK},
+		{name: "owner of the organization has the role", path: "/system/owner", role: "Owner", code: http.StatusOK},
+		{name: "user of the organization doesn't have the role", path: "/system/owner", role: "User", code: http.StatusForbidden},
+		{name: "owner of the other organization doesn't have the role", path: "/other/owner", role: "Owner", code: http.StatusForbidden},
+		{name: "anonymous user doesn't have the privilege", path: "/workbook/1", code: http.StatusUnauthorized},
+		{name: "user has the privilege", path: "/workbook/1", role: "User", code: http.StatusOK},
+		{name: "user doesn't have the privilege", path: "/workbook/2", role: "User", code: http.StatusForbidden},
+		{name: "workbook not found", path: "/workbook/3", role: "User", code: http.StatusNotFound},
+		{name: "unexpected error", path: "/workbook/4", role: "User", code: http.StatusInternalServerError},
+		{name: "invalid workbook id", path: "/workbook/x", role: "User", code: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, tt.path, http.NoBody)
+			require.NoError(t, err)
+			if tt.role != "" {
+				req.Header.Set("X-Role", tt.role)
+			}
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code != http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"message"`)
+			}
+		})
+	}
+}
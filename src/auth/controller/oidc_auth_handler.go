@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type OIDCUserHandler interface {
+	CreateAuthorizationURL(c *gin.Context)
+
+	Authorize(c *gin.Context)
+}
+
+type oidcUserHandler struct {
+	oidcUserUsecase usecase.OIDCUserUsecase
+}
+
+func NewOIDCAuthHandler(oidcUserUsecase usecase.OIDCUserUsecase) OIDCUserHandler {
+	return &oidcUserHandler{
+		oidcUserUsecase: oidcUserUsecase,
+	}
+}
+
+// CreateAuthorizationURL godoc
+// @Summary Get the URL of the OpenID Connect provider to sign in
+// @Produce json
+// @Param provider path string true "provider name"
+// @Param organizationName query string true "organization name"
+// @Success 200 {object} entity.OIDCAuthorizationURLResponse
+// @Failure 400
+// @Failure 404
+// @Router /v1/auth/oidc/{provider}/authorization_url [get]
+func (h *oidcUserHandler) CreateAuthorizationURL(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("CreateAuthorizationURL")
+
+	param := entity.OIDCAuthorizationURLParameter{}
+	if err := c.ShouldBindQuery(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	url, err := h.oidcUserUsecase.CreateAuthorizationURL(ctx, c.Param("provider"), param.OrganizationName)
+	if err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entity.OIDCAuthorizationURLResponse{
+		URL: url,
+	})
+}
+
+// Authorize godoc
+// @Summary Sign in with the authorization code returned from the OpenID Connect provider. The challenge token is returned instead of the tokens if the user needs TOTP
+// @Produce json
+// @Param provider path string true "provider name"
+// @Param param body entity.OIDCAuthParameter true "state and code returned from the provider"
+// @Success 200 {object} entity.AuthResultResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Router /v1/auth/oidc/{provider}/authorize [post]
+func (h *oidcUserHandler) Authorize(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("Authorize")
+
+	param := entity.OIDCAuthParameter{}
+	if err := c.ShouldBindJSON(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	authResult, err := h.oidcUserUsecase.Authorize(ctx, c.Param("provider"), param.State, param.Code)
+	if err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	logger.Infof("Authorize OK. TOTP required: %t", authResult.TOTPChallenge != nil)
+	c.JSON(http.StatusOK, toAuthResultEntity(authResult))
+}
+
+func (h *oidcUserHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, service.ErrOIDCProviderNotFound) || errors.Is(err, userS.ErrSystemOwnerNotFound) {
+		logger.Warnf("oidcUserHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": http.StatusText(http.StatusNotFound)})
+		return true
+	} else if errors.Is(err, service.ErrOIDCAuthRequestNotFound) {
+		logger.Warnf("oidcUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid or expired state"})
+		return true
+	} else if errors.Is(err, service.ErrInvalidAuthorizationCode) {
+		logger.Warnf("oidcUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid authorization code"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserProviderMismatch) {
+		logger.Warnf("oidcUserHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "The login ID is used by the other sign-in method"})
+		return true
+	} else if errors.Is(err, service.ErrInvalidIDToken) || errors.Is(err, userS.ErrAppUserDisabled) {
+		logger.Warnf("oidcUserHandler err: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"message": http.StatusText(http.StatusUnauthorized)})
+		return true
+	} else if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("oidcUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	}
+	logger.Errorf("oidcUserHandler err: %+v", err)
+	return false
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type PasswordUserHandler interface {
+	Authorize(c *gin.Context)
+
+	ChangePassword(c *gin.Context)
+
+	RequestPasswordReset(c *gin.Context)
+
+	ResetPassword(c *gin.Context)
+}
+
+type passwordUserHandler struct {
+	passwordUserUsecase usecase.PasswordUserUsecase
+}
+
+func NewPasswordAuthHandler(passwordUserUsecase usecase.PasswordUserUsecase) PasswordUserHandler {
+	return &passwordUserHandler{
+		passwordUserUsecase: passwordUserUsecase,
+	}
+}
+
+// Authorize godoc
+// @Summary Sign in with the login id and the password. The challenge token is returned instead of the tokens if the user needs TOTP
+// @Produce json
+// @Param param body entity.PasswordAuthParameter true "parameter to sign in"
+// @Success 200 {object} entity.AuthResultResponse
+// @Failure 400
+// @Failure 401
+// @Failure 423
+// @Router /v1/auth/password/authorize [post]
+func (h *passwordUserHandler) Authorize(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("Authorize")
+
+	param := entity.PasswordAuthParameter{}
+	if err := c.ShouldBindJSON(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	authResult, err := h.passwordUserUsecase.Authorize(ctx, param.OrganizationName, param.LoginID, param.Password)
+	if err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	logger.Infof("Authorize OK. TOTP required: %t", authResult.TOTPChallenge != nil)
+	c.JSON(http.StatusOK, toAuthResultEntity(authResult))
+}
+
+// ChangePassword godoc
+// @Summary Change the password of the user who has signed in
+// @Param param body entity.PasswordChangeParameter true "parameter to change the password"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 423
+// @Router /v1/auth/password/change [post]
+func (h *passwordUserHandler) ChangePassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.PasswordChangeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		if err := h.passwordUserUsecase.ChangePassword(ctx, organizationID, operatorID, param.CurrentPassword, param.NewPassword); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// RequestPasswordReset godoc
+// @Summary Issue the password reset token. The response doesn't tell whether the user exists
+// @Param param body entity.PasswordResetRequestParameter true "parameter to request the password reset"
+// @Success 204
+// @Failure 400
+// @Router /v1/auth/password/reset_request [post]
+func (h *passwordUserHandler) RequestPasswordReset(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	param := entity.PasswordResetRequestParameter{}
+	if err := c.ShouldBindJSON(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	if err := h.passwordUserUsecase.RequestPasswordReset(ctx, param.OrganizationName, param.LoginID); err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResetPassword godoc
+// @Summary Reset the password with the password reset token
+// @Param param body entity.PasswordResetParameter true "parameter to reset the password"
+// @Success 204
+// @Failure 400
+// @Router /v1/auth/password/reset [post]
+func (h *passwordUserHandler) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	param := entity.PasswordResetParameter{}
+	if err := c.ShouldBindJSON(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	if err := h.passwordUserUsecase.ResetPassword(ctx, param.OrganizationName, param.Token, param.NewPassword); err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *passwordUserHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, service.ErrInvalidCredential) || errors.Is(err, userS.ErrAppUserNotFound) || errors.Is(err, userS.ErrSystemOwnerNotFound) {
+		logger.Warnf("passwordUserHandler err: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid login id or password"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserLocked) {
+		logger.Warnf("passwordUserHandler err: %v", err)
+		c.JSON(http.StatusLocked, gin.H{"message": "Too many failed attempts. Try again later"})
+		return true
+	} else if errors.Is(err, service.ErrInvalidPasswordResetToken) {
+		logger.Warnf("passwordUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid or expired password reset token"})
+		return true
+	} else if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("passwordUserHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	}
+	logger.Errorf("passwordUserHandler err: %+v", err)
+	return false
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type PersonalAccessTokenHandler interface {
+	AddPersonalAccessToken(c *gin.Context)
+
+	FindPersonalAccessTokens(c *gin.Context)
+
+	RemovePersonalAccessToken(c *gin.Context)
+}
+
+type personalAccessTokenHandler struct {
+	personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase
+}
+
+func NewPersonalAccessTokenHandler(personalAccessTokenUsecase usecase.PersonalAccessTokenUsecase) PersonalAccessTokenHandler {
+	return &personalAccessTokenHandler{
+		personalAccessTokenUsecase: personalAccessTokenUsecase,
+	}
+}
+
+// AddPersonalAccessToken godoc
+// @Summary Create the personal access token. The token is returned only once
+// @Produce json
+// @Param param body entity.PersonalAccessTokenAddParameter true "parameter to create the token"
+// @Success 201 {object} entity.PersonalAccessTokenAddResponse
+// @Failure 400
+// @Failure 403
+// @Router /v1/auth/personal_access_token [post]
+func (h *personalAccessTokenHandler) AddPersonalAccessToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("AddPersonalAccessToken")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.PersonalAccessTokenAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		personalAccessToken, token, err := h.personalAccessTokenUsecase.AddPersonalAccessToken(ctx, organizationID, operatorID, &usecase.PersonalAccessTokenAddParameter{
+			Name:          param.Name,
+			Scope:         service.PersonalAccessTokenScope(param.Scope),
+			WorkbookIDs:   param.WorkbookIDs,
+			ExpiresInDays: param.ExpiresInDays,
+		})
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusCreated, entity.PersonalAccessTokenAddResponse{
+			PersonalAccessToken: toPersonalAccessTokenEntity(personalAccessToken),
+			Token:               token,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// FindPersonalAccessTokens godoc
+// @Summary Get the personal access tokens of the user
+// @Produce json
+// @Success 200 {object} entity.PersonalAccessTokensResponse
+// @Failure 403
+// @Router /v1/auth/personal_access_token [get]
+func (h *personalAccessTokenHandler) FindPersonalAccessTokens(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("FindPersonalAccessTokens")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		personalAccessTokens, err := h.personalAccessTokenUsecase.FindPersonalAccessTokens(ctx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		results := make([]entity.PersonalAccessToken, len(personalAccessTokens))
+		for i, personalAccessToken := range personalAccessTokens {
+			results[i] = toPersonalAccessTokenEntity(personalAccessToken)
+		}
+
+		c.JSON(http.StatusOK, entity.PersonalAccessTokensResponse{
+			Results: results,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// RemovePersonalAccessToken godoc
+// @Summary Revoke the personal access token
+// @Param tokenID path int true "Token ID"
+// @Success 204
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Router /v1/auth/personal_access_token/{tokenID} [delete]
+func (h *personalAccessTokenHandler) RemovePersonalAccessToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("RemovePersonalAccessToken")
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		tokenID, err := ginhelper.GetUintFromPath(c, "tokenID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.personalAccessTokenUsecase.RemovePersonalAccessToken(ctx, organizationID, operatorID, service.PersonalAccessTokenID(tokenID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *personalAccessTokenHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, service.ErrPersonalAccessTokenNotFound) {
+		logger.Warnf("personalAccessTokenHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": http.StatusText(http.StatusNotFound)})
+		return true
+	} else if errors.Is(err, service.ErrPersonalAccessTokenLimitExceeded) {
+		logger.Warnf("personalAccessTokenHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Too many personal access tokens"})
+		return true
+	} else if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("personalAccessTokenHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	}
+	logger.Errorf("personalAccessTokenHandler err: %+v", err)
+	return false
+}
+
+func toPersonalAccessTokenEntity(personalAccessToken *service.PersonalAccessToken) entity.PersonalAccessToken {
+	return entity.PersonalAccessToken{
+		ID:          uint(personalAccessToken.ID),
+		CreatedAt:   personalAccessToken.CreatedAt,
+		Name:        personalAccessToken.Name,
+		TokenPrefix: personalAccessToken.TokenPrefix,
+		Scope:       string(personalAccessToken.Scope),
+		WorkbookIDs: personalAccessToken.WorkbookIDs,
+		ExpiresAt:   personalAccessToken.ExpiresAt,
+		LastUsedAt:  personalAccessToken.LastUsedAt,
+	}
+}
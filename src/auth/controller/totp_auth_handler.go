@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kujilabo/cocotola-api/src/auth/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/auth/usecase"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type TOTPAuthHandler interface {
+	FindTOTPStatus(c *gin.Context)
+
+	StartEnrollment(c *gin.Context)
+
+	ConfirmEnrollment(c *gin.Context)
+
+	RegenerateRecoveryCodes(c *gin.Context)
+
+	Disable(c *gin.Context)
+
+	StartEnrollmentWithChallenge(c *gin.Context)
+
+	VerifyChallenge(c *gin.Context)
+}
+
+type totpAuthHandler struct {
+	totpUsecase usecase.TOTPUsecase
+}
+
+func NewTOTPAuthHandler(totpUsecase usecase.TOTPUsecase) TOTPAuthHandler {
+	return &totpAuthHandler{
+		totpUsecase: totpUsecase,
+	}
+}
+
+// FindTOTPStatus godoc
+// @Summary Return whether TOTP is enabled or required
+// @Produce json
+// @Success 200 {object} entity.TOTPStatusResponse
+// @Failure 401
+// @Router /v1/auth/totp [get]
+func (h *totpAuthHandler) FindTOTPStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		status, err := h.totpUsecase.FindTOTPStatus(ctx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.TOTPStatusResponse{
+			Enabled:                status.Enabled,
+			Required:               status.Required,
+			RemainingRecoveryCodes: status.RemainingRecoveryCodes,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// StartEnrollment godoc
+// @Summary Generate the secret. The provisioning URI is shown as the QR code
+// @Produce json
+// @Success 200 {object} entity.TOTPEnrollmentResponse
+// @Failure 401
+// @Failure 409
+// @Router /v1/auth/totp/enroll [post]
+func (h *totpAuthHandler) StartEnrollment(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		enrollment, err := h.totpUsecase.StartEnrollment(ctx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.TOTPEnrollmentResponse{
+			Secret:          enrollment.Secret,
+			ProvisioningURI: enrollment.ProvisioningURI,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// ConfirmEnrollment godoc
+// @Summary Enable TOTP with the code of the authenticator app. The recovery codes are returned only once
+// @Produce json
+// @Param param body entity.TOTPCodeParameter true "parameter to enable TOTP"
+// @Success 200 {object} entity.TOTPRecoveryCodesResponse
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Router /v1/auth/totp/confirm [post]
+func (h *totpAuthHandler) ConfirmEnrollment(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.TOTPCodeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		recoveryCodes, err := h.totpUsecase.ConfirmEnrollment(ctx, organizationID, operatorID, param.Code)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.TOTPRecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+		return nil
+	}, h.errorHandle)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Replace the recovery codes. The recovery codes are returned only once
+// @Produce json
+// @Param param body entity.TOTPCodeParameter true "parameter to replace the recovery codes"
+// @Success 200 {object} entity.TOTPRecoveryCodesResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /v1/auth/totp/recovery_codes [post]
+func (h *totpAuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.TOTPCodeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		recoveryCodes, err := h.totpUsecase.RegenerateRecoveryCodes(ctx, organizationID, operatorID, param.Code)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.TOTPRecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+		return nil
+	}, h.errorHandle)
+}
+
+// Disable godoc
+// @Summary Disable TOTP. The users whose roles need TOTP can't disable it
+// @Param param body entity.TOTPCodeParameter true "parameter to disable TOTP"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/auth/totp/disable [post]
+func (h *totpAuthHandler) Disable(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.TOTPCodeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		if err := h.totpUsecase.Disable(ctx, organizationID, operatorID, param.Code); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// StartEnrollmentWithChallenge godoc
+// @Summary Generate the secret for the user who has to enable TOTP to sign in
+// @Produce json
+// @Param param body entity.TOTPChallengeParameter true "parameter to generate the secret"
+// @Success 200 {object} entity.TOTPEnrollmentResponse
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Router /v1/auth/totp/challenge/enroll [post]
+func (h *totpAuthHandler) StartEnrollmentWithChallenge(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	param := entity.TOTPChallengeParameter{}
+	if err := c.ShouldBindJSON(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	enrollment, err := h.totpUsecase.StartEnrollmentWithChallenge(ctx, param.ChallengeToken)
+	if err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, entity.TOTPEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
+
+// VerifyChallenge godoc
+// @Summary Sign in with the challenge token and the TOTP code or the recovery code
+// @Produce json
+// @Param param body entity.TOTPVerifyParameter true "parameter to sign in"
+// @Success 200 {object} entity.TOTPVerifyResponse
+// @Failure 400
+// @Failure 401
+// @Failure 423
+// @Router /v1/auth/totp/challenge/verify [post]
+func (h *totpAuthHandler) VerifyChallenge(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	logger.Info("VerifyChallenge")
+
+	param := entity.TOTPVerifyParameter{}
+	if err := c.ShouldBindJSON(&param); err != nil {
+		logger.Warnf("invalid parameter. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return
+	}
+
+	result, err := h.totpUsecase.VerifyChallenge(ctx, param.ChallengeToken, param.Code, param.RecoveryCode)
+	if err != nil {
+		if !h.errorHandle(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": http.StatusText(http.StatusInternalServerError)})
+		}
+		return
+	}
+
+	logger.Info("VerifyChallenge OK")
+	c.JSON(http.StatusOK, entity.TOTPVerifyResponse{
+		AccessToken:   result.TokenSet.AccessToken,
+		RefreshToken:  result.TokenSet.RefreshToken,
+		RecoveryCodes: result.RecoveryCodes,
+	})
+}
+
+func (h *totpAuthHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, service.ErrInvalidTOTPCode) {
+		logger.Warnf("totpAuthHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid TOTP code"})
+		return true
+	} else if errors.Is(err, service.ErrTOTPChallengeNotFound) {
+		logger.Warnf("totpAuthHandler err: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Invalid or expired challenge token"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserLocked) {
+		logger.Warnf("totpAuthHandler err: %v", err)
+		c.JSON(http.StatusLocked, gin.H{"message": "Too many failed attempts. Try again later"})
+		return true
+	} else if errors.Is(err, service.ErrTOTPRequired) {
+		logger.Warnf("totpAuthHandler err: %v", err)
+		c.JSON(http.StatusForbidden, gin.H{"message": "TOTP is required for the role"})
+		return true
+	} else if errors.Is(err, service.ErrTOTPAlreadyEnabled) {
+		logger.Warnf("totpAuthHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "TOTP has already been enabled"})
+		return true
+	} else if errors.Is(err, service.ErrTOTPNotFound) {
+		logger.Warnf("totpAuthHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "TOTP isn't enabled"})
+		return true
+	}
+	logger.Errorf("totpAuthHandler err: %+v", err)
+	return false
+}
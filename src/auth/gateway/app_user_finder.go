@@ -0,0 +1,42 @@
+package gateway
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+// NewAppUserFindFunc returns the AppUserFindFunc which finds the app user in the DB
+func NewAppUserFindFunc(db *gorm.DB) service.AppUserFindFunc {
+	return func(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (userD.AppUserModel, userD.OrganizationModel, error) {
+		ctx, span := tracer.Start(ctx, "findAppUser")
+		defer span.End()
+
+		systemAdmin, err := userS.NewSystemAdminFromDB(ctx, db)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return nil, nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+		}
+
+		organization, err := systemOwner.GetOrganization(ctx)
+		if err != nil {
+			return nil, nil, liberrors.Errorf("failed to GetOrganization. err: %w", err)
+		}
+
+		appUser, err := systemOwner.FindAppUserByID(ctx, appUserID)
+		if err != nil {
+			return nil, nil, liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+		}
+
+		return appUser, organization, nil
+	}
+}
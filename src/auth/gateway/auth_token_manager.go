@@ -2,14 +2,19 @@ package gateway
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 
 	"github.com/kujilabo/cocotola-api/src/auth/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
 
 type AppUserClaims struct {
@@ -20,43 +25,77 @@ type AppUserClaims struct {
 	OrganizationName string `json:"organizationName"`
 	Role             string `json:"role"`
 	TokenType        string `json:"tokenType"`
+	FamilyID         string `json:"familyId,omitempty"`
 	jwt.StandardClaims
 }
 
 type authTokenManager struct {
-	signingKey     []byte
-	signingMethod  jwt.SigningMethod
-	tokenTimeout   time.Duration
-	refreshTimeout time.Duration
+	signingKeySet          *SigningKeySet
+	tokenTimeout           time.Duration
+	refreshTimeout         time.Duration
+	refreshTokenRepository service.RefreshTokenRepository
+	findAppUser            service.AppUserFindFunc
 }
 
-func NewAuthTokenManager(signingKey []byte, signingMethod jwt.SigningMethod, tokenTimeout, refreshTimeout time.Duration) service.AuthTokenManager {
+func NewAuthTokenManager(signingKeySet *SigningKeySet, tokenTimeout, refreshTimeout time.Duration, refreshTokenRepository service.RefreshTokenRepository, findAppUser service.AppUserFindFunc) service.AuthTokenManager {
 	return &authTokenManager{
-		signingKey:     signingKey,
-		signingMethod:  signingMethod,
-		tokenTimeout:   tokenTimeout,
-		refreshTimeout: refreshTimeout,
+		signingKeySet:          signingKeySet,
+		tokenTimeout:           tokenTimeout,
+		refreshTimeout:         refreshTimeout,
+		refreshTokenRepository: refreshTokenRepository,
+		findAppUser:            findAppUser,
 	}
 }
 
 func (m *authTokenManager) CreateTokenSet(ctx context.Context, appUser userD.AppUserModel, organization userD.OrganizationModel) (*service.TokenSet, error) {
-	accessToken, err := m.createJWT(ctx, appUser, organization, m.tokenTimeout, "access")
+	familyID, err := newTokenID()
 	if err != nil {
 		return nil, err
 	}
 
-	refreshToken, err := m.createJWT(ctx, appUser, organization, m.refreshTimeout, "refresh")
+	return m.createTokenSet(ctx, appUser, organization, familyID, time.Now().Add(m.refreshTimeout))
+}
+
+func (m *authTokenManager) createTokenSet(ctx context.Context, appUser userD.AppUserModel, organization userD.OrganizationModel, familyID string, familyExpiresAt time.Time) (*service.TokenSet, error) {
+	accessToken, err := m.createJWT(ctx, appUser, organization, time.Now().Add(m.tokenTimeout), "access", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	// the rotated token doesn't outlive the first token of the family
+	expiresAt := time.Now().Add(m.refreshTimeout)
+	if expiresAt.After(familyExpiresAt) {
+		expiresAt = familyExpiresAt
+	}
+
+	refreshToken, err := m.createJWT(ctx, appUser, organization, expiresAt, "refresh", tokenID, familyID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := m.refreshTokenRepository.AddRefreshToken(ctx, &service.RefreshToken{
+		TokenID:         tokenID,
+		FamilyID:        familyID,
+		OrganizationID:  userD.OrganizationID(organization.GetID()),
+		AppUserID:       userD.AppUserID(appUser.GetID()),
+		ExpiresAt:       expiresAt,
+		FamilyExpiresAt: familyExpiresAt,
+	}); err != nil {
+		return nil, liberrors.Errorf("failed to AddRefreshToken. err: %w", err)
+	}
+
 	return &service.TokenSet{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
 
-func (m *authTokenManager) createJWT(ctx context.Context, appUser userD.AppUserModel, organization userD.OrganizationModel, duration time.Duration, tokenType string) (string, error) {
+func (m *authTokenManager) createJWT(ctx context.Context, appUser userD.AppUserModel, organization userD.OrganizationModel, expiresAt time.Time, tokenType, tokenID, familyID string) (string, error) {
 	logger := log.FromContext(ctx)
 	now := time.Now()
 	claims := AppUserClaims{
@@ -67,65 +106,121 @@ func (m *authTokenManager) createJWT(ctx context.Context, appUser userD.AppUserM
 		OrganizationName: organization.GetName(),
 		Role:             appUser.GetRoles()[0],
 		TokenType:        tokenType,
+		FamilyID:         familyID,
 		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
 			IssuedAt:  now.Unix(),
-			ExpiresAt: now.Add(duration).Unix(),
+			ExpiresAt: expiresAt.Unix(),
 		},
 	}
 
 	logger.Debugf("claims: %+v", claims)
 
-	token := jwt.NewWithClaims(m.signingMethod, claims)
-	return token.SignedString(m.signingKey)
+	return m.signingKeySet.Sign(claims)
 }
 
-func (m *authTokenManager) RefreshToken(ctx context.Context, tokenString string) (string, error) {
+func (m *authTokenManager) parseRefreshToken(ctx context.Context, tokenString string) (*AppUserClaims, error) {
 	logger := log.FromContext(ctx)
-	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		return m.signingKey, nil
-	}
 
-	currentToken, err := jwt.ParseWithClaims(tokenString, &AppUserClaims{}, keyFunc)
+	currentToken, err := jwt.ParseWithClaims(tokenString, &AppUserClaims{}, m.signingKeySet.Keyfunc)
 	if err != nil {
 		logger.WithError(err).Infof("%v", err)
-		return "", service.NewUnauthorizedError(fmt.Sprintf("failed to ParseWithClaims. err: %v", err))
+		return nil, service.NewUnauthorizedError(fmt.Sprintf("failed to ParseWithClaims. err: %v", err))
 	}
 
 	currentClaims, ok := currentToken.Claims.(*AppUserClaims)
 	if !ok || !currentToken.Valid {
-		return "", service.NewUnauthorizedError("Invalid token. err: %v")
+		return nil, service.NewUnauthorizedError("Invalid token")
 	}
 
 	if currentClaims.TokenType != "refresh" {
-		return "", service.NewUnauthorizedError("Invalid token type")
+		return nil, service.NewUnauthorizedError("Invalid token type")
 	}
 
-	now := time.Now()
-	tmpID := uint(1)
-	userModel, err := userD.NewModel(currentClaims.AppUserID, 1, now, now, tmpID, tmpID)
+	if currentClaims.Id == "" || currentClaims.FamilyID == "" {
+		return nil, service.NewUnauthorizedError("Invalid token id")
+	}
+
+	return currentClaims, nil
+}
+
+func (m *authTokenManager) RefreshToken(ctx context.Context, tokenString string) (*service.TokenSet, error) {
+	logger := log.FromContext(ctx)
+
+	currentClaims, err := m.parseRefreshToken(ctx, tokenString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	appUser, err := userD.NewAppUserModel(userModel, userD.OrganizationID(currentClaims.OrganizationID), currentClaims.LoginID, currentClaims.Username, []string{currentClaims.Role}, map[string]string{})
+	stored, err := m.refreshTokenRepository.FindRefreshToken(ctx, currentClaims.Id)
 	if err != nil {
-		return "", err
+		if errors.Is(err, service.ErrRefreshTokenNotFound) {
+			return nil, service.NewUnauthorizedError("Unknown refresh token")
+		}
+		return nil, liberrors.Errorf("failed to FindRefreshToken. err: %w", err)
+	}
+
+	if stored.Revoked {
+		return nil, service.NewUnauthorizedError("Revoked refresh token")
+	}
+
+	if !time.Now().Before(stored.FamilyExpiresAt) {
+		return nil, service.NewUnauthorizedError("Expired refresh token")
 	}
 
-	orgModel, err := userD.NewModel(currentClaims.OrganizationID, 1, now, now, tmpID, tmpID)
+	used, err := m.refreshTokenRepository.UseRefreshToken(ctx, stored.TokenID)
 	if err != nil {
-		return "", err
+		return nil, liberrors.Errorf("failed to UseRefreshToken. err: %w", err)
+	}
+	if !used {
+		// the token which has already been rotated is presented again. it may have been stolen, so the whole family is revoked
+		logger.Warnf("refresh token reuse detected. organizationID: %d, appUserID: %d, familyID: %s", stored.OrganizationID, stored.AppUserID, stored.FamilyID)
+		if err := m.refreshTokenRepository.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, liberrors.Errorf("failed to RevokeFamily. err: %w", err)
+		}
+		return nil, service.NewUnauthorizedError("Refresh token reuse detected")
 	}
 
-	organization, err := userD.NewOrganizationModel(orgModel, currentClaims.OrganizationName)
+	// the user is reloaded so that the changes of the role take effect
+	appUser, organization, err := m.findAppUser(ctx, stored.OrganizationID, stored.AppUserID)
+	if errors.Is(err, userS.ErrAppUserNotFound) {
+		if err := m.refreshTokenRepository.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return nil, liberrors.Errorf("failed to RevokeFamily. err: %w", err)
+		}
+		return nil, service.NewUnauthorizedError("Unknown app user")
+	} else if err != nil {
+		return nil, liberrors.Errorf("failed to findAppUser. err: %w", err)
+	}
+
+	return m.createTokenSet(ctx, appUser, organization, stored.FamilyID, stored.FamilyExpiresAt)
+}
+
+func (m *authTokenManager) RevokeRefreshToken(ctx context.Context, tokenString string) error {
+	currentClaims, err := m.parseRefreshToken(ctx, tokenString)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if err := m.refreshTokenRepository.RevokeFamily(ctx, currentClaims.FamilyID); err != nil {
+		return liberrors.Errorf("failed to RevokeFamily. err: %w", err)
 	}
 
-	accessToken, err := m.createJWT(ctx, appUser, organization, m.tokenTimeout, "access")
+	return nil
+}
+
+func (m *authTokenManager) RevokeAllRefreshTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error) {
+	count, err := m.refreshTokenRepository.RevokeAppUserRefreshTokens(ctx, organizationID, appUserID)
 	if err != nil {
-		return "", err
+		return 0, liberrors.Errorf("failed to RevokeAppUserRefreshTokens. err: %w", err)
 	}
 
-	return accessToken, nil
+	return count, nil
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
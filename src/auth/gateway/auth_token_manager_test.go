@@ -0,0 +1,210 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/auth/gateway"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type memoryRefreshTokenRepository struct {
+	tokens map[string]*service.RefreshToken
+}
+
+func (r *memoryRefreshTokenRepository) AddRefreshToken(ctx context.Context, token *service.RefreshToken) error {
+	tmp := *token
+	r.tokens[token.TokenID] = &tmp
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) FindRefreshToken(ctx context.Context, tokenID string) (*service.RefreshToken, error) {
+	token, ok := r.tokens[tokenID]
+	if !ok {
+		return nil, service.ErrRefreshTokenNotFound
+	}
+	tmp := *token
+	return &tmp, nil
+}
+
+func (r *memoryRefreshTokenRepository) UseRefreshToken(ctx context.Context, tokenID string) (bool, error) {
+	token, ok := r.tokens[tokenID]
+	if !ok || token.Used {
+		return false, nil
+	}
+	token.Used = true
+	return true, nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID {
+			token.Revoked = true
+		}
+	}
+	return nil
+}
+
+func (r *memoryRefreshTokenRepository) RevokeAppUserRefreshTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error) {
+	count := 0
+	for _, token := range r.tokens {
+		if token.OrganizationID == organizationID && token.AppUserID == appUserID && !token.Revoked {
+			token.Revoked = true
+			count++
+		}
+	}
+	return count, nil
+}
+
+func isUnauthorizedError(err error) bool {
+	var unauthorizedError *service.UnauthorizedError
+	return errors.As(err, &unauthorizedError)
+}
+
+func Test_authTokenManager_RefreshToken(t *testing.T) {
+	bg := context.Background()
+	now := time.Now()
+	model, err := userD.NewModel(2, 1, now, now, 1, 1)
+	require.NoError(t, err)
+	appUser, err := userD.NewAppUserModel(model, userD.OrganizationID(1), "LOGIN_ID", "USERNAME", []string{"Student"}, map[string]string{})
+	require.NoError(t, err)
+	orgModel, err := userD.NewModel(1, 1, now, now, 1, 1)
+	require.NoError(t, err)
+	organization, err := userD.NewOrganizationModel(orgModel, "ORG_NAME")
+	require.NoError(t, err)
+
+	key, err := gateway.NewHMACSigningKey("", []byte("SIGNING_KEY"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	signingKeySet, err := gateway.NewSigningKeySet([]*gateway.SigningKey{key})
+	require.NoError(t, err)
+
+	// currentAppUser is the app user in the DB
+	var currentAppUser userD.AppUserModel
+	findAppUser := func(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (userD.AppUserModel, userD.OrganizationModel, error) {
+		if currentAppUser == nil {
+			return nil, nil, userS.ErrAppUserNotFound
+		}
+		return currentAppUser, organization, nil
+	}
+	newManagerWithRefreshTimeout := func(refreshTimeout time.Duration) (service.AuthTokenManager, *memoryRefreshTokenRepository) {
+		currentAppUser = appUser
+		repo := &memoryRefreshTokenRepository{tokens: map[string]*service.RefreshToken{}}
+		return gateway.NewAuthTokenManager(signingKeySet, time.Minute, refreshTimeout, repo, findAppUser), repo
+	}
+	newManager := func() (service.AuthTokenManager, *memoryRefreshTokenRepository) {
+		return newManagerWithRefreshTimeout(time.Hour)
+	}
+	parseClaims := func(tokenString string) *gateway.AppUserClaims {
+		token, err := jwt.ParseWithClaims(tokenString, &gateway.AppUserClaims{}, signingKeySet.Keyfunc)
+		require.NoError(t, err)
+		return token.Claims.(*gateway.AppUserClaims)
+	}
+
+	t.Run("rotate", func(t *testing.T) {
+		manager, _ := newManager()
+		tokenSet1, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+		tokenSet2, err := manager.RefreshToken(bg, tokenSet1.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, tokenSet1.RefreshToken, tokenSet2.RefreshToken)
+		_, err = manager.RefreshToken(bg, tokenSet2.RefreshToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("reuse revokes the family", func(t *testing.T) {
+		manager, _ := newManager()
+		tokenSet1, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+		tokenSet2, err := manager.RefreshToken(bg, tokenSet1.RefreshToken)
+		require.NoError(t, err)
+		otherTokenSet, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+
+		_, err = manager.RefreshToken(bg, tokenSet1.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+		_, err = manager.RefreshToken(bg, tokenSet2.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+		// the tokens of the other sign-in are still valid
+		_, err = manager.RefreshToken(bg, otherTokenSet.RefreshToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		manager, _ := newManager()
+		tokenSet1, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+		tokenSet2, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+		tokenSet3, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+
+		require.NoError(t, manager.RevokeRefreshToken(bg, tokenSet1.RefreshToken))
+		_, err = manager.RefreshToken(bg, tokenSet1.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+
+		count, err := manager.RevokeAllRefreshTokens(bg, userD.OrganizationID(1), userD.AppUserID(2))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		_, err = manager.RefreshToken(bg, tokenSet2.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+		_, err = manager.RefreshToken(bg, tokenSet3.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+	})
+
+	t.Run("access token can't be used", func(t *testing.T) {
+		manager, _ := newManager()
+		tokenSet, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+		_, err = manager.RefreshToken(bg, tokenSet.AccessToken)
+		assert.True(t, isUnauthorizedError(err))
+	})
+
+	t.Run("the current role of the app user is used", func(t *testing.T) {
+		manager, _ := newManager()
+		tokenSet1, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+
+		currentAppUser, err = userD.NewAppUserModel(model, userD.OrganizationID(1), "LOGIN_ID", "USERNAME", []string{"Owner"}, map[string]string{})
+		require.NoError(t, err)
+		tokenSet2, err := manager.RefreshToken(bg, tokenSet1.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, "Owner", parseClaims(tokenSet2.AccessToken).Role)
+	})
+
+	t.Run("the removed app user can't refresh", func(t *testing.T) {
+		manager, _ := newManager()
+		tokenSet, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+
+		currentAppUser = nil
+		_, err = manager.RefreshToken(bg, tokenSet.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+	})
+
+	t.Run("the rotated token doesn't outlive the first token of the family", func(t *testing.T) {
+		manager, repo := newManagerWithRefreshTimeout(3 * time.Second)
+		tokenSet1, err := manager.CreateTokenSet(bg, appUser, organization)
+		require.NoError(t, err)
+		claims1 := parseClaims(tokenSet1.RefreshToken)
+
+		time.Sleep(1100 * time.Millisecond)
+		tokenSet2, err := manager.RefreshToken(bg, tokenSet1.RefreshToken)
+		require.NoError(t, err)
+		claims2 := parseClaims(tokenSet2.RefreshToken)
+		assert.Equal(t, claims1.ExpiresAt, claims2.ExpiresAt)
+		assert.Equal(t, repo.tokens[claims1.Id].FamilyExpiresAt, repo.tokens[claims2.Id].FamilyExpiresAt)
+
+		// the family has expired even if the token is valid
+		repo.tokens[claims2.Id].FamilyExpiresAt = time.Now()
+		_, err = manager.RefreshToken(bg, tokenSet2.RefreshToken)
+		assert.True(t, isUnauthorizedError(err))
+	})
+}
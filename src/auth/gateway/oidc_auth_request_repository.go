@@ -0,0 +1,90 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+)
+
+type oidcAuthRequestEntity struct {
+	ID               uint
+	CreatedAt        time.Time
+	State            string
+	ProviderName     string
+	OrganizationName string
+	Nonce            string
+	CodeVerifier     string
+	ExpiresAt        time.Time
+}
+
+func (e *oidcAuthRequestEntity) TableName() string {
+	return "oidc_auth_request"
+}
+
+type oidcAuthRequestRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCAuthRequestRepository(db *gorm.DB) service.OIDCAuthRequestRepository {
+	return &oidcAuthRequestRepository{
+		db: db,
+	}
+}
+
+func (r *oidcAuthRequestRepository) AddAuthRequest(ctx context.Context, authRequest *service.OIDCAuthRequest) error {
+	_, span := tracer.Start(ctx, "oidcAuthRequestRepository.AddAuthRequest")
+	defer span.End()
+
+	if result := r.db.Where("expires_at < ?", time.Now()).Delete(&oidcAuthRequestEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	entity := oidcAuthRequestEntity{
+		State:            authRequest.State,
+		ProviderName:     authRequest.ProviderName,
+		OrganizationName: authRequest.OrganizationName,
+		Nonce:            authRequest.Nonce,
+		CodeVerifier:     authRequest.CodeVerifier,
+		ExpiresAt:        authRequest.ExpiresAt,
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *oidcAuthRequestRepository) UseAuthRequest(ctx context.Context, state string) (*service.OIDCAuthRequest, error) {
+	_, span := tracer.Start(ctx, "oidcAuthRequestRepository.UseAuthRequest")
+	defer span.End()
+
+	entity := oidcAuthRequestEntity{}
+	if result := r.db.Where("state = ?", state).First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrOIDCAuthRequestNotFound
+		}
+		return nil, result.Error
+	}
+
+	// only one of the concurrent requests can remove the row
+	result := r.db.Where("id = ?", entity.ID).Delete(&oidcAuthRequestEntity{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 || !time.Now().Before(entity.ExpiresAt) {
+		return nil, service.ErrOIDCAuthRequestNotFound
+	}
+
+	return &service.OIDCAuthRequest{
+		State:            entity.State,
+		ProviderName:     entity.ProviderName,
+		OrganizationName: entity.OrganizationName,
+		Nonce:            entity.Nonce,
+		CodeVerifier:     entity.CodeVerifier,
+		ExpiresAt:        entity.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,451 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+)
+
+const (
+	oidcClockSkew          = time.Minute
+	oidcJWKSRefreshMinimum = time.Minute
+)
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+}
+
+type oidcJSONWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// oidcAudience accepts both a string and an array of strings
+type oidcAudience []string
+
+func (a *oidcAudience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = []string{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// oidcBool accepts both a boolean and a string because some providers send "true"
+type oidcBool bool
+
+func (v *oidcBool) UnmarshalJSON(b []byte) error {
+	var boolValue bool
+	if err := json.Unmarshal(b, &boolValue); err == nil {
+		*v = oidcBool(boolValue)
+		return nil
+	}
+
+	var stringValue string
+	if err := json.Unmarshal(b, &stringValue); err != nil {
+		return err
+	}
+	*v = oidcBool(stringValue == "true")
+	return nil
+}
+
+type oidcIDTokenClaims struct {
+	Issuer          string       `json:"iss"`
+	Subject         string       `json:"sub"`
+	Audience        oidcAudience `json:"aud"`
+	AuthorizedParty string       `json:"azp"`
+	ExpiresAt       int64        `json:"exp"`
+	IssuedAt        int64        `json:"iat"`
+	Nonce           string       `json:"nonce"`
+	Email           string       `json:"email"`
+	EmailVerified   oidcBool     `json:"email_verified"`
+	Name            string       `json:"name"`
+}
+
+func (c *oidcIDTokenClaims) Valid() error {
+	now := time.Now()
+	if c.ExpiresAt == 0 || now.After(time.Unix(c.ExpiresAt, 0).Add(oidcClockSkew)) {
+		return errors.New("token is expired")
+	}
+	if now.Add(oidcClockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("token used before issued")
+	}
+	return nil
+}
+
+type oidcClient struct {
+	client       http.Client
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewOIDCClient returns the client of the OpenID Connect provider.
+// The provider metadata is discovered from the issuer on the first request
+func NewOIDCClient(issuer, clientID, clientSecret, redirectURL string, scopes []string, timeout time.Duration) service.OIDCClient {
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &oidcClient{
+		client: http.Client{
+			Timeout:   timeout,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+	}
+}
+
+func (c *oidcClient) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	ctx, span := tracer.Start(ctx, "oidcClient.AuthorizationURL")
+	defer span.End()
+
+	discovery, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", liberrors.Errorf("invalid authorization_endpoint. err: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", c.clientID)
+	q.Set("redirect_uri", c.redirectURL)
+	q.Set("scope", strings.Join(c.scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+func (c *oidcClient) RetrieveUserInfo(ctx context.Context, code, codeVerifier, nonce string) (*service.OIDCUserInfo, error) {
+	ctx, span := tracer.Start(ctx, "oidcClient.RetrieveUserInfo")
+	defer span.End()
+
+	discovery, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenResponse, err := c.exchangeCode(ctx, discovery, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := c.validateIDToken(ctx, discovery, tokenResponse.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.OIDCUserInfo{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+func (c *oidcClient) discover(ctx context.Context) (*oidcDiscovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	discovery := oidcDiscovery{}
+	if err := c.getJSON(ctx, c.issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, liberrors.Errorf("failed to discover the provider. issuer: %s, err: %w", c.issuer, err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != c.issuer {
+		return nil, liberrors.Errorf("issuer mismatch. expected: %s, actual: %s", c.issuer, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, liberrors.Errorf("incomplete provider metadata. issuer: %s", c.issuer)
+	}
+
+	c.discovery = &discovery
+	return c.discovery, nil
+}
+
+func (c *oidcClient) exchangeCode(ctx context.Context, discovery *oidcDiscovery, code, codeVerifier string) (*oidcTokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.redirectURL)
+	form.Set("client_id", c.clientID)
+	form.Set("code_verifier", codeVerifier)
+	if c.clientSecret != "" {
+		form.Set("client_secret", c.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to exchange the authorization code. err: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBytes, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		logger.Debugf("status:%d", resp.StatusCode)
+		logger.Debugf("Resp:%s", string(respBytes))
+		return nil, liberrors.Errorf("failed to exchange the authorization code. status: %d, err: %w", resp.StatusCode, service.ErrInvalidAuthorizationCode)
+	}
+
+	tokenResponse := oidcTokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, err
+	}
+
+	if tokenResponse.IDToken == "" {
+		return nil, liberrors.Errorf("id_token not found. err: %w", service.ErrInvalidIDToken)
+	}
+
+	return &tokenResponse, nil
+}
+
+func (c *oidcClient) validateIDToken(ctx context.Context, discovery *oidcDiscovery, idToken, nonce string) (*oidcIDTokenClaims, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.Alg() {
+		case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg():
+		default:
+			return nil, fmt.Errorf("unsupported signing method. alg: %s", token.Method.Alg())
+		}
+
+		keyID, _ := token.Header["kid"].(string)
+		return c.findKey(ctx, discovery, keyID)
+	}
+
+	claims := oidcIDTokenClaims{}
+	if _, err := jwt.ParseWithClaims(idToken, &claims, keyFunc); err != nil {
+		return nil, liberrors.Errorf("failed to ParseWithClaims. err: %v, %w", err, service.ErrInvalidIDToken)
+	}
+
+	if strings.TrimSuffix(claims.Issuer, "/") != c.issuer {
+		return nil, liberrors.Errorf("issuer mismatch. iss: %s, err: %w", claims.Issuer, service.ErrInvalidIDToken)
+	}
+
+	audienceFound := false
+	for _, audience := range claims.Audience {
+		if audience == c.clientID {
+			audienceFound = true
+		}
+	}
+	if !audienceFound {
+		return nil, liberrors.Errorf("audience mismatch. aud: %v, err: %w", claims.Audience, service.ErrInvalidIDToken)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != c.clientID {
+		return nil, liberrors.Errorf("authorized party mismatch. azp: %s, err: %w", claims.AuthorizedParty, service.ErrInvalidIDToken)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, liberrors.Errorf("nonce mismatch. err: %w", service.ErrInvalidIDToken)
+	}
+
+	if claims.Subject == "" {
+		return nil, liberrors.Errorf("sub not found. err: %w", service.ErrInvalidIDToken)
+	}
+
+	return &claims, nil
+}
+
+// findKey returns the key of the provider. The keys are fetched again when the key is not found because the provider may have rotated the keys
+func (c *oidcClient) findKey(ctx context.Context, discovery *oidcDiscovery, keyID string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.lookupKey(keyID); ok {
+		return key, nil
+	}
+
+	if time.Since(c.keysFetchedAt) < oidcJWKSRefreshMinimum {
+		return nil, fmt.Errorf("key not found. kid: %s", keyID)
+	}
+
+	keys, err := c.fetchKeys(ctx, discovery)
+	if err != nil {
+		return nil, err
+	}
+	c.keys = keys
+	c.keysFetchedAt = time.Now()
+
+	if key, ok := c.lookupKey(keyID); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("key not found. kid: %s", keyID)
+}
+
+func (c *oidcClient) lookupKey(keyID string) (interface{}, bool) {
+	if keyID != "" {
+		key, ok := c.keys[keyID]
+		return key, ok
+	}
+
+	// the kid header can be omitted only when the provider has a single key
+	if len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *oidcClient) fetchKeys(ctx context.Context, discovery *oidcDiscovery) (map[string]interface{}, error) {
+	logger := log.FromContext(ctx)
+
+	jwks := struct {
+		Keys []oidcJSONWebKey `json:"keys"`
+	}{}
+	if err := c.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return nil, liberrors.Errorf("failed to fetch the keys. err: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			logger.Warnf("failed to parse the key. kid: %s, err: %v", jwk.KeyID, err)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	return keys, nil
+}
+
+func (c *oidcClient) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status. url: %s, status: %d", u, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (k *oidcJSONWebKey) publicKey() (interface{}, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBase64URLInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URLInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve. crv: %s", k.Curve)
+		}
+		x, err := decodeBase64URLInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URLInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("invalid point")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type. kty: %s", k.KeyType)
+	}
+}
+
+func decodeBase64URLInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
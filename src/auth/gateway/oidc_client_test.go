@@ -0,0 +1,144 @@
+package gateway_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/auth/gateway"
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+)
+
+type testOIDCProvider struct {
+	server       *httptest.Server
+	privateKey   *rsa.PrivateKey
+	idTokenClaim func() jwt.MapClaims
+	codeVerifier string
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testOIDCProvider{privateKey: privateKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "KEY1",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "CODE" || r.PostForm.Get("code_verifier") != p.codeVerifier {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.idTokenClaim())
+		token.Header["kid"] = "KEY1"
+		idToken, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "ACCESS_TOKEN",
+			"id_token":     idToken,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *testOIDCProvider) claims(mutate func(jwt.MapClaims)) func() jwt.MapClaims {
+	return func() jwt.MapClaims {
+		now := time.Now()
+		claims := jwt.MapClaims{
+			"iss":            p.server.URL,
+			"sub":            "SUBJECT",
+			"aud":            "CLIENT_ID",
+			"exp":            now.Add(time.Minute).Unix(),
+			"iat":            now.Unix(),
+			"nonce":          "NONCE",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"name":           "USER",
+		}
+		mutate(claims)
+		return claims
+	}
+}
+
+func Test_oidcClient(t *testing.T) {
+	bg := context.Background()
+	provider := newTestOIDCProvider(t)
+	defer provider.server.Close()
+	provider.codeVerifier = "CODE_VERIFIER"
+
+	client := gateway.NewOIDCClient(provider.server.URL, "CLIENT_ID", "CLIENT_SECRET", "http://localhost/callback", nil, time.Second)
+
+	authorizationURL, err := client.AuthorizationURL(bg, "STATE", "NONCE", "CODE_CHALLENGE")
+	require.NoError(t, err)
+	u, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, "/authorize", u.Path)
+	assert.Equal(t, "CLIENT_ID", u.Query().Get("client_id"))
+	assert.Equal(t, "STATE", u.Query().Get("state"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		code   string
+		err    error
+	}{
+		{name: "valid", mutate: func(claims jwt.MapClaims) {}, code: "CODE"},
+		{name: "audience array", mutate: func(claims jwt.MapClaims) {
+			claims["aud"] = []string{"CLIENT_ID", "OTHER"}
+			claims["azp"] = "CLIENT_ID"
+		}, code: "CODE"},
+		{name: "invalid code", mutate: func(claims jwt.MapClaims) {}, code: "INVALID_CODE", err: service.ErrInvalidAuthorizationCode},
+		{name: "invalid nonce", mutate: func(claims jwt.MapClaims) { claims["nonce"] = "OTHER" }, code: "CODE", err: service.ErrInvalidIDToken},
+		{name: "invalid audience", mutate: func(claims jwt.MapClaims) { claims["aud"] = "OTHER" }, code: "CODE", err: service.ErrInvalidIDToken},
+		{name: "invalid issuer", mutate: func(claims jwt.MapClaims) { claims["iss"] = "https://example.com" }, code: "CODE", err: service.ErrInvalidIDToken},
+		{name: "expired", mutate: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() }, code: "CODE", err: service.ErrInvalidIDToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.idTokenClaim = provider.claims(tt.mutate)
+			userInfo, err := client.RetrieveUserInfo(bg, tt.code, "CODE_VERIFIER", "NONCE")
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "err: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "SUBJECT", userInfo.Subject)
+			assert.Equal(t, "user@example.com", userInfo.Email)
+			assert.True(t, userInfo.EmailVerified)
+			assert.Equal(t, "USER", userInfo.Name)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type logPasswordResetNotifier struct {
+}
+
+// NewLogPasswordResetNotifier returns the notifier which writes the token to the debug log.
+// It is used until the tokens are delivered by email
+func NewLogPasswordResetNotifier() service.PasswordResetNotifier {
+	return &logPasswordResetNotifier{}
+}
+
+func (n *logPasswordResetNotifier) NotifyPasswordReset(ctx context.Context, appUser userD.AppUserModel, token string, expiresAt time.Time) error {
+	logger := log.FromContext(ctx)
+	logger.Infof("password reset is requested. appUserID: %d", appUser.GetID())
+	logger.Debugf("password reset token. loginID: %s, token: %s, expiresAt: %v", appUser.GetLoginID(), token, expiresAt)
+	return nil
+}
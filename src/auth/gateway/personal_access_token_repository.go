@@ -0,0 +1,205 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+const personalAccessTokenLastUsedInterval = time.Minute
+
+type personalAccessTokenEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	OrganizationID uint
+	AppUserID      uint
+	Name           string
+	TokenPrefix    string
+	HashedToken    string
+	Scope          string
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+}
+
+func (e *personalAccessTokenEntity) TableName() string {
+	return "personal_access_token"
+}
+
+func (e *personalAccessTokenEntity) toModel(workbookIDs []uint) *service.PersonalAccessToken {
+	return &service.PersonalAccessToken{
+		ID:             service.PersonalAccessTokenID(e.ID),
+		CreatedAt:      e.CreatedAt,
+		OrganizationID: userD.OrganizationID(e.OrganizationID),
+		AppUserID:      userD.AppUserID(e.AppUserID),
+		Name:           e.Name,
+		TokenPrefix:    e.TokenPrefix,
+		Scope:          service.PersonalAccessTokenScope(e.Scope),
+		WorkbookIDs:    workbookIDs,
+		ExpiresAt:      e.ExpiresAt,
+		LastUsedAt:     e.LastUsedAt,
+	}
+}
+
+type personalAccessTokenWorkbookEntity struct {
+	PersonalAccessTokenID uint
+	WorkbookID            uint
+}
+
+func (e *personalAccessTokenWorkbookEntity) TableName() string {
+	return "personal_access_token_workbook"
+}
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) service.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *personalAccessTokenRepository) AddPersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, param *service.PersonalAccessTokenAddParameter) (service.PersonalAccessTokenID, error) {
+	_, span := tracer.Start(ctx, "personalAccessTokenRepository.AddPersonalAccessToken")
+	defer span.End()
+
+	entity := personalAccessTokenEntity{
+		OrganizationID: uint(organizationID),
+		AppUserID:      uint(appUserID),
+		Name:           param.Name,
+		TokenPrefix:    param.TokenPrefix,
+		HashedToken:    param.HashedToken,
+		Scope:          string(param.Scope),
+		ExpiresAt:      param.ExpiresAt,
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return 0, result.Error
+	}
+
+	for _, workbookID := range param.WorkbookIDs {
+		if result := r.db.Create(&personalAccessTokenWorkbookEntity{
+			PersonalAccessTokenID: entity.ID,
+			WorkbookID:            workbookID,
+		}); result.Error != nil {
+			return 0, result.Error
+		}
+	}
+
+	return service.PersonalAccessTokenID(entity.ID), nil
+}
+
+func (r *personalAccessTokenRepository) CountPersonalAccessTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error) {
+	_, span := tracer.Start(ctx, "personalAccessTokenRepository.CountPersonalAccessTokens")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&personalAccessTokenEntity{}).
+		Where("organization_id = ? and app_user_id = ?", uint(organizationID), uint(appUserID)).
+		Count(&count); result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *personalAccessTokenRepository) FindPersonalAccessTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) ([]*service.PersonalAccessToken, error) {
+	_, span := tracer.Start(ctx, "personalAccessTokenRepository.FindPersonalAccessTokens")
+	defer span.End()
+
+	entities := []personalAccessTokenEntity{}
+	if result := r.db.
+		Where("organization_id = ? and app_user_id = ?", uint(organizationID), uint(appUserID)).
+		Order("id").
+		Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	tokens := make([]*service.PersonalAccessToken, len(entities))
+	for i, entity := range entities {
+		workbookIDs, err := r.findWorkbookIDs(entity.ID)
+		if err != nil {
+			return nil, err
+		}
+		tokens[i] = entity.toModel(workbookIDs)
+	}
+
+	return tokens, nil
+}
+
+func (r *personalAccessTokenRepository) FindPersonalAccessTokenByHashedToken(ctx context.Context, hashedToken string) (*service.PersonalAccessToken, error) {
+	_, span := tracer.Start(ctx, "personalAccessTokenRepository.FindPersonalAccessTokenByHashedToken")
+	defer span.End()
+
+	entity := personalAccessTokenEntity{}
+	if result := r.db.Where("hashed_token = ?", hashedToken).First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrPersonalAccessTokenNotFound
+		}
+		return nil, result.Error
+	}
+
+	workbookIDs, err := r.findWorkbookIDs(entity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.toModel(workbookIDs), nil
+}
+
+func (r *personalAccessTokenRepository) UpdateLastUsedAt(ctx context.Context, id service.PersonalAccessTokenID, lastUsedAt time.Time) error {
+	_, span := tracer.Start(ctx, "personalAccessTokenRepository.UpdateLastUsedAt")
+	defer span.End()
+
+	// the condition skips the update on every request from the scripts
+	if result := r.db.Model(&personalAccessTokenEntity{}).
+		Where("id = ?", uint(id)).
+		Where("last_used_at is null or last_used_at < ?", lastUsedAt.Add(-personalAccessTokenLastUsedInterval)).
+		Update("last_used_at", lastUsedAt); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *personalAccessTokenRepository) RemovePersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, id service.PersonalAccessTokenID) error {
+	_, span := tracer.Start(ctx, "personalAccessTokenRepository.RemovePersonalAccessToken")
+	defer span.End()
+
+	entity := personalAccessTokenEntity{}
+	if result := r.db.
+		Where("organization_id = ? and app_user_id = ?", uint(organizationID), uint(appUserID)).
+		Where("id = ?", uint(id)).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return service.ErrPersonalAccessTokenNotFound
+		}
+		return result.Error
+	}
+
+	if result := r.db.Where("personal_access_token_id = ?", entity.ID).Delete(&personalAccessTokenWorkbookEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	if result := r.db.Delete(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *personalAccessTokenRepository) findWorkbookIDs(personalAccessTokenID uint) ([]uint, error) {
+	workbookIDs := []uint{}
+	if result := r.db.Model(&personalAccessTokenWorkbookEntity{}).
+		Where("personal_access_token_id = ?", personalAccessTokenID).
+		Order("workbook_id").
+		Pluck("workbook_id", &workbookIDs); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return workbookIDs, nil
+}
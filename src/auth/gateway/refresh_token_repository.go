@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type refreshTokenEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint
+	AppUserID      uint
+	TokenID        string
+	FamilyID       string
+	Used           bool
+	Revoked        bool
+	ExpiresAt      time.Time
+	// FamilyExpiresAt is the expiry of the first token of the family
+	FamilyExpiresAt time.Time
+}
+
+func (e *refreshTokenEntity) TableName() string {
+	return "refresh_token"
+}
+
+func (e *refreshTokenEntity) toModel() *service.RefreshToken {
+	return &service.RefreshToken{
+		TokenID:         e.TokenID,
+		FamilyID:        e.FamilyID,
+		OrganizationID:  userD.OrganizationID(e.OrganizationID),
+		AppUserID:       userD.AppUserID(e.AppUserID),
+		Used:            e.Used,
+		Revoked:         e.Revoked,
+		ExpiresAt:       e.ExpiresAt,
+		FamilyExpiresAt: e.FamilyExpiresAt,
+	}
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) service.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *refreshTokenRepository) AddRefreshToken(ctx context.Context, token *service.RefreshToken) error {
+	_, span := tracer.Start(ctx, "refreshTokenRepository.AddRefreshToken")
+	defer span.End()
+
+	if result := r.db.
+		Where("organization_id = ? and app_user_id = ?", uint(token.OrganizationID), uint(token.AppUserID)).
+		Where("expires_at < ?", time.Now()).
+		Delete(&refreshTokenEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	entity := refreshTokenEntity{
+		OrganizationID:  uint(token.OrganizationID),
+		AppUserID:       uint(token.AppUserID),
+		TokenID:         token.TokenID,
+		FamilyID:        token.FamilyID,
+		ExpiresAt:       token.ExpiresAt,
+		FamilyExpiresAt: token.FamilyExpiresAt,
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) FindRefreshToken(ctx context.Context, tokenID string) (*service.RefreshToken, error) {
+	_, span := tracer.Start(ctx, "refreshTokenRepository.FindRefreshToken")
+	defer span.End()
+
+	entity := refreshTokenEntity{}
+	if result := r.db.Where("token_id = ?", tokenID).First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrRefreshTokenNotFound
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel(), nil
+}
+
+func (r *refreshTokenRepository) UseRefreshToken(ctx context.Context, tokenID string) (bool, error) {
+	_, span := tracer.Start(ctx, "refreshTokenRepository.UseRefreshToken")
+	defer span.End()
+
+	// the condition on `used` makes only one of the concurrent requests succeed
+	result := r.db.Model(&refreshTokenEntity{}).
+		Where("token_id = ? and used = 0", tokenID).
+		Update("used", true)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, span := tracer.Start(ctx, "refreshTokenRepository.RevokeFamily")
+	defer span.End()
+
+	if result := r.db.Model(&refreshTokenEntity{}).
+		Where("family_id = ?", familyID).
+		Update("revoked", true); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeAppUserRefreshTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error) {
+	_, span := tracer.Start(ctx, "refreshTokenRepository.RevokeAppUserRefreshTokens")
+	defer span.End()
+
+	result := r.db.Model(&refreshTokenEntity{}).
+		Where("organization_id = ? and app_user_id = ?", uint(organizationID), uint(appUserID)).
+		Where("revoked = 0 and expires_at > ?", time.Now()).
+		Update("revoked", true)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,226 @@
+package gateway
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+)
+
+// SigningKey is the key to sign and verify the tokens.
+// The key is used to sign the new tokens from ActiveFrom and is used to verify the tokens until RetireAt
+type SigningKey struct {
+	KeyID      string
+	Method     jwt.SigningMethod
+	signingKey interface{}
+	verifyKey  interface{}
+	ActiveFrom time.Time
+	RetireAt   time.Time
+}
+
+// NewHMACSigningKey returns the HS256 key. The key whose id is empty verifies the tokens which don't have the kid header
+func NewHMACSigningKey(keyID string, secret []byte, activeFrom, retireAt time.Time) (*SigningKey, error) {
+	if len(secret) == 0 {
+		return nil, liberrors.Errorf("secret is empty. err: %w", libD.ErrInvalidArgument)
+	}
+
+	return &SigningKey{
+		KeyID:      keyID,
+		Method:     jwt.SigningMethodHS256,
+		signingKey: secret,
+		verifyKey:  secret,
+		ActiveFrom: activeFrom,
+		RetireAt:   retireAt,
+	}, nil
+}
+
+// NewPEMSigningKey returns the RS256 or ES256 key from the PEM encoded private key
+func NewPEMSigningKey(keyID, algorithm string, privateKeyPEM []byte, activeFrom, retireAt time.Time) (*SigningKey, error) {
+	if keyID == "" {
+		return nil, liberrors.Errorf("kid is required for %s. err: %w", algorithm, libD.ErrInvalidArgument)
+	}
+
+	switch algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to ParseRSAPrivateKeyFromPEM. kid: %s, err: %w", keyID, err)
+		}
+		return &SigningKey{
+			KeyID:      keyID,
+			Method:     jwt.SigningMethodRS256,
+			signingKey: privateKey,
+			verifyKey:  &privateKey.PublicKey,
+			ActiveFrom: activeFrom,
+			RetireAt:   retireAt,
+		}, nil
+	case jwt.SigningMethodES256.Alg():
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM(privateKeyPEM)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to ParseECPrivateKeyFromPEM. kid: %s, err: %w", keyID, err)
+		}
+		if privateKey.Curve.Params().Name != "P-256" {
+			return nil, liberrors.Errorf("ES256 requires P-256 curve. kid: %s, err: %w", keyID, libD.ErrInvalidArgument)
+		}
+		return &SigningKey{
+			KeyID:      keyID,
+			Method:     jwt.SigningMethodES256,
+			signingKey: privateKey,
+			verifyKey:  &privateKey.PublicKey,
+			ActiveFrom: activeFrom,
+			RetireAt:   retireAt,
+		}, nil
+	default:
+		return nil, liberrors.Errorf("unsupported algorithm. algorithm: %s, err: %w", algorithm, libD.ErrInvalidArgument)
+	}
+}
+
+func (k *SigningKey) isRetired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+func (k *SigningKey) isActive(now time.Time) bool {
+	return !now.Before(k.ActiveFrom) && !k.isRetired(now)
+}
+
+// SigningKeySet holds the keys in rotation.
+// The new tokens are signed with the active key whose ActiveFrom is the latest, so the keys are rotated without restarting the server
+type SigningKeySet struct {
+	keys []*SigningKey
+	now  func() time.Time
+}
+
+func NewSigningKeySet(keys []*SigningKey) (*SigningKeySet, error) {
+	if len(keys) == 0 {
+		return nil, liberrors.Errorf("no signing keys. err: %w", libD.ErrInvalidArgument)
+	}
+
+	keyIDs := map[string]bool{}
+	for _, key := range keys {
+		if keyIDs[key.KeyID] {
+			return nil, liberrors.Errorf("duplicated kid. kid: %s, err: %w", key.KeyID, libD.ErrInvalidArgument)
+		}
+		keyIDs[key.KeyID] = true
+	}
+
+	return &SigningKeySet{
+		keys: keys,
+		now:  time.Now,
+	}, nil
+}
+
+// SigningKey returns the key to sign the new tokens
+func (s *SigningKeySet) SigningKey() (*SigningKey, error) {
+	now := s.now()
+	var current *SigningKey
+	for _, key := range s.keys {
+		if !key.isActive(now) {
+			continue
+		}
+		// the latter key wins when the keys become active at the same time
+		if current == nil || !key.ActiveFrom.Before(current.ActiveFrom) {
+			current = key
+		}
+	}
+
+	if current == nil {
+		return nil, errors.New("no active signing key")
+	}
+
+	return current, nil
+}
+
+// Sign signs the claims with the current signing key and sets its id to the kid header
+func (s *SigningKeySet) Sign(claims jwt.Claims) (string, error) {
+	key, err := s.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.KeyID != "" {
+		token.Header["kid"] = key.KeyID
+	}
+
+	return token.SignedString(key.signingKey)
+}
+
+// Keyfunc returns the key to verify the token.
+// The token is rejected unless its algorithm matches the algorithm of the key so that the public key can't be used as the HMAC secret
+func (s *SigningKeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	keyID := ""
+	if kid, ok := token.Header["kid"]; ok {
+		kidString, ok := kid.(string)
+		if !ok {
+			return nil, errors.New("invalid kid")
+		}
+		keyID = kidString
+	}
+
+	now := s.now()
+	for _, key := range s.keys {
+		if key.KeyID != keyID {
+			continue
+		}
+		if key.isRetired(now) {
+			return nil, fmt.Errorf("signing key has been retired. kid: %s", keyID)
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method. kid: %s, alg: %s", keyID, token.Method.Alg())
+		}
+		return key.verifyKey, nil
+	}
+
+	return nil, fmt.Errorf("signing key not found. kid: %s", keyID)
+}
+
+// JSONWebKeys returns the public keys which haven't been retired.
+// The keys which will become active are also published so that the verifiers can fetch them in advance
+func (s *SigningKeySet) JSONWebKeys() []service.JSONWebKey {
+	now := s.now()
+	jwks := make([]service.JSONWebKey, 0)
+	for _, key := range s.keys {
+		if key.isRetired(now) {
+			continue
+		}
+
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks = append(jwks, service.JSONWebKey{
+				KeyType:   "RSA",
+				Use:       "sig",
+				KeyID:     key.KeyID,
+				Algorithm: key.Method.Alg(),
+				N:         encodeBase64URL(publicKey.N.Bytes()),
+				E:         encodeBase64URL(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwks = append(jwks, service.JSONWebKey{
+				KeyType:   "EC",
+				Use:       "sig",
+				KeyID:     key.KeyID,
+				Algorithm: key.Method.Alg(),
+				Curve:     publicKey.Curve.Params().Name,
+				X:         encodeBase64URL(publicKey.X.FillBytes(make([]byte, size))),
+				Y:         encodeBase64URL(publicKey.Y.FillBytes(make([]byte, size))),
+			})
+		}
+		// HMAC secrets are never published
+	}
+
+	return jwks
+}
+
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,106 @@
+package gateway_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/auth/gateway"
+)
+
+func newRSAPrivateKeyPEM(t *testing.T) []byte {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+}
+
+func newECPrivateKeyPEM(t *testing.T) []byte {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	b, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+}
+
+func Test_SigningKeySet(t *testing.T) {
+	now := time.Now()
+	legacyKey, err := gateway.NewHMACSigningKey("", []byte("SIGNING_KEY"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	rsaKey, err := gateway.NewPEMSigningKey("RSA_KEY", "RS256", newRSAPrivateKeyPEM(t), now.Add(-time.Hour), time.Time{})
+	require.NoError(t, err)
+	ecKey, err := gateway.NewPEMSigningKey("EC_KEY", "ES256", newECPrivateKeyPEM(t), now.Add(time.Hour), time.Time{})
+	require.NoError(t, err)
+	retiredKey, err := gateway.NewPEMSigningKey("RETIRED_KEY", "ES256", newECPrivateKeyPEM(t), time.Time{}, now.Add(-time.Minute))
+	require.NoError(t, err)
+
+	signingKeySet, err := gateway.NewSigningKeySet([]*gateway.SigningKey{legacyKey, rsaKey, ecKey, retiredKey})
+	require.NoError(t, err)
+
+	// the active key whose ActiveFrom is the latest signs the token
+	signingKey, err := signingKeySet.SigningKey()
+	require.NoError(t, err)
+	assert.Equal(t, "RSA_KEY", signingKey.KeyID)
+
+	tokenString, err := signingKeySet.Sign(&jwt.StandardClaims{ExpiresAt: now.Add(time.Minute).Unix()})
+	require.NoError(t, err)
+	token, err := jwt.ParseWithClaims(tokenString, &jwt.StandardClaims{}, signingKeySet.Keyfunc)
+	require.NoError(t, err)
+	assert.Equal(t, "RSA_KEY", token.Header["kid"])
+	assert.Equal(t, "RS256", token.Method.Alg())
+
+	// the token without kid is verified with the legacy key
+	legacyTokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{}).SignedString([]byte("SIGNING_KEY"))
+	require.NoError(t, err)
+	_, err = jwt.ParseWithClaims(legacyTokenString, &jwt.StandardClaims{}, signingKeySet.Keyfunc)
+	assert.NoError(t, err)
+
+	// the algorithm must match the key
+	forgedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{})
+	forgedToken.Header["kid"] = "RSA_KEY"
+	forgedTokenString, err := forgedToken.SignedString([]byte("SIGNING_KEY"))
+	require.NoError(t, err)
+	_, err = jwt.ParseWithClaims(forgedTokenString, &jwt.StandardClaims{}, signingKeySet.Keyfunc)
+	assert.Error(t, err)
+
+	// the unknown key is rejected
+	unknownToken := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.StandardClaims{})
+	unknownToken.Header["kid"] = "UNKNOWN_KEY"
+	unknownTokenString, err := unknownToken.SignedString([]byte("SIGNING_KEY"))
+	require.NoError(t, err)
+	_, err = jwt.ParseWithClaims(unknownTokenString, &jwt.StandardClaims{}, signingKeySet.Keyfunc)
+	assert.Error(t, err)
+
+	// the HMAC key and the retired key aren't published. the next key is published in advance
+	jwks := signingKeySet.JSONWebKeys()
+	require.Len(t, jwks, 2)
+	assert.Equal(t, "RSA_KEY", jwks[0].KeyID)
+	assert.Equal(t, "RSA", jwks[0].KeyType)
+	assert.Equal(t, "AQAB", jwks[0].E)
+	assert.Equal(t, "EC_KEY", jwks[1].KeyID)
+	assert.Equal(t, "P-256", jwks[1].Curve)
+	assert.Len(t, jwks[1].X, 43)
+}
+
+func Test_NewSigningKeySet(t *testing.T) {
+	_, err := gateway.NewSigningKeySet(nil)
+	assert.Error(t, err)
+
+	key1, err := gateway.NewHMACSigningKey("KEY", []byte("SIGNING_KEY1"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	key2, err := gateway.NewHMACSigningKey("KEY", []byte("SIGNING_KEY2"), time.Time{}, time.Time{})
+	require.NoError(t, err)
+	_, err = gateway.NewSigningKeySet([]*gateway.SigningKey{key1, key2})
+	assert.Error(t, err)
+
+	_, err = gateway.NewPEMSigningKey("KEY", "RS256", newECPrivateKeyPEM(t), time.Time{}, time.Time{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,322 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type appUserTOTPEntity struct {
+	AppUserID      uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint
+	Secret         string
+	EnabledAt      *time.Time
+	LastUsedStep   int64
+}
+
+func (e *appUserTOTPEntity) TableName() string {
+	return "app_user_totp"
+}
+
+func (e *appUserTOTPEntity) toModel() *service.TOTPCredential {
+	return &service.TOTPCredential{
+		OrganizationID: userD.OrganizationID(e.OrganizationID),
+		AppUserID:      userD.AppUserID(e.AppUserID),
+		Secret:         e.Secret,
+		EnabledAt:      e.EnabledAt,
+		LastUsedStep:   e.LastUsedStep,
+	}
+}
+
+type appUserRecoveryCodeEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	OrganizationID uint
+	AppUserID      uint
+	HashedCode     string
+}
+
+func (e *appUserRecoveryCodeEntity) TableName() string {
+	return "app_user_recovery_code"
+}
+
+type totpChallengeEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	OrganizationID uint
+	AppUserID      uint
+	HashedToken    string
+	ExpiresAt      time.Time
+}
+
+func (e *totpChallengeEntity) TableName() string {
+	return "totp_challenge"
+}
+
+func (e *totpChallengeEntity) toModel() *service.TOTPChallenge {
+	return &service.TOTPChallenge{
+		OrganizationID: userD.OrganizationID(e.OrganizationID),
+		AppUserID:      userD.AppUserID(e.AppUserID),
+		HashedToken:    e.HashedToken,
+		ExpiresAt:      e.ExpiresAt,
+	}
+}
+
+type totpRepository struct {
+	db *gorm.DB
+}
+
+func NewTOTPRepository(db *gorm.DB) service.TOTPRepository {
+	return &totpRepository{
+		db: db,
+	}
+}
+
+func (r *totpRepository) SaveTOTPSecret(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, secret string) error {
+	_, span := tracer.Start(ctx, "totpRepository.SaveTOTPSecret")
+	defer span.End()
+
+	entity := appUserTOTPEntity{}
+	result := r.db.
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ?", uint(appUserID)).
+		First(&entity)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		if result := r.db.Create(&appUserTOTPEntity{
+			AppUserID:      uint(appUserID),
+			OrganizationID: uint(organizationID),
+			Secret:         secret,
+		}); result.Error != nil {
+			return result.Error
+		}
+		return nil
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	if entity.EnabledAt != nil {
+		return service.ErrTOTPAlreadyEnabled
+	}
+
+	if result := r.db.Model(&appUserTOTPEntity{}).
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ? and enabled_at is null", uint(appUserID)).
+		Update("secret", secret); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *totpRepository) FindTOTPCredential(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (*service.TOTPCredential, error) {
+	_, span := tracer.Start(ctx, "totpRepository.FindTOTPCredential")
+	defer span.End()
+
+	entity := appUserTOTPEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ?", uint(appUserID)).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrTOTPNotFound
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel(), nil
+}
+
+func (r *totpRepository) EnableTOTP(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, step int64) error {
+	_, span := tracer.Start(ctx, "totpRepository.EnableTOTP")
+	defer span.End()
+
+	result := r.db.Model(&appUserTOTPEntity{}).
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ? and enabled_at is null", uint(appUserID)).
+		Updates(map[string]interface{}{
+			"enabled_at":     time.Now(),
+			"last_used_step": step,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrTOTPAlreadyEnabled
+	}
+
+	return nil
+}
+
+func (r *totpRepository) ReplaceRecoveryCodes(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, hashedRecoveryCodes []string) error {
+	_, span := tracer.Start(ctx, "totpRepository.ReplaceRecoveryCodes")
+	defer span.End()
+
+	if result := r.db.
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ?", uint(appUserID)).
+		Delete(&appUserRecoveryCodeEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	entities := make([]appUserRecoveryCodeEntity, len(hashedRecoveryCodes))
+	for i, hashedCode := range hashedRecoveryCodes {
+		entities[i] = appUserRecoveryCodeEntity{
+			OrganizationID: uint(organizationID),
+			AppUserID:      uint(appUserID),
+			HashedCode:     hashedCode,
+		}
+	}
+	if len(entities) > 0 {
+		if result := r.db.Create(&entities); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	return nil
+}
+
+func (r *totpRepository) UseTOTPStep(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, step int64) error {
+	_, span := tracer.Start(ctx, "totpRepository.UseTOTPStep")
+	defer span.End()
+
+	// only one of the concurrent requests with the same code can update the row
+	result := r.db.Model(&appUserTOTPEntity{}).
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ? and last_used_step < ?", uint(appUserID), step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrInvalidTOTPCode
+	}
+
+	return nil
+}
+
+func (r *totpRepository) UseRecoveryCode(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, hashedCode string) error {
+	_, span := tracer.Start(ctx, "totpRepository.UseRecoveryCode")
+	defer span.End()
+
+	result := r.db.
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ? and hashed_code = ?", uint(appUserID), hashedCode).
+		Delete(&appUserRecoveryCodeEntity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrInvalidTOTPCode
+	}
+
+	return nil
+}
+
+func (r *totpRepository) CountRecoveryCodes(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error) {
+	_, span := tracer.Start(ctx, "totpRepository.CountRecoveryCodes")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&appUserRecoveryCodeEntity{}).
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ?", uint(appUserID)).
+		Count(&count); result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *totpRepository) RemoveTOTP(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) error {
+	_, span := tracer.Start(ctx, "totpRepository.RemoveTOTP")
+	defer span.End()
+
+	if result := r.db.
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ?", uint(appUserID)).
+		Delete(&appUserRecoveryCodeEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	result := r.db.
+		Where("organization_id = ?", uint(organizationID)).
+		Where("app_user_id = ?", uint(appUserID)).
+		Delete(&appUserTOTPEntity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrTOTPNotFound
+	}
+
+	return nil
+}
+
+func (r *totpRepository) AddTOTPChallenge(ctx context.Context, challenge *service.TOTPChallenge) error {
+	_, span := tracer.Start(ctx, "totpRepository.AddTOTPChallenge")
+	defer span.End()
+
+	if result := r.db.Where("expires_at < ?", time.Now()).Delete(&totpChallengeEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	if result := r.db.Create(&totpChallengeEntity{
+		OrganizationID: uint(challenge.OrganizationID),
+		AppUserID:      uint(challenge.AppUserID),
+		HashedToken:    challenge.HashedToken,
+		ExpiresAt:      challenge.ExpiresAt,
+	}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *totpRepository) FindTOTPChallenge(ctx context.Context, hashedToken string) (*service.TOTPChallenge, error) {
+	_, span := tracer.Start(ctx, "totpRepository.FindTOTPChallenge")
+	defer span.End()
+
+	entity := totpChallengeEntity{}
+	if result := r.db.Where("hashed_token = ?", hashedToken).First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrTOTPChallengeNotFound
+		}
+		return nil, result.Error
+	}
+	if !time.Now().Before(entity.ExpiresAt) {
+		return nil, service.ErrTOTPChallengeNotFound
+	}
+
+	return entity.toModel(), nil
+}
+
+func (r *totpRepository) UseTOTPChallenge(ctx context.Context, hashedToken string) (*service.TOTPChallenge, error) {
+	_, span := tracer.Start(ctx, "totpRepository.UseTOTPChallenge")
+	defer span.End()
+
+	entity := totpChallengeEntity{}
+	if result := r.db.Where("hashed_token = ?", hashedToken).First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrTOTPChallengeNotFound
+		}
+		return nil, result.Error
+	}
+
+	// only one of the concurrent requests can remove the row
+	result := r.db.Where("id = ?", entity.ID).Delete(&totpChallengeEntity{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 || !time.Now().Before(entity.ExpiresAt) {
+		return nil, service.ErrTOTPChallengeNotFound
+	}
+
+	return entity.toModel(), nil
+}
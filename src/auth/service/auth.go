@@ -1,5 +1,10 @@
 package service
 
+import "errors"
+
+// ErrAppUserProviderMismatch is returned when the user of the login ID signs in with the other identity provider or the password
+var ErrAppUserProviderMismatch = errors.New("app user provider mismatch")
+
 type TokenSet struct {
 	AccessToken  string
 	RefreshToken string
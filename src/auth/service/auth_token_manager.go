@@ -6,7 +6,21 @@ import (
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
+// AppUserFindFunc returns the current app user and the organization.
+// It returns ErrAppUserNotFound of the user service if the user has been removed or disabled
+type AppUserFindFunc func(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (userD.AppUserModel, userD.OrganizationModel, error)
+
 type AuthTokenManager interface {
 	CreateTokenSet(ctx context.Context, appUser userD.AppUserModel, organization userD.OrganizationModel) (*TokenSet, error)
-	RefreshToken(ctx context.Context, tokenString string) (string, error)
+
+	// RefreshToken rotates the refresh token and returns the new token set of the current app user.
+	// If the refresh token which has already been rotated is presented, all the tokens of its family are revoked.
+	// The family expires when its first token expires
+	RefreshToken(ctx context.Context, tokenString string) (*TokenSet, error)
+
+	// RevokeRefreshToken revokes all the tokens of the family which the refresh token belongs to
+	RevokeRefreshToken(ctx context.Context, tokenString string) error
+
+	// RevokeAllRefreshTokens revokes all the refresh tokens of the user and returns the number of the revoked tokens
+	RevokeAllRefreshTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error)
 }
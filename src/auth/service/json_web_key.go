@@ -0,0 +1,20 @@
+package service
+
+// JSONWebKey is the public key to verify the tokens, defined in RFC 7517
+type JSONWebKey struct {
+	KeyType   string
+	Use       string
+	KeyID     string
+	Algorithm string
+	// RSA
+	N string
+	E string
+	// EC
+	Curve string
+	X     string
+	Y     string
+}
+
+type JSONWebKeyProvider interface {
+	JSONWebKeys() []JSONWebKey
+}
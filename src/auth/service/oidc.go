@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrOIDCProviderNotFound = errors.New("OIDC provider not found")
+var ErrOIDCAuthRequestNotFound = errors.New("OIDC auth request not found")
+var ErrInvalidAuthorizationCode = errors.New("invalid authorization code")
+var ErrInvalidIDToken = errors.New("invalid ID token")
+
+// OIDCAuthRequest is the state of the authorization request which is kept until the user comes back from the provider
+type OIDCAuthRequest struct {
+	State            string
+	ProviderName     string
+	OrganizationName string
+	Nonce            string
+	CodeVerifier     string
+	ExpiresAt        time.Time
+}
+
+type OIDCAuthRequestRepository interface {
+	// AddAuthRequest saves the request. The expired requests are removed
+	AddAuthRequest(ctx context.Context, authRequest *OIDCAuthRequest) error
+
+	// UseAuthRequest removes the request and returns it. It returns ErrOIDCAuthRequestNotFound if the request has expired
+	UseAuthRequest(ctx context.Context, state string) (*OIDCAuthRequest, error)
+}
+
+type OIDCUserInfo struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type OIDCClient interface {
+	// AuthorizationURL returns the URL of the provider to which the user is redirected
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// RetrieveUserInfo exchanges the authorization code for the ID token and returns the claims of the validated ID token
+	RetrieveUserInfo(ctx context.Context, code, codeVerifier, nonce string) (*OIDCUserInfo, error)
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var (
+	// ErrInvalidCredential is returned whether the login id or the password is wrong so that the existence of the user isn't revealed
+	ErrInvalidCredential         = errors.New("invalid credential")
+	ErrAppUserLocked             = errors.New("app user is locked")
+	ErrInvalidPasswordResetToken = errors.New("invalid password reset token")
+)
+
+// PasswordResetNotifier delivers the password reset token to the user
+type PasswordResetNotifier interface {
+	NotifyPasswordReset(ctx context.Context, appUser userD.AppUserModel, token string, expiresAt time.Time) error
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+var ErrPersonalAccessTokenLimitExceeded = errors.New("personal access token limit exceeded")
+
+type PersonalAccessTokenScope string
+
+const (
+	PersonalAccessTokenScopeRead  PersonalAccessTokenScope = "read"
+	PersonalAccessTokenScopeWrite PersonalAccessTokenScope = "write"
+)
+
+type PersonalAccessTokenID uint
+
+// PersonalAccessToken is the API key of the user. Only the hash of the token is stored
+type PersonalAccessToken struct {
+	ID             PersonalAccessTokenID
+	CreatedAt      time.Time
+	OrganizationID userD.OrganizationID
+	AppUserID      userD.AppUserID
+	Name           string
+	TokenPrefix    string
+	Scope          PersonalAccessTokenScope
+	// WorkbookIDs limits the workbooks which the token can access. The token can access all the workbooks if it's empty
+	WorkbookIDs []uint
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+}
+
+func (t *PersonalAccessToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+type PersonalAccessTokenAddParameter struct {
+	Name        string
+	TokenPrefix string
+	HashedToken string
+	Scope       PersonalAccessTokenScope
+	WorkbookIDs []uint
+	ExpiresAt   *time.Time
+}
+
+type PersonalAccessTokenRepository interface {
+	AddPersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, param *PersonalAccessTokenAddParameter) (PersonalAccessTokenID, error)
+
+	CountPersonalAccessTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error)
+
+	FindPersonalAccessTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) ([]*PersonalAccessToken, error)
+
+	FindPersonalAccessTokenByHashedToken(ctx context.Context, hashedToken string) (*PersonalAccessToken, error)
+
+	// UpdateLastUsedAt records the time when the token was used. It is recorded at most once a minute
+	UpdateLastUsedAt(ctx context.Context, id PersonalAccessTokenID, lastUsedAt time.Time) error
+
+	RemovePersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, id PersonalAccessTokenID) error
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
+// RefreshToken is the persisted state of the refresh token.
+// The refresh tokens which are rotated from the same token belong to the same family
+type RefreshToken struct {
+	TokenID        string
+	FamilyID       string
+	OrganizationID userD.OrganizationID
+	AppUserID      userD.AppUserID
+	Used           bool
+	Revoked        bool
+	ExpiresAt      time.Time
+	// FamilyExpiresAt is the expiry of the first token of the family. The rotated tokens don't outlive it
+	FamilyExpiresAt time.Time
+}
+
+type RefreshTokenRepository interface {
+	// AddRefreshToken saves the token. The expired tokens of the user are removed
+	AddRefreshToken(ctx context.Context, token *RefreshToken) error
+
+	FindRefreshToken(ctx context.Context, tokenID string) (*RefreshToken, error)
+
+	// UseRefreshToken marks the token as used. It returns false if the token has already been used
+	UseRefreshToken(ctx context.Context, tokenID string) (bool, error)
+
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeAppUserRefreshTokens revokes all the tokens of the user and returns the number of the revoked tokens
+	RevokeAppUserRefreshTokens(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error)
+}
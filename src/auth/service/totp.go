@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrTOTPNotFound = errors.New("TOTP not found")
+var ErrTOTPAlreadyEnabled = errors.New("TOTP already enabled")
+var ErrTOTPRequired = errors.New("TOTP required")
+var ErrInvalidTOTPCode = errors.New("invalid TOTP code")
+var ErrTOTPChallengeNotFound = errors.New("TOTP challenge not found")
+
+// TOTPCredential is the shared secret of the authenticator app. It can't be used until the user confirms it with a code
+type TOTPCredential struct {
+	OrganizationID userD.OrganizationID
+	AppUserID      userD.AppUserID
+	Secret         string
+	EnabledAt      *time.Time
+	// LastUsedStep is the time step of the last accepted code. The codes of the step and the former steps are rejected
+	LastUsedStep int64
+}
+
+func (c *TOTPCredential) IsEnabled() bool {
+	return c.EnabledAt != nil
+}
+
+// TOTPChallenge is issued when the password of the user who needs the second factor is verified. Only the hash of the token is stored
+type TOTPChallenge struct {
+	OrganizationID userD.OrganizationID
+	AppUserID      userD.AppUserID
+	HashedToken    string
+	ExpiresAt      time.Time
+}
+
+type TOTPRepository interface {
+	// SaveTOTPSecret replaces the secret which hasn't been confirmed. It returns ErrTOTPAlreadyEnabled if TOTP has been enabled
+	SaveTOTPSecret(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, secret string) error
+
+	FindTOTPCredential(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (*TOTPCredential, error)
+
+	// EnableTOTP enables TOTP and records the step of the code which confirmed the secret
+	EnableTOTP(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, step int64) error
+
+	ReplaceRecoveryCodes(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, hashedRecoveryCodes []string) error
+
+	// UseTOTPStep records the step of the accepted code. It returns ErrInvalidTOTPCode if the step has already been used
+	UseTOTPStep(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, step int64) error
+
+	// UseRecoveryCode removes the recovery code. It returns ErrInvalidTOTPCode if the code is unknown
+	UseRecoveryCode(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID, hashedCode string) error
+
+	CountRecoveryCodes(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) (int, error)
+
+	// RemoveTOTP removes the secret and the recovery codes
+	RemoveTOTP(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) error
+
+	// AddTOTPChallenge saves the challenge. The expired challenges are removed
+	AddTOTPChallenge(ctx context.Context, challenge *TOTPChallenge) error
+
+	FindTOTPChallenge(ctx context.Context, hashedToken string) (*TOTPChallenge, error)
+
+	// UseTOTPChallenge removes the challenge and returns it. It returns ErrTOTPChallengeNotFound if the challenge has expired
+	UseTOTPChallenge(ctx context.Context, hashedToken string) (*TOTPChallenge, error)
+}
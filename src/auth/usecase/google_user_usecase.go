@@ -2,15 +2,12 @@ package usecase
 
 import (
 	"context"
-	"errors"
 
 	"gorm.io/gorm"
 
 	"github.com/kujilabo/cocotola-api/src/auth/service"
-	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
-	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
 
 type GoogleUserUsecase interface {
@@ -18,21 +15,24 @@ type GoogleUserUsecase interface {
 
 	RetrieveUserInfo(ctx context.Context, GoogleAuthResponse *service.GoogleAuthResponse) (*service.GoogleUserInfo, error)
 
-	RegisterAppUser(ctx context.Context, googleUserInfo *service.GoogleUserInfo, googleAuthResponse *service.GoogleAuthResponse, organizationName string) (*service.TokenSet, error)
+	// RegisterAppUser returns the TOTP challenge instead of the token set if the user needs TOTP
+	RegisterAppUser(ctx context.Context, googleUserInfo *service.GoogleUserInfo, googleAuthResponse *service.GoogleAuthResponse, organizationName string) (*AuthResult, error)
 }
 
 type googleUserUsecase struct {
 	db                      *gorm.DB
 	googleAuthClient        service.GoogleAuthClient
 	authTokenManager        service.AuthTokenManager
+	totpUsecase             TOTPUsecase
 	registerAppUserCallback func(ctx context.Context, db *gorm.DB, organizationName string, appUser userD.AppUserModel) error
 }
 
-func NewGoogleUserUsecase(db *gorm.DB, googleAuthClient service.GoogleAuthClient, authTokenManager service.AuthTokenManager, registerAppUserCallback func(ctx context.Context, db *gorm.DB, organizationName string, appUser userD.AppUserModel) error) GoogleUserUsecase {
+func NewGoogleUserUsecase(db *gorm.DB, googleAuthClient service.GoogleAuthClient, authTokenManager service.AuthTokenManager, totpUsecase TOTPUsecase, registerAppUserCallback func(ctx context.Context, db *gorm.DB, organizationName string, appUser userD.AppUserModel) error) GoogleUserUsecase {
 	return &googleUserUsecase{
 		db:                      db,
 		googleAuthClient:        googleAuthClient,
 		authTokenManager:        authTokenManager,
+		totpUsecase:             totpUsecase,
 		registerAppUserCallback: registerAppUserCallback,
 	}
 }
@@ -45,85 +45,13 @@ func (s *googleUserUsecase) RetrieveUserInfo(ctx context.Context, googleAuthResp
 	return s.googleAuthClient.RetrieveUserInfo(ctx, googleAuthResponse)
 }
 
-func (s *googleUserUsecase) RegisterAppUser(ctx context.Context, googleUserInfo *service.GoogleUserInfo, googleAuthResponse *service.GoogleAuthResponse, organizationName string) (*service.TokenSet, error) {
+func (s *googleUserUsecase) RegisterAppUser(ctx context.Context, googleUserInfo *service.GoogleUserInfo, googleAuthResponse *service.GoogleAuthResponse, organizationName string) (*AuthResult, error) {
 	logger := log.FromContext(ctx)
-	var tokenSet *service.TokenSet
+	logger.Infof("googleuserIndo: %+v", googleUserInfo)
 
-	if err := s.db.Transaction(func(tx *gorm.DB) error {
-		systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
-		if err != nil {
-			return err
-		}
-
-		systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationName(ctx, organizationName)
-		if err != nil {
-			return liberrors.Errorf("failed to FindSystemOwnerByOrganizationName. err: %w", err)
-		}
-
-		organization, err := systemOwner.GetOrganization(ctx)
-		if err != nil {
-			return liberrors.Errorf("failed to FindOrganization. err: %w", err)
-		}
-
-		loginID := googleUserInfo.Email
-		logger.Infof("googleuserIndo: %+v", googleUserInfo)
-
-		appUser, err := systemOwner.FindAppUserByLoginID(ctx, loginID)
-		if err == nil {
-			logger.Infof("user already exists. student: %+v", appUser)
-			tokenSetTmp, err := s.authTokenManager.CreateTokenSet(ctx, appUser, organization)
-			if err != nil {
-				return err
-			}
-
-			tokenSet = tokenSetTmp
-			return nil
-		}
-
-		if !errors.Is(err, userS.ErrAppUserNotFound) {
-			logger.Infof("Unsupported %v", err)
-			return err
-		}
-
-		logger.Infof("Add student. %+v", appUser)
-		parameter, err := userS.NewAppUserAddParameter(
-			googleUserInfo.Email,
-			googleUserInfo.Name,
-			[]string{""},
-			map[string]string{
-				"password":             "----",
-				"provider":             "google",
-				"providerId":           googleUserInfo.Email,
-				"providerAccessToken":  googleAuthResponse.AccessToken,
-				"providerRefreshToken": googleAuthResponse.RefreshToken,
-			},
-		)
-		if err != nil {
-			return liberrors.Errorf("invalid AppUserAddParameter. err: %w", err)
-		}
-
-		studentID, err := systemOwner.AddAppUser(ctx, parameter)
-		if err != nil {
-			return liberrors.Errorf("failed to AddStudent. err: %w", err)
-		}
-
-		student2, err := systemOwner.FindAppUserByID(ctx, studentID)
-		if err != nil {
-			return liberrors.Errorf("failed to FindStudentByID. err: %w", err)
-		}
-
-		if err := s.registerAppUserCallback(ctx, tx, organizationName, student2); err != nil {
-			return liberrors.Errorf("failed to registerStudentCallback. err: %w", err)
-		}
-
-		tokenSetTmp, err := s.authTokenManager.CreateTokenSet(ctx, student2, organization)
-		if err != nil {
-			return err
-		}
-		tokenSet = tokenSetTmp
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-	return tokenSet, nil
+	return registerAppUser(ctx, s.db, s.authTokenManager, s.totpUsecase, s.registerAppUserCallback, organizationName, googleUserInfo.Email, googleUserInfo.Name, "google", googleUserInfo.Email, map[string]string{
+		"password":             "----",
+		"providerAccessToken":  googleAuthResponse.AccessToken,
+		"providerRefreshToken": googleAuthResponse.RefreshToken,
+	})
 }
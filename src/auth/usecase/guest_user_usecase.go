@@ -2,32 +2,80 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/kujilabo/cocotola-api/src/auth/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 	userS "github.com/kujilabo/cocotola-api/src/user/service"
 )
 
+const (
+	guestLoginIDPrefix   = "guest-"
+	guestLoginIDLength   = 16
+	guestUsername        = "Guest"
+	guestRemoveBatchSize = 100
+	guestProviderGoogle  = "google"
+)
+
+type GuestUserPasswordUpgradeParameter struct {
+	// the email can't be the login ID because it isn't verified. The email is registered by signing in with Google or the OIDC provider.
+	// '#' is the prefix of the login IDs of the removed users
+	LoginID  string `validate:"required,max=200,excludesall=@#"`
+	Username string `validate:"required,max=40"`
+	Password string `validate:"min=8,max=72"`
+}
+
 type GuestUserUsecase interface {
+	// RetrieveGuestToken adds the guest who expires after the TTL and returns the token set of the guest.
+	// It returns ErrGuestUserLimitExceeded if the organization already has the maximum number of the guests
 	RetrieveGuestToken(ctx context.Context, organizationName string) (*service.TokenSet, error)
+
+	// UpgradeWithPassword registers the login ID and the password of the guest. The workbooks and the recordbooks are kept
+	UpgradeWithPassword(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *GuestUserPasswordUpgradeParameter) (*service.TokenSet, error)
+
+	// UpgradeWithGoogle links the Google account to the guest. The workbooks and the recordbooks are kept
+	UpgradeWithGoogle(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) (*service.TokenSet, error)
+
+	// RemoveExpiredGuestUsers removes the expired guests, and returns the number of them
+	RemoveExpiredGuestUsers(ctx context.Context) (int, error)
 }
 
 type guestUserUsecase struct {
 	db               *gorm.DB
+	userRfFunc       userS.RepositoryFactoryFunc
+	googleAuthClient service.GoogleAuthClient
 	authTokenManager service.AuthTokenManager
+	guestTTL         time.Duration
+	guestMaxCount    int
 }
 
-func NewGuestUserUsecase(authTokenManager service.AuthTokenManager) GuestUserUsecase {
+func NewGuestUserUsecase(db *gorm.DB, userRfFunc userS.RepositoryFactoryFunc, googleAuthClient service.GoogleAuthClient, authTokenManager service.AuthTokenManager, guestTTL time.Duration, guestMaxCount int) GuestUserUsecase {
 	return &guestUserUsecase{
+		db:               db,
+		userRfFunc:       userRfFunc,
+		googleAuthClient: googleAuthClient,
 		authTokenManager: authTokenManager,
+		guestTTL:         guestTTL,
+		guestMaxCount:    guestMaxCount,
 	}
 }
 
 func (s *guestUserUsecase) RetrieveGuestToken(ctx context.Context, organizationName string) (*service.TokenSet, error) {
+	logger := log.FromContext(ctx)
+
+	loginID, err := newGuestLoginID()
+	if err != nil {
+		return nil, err
+	}
+
 	var tokenSet *service.TokenSet
 	if err := s.db.Transaction(func(tx *gorm.DB) error {
 		systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
@@ -40,26 +88,49 @@ func (s *guestUserUsecase) RetrieveGuestToken(ctx context.Context, organizationN
 			return liberrors.Errorf("failed to FindSystemOwnerByOrganizationName. err: %w", err)
 		}
 
-		// guest, err := systemOwner.FindAppUserByLoginID(ctx, "guest")
-		// if err != nil {
-		// 	return nil, fmt.Errorf("failed to FindAppUserByLoginID. err: %w", err)
-		// }
-
 		organization, err := systemOwner.GetOrganization(ctx)
 		if err != nil {
 			return liberrors.Errorf("failed to GetOrganization. err: %w", err)
 		}
 
-		model, err := userD.NewModel(0, 1, time.Now(), time.Now(), 0, 0)
+		userRf, err := s.userRfFunc(ctx, tx)
 		if err != nil {
-			return liberrors.Errorf("failed to FindAppUserByLoginID. err: %w", err)
+			return err
+		}
+		guestRepo := userRf.NewGuestUserRepository()
+
+		// the endpoint doesn't require the authentication, so the number of the guests is limited.
+		// The limit may be exceeded slightly by the concurrent requests
+		count, err := guestRepo.CountGuestUsers(ctx, systemOwner, time.Now())
+		if err != nil {
+			return liberrors.Errorf("failed to CountGuestUsers. err: %w", err)
+		}
+		if count >= s.guestMaxCount {
+			return liberrors.Errorf("max: %d, err: %w", s.guestMaxCount, userS.ErrGuestUserLimitExceeded)
+		}
+
+		parameter, err := userS.NewAppUserAddParameter(loginID, guestUsername, []string{userD.GuestRole.GetName()}, map[string]string{})
+		if err != nil {
+			return liberrors.Errorf("invalid AppUserAddParameter. err: %w", err)
+		}
+
+		// the personal space is added so that the guest can make the workbooks
+		guestID, err := systemOwner.AddAppUser(ctx, parameter)
+		if err != nil {
+			return liberrors.Errorf("failed to AddAppUser. err: %w", err)
+		}
+
+		if err := guestRepo.AddGuestUser(ctx, systemOwner, guestID, time.Now().Add(s.guestTTL)); err != nil {
+			return liberrors.Errorf("failed to AddGuestUser. err: %w", err)
 		}
 
-		guest, err := userD.NewAppUserModel(model, userD.OrganizationID(organization.GetID()), "guest", "Guest", []string{}, map[string]string{})
+		guest, err := systemOwner.FindAppUserByID(ctx, guestID)
 		if err != nil {
-			return liberrors.Errorf("failed to FindAppUserByLoginID. err: %w", err)
+			return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
 		}
 
+		logger.Infof("guest added. organizationID: %d, appUserID: %d", organization.GetID(), guestID)
+
 		tokenSetTmp, err := s.authTokenManager.CreateTokenSet(ctx, guest, organization)
 		if err != nil {
 			return err
@@ -72,3 +143,148 @@ func (s *guestUserUsecase) RetrieveGuestToken(ctx context.Context, organizationN
 	}
 	return tokenSet, nil
 }
+
+func (s *guestUserUsecase) UpgradeWithPassword(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *GuestUserPasswordUpgradeParameter) (*service.TokenSet, error) {
+	if err := libD.Validator.Struct(param); err != nil {
+		return nil, liberrors.Errorf("invalid GuestUserPasswordUpgradeParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+	}
+
+	return s.upgrade(ctx, organizationID, operatorID, &userS.GuestUserUpgradeParameter{
+		LoginID:  param.LoginID,
+		Username: param.Username,
+		Password: param.Password,
+	})
+}
+
+func (s *guestUserUsecase) UpgradeWithGoogle(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) (*service.TokenSet, error) {
+	googleAuthResponse, err := s.googleAuthClient.RetrieveAccessToken(ctx, code)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to RetrieveAccessToken. err: %v, %w", err, service.ErrInvalidAuthorizationCode)
+	}
+
+	googleUserInfo, err := s.googleAuthClient.RetrieveUserInfo(ctx, googleAuthResponse)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to RetrieveUserInfo. err: %v, %w", err, service.ErrInvalidAuthorizationCode)
+	}
+
+	// the same login ID as the users who sign in with Google at first
+	return s.upgrade(ctx, organizationID, operatorID, &userS.GuestUserUpgradeParameter{
+		LoginID:    googleUserInfo.Email,
+		Username:   googleUserInfo.Name,
+		Provider:   guestProviderGoogle,
+		ProviderID: googleUserInfo.Email,
+	})
+}
+
+func (s *guestUserUsecase) upgrade(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *userS.GuestUserUpgradeParameter) (*service.TokenSet, error) {
+	logger := log.FromContext(ctx)
+
+	var tokenSet *service.TokenSet
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+		}
+
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		guestRepo := userRf.NewGuestUserRepository()
+
+		guest, err := guestRepo.FindGuestUser(ctx, systemOwner, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindGuestUser. err: %w", err)
+		}
+		if guest.IsExpired(time.Now()) {
+			return liberrors.Errorf("expired. appUserID: %d, err: %w", operatorID, userS.ErrGuestUserNotFound)
+		}
+
+		if err := guestRepo.UpgradeGuestUser(ctx, systemOwner, operatorID, param); err != nil {
+			return liberrors.Errorf("failed to UpgradeGuestUser. err: %w", err)
+		}
+
+		appUser, err := systemOwner.FindAppUserByID(ctx, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+		}
+
+		organization, err := systemOwner.GetOrganization(ctx)
+		if err != nil {
+			return liberrors.Errorf("failed to GetOrganization. err: %w", err)
+		}
+
+		// the refresh tokens of the guest have the guest role
+		if _, err := s.authTokenManager.RevokeAllRefreshTokens(ctx, organizationID, operatorID); err != nil {
+			return liberrors.Errorf("failed to RevokeAllRefreshTokens. err: %w", err)
+		}
+
+		tokenSetTmp, err := s.authTokenManager.CreateTokenSet(ctx, appUser, organization)
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("guest upgraded. organizationID: %d, appUserID: %d, provider: %s", organizationID, operatorID, param.Provider)
+		tokenSet = tokenSetTmp
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return tokenSet, nil
+}
+
+func (s *guestUserUsecase) RemoveExpiredGuestUsers(ctx context.Context) (int, error) {
+	logger := log.FromContext(ctx)
+
+	systemAdmin, err := userS.NewSystemAdminFromDB(ctx, s.db)
+	if err != nil {
+		return 0, err
+	}
+
+	userRf, err := s.userRfFunc(ctx, s.db)
+	if err != nil {
+		return 0, err
+	}
+
+	guests, err := userRf.NewGuestUserRepository().FindExpiredGuestUsers(ctx, systemAdmin, time.Now(), guestRemoveBatchSize)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to FindExpiredGuestUsers. err: %w", err)
+	}
+
+	count := 0
+	for _, guest := range guests {
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			userRf, err := s.userRfFunc(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			return userRf.NewGuestUserRepository().RemoveGuestUser(ctx, systemAdmin, guest)
+		}); err != nil {
+			if errors.Is(err, userS.ErrGuestUserNotFound) {
+				continue
+			}
+			return count, liberrors.Errorf("failed to RemoveGuestUser. appUserID: %d, err: %w", guest.AppUserID, err)
+		}
+
+		if _, err := s.authTokenManager.RevokeAllRefreshTokens(ctx, guest.OrganizationID, guest.AppUserID); err != nil {
+			logger.Warnf("failed to RevokeAllRefreshTokens. appUserID: %d, err: %v", guest.AppUserID, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func newGuestLoginID() (string, error) {
+	b := make([]byte, guestLoginIDLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", liberrors.Errorf("failed to generate login ID. err: %w", err)
+	}
+	return guestLoginIDPrefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+)
+
+type OIDCUserUsecase interface {
+	// CreateAuthorizationURL starts the authorization code flow with PKCE and returns the URL of the provider
+	CreateAuthorizationURL(ctx context.Context, providerName, organizationName string) (string, error)
+
+	// Authorize completes the authorization code flow and returns the token set of the user, or the TOTP challenge if the user needs TOTP
+	Authorize(ctx context.Context, providerName, state, code string) (*AuthResult, error)
+}
+
+type oidcUserUsecase struct {
+	db                        *gorm.DB
+	oidcClients               map[string]service.OIDCClient
+	oidcAuthRequestRepository service.OIDCAuthRequestRepository
+	authTokenManager          service.AuthTokenManager
+	totpUsecase               TOTPUsecase
+	registerAppUserCallback   RegisterAppUserCallback
+	authRequestTTL            time.Duration
+}
+
+func NewOIDCUserUsecase(db *gorm.DB, oidcClients map[string]service.OIDCClient, oidcAuthRequestRepository service.OIDCAuthRequestRepository, authTokenManager service.AuthTokenManager, totpUsecase TOTPUsecase, registerAppUserCallback RegisterAppUserCallback, authRequestTTL time.Duration) OIDCUserUsecase {
+	return &oidcUserUsecase{
+		db:                        db,
+		oidcClients:               oidcClients,
+		oidcAuthRequestRepository: oidcAuthRequestRepository,
+		authTokenManager:          authTokenManager,
+		totpUsecase:               totpUsecase,
+		registerAppUserCallback:   registerAppUserCallback,
+		authRequestTTL:            authRequestTTL,
+	}
+}
+
+func (s *oidcUserUsecase) CreateAuthorizationURL(ctx context.Context, providerName, organizationName string) (string, error) {
+	oidcClient, ok := s.oidcClients[providerName]
+	if !ok {
+		return "", liberrors.Errorf("provider: %s, err: %w", providerName, service.ErrOIDCProviderNotFound)
+	}
+
+	if organizationName == "" {
+		return "", liberrors.Errorf("organizationName is required. err: %w", libD.ErrInvalidArgument)
+	}
+
+	state, err := newRandomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := newRandomString()
+	if err != nil {
+		return "", err
+	}
+	codeVerifier, err := newRandomString()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.oidcAuthRequestRepository.AddAuthRequest(ctx, &service.OIDCAuthRequest{
+		State:            state,
+		ProviderName:     providerName,
+		OrganizationName: organizationName,
+		Nonce:            nonce,
+		CodeVerifier:     codeVerifier,
+		ExpiresAt:        time.Now().Add(s.authRequestTTL),
+	}); err != nil {
+		return "", liberrors.Errorf("failed to AddAuthRequest. err: %w", err)
+	}
+
+	codeChallenge := sha256.Sum256([]byte(codeVerifier))
+	return oidcClient.AuthorizationURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(codeChallenge[:]))
+}
+
+func (s *oidcUserUsecase) Authorize(ctx context.Context, providerName, state, code string) (*AuthResult, error) {
+	logger := log.FromContext(ctx)
+
+	oidcClient, ok := s.oidcClients[providerName]
+	if !ok {
+		return nil, liberrors.Errorf("provider: %s, err: %w", providerName, service.ErrOIDCProviderNotFound)
+	}
+
+	authRequest, err := s.oidcAuthRequestRepository.UseAuthRequest(ctx, state)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to UseAuthRequest. err: %w", err)
+	}
+	if authRequest.ProviderName != providerName {
+		return nil, liberrors.Errorf("provider mismatch. provider: %s, err: %w", providerName, service.ErrOIDCAuthRequestNotFound)
+	}
+
+	userInfo, err := oidcClient.RetrieveUserInfo(ctx, code, authRequest.CodeVerifier, authRequest.Nonce)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to RetrieveUserInfo. err: %w", err)
+	}
+
+	// the email is the login id, so the unverified email would let the user take over the account of the other user
+	if userInfo.Email == "" || !userInfo.EmailVerified {
+		return nil, liberrors.Errorf("verified email not found. sub: %s, err: %w", userInfo.Subject, service.ErrInvalidIDToken)
+	}
+
+	logger.Infof("oidcUserInfo: %+v", userInfo)
+	username := userInfo.Name
+	if username == "" {
+		username = userInfo.Email
+	}
+
+	// the subject identifies the user in the provider even if the email is changed
+	return registerAppUser(ctx, s.db, s.authTokenManager, s.totpUsecase, s.registerAppUserCallback, authRequest.OrganizationName, userInfo.Email, username, "oidc:"+providerName, userInfo.Subject, map[string]string{
+		"password": "----",
+	})
+}
+
+func newRandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
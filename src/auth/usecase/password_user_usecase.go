@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/lib/passwordhelper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+const (
+	// bcrypt ignores the bytes after the 72nd byte
+	passwordMinLength = 8
+	passwordMaxLength = 72
+
+	passwordResetTokenLength = 32
+)
+
+type PasswordUserUsecase interface {
+	Authorize(ctx context.Context, organizationName, loginID, password string) (*AuthResult, error)
+
+	ChangePassword(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, currentPassword, newPassword string) error
+
+	// RequestPasswordReset issues the password reset token and notifies the user of it.
+	// It doesn't return any errors even if the user doesn't exist so that the existence of the user isn't revealed
+	RequestPasswordReset(ctx context.Context, organizationName, loginID string) error
+
+	ResetPassword(ctx context.Context, organizationName, token, newPassword string) error
+}
+
+type passwordUserUsecase struct {
+	db                    *gorm.DB
+	userRfFunc            userS.RepositoryFactoryFunc
+	authTokenManager      service.AuthTokenManager
+	totpUsecase           TOTPUsecase
+	passwordResetNotifier service.PasswordResetNotifier
+	maxLoginFailures      int
+	lockDuration          time.Duration
+	resetTokenTTL         time.Duration
+	// dummyHashedPassword is compared when the user doesn't exist so that the response time doesn't reveal the existence of the user
+	dummyHashedPassword string
+}
+
+type newPasswordParameter struct {
+	Password string `validate:"min=8,max=72"`
+}
+
+func NewPasswordUserUsecase(db *gorm.DB, userRfFunc userS.RepositoryFactoryFunc, authTokenManager service.AuthTokenManager, totpUsecase TOTPUsecase, passwordResetNotifier service.PasswordResetNotifier, maxLoginFailures int, lockDuration, resetTokenTTL time.Duration) (PasswordUserUsecase, error) {
+	dummyHashedPassword, err := passwordhelper.HashPassword("dummy-password")
+	if err != nil {
+		return nil, err
+	}
+
+	return &passwordUserUsecase{
+		db:                    db,
+		userRfFunc:            userRfFunc,
+		authTokenManager:      authTokenManager,
+		totpUsecase:           totpUsecase,
+		passwordResetNotifier: passwordResetNotifier,
+		maxLoginFailures:      maxLoginFailures,
+		lockDuration:          lockDuration,
+		resetTokenTTL:         resetTokenTTL,
+		dummyHashedPassword:   dummyHashedPassword,
+	}, nil
+}
+
+func (s *passwordUserUsecase) Authorize(ctx context.Context, organizationName, loginID, password string) (*AuthResult, error) {
+	logger := log.FromContext(ctx)
+	var appUser userD.AppUserModel
+	var organization userD.OrganizationModel
+	// the failure is returned after the transaction is committed so that the failure count is saved
+	var authErr error
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		systemOwner, passwordRepo, err := s.findSystemOwner(ctx, tx, organizationName)
+		if errors.Is(err, userS.ErrSystemOwnerNotFound) {
+			passwordhelper.ComparePasswords(s.dummyHashedPassword, password)
+			authErr = service.ErrInvalidCredential
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		credential, err := passwordRepo.FindPasswordCredential(ctx, systemOwner, loginID)
+		if errors.Is(err, userS.ErrAppUserNotFound) {
+			passwordhelper.ComparePasswords(s.dummyHashedPassword, password)
+			authErr = service.ErrInvalidCredential
+			return nil
+		} else if err != nil {
+			return liberrors.Errorf("failed to FindPasswordCredential. err: %w", err)
+		}
+
+		if credential.IsLocked(time.Now()) {
+			logger.Warnf("the user is locked. appUserID: %d", credential.AppUserID)
+			authErr = service.ErrAppUserLocked
+			return nil
+		}
+
+		if !s.verifyPassword(credential, password) {
+			if err := passwordRepo.RecordLoginFailure(ctx, systemOwner, credential.AppUserID, s.maxLoginFailures, s.lockDuration); err != nil {
+				return liberrors.Errorf("failed to RecordLoginFailure. err: %w", err)
+			}
+			authErr = service.ErrInvalidCredential
+			return nil
+		}
+
+		if credential.FailedLoginCount > 0 || credential.LockedUntil != nil {
+			if err := passwordRepo.ResetLoginFailure(ctx, systemOwner, credential.AppUserID); err != nil {
+				return liberrors.Errorf("failed to ResetLoginFailure. err: %w", err)
+			}
+		}
+
+		tmpAppUser, err := systemOwner.FindAppUserByID(ctx, credential.AppUserID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+		}
+
+		tmpOrganization, err := systemOwner.GetOrganization(ctx)
+		if err != nil {
+			return liberrors.Errorf("failed to GetOrganization. err: %w", err)
+		}
+
+		appUser = tmpAppUser
+		organization = tmpOrganization
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	return issueAuthResult(ctx, s.authTokenManager, s.totpUsecase, appUser, organization)
+}
+
+func (s *passwordUserUsecase) ChangePassword(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, currentPassword, newPassword string) error {
+	if err := validateNewPassword(newPassword); err != nil {
+		return err
+	}
+
+	var authErr error
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, organizationID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+		}
+
+		appUser, err := systemOwner.FindAppUserByID(ctx, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+		}
+
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		passwordRepo := userRf.NewAppUserPasswordRepository()
+
+		credential, err := passwordRepo.FindPasswordCredential(ctx, systemOwner, appUser.GetLoginID())
+		if err != nil {
+			return liberrors.Errorf("failed to FindPasswordCredential. err: %w", err)
+		}
+
+		if credential.IsLocked(time.Now()) {
+			authErr = service.ErrAppUserLocked
+			return nil
+		}
+
+		if !s.verifyPassword(credential, currentPassword) {
+			if err := passwordRepo.RecordLoginFailure(ctx, systemOwner, credential.AppUserID, s.maxLoginFailures, s.lockDuration); err != nil {
+				return liberrors.Errorf("failed to RecordLoginFailure. err: %w", err)
+			}
+			authErr = service.ErrInvalidCredential
+			return nil
+		}
+
+		if err := passwordRepo.UpdatePassword(ctx, systemOwner, credential.AppUserID, newPassword); err != nil {
+			return liberrors.Errorf("failed to UpdatePassword. err: %w", err)
+		}
+
+		return passwordRepo.ResetLoginFailure(ctx, systemOwner, credential.AppUserID)
+	}); err != nil {
+		return err
+	}
+
+	return authErr
+}
+
+func (s *passwordUserUsecase) RequestPasswordReset(ctx context.Context, organizationName, loginID string) error {
+	logger := log.FromContext(ctx)
+	var appUser userD.AppUserModel
+	var token string
+	var expiresAt time.Time
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		systemOwner, passwordRepo, err := s.findSystemOwner(ctx, tx, organizationName)
+		if errors.Is(err, userS.ErrSystemOwnerNotFound) {
+			logger.Infof("organization not found. organizationName: %s", organizationName)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		credential, err := passwordRepo.FindPasswordCredential(ctx, systemOwner, loginID)
+		if errors.Is(err, userS.ErrAppUserNotFound) {
+			logger.Infof("user not found")
+			return nil
+		} else if err != nil {
+			return liberrors.Errorf("failed to FindPasswordCredential. err: %w", err)
+		}
+
+		if credential.Provider != "" {
+			logger.Infof("the user signs in with the external identity provider. appUserID: %d", credential.AppUserID)
+			return nil
+		}
+
+		tmpToken, err := newPasswordResetToken()
+		if err != nil {
+			return err
+		}
+
+		tmpExpiresAt := time.Now().Add(s.resetTokenTTL)
+		if err := passwordRepo.AddPasswordResetToken(ctx, systemOwner, credential.AppUserID, hashPasswordResetToken(tmpToken), tmpExpiresAt); err != nil {
+			return liberrors.Errorf("failed to AddPasswordResetToken. err: %w", err)
+		}
+
+		tmpAppUser, err := systemOwner.FindAppUserByID(ctx, credential.AppUserID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+		}
+
+		appUser = tmpAppUser
+		token = tmpToken
+		expiresAt = tmpExpiresAt
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if appUser == nil {
+		return nil
+	}
+
+	if err := s.passwordResetNotifier.NotifyPasswordReset(ctx, appUser, token, expiresAt); err != nil {
+		return liberrors.Errorf("failed to NotifyPasswordReset. err: %w", err)
+	}
+	return nil
+}
+
+func (s *passwordUserUsecase) ResetPassword(ctx context.Context, organizationName, token, newPassword string) error {
+	if err := validateNewPassword(newPassword); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		systemOwner, passwordRepo, err := s.findSystemOwner(ctx, tx, organizationName)
+		if errors.Is(err, userS.ErrSystemOwnerNotFound) {
+			return service.ErrInvalidPasswordResetToken
+		} else if err != nil {
+			return err
+		}
+
+		appUserID, err := passwordRepo.UsePasswordResetToken(ctx, systemOwner, hashPasswordResetToken(token))
+		if errors.Is(err, userS.ErrPasswordResetTokenNotFound) {
+			return service.ErrInvalidPasswordResetToken
+		} else if err != nil {
+			return liberrors.Errorf("failed to UsePasswordResetToken. err: %w", err)
+		}
+
+		if err := passwordRepo.UpdatePassword(ctx, systemOwner, appUserID, newPassword); err != nil {
+			return liberrors.Errorf("failed to UpdatePassword. err: %w", err)
+		}
+
+		// the user who has been locked can sign in with the new password
+		return passwordRepo.ResetLoginFailure(ctx, systemOwner, appUserID)
+	})
+}
+
+func (s *passwordUserUsecase) findSystemOwner(ctx context.Context, tx *gorm.DB, organizationName string) (userS.SystemOwner, userS.AppUserPasswordRepository, error) {
+	systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationName(ctx, organizationName)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationName. err: %w", err)
+	}
+
+	userRf, err := s.userRfFunc(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return systemOwner, userRf.NewAppUserPasswordRepository(), nil
+}
+
+// verifyPassword returns false for the users of the external identity providers.
+// Their passwords are placeholders and too short to be accepted
+func (s *passwordUserUsecase) verifyPassword(credential *userS.PasswordCredential, password string) bool {
+	if credential.Provider != "" || credential.HashedPassword == "" || len(password) < passwordMinLength || len(password) > passwordMaxLength {
+		passwordhelper.ComparePasswords(s.dummyHashedPassword, password)
+		return false
+	}
+
+	return passwordhelper.ComparePasswords(credential.HashedPassword, password)
+}
+
+func validateNewPassword(password string) error {
+	if err := libD.Validator.Struct(&newPasswordParameter{Password: password}); err != nil {
+		return liberrors.Errorf("the password must be between %d and %d characters. %w", passwordMinLength, passwordMaxLength, libD.ErrInvalidArgument)
+	}
+	return nil
+}
+
+func newPasswordResetToken() (string, error) {
+	b := make([]byte, passwordResetTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", liberrors.Errorf("failed to generate token. err: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashPasswordResetToken hashes the token so that the tokens in the database can't be used
+func hashPasswordResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+const (
+	// PersonalAccessTokenPrefix makes the tokens distinguishable from the JWTs and easy to find by secret scanners
+	PersonalAccessTokenPrefix        = "cct_"
+	personalAccessTokenLength        = 32
+	personalAccessTokenDisplayLength = 12
+	personalAccessTokenMaxCount      = 20
+)
+
+type PersonalAccessTokenAddParameter struct {
+	Name          string                           `validate:"required,max=40"`
+	Scope         service.PersonalAccessTokenScope `validate:"oneof=read write"`
+	WorkbookIDs   []uint                           `validate:"max=100,dive,gte=1"`
+	ExpiresInDays int                              `validate:"gte=0,lte=365"`
+}
+
+// PersonalAccessTokenAuthResult is the user who is authenticated with the personal access token
+type PersonalAccessTokenAuthResult struct {
+	OrganizationID userD.OrganizationID
+	AppUserID      userD.AppUserID
+	Role           string
+	Scope          service.PersonalAccessTokenScope
+	WorkbookIDs    []uint
+}
+
+type PersonalAccessTokenUsecase interface {
+	// AddPersonalAccessToken returns the token. The token can't be retrieved later
+	AddPersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *PersonalAccessTokenAddParameter) (*service.PersonalAccessToken, string, error)
+
+	FindPersonalAccessTokens(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) ([]*service.PersonalAccessToken, error)
+
+	RemovePersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, id service.PersonalAccessTokenID) error
+
+	// Authenticate returns ErrPersonalAccessTokenNotFound if the token is unknown or has expired
+	Authenticate(ctx context.Context, token string) (*PersonalAccessTokenAuthResult, error)
+}
+
+type PersonalAccessTokenRepositoryFunc func(ctx context.Context, db *gorm.DB) (service.PersonalAccessTokenRepository, error)
+
+type personalAccessTokenUsecase struct {
+	db       *gorm.DB
+	repoFunc PersonalAccessTokenRepositoryFunc
+}
+
+func NewPersonalAccessTokenUsecase(db *gorm.DB, repoFunc PersonalAccessTokenRepositoryFunc) PersonalAccessTokenUsecase {
+	return &personalAccessTokenUsecase{
+		db:       db,
+		repoFunc: repoFunc,
+	}
+}
+
+func (s *personalAccessTokenUsecase) AddPersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *PersonalAccessTokenAddParameter) (*service.PersonalAccessToken, string, error) {
+	if err := libD.Validator.Struct(param); err != nil {
+		return nil, "", liberrors.Errorf("invalid PersonalAccessTokenAddParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+	}
+
+	token, err := newPersonalAccessToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var expiresAt *time.Time
+	if param.ExpiresInDays > 0 {
+		tmp := time.Now().AddDate(0, 0, param.ExpiresInDays)
+		expiresAt = &tmp
+	}
+
+	var personalAccessToken *service.PersonalAccessToken
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		count, err := repo.CountPersonalAccessTokens(ctx, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to CountPersonalAccessTokens. err: %w", err)
+		}
+		if count >= personalAccessTokenMaxCount {
+			return liberrors.Errorf("max: %d, err: %w", personalAccessTokenMaxCount, service.ErrPersonalAccessTokenLimitExceeded)
+		}
+
+		id, err := repo.AddPersonalAccessToken(ctx, organizationID, operatorID, &service.PersonalAccessTokenAddParameter{
+			Name:        param.Name,
+			TokenPrefix: token[:personalAccessTokenDisplayLength],
+			HashedToken: hashPersonalAccessToken(token),
+			Scope:       param.Scope,
+			WorkbookIDs: uniqueWorkbookIDs(param.WorkbookIDs),
+			ExpiresAt:   expiresAt,
+		})
+		if err != nil {
+			return liberrors.Errorf("failed to AddPersonalAccessToken. err: %w", err)
+		}
+
+		tokens, err := repo.FindPersonalAccessTokens(ctx, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindPersonalAccessTokens. err: %w", err)
+		}
+		for _, t := range tokens {
+			if t.ID == id {
+				personalAccessToken = t
+			}
+		}
+		if personalAccessToken == nil {
+			return service.ErrPersonalAccessTokenNotFound
+		}
+
+		return nil
+	}); err != nil {
+		return nil, "", err
+	}
+
+	return personalAccessToken, token, nil
+}
+
+func (s *personalAccessTokenUsecase) FindPersonalAccessTokens(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) ([]*service.PersonalAccessToken, error) {
+	repo, err := s.repoFunc(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.FindPersonalAccessTokens(ctx, organizationID, operatorID)
+}
+
+func (s *personalAccessTokenUsecase) RemovePersonalAccessToken(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, id service.PersonalAccessTokenID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		return repo.RemovePersonalAccessToken(ctx, organizationID, operatorID, id)
+	})
+}
+
+func (s *personalAccessTokenUsecase) Authenticate(ctx context.Context, token string) (*PersonalAccessTokenAuthResult, error) {
+	logger := log.FromContext(ctx)
+
+	if !strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+		return nil, service.ErrPersonalAccessTokenNotFound
+	}
+
+	repo, err := s.repoFunc(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	personalAccessToken, err := repo.FindPersonalAccessTokenByHashedToken(ctx, hashPersonalAccessToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if personalAccessToken.IsExpired(now) {
+		return nil, liberrors.Errorf("expired. id: %d, err: %w", personalAccessToken.ID, service.ErrPersonalAccessTokenNotFound)
+	}
+
+	// the role isn't stored in the token so that the change of the role takes effect immediately
+	systemAdmin, err := userS.NewSystemAdminFromDB(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, personalAccessToken.OrganizationID)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+	}
+
+	appUser, err := systemOwner.FindAppUserByID(ctx, personalAccessToken.AppUserID)
+	if err != nil {
+		if errors.Is(err, userS.ErrAppUserNotFound) {
+			return nil, liberrors.Errorf("app user not found. id: %d, err: %w", personalAccessToken.ID, service.ErrPersonalAccessTokenNotFound)
+		}
+		return nil, liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+	}
+
+	if err := repo.UpdateLastUsedAt(ctx, personalAccessToken.ID, now); err != nil {
+		// the request can be processed without the last used time
+		logger.Warnf("failed to UpdateLastUsedAt. id: %d, err: %v", personalAccessToken.ID, err)
+	}
+
+	role := ""
+	if len(appUser.GetRoles()) > 0 {
+		role = appUser.GetRoles()[0]
+	}
+
+	return &PersonalAccessTokenAuthResult{
+		OrganizationID: personalAccessToken.OrganizationID,
+		AppUserID:      personalAccessToken.AppUserID,
+		Role:           role,
+		Scope:          personalAccessToken.Scope,
+		WorkbookIDs:    personalAccessToken.WorkbookIDs,
+	}, nil
+}
+
+func newPersonalAccessToken() (string, error) {
+	b := make([]byte, personalAccessTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", liberrors.Errorf("failed to generate token. err: %w", err)
+	}
+	return PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashPersonalAccessToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func uniqueWorkbookIDs(workbookIDs []uint) []uint {
+	found := map[uint]bool{}
+	unique := make([]uint, 0, len(workbookIDs))
+	for _, workbookID := range workbookIDs {
+		if !found[workbookID] {
+			found[workbookID] = true
+			unique = append(unique, workbookID)
+		}
+	}
+	return unique
+}
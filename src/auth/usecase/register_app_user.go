@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type RegisterAppUserCallback func(ctx context.Context, db *gorm.DB, organizationName string, appUser userD.AppUserModel) error
+
+// AuthResult has the TOTP challenge instead of the token set when the user needs the second factor
+type AuthResult struct {
+	TokenSet      *service.TokenSet
+	TOTPChallenge *TOTPChallengeResult
+}
+
+// issueAuthResult is the only way to issue the token set when the user signs in, so that no sign-in method skips TOTP
+func issueAuthResult(ctx context.Context, authTokenManager service.AuthTokenManager, totpUsecase TOTPUsecase, appUser userD.AppUserModel, organization userD.OrganizationModel) (*AuthResult, error) {
+	totpChallenge, err := totpUsecase.IssueChallenge(ctx, appUser)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to IssueChallenge. err: %w", err)
+	}
+	if totpChallenge != nil {
+		return &AuthResult{TOTPChallenge: totpChallenge}, nil
+	}
+
+	tokenSet, err := authTokenManager.CreateTokenSet(ctx, appUser, organization)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthResult{TokenSet: tokenSet}, nil
+}
+
+// registerAppUser signs in the user who has signed in with the external provider.
+// The user is added and registerAppUserCallback is called if the user doesn't exist.
+// The existing user is signed in only if the user has been added by the same provider with the same provider ID.
+// The auth result is issued after the transaction is committed because the TOTP challenge is saved outside of the transaction
+func registerAppUser(ctx context.Context, db *gorm.DB, authTokenManager service.AuthTokenManager, totpUsecase TOTPUsecase, registerAppUserCallback RegisterAppUserCallback, organizationName, loginID, username, provider, providerID string, properties map[string]string) (*AuthResult, error) {
+	var appUser userD.AppUserModel
+	var organization userD.OrganizationModel
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		appUserTmp, organizationTmp, err := findOrAddAppUser(ctx, tx, registerAppUserCallback, organizationName, loginID, username, provider, providerID, properties)
+		if err != nil {
+			return err
+		}
+
+		appUser = appUserTmp
+		organization = organizationTmp
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return issueAuthResult(ctx, authTokenManager, totpUsecase, appUser, organization)
+}
+
+func findOrAddAppUser(ctx context.Context, tx *gorm.DB, registerAppUserCallback RegisterAppUserCallback, organizationName, loginID, username, provider, providerID string, properties map[string]string) (userD.AppUserModel, userD.OrganizationModel, error) {
+	logger := log.FromContext(ctx)
+
+	systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationName(ctx, organizationName)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationName. err: %w", err)
+	}
+
+	organization, err := systemOwner.GetOrganization(ctx)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindOrganization. err: %w", err)
+	}
+
+	appUser, err := systemOwner.FindAppUserByLoginID(ctx, loginID)
+	if err == nil {
+		// the email which is verified by the provider doesn't prove the ownership of the account which is added by the other provider or the password
+		if appUser.GetProperties()["provider"] != provider || appUser.GetProperties()["providerId"] != providerID {
+			return nil, nil, liberrors.Errorf("loginID: %s, provider: %s, err: %w", loginID, provider, service.ErrAppUserProviderMismatch)
+		}
+
+		logger.Infof("user already exists. student: %+v", appUser)
+		return appUser, organization, nil
+	}
+
+	if errors.Is(err, userS.ErrAppUserDisabled) || !errors.Is(err, userS.ErrAppUserNotFound) {
+		logger.Infof("Unsupported %v", err)
+		return nil, nil, err
+	}
+
+	properties["provider"] = provider
+	properties["providerId"] = providerID
+
+	logger.Infof("Add student. %+v", appUser)
+	parameter, err := userS.NewAppUserAddParameter(loginID, username, []string{""}, properties)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("invalid AppUserAddParameter. err: %w", err)
+	}
+
+	studentID, err := systemOwner.AddAppUser(ctx, parameter)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to AddStudent. err: %w", err)
+	}
+
+	student2, err := systemOwner.FindAppUserByID(ctx, studentID)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindStudentByID. err: %w", err)
+	}
+
+	if err := registerAppUserCallback(ctx, tx, organizationName, student2); err != nil {
+		return nil, nil, liberrors.Errorf("failed to registerStudentCallback. err: %w", err)
+	}
+
+	return student2, organization, nil
+}
@@ -0,0 +1,569 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/auth/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/lib/totphelper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+const (
+	totpChallengeTokenLength = 32
+	recoveryCodeCount        = 10
+	recoveryCodeLength       = 5
+)
+
+// TOTPEnrollment is the secret which the user registers with the authenticator app
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type TOTPStatus struct {
+	Enabled bool
+	// Required is true if the role of the user needs TOTP
+	Required               bool
+	RemainingRecoveryCodes int
+}
+
+// TOTPChallengeResult is returned instead of the token set when the user needs to enter the TOTP code.
+// EnrollmentRequired is true if the role of the user needs TOTP but the user hasn't enabled it
+type TOTPChallengeResult struct {
+	Token              string
+	EnrollmentRequired bool
+	ExpiresAt          time.Time
+}
+
+// TOTPVerifyResult has the recovery codes only when TOTP is enabled by the verification
+type TOTPVerifyResult struct {
+	TokenSet      *service.TokenSet
+	RecoveryCodes []string
+}
+
+type TOTPUsecase interface {
+	FindTOTPStatus(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (*TOTPStatus, error)
+
+	// StartEnrollment generates the secret. TOTP isn't enabled until the user confirms it with a code
+	StartEnrollment(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (*TOTPEnrollment, error)
+
+	// ConfirmEnrollment enables TOTP and returns the recovery codes. They can't be retrieved later
+	ConfirmEnrollment(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) ([]string, error)
+
+	// RegenerateRecoveryCodes replaces the recovery codes
+	RegenerateRecoveryCodes(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) ([]string, error)
+
+	// Disable returns ErrTOTPRequired if the role of the user needs TOTP
+	Disable(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) error
+
+	// IssueChallenge returns nil if the user doesn't need TOTP
+	IssueChallenge(ctx context.Context, appUser userD.AppUserModel) (*TOTPChallengeResult, error)
+
+	// StartEnrollmentWithChallenge generates the secret for the user who has to enable TOTP to sign in
+	StartEnrollmentWithChallenge(ctx context.Context, challengeToken string) (*TOTPEnrollment, error)
+
+	// VerifyChallenge verifies the TOTP code or the recovery code and returns the token set.
+	// The failures are counted in the same way as the password failures
+	VerifyChallenge(ctx context.Context, challengeToken, code, recoveryCode string) (*TOTPVerifyResult, error)
+}
+
+type TOTPRepositoryFunc func(ctx context.Context, db *gorm.DB) (service.TOTPRepository, error)
+
+type totpUsecase struct {
+	db               *gorm.DB
+	userRfFunc       userS.RepositoryFactoryFunc
+	repoFunc         TOTPRepositoryFunc
+	authTokenManager service.AuthTokenManager
+	issuer           string
+	requiredRoles    map[string]bool
+	challengeTTL     time.Duration
+	maxLoginFailures int
+	lockDuration     time.Duration
+}
+
+func NewTOTPUsecase(db *gorm.DB, userRfFunc userS.RepositoryFactoryFunc, repoFunc TOTPRepositoryFunc, authTokenManager service.AuthTokenManager, issuer string, requiredRoles []string, challengeTTL time.Duration, maxLoginFailures int, lockDuration time.Duration) TOTPUsecase {
+	requiredRoleMap := make(map[string]bool)
+	for _, role := range requiredRoles {
+		requiredRoleMap[role] = true
+	}
+
+	return &totpUsecase{
+		db:               db,
+		userRfFunc:       userRfFunc,
+		repoFunc:         repoFunc,
+		authTokenManager: authTokenManager,
+		issuer:           issuer,
+		requiredRoles:    requiredRoleMap,
+		challengeTTL:     challengeTTL,
+		maxLoginFailures: maxLoginFailures,
+		lockDuration:     lockDuration,
+	}
+}
+
+func (s *totpUsecase) FindTOTPStatus(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (*TOTPStatus, error) {
+	var status *TOTPStatus
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, appUser, err := s.findAppUser(ctx, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		credential, err := repo.FindTOTPCredential(ctx, organizationID, operatorID)
+		if errors.Is(err, service.ErrTOTPNotFound) {
+			status = &TOTPStatus{Required: s.isRequired(appUser)}
+			return nil
+		} else if err != nil {
+			return liberrors.Errorf("failed to FindTOTPCredential. err: %w", err)
+		}
+
+		count, err := repo.CountRecoveryCodes(ctx, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to CountRecoveryCodes. err: %w", err)
+		}
+
+		status = &TOTPStatus{
+			Enabled:                credential.IsEnabled(),
+			Required:               s.isRequired(appUser),
+			RemainingRecoveryCodes: count,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (s *totpUsecase) StartEnrollment(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID) (*TOTPEnrollment, error) {
+	var enrollment *TOTPEnrollment
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, appUser, err := s.findAppUser(ctx, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpEnrollment, err := s.startEnrollment(ctx, tx, appUser)
+		if err != nil {
+			return err
+		}
+
+		enrollment = tmpEnrollment
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+func (s *totpUsecase) ConfirmEnrollment(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) ([]string, error) {
+	var recoveryCodes []string
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		tmpRecoveryCodes, err := s.confirmEnrollment(ctx, repo, organizationID, operatorID, code)
+		if err != nil {
+			return err
+		}
+
+		recoveryCodes = tmpRecoveryCodes
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+func (s *totpUsecase) RegenerateRecoveryCodes(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) ([]string, error) {
+	var recoveryCodes []string
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := s.verifyCode(ctx, repo, organizationID, operatorID, code); err != nil {
+			return err
+		}
+
+		tmpRecoveryCodes, err := s.replaceRecoveryCodes(ctx, repo, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		recoveryCodes = tmpRecoveryCodes
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+func (s *totpUsecase) Disable(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, code string) error {
+	logger := log.FromContext(ctx)
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		_, appUser, err := s.findAppUser(ctx, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		if s.isRequired(appUser) {
+			return liberrors.Errorf("role: %v, err: %w", appUser.GetRoles(), service.ErrTOTPRequired)
+		}
+
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if err := s.verifyCode(ctx, repo, organizationID, operatorID, code); err != nil {
+			return err
+		}
+
+		if err := repo.RemoveTOTP(ctx, organizationID, operatorID); err != nil {
+			return liberrors.Errorf("failed to RemoveTOTP. err: %w", err)
+		}
+
+		logger.Infof("TOTP disabled. appUserID: %d", operatorID)
+		return nil
+	})
+}
+
+func (s *totpUsecase) IssueChallenge(ctx context.Context, appUser userD.AppUserModel) (*TOTPChallengeResult, error) {
+	organizationID := appUser.GetOrganizationID()
+	appUserID := userD.AppUserID(appUser.GetID())
+
+	repo, err := s.repoFunc(ctx, s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := false
+	credential, err := repo.FindTOTPCredential(ctx, organizationID, appUserID)
+	if err == nil {
+		enabled = credential.IsEnabled()
+	} else if !errors.Is(err, service.ErrTOTPNotFound) {
+		return nil, liberrors.Errorf("failed to FindTOTPCredential. err: %w", err)
+	}
+
+	if !enabled && !s.isRequired(appUser) {
+		return nil, nil
+	}
+
+	token, err := newTOTPChallengeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.challengeTTL)
+	if err := repo.AddTOTPChallenge(ctx, &service.TOTPChallenge{
+		OrganizationID: organizationID,
+		AppUserID:      appUserID,
+		HashedToken:    hashTOTPValue(token),
+		ExpiresAt:      expiresAt,
+	}); err != nil {
+		return nil, liberrors.Errorf("failed to AddTOTPChallenge. err: %w", err)
+	}
+
+	return &TOTPChallengeResult{
+		Token:              token,
+		EnrollmentRequired: !enabled,
+		ExpiresAt:          expiresAt,
+	}, nil
+}
+
+func (s *totpUsecase) StartEnrollmentWithChallenge(ctx context.Context, challengeToken string) (*TOTPEnrollment, error) {
+	var enrollment *TOTPEnrollment
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		challenge, err := repo.FindTOTPChallenge(ctx, hashTOTPValue(challengeToken))
+		if err != nil {
+			return liberrors.Errorf("failed to FindTOTPChallenge. err: %w", err)
+		}
+
+		_, appUser, err := s.findAppUser(ctx, tx, challenge.OrganizationID, challenge.AppUserID)
+		if err != nil {
+			return err
+		}
+
+		tmpEnrollment, err := s.startEnrollment(ctx, tx, appUser)
+		if err != nil {
+			return err
+		}
+
+		enrollment = tmpEnrollment
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return enrollment, nil
+}
+
+func (s *totpUsecase) VerifyChallenge(ctx context.Context, challengeToken, code, recoveryCode string) (*TOTPVerifyResult, error) {
+	logger := log.FromContext(ctx)
+	var appUser userD.AppUserModel
+	var organization userD.OrganizationModel
+	var recoveryCodes []string
+	// the failure is returned after the transaction is committed so that the failure count is saved
+	var authErr error
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		repo, err := s.repoFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		challenge, err := repo.FindTOTPChallenge(ctx, hashTOTPValue(challengeToken))
+		if err != nil {
+			return liberrors.Errorf("failed to FindTOTPChallenge. err: %w", err)
+		}
+		organizationID := challenge.OrganizationID
+		appUserID := challenge.AppUserID
+
+		systemOwner, tmpAppUser, err := s.findAppUser(ctx, tx, organizationID, appUserID)
+		if err != nil {
+			return err
+		}
+
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		passwordRepo := userRf.NewAppUserPasswordRepository()
+
+		passwordCredential, err := passwordRepo.FindPasswordCredential(ctx, systemOwner, tmpAppUser.GetLoginID())
+		if err != nil {
+			return liberrors.Errorf("failed to FindPasswordCredential. err: %w", err)
+		}
+		if passwordCredential.IsLocked(time.Now()) {
+			logger.Warnf("the user is locked. appUserID: %d", appUserID)
+			authErr = service.ErrAppUserLocked
+			return nil
+		}
+
+		credential, err := repo.FindTOTPCredential(ctx, organizationID, appUserID)
+		if err != nil && !errors.Is(err, service.ErrTOTPNotFound) {
+			return liberrors.Errorf("failed to FindTOTPCredential. err: %w", err)
+		}
+
+		var verifyErr error
+		switch {
+		case credential != nil && !credential.IsEnabled() && code != "":
+			// the user who has to enable TOTP confirms the secret at the same time
+			recoveryCodes, verifyErr = s.confirmEnrollment(ctx, repo, organizationID, appUserID, code)
+		case credential != nil && credential.IsEnabled() && code != "":
+			verifyErr = s.verifyCode(ctx, repo, organizationID, appUserID, code)
+		case credential != nil && credential.IsEnabled() && recoveryCode != "":
+			verifyErr = repo.UseRecoveryCode(ctx, organizationID, appUserID, hashTOTPValue(normalizeRecoveryCode(recoveryCode)))
+			if verifyErr == nil {
+				logger.Infof("recovery code used. appUserID: %d", appUserID)
+			}
+		default:
+			verifyErr = service.ErrInvalidTOTPCode
+		}
+		if errors.Is(verifyErr, service.ErrInvalidTOTPCode) {
+			if err := passwordRepo.RecordLoginFailure(ctx, systemOwner, appUserID, s.maxLoginFailures, s.lockDuration); err != nil {
+				return liberrors.Errorf("failed to RecordLoginFailure. err: %w", err)
+			}
+			authErr = verifyErr
+			return nil
+		} else if verifyErr != nil {
+			return verifyErr
+		}
+
+		if _, err := repo.UseTOTPChallenge(ctx, challenge.HashedToken); err != nil {
+			return liberrors.Errorf("failed to UseTOTPChallenge. err: %w", err)
+		}
+
+		tmpOrganization, err := systemOwner.GetOrganization(ctx)
+		if err != nil {
+			return liberrors.Errorf("failed to GetOrganization. err: %w", err)
+		}
+
+		appUser = tmpAppUser
+		organization = tmpOrganization
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if authErr != nil {
+		return nil, authErr
+	}
+
+	tokenSet, err := s.authTokenManager.CreateTokenSet(ctx, appUser, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TOTPVerifyResult{
+		TokenSet:      tokenSet,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *totpUsecase) startEnrollment(ctx context.Context, tx *gorm.DB, appUser userD.AppUserModel) (*TOTPEnrollment, error) {
+	secret, err := totphelper.GenerateSecret()
+	if err != nil {
+		return nil, liberrors.Errorf("failed to GenerateSecret. err: %w", err)
+	}
+
+	repo, err := s.repoFunc(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.SaveTOTPSecret(ctx, appUser.GetOrganizationID(), userD.AppUserID(appUser.GetID()), secret); err != nil {
+		return nil, liberrors.Errorf("failed to SaveTOTPSecret. err: %w", err)
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totphelper.ProvisioningURI(s.issuer, appUser.GetLoginID(), secret),
+	}, nil
+}
+
+func (s *totpUsecase) confirmEnrollment(ctx context.Context, repo service.TOTPRepository, organizationID userD.OrganizationID, appUserID userD.AppUserID, code string) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	credential, err := repo.FindTOTPCredential(ctx, organizationID, appUserID)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindTOTPCredential. err: %w", err)
+	}
+	if credential.IsEnabled() {
+		return nil, service.ErrTOTPAlreadyEnabled
+	}
+
+	step, ok := totphelper.Verify(credential.Secret, code, time.Now())
+	if !ok {
+		return nil, service.ErrInvalidTOTPCode
+	}
+
+	if err := repo.EnableTOTP(ctx, organizationID, appUserID, step); err != nil {
+		return nil, liberrors.Errorf("failed to EnableTOTP. err: %w", err)
+	}
+
+	recoveryCodes, err := s.replaceRecoveryCodes(ctx, repo, organizationID, appUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("TOTP enabled. appUserID: %d", appUserID)
+	return recoveryCodes, nil
+}
+
+// verifyCode verifies the code of the enabled TOTP. The code can't be used twice
+func (s *totpUsecase) verifyCode(ctx context.Context, repo service.TOTPRepository, organizationID userD.OrganizationID, appUserID userD.AppUserID, code string) error {
+	credential, err := repo.FindTOTPCredential(ctx, organizationID, appUserID)
+	if err != nil {
+		return liberrors.Errorf("failed to FindTOTPCredential. err: %w", err)
+	}
+	if !credential.IsEnabled() {
+		return service.ErrTOTPNotFound
+	}
+
+	step, ok := totphelper.Verify(credential.Secret, code, time.Now())
+	if !ok || step <= credential.LastUsedStep {
+		return service.ErrInvalidTOTPCode
+	}
+
+	return repo.UseTOTPStep(ctx, organizationID, appUserID, step)
+}
+
+func (s *totpUsecase) replaceRecoveryCodes(ctx context.Context, repo service.TOTPRepository, organizationID userD.OrganizationID, appUserID userD.AppUserID) ([]string, error) {
+	recoveryCodes := make([]string, recoveryCodeCount)
+	hashedRecoveryCodes := make([]string, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		recoveryCode, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		recoveryCodes[i] = recoveryCode
+		hashedRecoveryCodes[i] = hashTOTPValue(normalizeRecoveryCode(recoveryCode))
+	}
+
+	if err := repo.ReplaceRecoveryCodes(ctx, organizationID, appUserID, hashedRecoveryCodes); err != nil {
+		return nil, liberrors.Errorf("failed to ReplaceRecoveryCodes. err: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *totpUsecase) findAppUser(ctx context.Context, tx *gorm.DB, organizationID userD.OrganizationID, appUserID userD.AppUserID) (userS.SystemOwner, userS.AppUser, error) {
+	systemAdmin, err := userS.NewSystemAdminFromDB(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+	}
+
+	appUser, err := systemOwner.FindAppUserByID(ctx, appUserID)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+	}
+
+	return systemOwner, appUser, nil
+}
+
+func (s *totpUsecase) isRequired(appUser userD.AppUserModel) bool {
+	for _, role := range appUser.GetRoles() {
+		if s.requiredRoles[role] {
+			return true
+		}
+	}
+	return false
+}
+
+func newTOTPChallengeToken() (string, error) {
+	b := make([]byte, totpChallengeTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", liberrors.Errorf("failed to generate token. err: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// newRecoveryCode returns the code such as "0a1b2-c3d4e"
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", liberrors.Errorf("failed to generate recovery code. err: %w", err)
+	}
+	code := hex.EncodeToString(b)
+	return code[:recoveryCodeLength] + "-" + code[recoveryCodeLength:], nil
+}
+
+func normalizeRecoveryCode(recoveryCode string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(recoveryCode))
+}
+
+// hashTOTPValue hashes the challenge tokens and the recovery codes so that the values in the database can't be used
+func hashTOTPValue(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,57 @@
+package totphelper_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kujilabo/cocotola-api/src/lib/totphelper"
+)
+
+func Test_GenerateCode(t *testing.T) {
+	// the test vectors of RFC 6238 truncated to 6 digits
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+	for _, tt := range tests {
+		code, err := totphelper.GenerateCode(secret, totphelper.Step(time.Unix(tt.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code)
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	secret, err := totphelper.GenerateSecret()
+	require.NoError(t, err)
+	now := time.Now()
+	code, err := totphelper.GenerateCode(secret, totphelper.Step(now))
+	require.NoError(t, err)
+
+	step, ok := totphelper.Verify(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, totphelper.Step(now), step)
+
+	// the code is accepted 30 seconds later because of the clock drift
+	_, ok = totphelper.Verify(secret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+
+	_, ok = totphelper.Verify(secret, code, now.Add(90*time.Second))
+	assert.False(t, ok)
+	_, ok = totphelper.Verify(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func Test_ProvisioningURI(t *testing.T) {
+	uri := totphelper.ProvisioningURI("cocotola", "cocotola-owner", "SECRET")
+	assert.Equal(t, "otpauth://totp/cocotola:cocotola-owner?algorithm=SHA1&digits=6&issuer=cocotola&period=30&secret=SECRET", uri)
+}
@@ -15,7 +15,6 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -38,6 +37,7 @@ import (
 	appS "github.com/kujilabo/cocotola-api/src/app/service"
 	studentU "github.com/kujilabo/cocotola-api/src/app/usecase/student"
 	authG "github.com/kujilabo/cocotola-api/src/auth/gateway"
+	authS "github.com/kujilabo/cocotola-api/src/auth/service"
 	authU "github.com/kujilabo/cocotola-api/src/auth/usecase"
 	english_sentence "github.com/kujilabo/cocotola-api/src/data/english_sentence"
 	english_word "github.com/kujilabo/cocotola-api/src/data/english_word"
@@ -45,12 +45,15 @@ import (
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	pluginCommonGateway "github.com/kujilabo/cocotola-api/src/plugin/common/gateway"
 	pluginCommonS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	pluginCommonU "github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
 	pluginEnglishDomain "github.com/kujilabo/cocotola-api/src/plugin/english/domain"
 	pluginEnglishGateway "github.com/kujilabo/cocotola-api/src/plugin/english/gateway"
 	pluginEnglishS "github.com/kujilabo/cocotola-api/src/plugin/english/service"
+	pluginEnglishUsecase "github.com/kujilabo/cocotola-api/src/plugin/english/usecase"
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 	userG "github.com/kujilabo/cocotola-api/src/user/gateway"
 	userS "github.com/kujilabo/cocotola-api/src/user/service"
+	userU "github.com/kujilabo/cocotola-api/src/user/usecase"
 )
 
 // type newIteratorFunc func(ctx context.Context, workbookID appD.WorkbookID, problemType string, reader io.Reader) (appS.ProblemAddParameterIterator, error)
@@ -93,6 +96,7 @@ func main() {
 		return userG.NewRepositoryFactory(db)
 	}
 	appS.UserRfFunc = userRfFunc
+	appS.SetGuestQuotaPercent(cfg.App.GuestQuotaPercent)
 
 	if err := initApp1(ctx, db, cfg.App.OwnerPassword); err != nil {
 		panic(err)
@@ -102,6 +106,11 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	var synthesizerCacheClient appS.SynthesizerCacheClient
+	if cfg.Synthesizer.CacheEnabled {
+		synthesizerCacheClient = appS.NewSynthesizerCacheClient(synthesizer, appG.NewAudioCacheRepository(db))
+		synthesizer = synthesizerCacheClient
+	}
 
 	// translator
 	connTranslator, err := grpc.Dial(cfg.Translator.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -111,17 +120,27 @@ func main() {
 		panic(err)
 	}
 	defer connTranslator.Close()
-	// translatorClient := pluginCommonGateway.NewTranslatorHTTPClient(cfg.Translator.Endpoint, cfg.Translator.Username, cfg.Translator.Password, time.Duration(cfg.Translator.TimeoutSec)*time.Second)
-	translatorClient := pluginCommonGateway.NewTranslatorGRPCClient(connTranslator, cfg.Translator.Username, cfg.Translator.Password, time.Duration(cfg.Translator.TimeoutSec)*time.Second)
+	translatorClient, err := newTranslatorClient(cfg.Translator, connTranslator)
+	if err != nil {
+		panic(err)
+	}
+	if cacheCfg := cfg.Translator.Cache; cacheCfg != nil {
+		var translationCacheRepo pluginCommonS.TranslationCacheRepository
+		if cacheCfg.DBEnabled {
+			translationCacheRepo = pluginCommonGateway.NewTranslationCacheRepository(db)
+			go removeExpiredTranslationCaches(ctx, translationCacheRepo, time.Duration(cacheCfg.PurgeIntervalMin)*time.Minute)
+		}
+		translatorClient = pluginCommonS.NewTranslatorCacheClient(translatorClient, translationCacheRepo, cacheCfg.Size, time.Duration(cacheCfg.TTLSec)*time.Second, time.Duration(cacheCfg.NegativeTTLSec)*time.Second)
+	}
 
 	tatoebaClient := pluginCommonGateway.NewTatoebaClient(cfg.Tatoeba.Endpoint, cfg.Tatoeba.Username, cfg.Tatoeba.Password, time.Duration(cfg.Tatoeba.TimeoutSec)*time.Second)
 
-	pf, problemRepositories, problemImportProcessor := initPf(synthesizer, translatorClient, tatoebaClient)
+	pf, problemRepositories, problemImportProcessor := initPf(synthesizer, translatorClient, pluginCommonGateway.NewGlossaryRepository(db), tatoebaClient)
 
-	newIterator := func(ctx context.Context, workbookID appD.WorkbookID, problemType string, reader io.Reader) (appS.ProblemAddParameterIterator, error) {
+	newIterator := func(ctx context.Context, organizationID userD.OrganizationID, workbookID appD.WorkbookID, problemType string, reader io.Reader) (appS.ProblemAddParameterIterator, error) {
 		processor, ok := problemImportProcessor[problemType]
 		if ok {
-			return processor.CreateCSVReader(ctx, workbookID, reader)
+			return processor.CreateCSVReader(ctx, organizationID, workbookID, reader)
 		}
 		return nil, liberrors.Errorf("processor not found. problemType: %s", problemType)
 	}
@@ -167,19 +186,19 @@ func main() {
 	// 	logrus.Info(y)
 	// }
 
-	result := run(context.Background(), cfg, db, pf, rfFunc, userRfFunc, synthesizer, translatorClient, tatoebaClient, newIterator)
+	result := run(context.Background(), cfg, db, pf, rfFunc, userRfFunc, synthesizer, synthesizerCacheClient, translatorClient, tatoebaClient, newIterator)
 
 	time.Sleep(gracefulShutdownTime2)
 	logrus.Info("exited")
 	os.Exit(result)
 }
 
-func run(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.ProcessorFactory, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, synthesizerClient appS.SynthesizerClient, translatorClient pluginCommonS.TranslatorClient, tatoebaClient pluginCommonS.TatoebaClient, newIteratorFunc controller.NewIteratorFunc) int {
+func run(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.ProcessorFactory, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, synthesizerClient appS.SynthesizerClient, synthesizerCacheClient appS.SynthesizerCacheClient, translatorClient pluginCommonS.TranslatorClient, tatoebaClient pluginCommonS.TatoebaClient, newIteratorFunc controller.NewIteratorFunc) int {
 	var eg *errgroup.Group
 	eg, ctx = errgroup.WithContext(ctx)
 
 	eg.Go(func() error {
-		return httpServer(ctx, cfg, db, pf, rfFunc, userRfFunc, synthesizerClient, translatorClient, tatoebaClient, newIteratorFunc)
+		return httpServer(ctx, cfg, db, pf, rfFunc, userRfFunc, synthesizerClient, synthesizerCacheClient, translatorClient, tatoebaClient, newIteratorFunc)
 	})
 	eg.Go(func() error {
 		return metricsServer(ctx, cfg)
@@ -213,7 +232,7 @@ func signalNotify(ctx context.Context) error {
 	}
 }
 
-func httpServer(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.ProcessorFactory, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, synthesizerClient appS.SynthesizerClient, translatorClient pluginCommonS.TranslatorClient, tatoebaClient pluginCommonS.TatoebaClient, newIteratorFunc controller.NewIteratorFunc) error {
+func httpServer(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.ProcessorFactory, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, synthesizerClient appS.SynthesizerClient, synthesizerCacheClient appS.SynthesizerCacheClient, translatorClient pluginCommonS.TranslatorClient, tatoebaClient pluginCommonS.TatoebaClient, newIteratorFunc controller.NewIteratorFunc) error {
 	// cors
 	corsConfig := config.InitCORS(cfg.CORS)
 	logrus.Infof("cors: %+v", corsConfig)
@@ -226,9 +245,16 @@ func httpServer(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.Pr
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	signingKey := []byte(cfg.Auth.SigningKey)
-	signingMethod := jwt.SigningMethodHS256
-	authTokenManager := authG.NewAuthTokenManager(signingKey, signingMethod, time.Duration(cfg.Auth.AccessTokenTTLMin)*time.Minute, time.Duration(cfg.Auth.RefreshTokenTTLHour)*time.Hour)
+	signingKeySet, err := newSigningKeySet(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	systemOrganizationID, err := findSystemOrganizationID(ctx, db)
+	if err != nil {
+		return err
+	}
+	authTokenManager := authG.NewAuthTokenManager(signingKeySet, time.Duration(cfg.Auth.AccessTokenTTLMin)*time.Minute, time.Duration(cfg.Auth.RefreshTokenTTLHour)*time.Hour, authG.NewRefreshTokenRepository(db), authG.NewAppUserFindFunc(db))
 
 	googleAuthClient := authG.NewGoogleAuthClient(cfg.Auth.GoogleClientID, cfg.Auth.GoogleClientSecret, cfg.Auth.GoogleCallbackURL, time.Duration(cfg.Auth.APITimeoutSec)*time.Second)
 
@@ -244,14 +270,49 @@ func httpServer(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.Pr
 		return callback(ctx, cfg.App.TestUserEmail, pf, rf, userRf, organizationName, appUser)
 	}
 
-	googleUserUsecase := authU.NewGoogleUserUsecase(db, googleAuthClient, authTokenManager, registerAppUserCallback)
-	guestUserUsecase := authU.NewGuestUserUsecase(authTokenManager)
-	studentUsecaseWorkbook := studentU.NewStudentUsecaseWorkbook(db, pf, rfFunc, userRfFunc)
+	guestUserUsecase := authU.NewGuestUserUsecase(db, userRfFunc, googleAuthClient, authTokenManager, time.Duration(cfg.Auth.GuestTTLHour)*time.Hour, cfg.Auth.GuestMaxCount)
+	go removeExpiredGuestUsers(ctx, guestUserUsecase, time.Duration(cfg.Auth.GuestGCIntervalMin)*time.Minute)
+	totpUsecase := authU.NewTOTPUsecase(db, userRfFunc, func(ctx context.Context, db *gorm.DB) (authS.TOTPRepository, error) {
+		return authG.NewTOTPRepository(db), nil
+	}, authTokenManager, cfg.Auth.TOTPIssuer, cfg.Auth.TOTPRequiredRoles, time.Duration(cfg.Auth.TOTPChallengeTTLMin)*time.Minute, cfg.Auth.PasswordMaxLoginFailures, time.Duration(cfg.Auth.PasswordLockMin)*time.Minute)
+	googleUserUsecase := authU.NewGoogleUserUsecase(db, googleAuthClient, authTokenManager, totpUsecase, registerAppUserCallback)
+	passwordUserUsecase, err := authU.NewPasswordUserUsecase(db, userRfFunc, authTokenManager, totpUsecase, authG.NewLogPasswordResetNotifier(), cfg.Auth.PasswordMaxLoginFailures, time.Duration(cfg.Auth.PasswordLockMin)*time.Minute, time.Duration(cfg.Auth.PasswordResetTokenTTLMin)*time.Minute)
+	if err != nil {
+		return err
+	}
+	oidcClients := map[string]authS.OIDCClient{}
+	for _, providerCfg := range cfg.Auth.OIDCProviders {
+		if _, ok := oidcClients[providerCfg.Name]; ok {
+			return liberrors.Errorf("duplicated OIDC provider. name: %s", providerCfg.Name)
+		}
+		oidcClients[providerCfg.Name] = authG.NewOIDCClient(providerCfg.Issuer, providerCfg.ClientID, providerCfg.ClientSecret, providerCfg.RedirectURL, providerCfg.Scopes, time.Duration(cfg.Auth.APITimeoutSec)*time.Second)
+	}
+	oidcUserUsecase := authU.NewOIDCUserUsecase(db, oidcClients, authG.NewOIDCAuthRequestRepository(db), authTokenManager, totpUsecase, registerAppUserCallback, time.Duration(cfg.Auth.OIDCAuthRequestTTLMin)*time.Minute)
+	personalAccessTokenUsecase := authU.NewPersonalAccessTokenUsecase(db, func(ctx context.Context, db *gorm.DB) (authS.PersonalAccessTokenRepository, error) {
+		return authG.NewPersonalAccessTokenRepository(db), nil
+	})
+	organizationAdminUsecase := userU.NewOrganizationAdminUsecase(db, userRfFunc, func(ctx context.Context, organizationName string, appUser userD.AppUserModel) error {
+		return passwordUserUsecase.RequestPasswordReset(ctx, organizationName, appUser.GetLoginID())
+	}, func(ctx context.Context, organizationID userD.OrganizationID, appUserID userD.AppUserID) error {
+		_, err := authTokenManager.RevokeAllRefreshTokens(ctx, organizationID, appUserID)
+		return err
+	})
+	appUserGroupUsecase := userU.NewAppUserGroupUsecase(db, userRfFunc)
+	spaceUsecase := userU.NewSpaceUsecase(db, userRfFunc)
+	studentUsecaseAudio := studentU.NewStudentUsecaseAudio(db, pf, rfFunc, userRfFunc, synthesizerClient, time.Duration(cfg.Synthesizer.GenerationIntervalMSec)*time.Millisecond, cfg.Synthesizer.GenerationQuotaPerDay)
+	studentUsecaseWorkbook := studentU.NewStudentUsecaseWorkbook(db, pf, rfFunc, userRfFunc, studentUsecaseAudio)
 	studentUsecaseProblem := studentU.NewStudentUsecaseProblem(db, pf, rfFunc, userRfFunc)
 	studentUseCaseStudy := studentU.NewStudentUsecaseStudy(db, pf, rfFunc, userRfFunc)
-	studentUsecaseAudio := studentU.NewStudentUsecaseAudio(db, pf, rfFunc, userRfFunc, synthesizerClient)
+	studentUsecaseNGSL := pluginEnglishUsecase.NewStudentUsecaseNGSL(db, pf, rfFunc, userRfFunc)
+	studentUsecaseTatoeba := pluginEnglishUsecase.NewStudentUsecaseTatoeba(db, pf, rfFunc, userRfFunc, tatoebaClient)
+	tatoebaImportUsecase := pluginCommonU.NewTatoebaImportUsecase(db, func(ctx context.Context, db *gorm.DB) (pluginCommonS.TatoebaImportJobRepository, error) {
+		return pluginCommonGateway.NewTatoebaImportJobRepository(db), nil
+	}, tatoebaClient, cfg.Tatoeba.ImportWorkDir, cfg.Tatoeba.ImportBatchLines)
+	glossaryUsecase := pluginCommonU.NewGlossaryUsecase(db, func(ctx context.Context, db *gorm.DB) (pluginCommonS.GlossaryRepository, error) {
+		return pluginCommonGateway.NewGlossaryRepository(db), nil
+	})
 
-	router := controller.NewRouter(googleUserUsecase, guestUserUsecase, studentUsecaseWorkbook, studentUsecaseProblem, studentUsecaseAudio, studentUseCaseStudy, translatorClient, tatoebaClient, newIteratorFunc, corsConfig, cfg.App, cfg.Auth, cfg.Debug)
+	router := controller.NewRouter(signingKeySet, authTokenManager, googleUserUsecase, guestUserUsecase, passwordUserUsecase, totpUsecase, oidcUserUsecase, personalAccessTokenUsecase, organizationAdminUsecase, appUserGroupUsecase, spaceUsecase, studentUsecaseWorkbook, studentUsecaseProblem, studentUsecaseAudio, synthesizerCacheClient, studentUseCaseStudy, translatorClient, tatoebaClient, tatoebaImportUsecase, glossaryUsecase, studentUsecaseNGSL, studentUsecaseTatoeba, newIteratorFunc, systemOrganizationID, corsConfig, cfg.App, cfg.Auth, cfg.Debug)
 
 	if cfg.Swagger.Enabled {
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -292,6 +353,50 @@ func httpServer(ctx context.Context, cfg *config.Config, db *gorm.DB, pf appS.Pr
 	}
 }
 
+// removeExpiredGuestUsers removes the expired guests every interval until ctx is done
+func removeExpiredGuestUsers(ctx context.Context, guestUserUsecase authU.GuestUserUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := guestUserUsecase.RemoveExpiredGuestUsers(ctx)
+			if err != nil {
+				logrus.Errorf("failed to RemoveExpiredGuestUsers. err: %+v", err)
+				continue
+			}
+			if count > 0 {
+				logrus.Infof("expired guests removed. count: %d", count)
+			}
+		}
+	}
+}
+
+// removeExpiredTranslationCaches removes the expired translation caches every interval until ctx is done
+func removeExpiredTranslationCaches(ctx context.Context, translationCacheRepo pluginCommonS.TranslationCacheRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := translationCacheRepo.RemoveExpiredTranslations(ctx, time.Now())
+			if err != nil {
+				logrus.Errorf("failed to RemoveExpiredTranslations. err: %+v", err)
+				continue
+			}
+			if count > 0 {
+				logrus.Infof("expired translation caches removed. count: %d", count)
+			}
+		}
+	}
+}
+
 func metricsServer(ctx context.Context, cfg *config.Config) error {
 	router := gin.New()
 	router.Use(gin.Recovery())
@@ -329,9 +434,85 @@ func metricsServer(ctx context.Context, cfg *config.Config) error {
 		return err
 	}
 }
-func initPf(synthesizerClient appS.SynthesizerClient, translatorClient pluginCommonS.TranslatorClient, tatoebaClient pluginCommonS.TatoebaClient) (appS.ProcessorFactory, map[string]func(context.Context, *gorm.DB) (appS.ProblemRepository, error), map[string]appS.ProblemImportProcessor) {
+func newTranslatorClient(cfg *config.TranslatorConfig, connTranslator *grpc.ClientConn) (pluginCommonS.TranslatorClient, error) {
+	timeout := time.Duration(cfg.TimeoutSec) * time.Second
+	transports := map[string]pluginCommonS.TranslatorClient{
+		"grpc": pluginCommonGateway.NewTranslatorGRPCClient(connTranslator, cfg.Username, cfg.Password, timeout),
+		"http": pluginCommonGateway.NewTranslatorHTTPClient(cfg.Endpoint, cfg.Username, cfg.Password, timeout),
+	}
+
+	primary := "grpc"
+	if cfg.Primary != "" {
+		primary = cfg.Primary
+	}
+	primaryClient, ok := transports[primary]
+	if !ok {
+		return nil, liberrors.Errorf("unsupported translator transport. primary: %s", primary)
+	}
 
-	englishWordProblemProcessor := pluginEnglishS.NewEnglishWordProblemProcessor(synthesizerClient, translatorClient, tatoebaClient, pluginEnglishGateway.NewEnglishWordProblemAddParameterCSVReader)
+	resilienceCfg := cfg.Resilience
+	if resilienceCfg == nil {
+		return primaryClient, nil
+	}
+
+	var secondaryClient pluginCommonS.TranslatorClient
+	if resilienceCfg.Secondary != "" && resilienceCfg.Secondary != primary {
+		secondaryClient, ok = transports[resilienceCfg.Secondary]
+		if !ok {
+			return nil, liberrors.Errorf("unsupported translator transport. secondary: %s", resilienceCfg.Secondary)
+		}
+	}
+
+	return pluginCommonS.NewTranslatorResilientClient(primaryClient, secondaryClient, resilienceCfg.MaxRetries, time.Duration(resilienceCfg.RetryIntervalMSec)*time.Millisecond, resilienceCfg.FailureThreshold, time.Duration(resilienceCfg.OpenTimeoutSec)*time.Second), nil
+}
+
+func newSigningKeySet(cfg *config.AuthConfig) (*authG.SigningKeySet, error) {
+	parseTime := func(value string) (time.Time, error) {
+		if value == "" {
+			return time.Time{}, nil
+		}
+		return time.Parse(time.RFC3339, value)
+	}
+
+	keys := make([]*authG.SigningKey, 0)
+	if cfg.SigningKey != "" {
+		retireAt, err := parseTime(cfg.SigningKeyRetireAt)
+		if err != nil {
+			return nil, liberrors.Errorf("invalid signingKeyRetireAt. err: %w", err)
+		}
+		key, err := authG.NewHMACSigningKey("", []byte(cfg.SigningKey), time.Time{}, retireAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	for _, keyCfg := range cfg.SigningKeys {
+		activeFrom, err := parseTime(keyCfg.ActiveFrom)
+		if err != nil {
+			return nil, liberrors.Errorf("invalid activeFrom. kid: %s, err: %w", keyCfg.KeyID, err)
+		}
+		retireAt, err := parseTime(keyCfg.RetireAt)
+		if err != nil {
+			return nil, liberrors.Errorf("invalid retireAt. kid: %s, err: %w", keyCfg.KeyID, err)
+		}
+		privateKeyPEM, err := os.ReadFile(keyCfg.PrivateKeyFile)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to read the private key. kid: %s, err: %w", keyCfg.KeyID, err)
+		}
+		key, err := authG.NewPEMSigningKey(keyCfg.KeyID, keyCfg.Algorithm, privateKeyPEM, activeFrom, retireAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return authG.NewSigningKeySet(keys)
+}
+
+func initPf(synthesizerClient appS.SynthesizerClient, translatorClient pluginCommonS.TranslatorClient, glossaryRepo pluginCommonS.GlossaryRepository, tatoebaClient pluginCommonS.TatoebaClient) (appS.ProcessorFactory, map[string]func(context.Context, *gorm.DB) (appS.ProblemRepository, error), map[string]appS.ProblemImportProcessor) {
+
+	englishWordProblemProcessor := pluginEnglishS.NewEnglishWordProblemProcessor(synthesizerClient, translatorClient, glossaryRepo, tatoebaClient, pluginEnglishGateway.NewEnglishWordProblemAddParameterCSVReader)
 	englishPhraseProblemProcessor := pluginEnglishS.NewEnglishPhraseProblemProcessor(synthesizerClient, translatorClient)
 	englishSentenceProblemProcessor := pluginEnglishS.NewEnglishSentenceProblemProcessor(synthesizerClient, translatorClient, pluginEnglishGateway.NewEnglishSentenceProblemAddParameterCSVReader)
 
@@ -356,6 +537,16 @@ func initPf(synthesizerClient appS.SynthesizerClient, translatorClient pluginCom
 		pluginEnglishDomain.EnglishSentenceProblemType: englishSentenceProblemProcessor,
 	}
 
+	problemSentenceProcessor := map[string]appS.ProblemSentenceProcessor{
+		pluginEnglishDomain.EnglishWordProblemType: englishWordProblemProcessor,
+	}
+
+	problemAudioProcessor := map[string]appS.ProblemAudioProcessor{
+		pluginEnglishDomain.EnglishWordProblemType:     englishWordProblemProcessor,
+		pluginEnglishDomain.EnglishPhraseProblemType:   englishPhraseProblemProcessor,
+		pluginEnglishDomain.EnglishSentenceProblemType: englishSentenceProblemProcessor,
+	}
+
 	englishWordProblemRepositoryFunc := func(ctx context.Context, db *gorm.DB) (appS.ProblemRepository, error) {
 		// fmt.Println("-------Word")
 		return pluginEnglishGateway.NewEnglishWordProblemRepository(db, synthesizerClient, pluginEnglishDomain.EnglishWordProblemType)
@@ -368,7 +559,7 @@ func initPf(synthesizerClient appS.SynthesizerClient, translatorClient pluginCom
 		return pluginEnglishGateway.NewEnglishSentenceProblemRepository(db, synthesizerClient, pluginEnglishDomain.EnglishSentenceProblemType)
 	}
 
-	pf := appS.NewProcessorFactory(problemAddProcessor, problemUpdateProcessor, problemRemoveProcessor, problemImportProcessor, problemQuotaProcessor)
+	pf := appS.NewProcessorFactory(problemAddProcessor, problemUpdateProcessor, problemRemoveProcessor, problemImportProcessor, problemQuotaProcessor, problemSentenceProcessor, problemAudioProcessor)
 
 	problemRepositories := map[string]func(context.Context, *gorm.DB) (appS.ProblemRepository, error){
 		pluginEnglishDomain.EnglishWordProblemType:     englishWordProblemRepositoryFunc,
@@ -452,6 +643,21 @@ func initApp1(ctx context.Context, db *gorm.DB, password string) error {
 	return nil
 }
 
+// findSystemOrganizationID returns the ID of the organization which is added by initApp1. The owners of it administer the whole service
+func findSystemOrganizationID(ctx context.Context, db *gorm.DB) (userD.OrganizationID, error) {
+	systemAdmin, err := userS.NewSystemAdminFromDB(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	organization, err := systemAdmin.FindOrganizationByName(ctx, appS.OrganizationName)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to FindOrganizationByName. err: %w", err)
+	}
+
+	return userD.OrganizationID(organization.GetID()), nil
+}
+
 func initApp2(ctx context.Context, db *gorm.DB, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc) error {
 	if err := initApp2_1(ctx, db, rfFunc, userRfFunc); err != nil {
 		return liberrors.Errorf("failed to initApp2_1. err: %w", err)
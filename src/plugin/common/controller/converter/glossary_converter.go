@@ -0,0 +1,40 @@
+package converter
+
+import (
+	"context"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+)
+
+func ToGlossaryFindResponse(ctx context.Context, entries []domain.Translation) *entity.GlossaryFindResponse {
+	results := make([]entity.GlossaryEntry, len(entries))
+	for i, e := range entries {
+		results[i] = entity.GlossaryEntry{
+			Lang2:      e.GetLang2().String(),
+			Text:       e.GetText(),
+			Pos:        int(e.GetPos()),
+			Translated: e.GetTranslated(),
+		}
+	}
+
+	return &entity.GlossaryFindResponse{
+		Results: results,
+	}
+}
+
+func ToGlossaryEntrySetParameter(ctx context.Context, param *entity.GlossaryEntrySetParameter) (service.TranslationAddParameter, error) {
+	pos, err := domain.NewWordPos(param.Pos)
+	if err != nil {
+		return nil, err
+	}
+
+	lang2, err := appD.NewLang2(param.Lang2)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.NewTransalationAddParameter(param.Text, pos, lang2, param.Translated)
+}
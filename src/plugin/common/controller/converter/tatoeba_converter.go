@@ -39,3 +39,31 @@ func ToTatoebaSentenceResponse(ctx context.Context, result *service.TatoebaSente
 		Results:    entities,
 	}, nil
 }
+
+func ToTatoebaImportJobAddParameter(ctx context.Context, param *entity.TatoebaImportJobStartParameter) (*service.TatoebaImportJobAddParameter, error) {
+	kind, err := service.NewTatoebaImportKind(param.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service.TatoebaImportJobAddParameter{
+		Kind:     kind,
+		FileName: param.FileName,
+		FileSize: param.FileSize,
+	}, nil
+}
+
+func ToTatoebaImportJobResponse(ctx context.Context, job service.TatoebaImportJob) *entity.TatoebaImportJobResponse {
+	return &entity.TatoebaImportJobResponse{
+		ID:             uint(job.GetID()),
+		Kind:           string(job.GetKind()),
+		FileName:       job.GetFileName(),
+		FileSize:       job.GetFileSize(),
+		ReceivedSize:   job.GetReceivedSize(),
+		ProcessedLines: job.GetProcessedLines(),
+		Status:         string(job.GetStatus()),
+		ErrorMessage:   job.GetErrorMessage(),
+		CreatedAt:      job.GetCreatedAt(),
+		UpdatedAt:      job.GetUpdatedAt(),
+	}
+}
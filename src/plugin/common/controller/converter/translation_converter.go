@@ -2,11 +2,14 @@ package converter
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	appD "github.com/kujilabo/cocotola-api/src/app/domain"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/entity"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
 )
 
 func ToTranslationFindResposne(ctx context.Context, translations []domain.Translation) (*entity.TranslationFindResponse, error) {
@@ -68,3 +71,68 @@ func ToTranslationAddParameter(ctx context.Context, param *entity.TranslationAdd
 func ToTranslationUpdateParameter(ctx context.Context, param *entity.TranslationUpdateParameter) (service.TranslationUpdateParameter, error) {
 	return service.NewTransaltionUpdateParameter(param.Translated)
 }
+
+func ToTranslationExportParameter(ctx context.Context, param *entity.TranslationExportParameter) (*usecase.TranslationExportParameter, error) {
+	format := usecase.TranslationFormatCSV
+	if param.Format != "" {
+		f, err := usecase.NewTranslationFormat(param.Format)
+		if err != nil {
+			return nil, err
+		}
+		format = f
+	}
+
+	lang2 := appD.Lang2JA
+	if param.Lang2 != "" {
+		l, err := appD.NewLang2(param.Lang2)
+		if err != nil {
+			return nil, err
+		}
+		lang2 = l
+	}
+
+	var pos *domain.WordPos
+	if param.Pos != nil {
+		p, err := domain.NewWordPos(*param.Pos)
+		if err != nil {
+			return nil, err
+		}
+		pos = &p
+	}
+
+	updatedSince := time.Time{}
+	if param.UpdatedSince != nil {
+		updatedSince = *param.UpdatedSince
+	}
+
+	return &usecase.TranslationExportParameter{
+		Format:       format,
+		Lang2:        lang2,
+		Pos:          pos,
+		FirstLetter:  strings.ToLower(param.Letter),
+		UpdatedSince: updatedSince,
+	}, nil
+}
+
+func ToTranslationImportResponse(ctx context.Context, result *usecase.TranslationImportResult, dryRun bool) *entity.TranslationImportResponse {
+	toDiffs := func(diffs []usecase.TranslationDiff) []entity.TranslationDiff {
+		results := make([]entity.TranslationDiff, len(diffs))
+		for i, d := range diffs {
+			results[i] = entity.TranslationDiff{
+				Lang2:  d.Lang2,
+				Text:   d.Text,
+				Pos:    int(d.Pos),
+				Before: d.Before,
+				After:  d.After,
+			}
+		}
+		return results
+	}
+
+	return &entity.TranslationImportResponse{
+		DryRun:    dryRun,
+		Added:     toDiffs(result.Added),
+		Updated:   toDiffs(result.Updated),
+		Unchanged: result.Unchanged,
+	}
+}
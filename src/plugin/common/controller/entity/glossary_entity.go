@@ -0,0 +1,23 @@
+package entity
+
+type GlossaryEntry struct {
+	Lang2      string `json:"lang2"`
+	Text       string `json:"text"`
+	Pos        int    `json:"pos"`
+	Translated string `json:"translated"`
+}
+
+type GlossaryEntrySetParameter struct {
+	Lang2      string `json:"lang2" binding:"required,len=2"`
+	Text       string `json:"text" binding:"required"`
+	Pos        int    `json:"pos" binding:"required"`
+	Translated string `json:"translated" binding:"required"`
+}
+
+type GlossaryFindResponse struct {
+	Results []GlossaryEntry `json:"results"`
+}
+
+type GlossaryImportResponse struct {
+	Count int `json:"count"`
+}
@@ -25,3 +25,22 @@ type TatoebaSentenceFindResponse struct {
 	TotalCount int64                 `json:"totalCount"`
 	Results    []TatoebaSentencePair `json:"results"`
 }
+
+type TatoebaImportJobStartParameter struct {
+	Kind     string `json:"kind" binding:"required,oneof=sentence link"`
+	FileName string `json:"fileName" binding:"max=200"`
+	FileSize int64  `json:"fileSize" binding:"required,gte=1"`
+}
+
+type TatoebaImportJobResponse struct {
+	ID             uint      `json:"id"`
+	Kind           string    `json:"kind"`
+	FileName       string    `json:"fileName"`
+	FileSize       int64     `json:"fileSize"`
+	ReceivedSize   int64     `json:"receivedSize"`
+	ProcessedLines int       `json:"processedLines"`
+	Status         string    `json:"status"`
+	ErrorMessage   string    `json:"errorMessage,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type TranslationFindParameter struct {
 	Letter string `json:"letter"`
 	Lang2  string `json:"lang2"`
@@ -27,3 +29,26 @@ type TranslationAddParameter struct {
 type TranslationUpdateParameter struct {
 	Translated string `json:"translated" binding:"required"`
 }
+
+type TranslationExportParameter struct {
+	Format       string     `form:"format"`
+	Lang2        string     `form:"lang2"`
+	Pos          *int       `form:"pos"`
+	Letter       string     `form:"letter" binding:"omitempty,len=1"`
+	UpdatedSince *time.Time `form:"updatedSince" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type TranslationDiff struct {
+	Lang2  string `json:"lang2"`
+	Text   string `json:"text"`
+	Pos    int    `json:"pos"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after"`
+}
+
+type TranslationImportResponse struct {
+	DryRun    bool              `json:"dryRun"`
+	Added     []TranslationDiff `json:"added"`
+	Updated   []TranslationDiff `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+}
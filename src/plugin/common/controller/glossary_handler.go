@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/converter"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type GlossaryHandler interface {
+	FindGlossaryEntries(c *gin.Context)
+	SetGlossaryEntry(c *gin.Context)
+	RemoveGlossaryEntry(c *gin.Context)
+	ImportGlossary(c *gin.Context)
+	ExportGlossary(c *gin.Context)
+}
+
+type glossaryHandler struct {
+	glossaryUsecase usecase.GlossaryUsecase
+}
+
+func NewGlossaryHandler(glossaryUsecase usecase.GlossaryUsecase) GlossaryHandler {
+	return &glossaryHandler{
+		glossaryUsecase: glossaryUsecase,
+	}
+}
+
+func (h *glossaryHandler) FindGlossaryEntries(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		entries, err := h.glossaryUsecase.FindGlossaryEntries(ctx, organizationID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindGlossaryEntries. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToGlossaryFindResponse(ctx, entries))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *glossaryHandler) SetGlossaryEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.GlossaryEntrySetParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		parameter, err := converter.ToGlossaryEntrySetParameter(ctx, &param)
+		if err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.glossaryUsecase.SetGlossaryEntry(ctx, organizationID, operatorID, parameter); err != nil {
+			return liberrors.Errorf("failed to SetGlossaryEntry. err: %w", err)
+		}
+
+		c.Status(http.StatusOK)
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *glossaryHandler) RemoveGlossaryEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		lang2, err := appD.NewLang2(ginhelper.GetStringFromPath(c, "lang2"))
+		if err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		text := ginhelper.GetStringFromPath(c, "text")
+
+		posValue, err := ginhelper.GetIntFromPath(c, "pos")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+		pos, err := domain.NewWordPos(posValue)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.glossaryUsecase.RemoveGlossaryEntry(ctx, organizationID, lang2, text, pos); err != nil {
+			return liberrors.Errorf("failed to RemoveGlossaryEntry. err: %w", err)
+		}
+
+		c.Status(http.StatusOK)
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *glossaryHandler) ImportGlossary(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		file, err := c.FormFile("file")
+		if err != nil {
+			if errors.Is(err, http.ErrMissingFile) {
+				logger.Warnf("err: %+v", err)
+				c.Status(http.StatusBadRequest)
+				return nil
+			}
+			return err
+		}
+
+		multipartFile, err := file.Open()
+		if err != nil {
+			return liberrors.Errorf("failed to file.Open. err: %w", err)
+		}
+		defer multipartFile.Close()
+
+		count, err := h.glossaryUsecase.ImportGlossary(ctx, organizationID, operatorID, multipartFile)
+		if err != nil {
+			return liberrors.Errorf("failed to ImportGlossary. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, entity.GlossaryImportResponse{Count: count})
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *glossaryHandler) ExportGlossary(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", `attachment; filename="glossary.csv"`)
+		c.Status(http.StatusOK)
+
+		if err := h.glossaryUsecase.ExportGlossary(ctx, organizationID, c.Writer); err != nil {
+			return liberrors.Errorf("failed to ExportGlossary. err: %w", err)
+		}
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *glossaryHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("glossaryHandler. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid glossary entry"})
+		return true
+	} else if errors.Is(err, service.ErrGlossaryEntryNotFound) {
+		logger.Warnf("glossaryHandler. err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Glossary entry not found"})
+		return true
+	}
+	logger.Errorf("glossaryHandler. err: %v", err)
+	return false
+}
@@ -65,7 +65,7 @@ func (h *tatoebaHandler) FindSentencePairs(c *gin.Context) {
 func (h *tatoebaHandler) ImportSentences(c *gin.Context) {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 
 		file, err := c.FormFile("file")
 		if err != nil {
@@ -95,7 +95,7 @@ func (h *tatoebaHandler) ImportSentences(c *gin.Context) {
 func (h *tatoebaHandler) ImportLinks(c *gin.Context) {
 	ctx := c.Request.Context()
 	logger := log.FromContext(ctx)
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 
 		file, err := c.FormFile("file")
 		if err != nil {
@@ -113,7 +113,7 @@ func (h *tatoebaHandler) ImportLinks(c *gin.Context) {
 		}
 		defer multipartFile.Close()
 
-		if err := h.tatoebaClient.ImportSentences(ctx, multipartFile); err != nil {
+		if err := h.tatoebaClient.ImportLinks(ctx, multipartFile); err != nil {
 			return err
 		}
 
@@ -58,7 +58,7 @@ func initTatoebaRouter(tatoebaClient service.TatoebaClient) *gin.Engine {
 	router.Use(gin.Recovery())
 	v1 := router.Group("v1")
 	plugin := v1.Group("plugin")
-	controller.InitTatoebaPluginRouter(plugin, tatoebaClient)
+	controller.InitTatoebaPluginRouter(plugin, tatoebaClient, nil)
 	return router
 }
 
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/converter"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+// TatoebaImportHandler receives the tatoeba files in chunks.
+// The client creates a job, uploads the chunks in order with their offsets, and polls the job until it is completed.
+// If the connection is dropped, the client gets the job and resumes uploading from the received size
+type TatoebaImportHandler interface {
+	StartImport(c *gin.Context)
+	UploadChunk(c *gin.Context)
+	FindJob(c *gin.Context)
+	ResumeJob(c *gin.Context)
+}
+
+type tatoebaImportHandler struct {
+	tatoebaImportUsecase usecase.TatoebaImportUsecase
+}
+
+func NewTatoebaImportHandler(tatoebaImportUsecase usecase.TatoebaImportUsecase) TatoebaImportHandler {
+	return &tatoebaImportHandler{
+		tatoebaImportUsecase: tatoebaImportUsecase,
+	}
+}
+
+func (h *tatoebaImportHandler) StartImport(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.TatoebaImportJobStartParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		parameter, err := converter.ToTatoebaImportJobAddParameter(ctx, &param)
+		if err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		job, err := h.tatoebaImportUsecase.StartImport(ctx, organizationID, operatorID, parameter)
+		if err != nil {
+			return liberrors.Errorf("failed to StartImport. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToTatoebaImportJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *tatoebaImportHandler) UploadChunk(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		jobID, err := ginhelper.GetUintFromPath(c, "jobID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		offset, err := strconv.ParseInt(c.Query("offset"), 10, 64)
+		if err != nil || offset < 0 {
+			logger.Warnf("invalid offset. offset: %s", c.Query("offset"))
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		job, err := h.tatoebaImportUsecase.UploadChunk(ctx, organizationID, service.TatoebaImportJobID(jobID), offset, c.Request.Body)
+		if errors.Is(err, usecase.ErrTatoebaImportOffsetMismatch) && job != nil {
+			logger.Warnf("tatoebaImportHandler. err: %v", err)
+			c.JSON(http.StatusConflict, converter.ToTatoebaImportJobResponse(ctx, job))
+			return nil
+		} else if err != nil {
+			return liberrors.Errorf("failed to UploadChunk. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToTatoebaImportJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *tatoebaImportHandler) FindJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		jobID, err := ginhelper.GetUintFromPath(c, "jobID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		job, err := h.tatoebaImportUsecase.FindJob(ctx, organizationID, service.TatoebaImportJobID(jobID))
+		if err != nil {
+			return liberrors.Errorf("failed to FindJob. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToTatoebaImportJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *tatoebaImportHandler) ResumeJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		jobID, err := ginhelper.GetUintFromPath(c, "jobID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		job, err := h.tatoebaImportUsecase.ResumeJob(ctx, organizationID, service.TatoebaImportJobID(jobID))
+		if err != nil {
+			return liberrors.Errorf("failed to ResumeJob. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToTatoebaImportJobResponse(ctx, job))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *tatoebaImportHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("tatoebaImportHandler. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid argument"})
+		return true
+	} else if errors.Is(err, service.ErrTatoebaImportJobNotFound) {
+		logger.Warnf("tatoebaImportHandler. err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Tatoeba import job not found"})
+		return true
+	} else if errors.Is(err, usecase.ErrTatoebaImportOffsetMismatch) {
+		logger.Warnf("tatoebaImportHandler. err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Offset doesn't match the received size"})
+		return true
+	} else if errors.Is(err, usecase.ErrTatoebaImportJobNotResumable) {
+		logger.Warnf("tatoebaImportHandler. err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Tatoeba import job is not resumable"})
+		return true
+	}
+	logger.Errorf("tatoebaImportHandler. err: %v", err)
+	return false
+}
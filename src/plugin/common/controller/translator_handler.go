@@ -1,19 +1,23 @@
 package controller
 
 import (
-	"bytes"
-	"encoding/csv"
 	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/converter"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/controller/entity"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
 	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
 	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
@@ -26,20 +30,25 @@ type TranslationHandler interface {
 	UpdateTranslation(c *gin.Context)
 	RemoveTranslation(c *gin.Context)
 	ExportTranslations(c *gin.Context)
+	ImportTranslations(c *gin.Context)
 }
 
 type translationHandler struct {
-	translatorClient service.TranslatorClient
+	translatorClient   service.TranslatorClient
+	translationUsecase usecase.TranslationUsecase
 }
 
-func NewTranslationHandler(translatorClient service.TranslatorClient) TranslationHandler {
-	return &translationHandler{translatorClient: translatorClient}
+func NewTranslationHandler(translatorClient service.TranslatorClient, translationUsecase usecase.TranslationUsecase) TranslationHandler {
+	return &translationHandler{
+		translatorClient:   translatorClient,
+		translationUsecase: translationUsecase,
+	}
 }
 
 func (h *translationHandler) FindTranslations(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 
 		param := entity.TranslationFindParameter{}
 		if err := c.ShouldBindJSON(&param); err != nil {
@@ -73,7 +82,7 @@ func (h *translationHandler) FindTranslationByTextAndPos(c *gin.Context) {
 	logger := log.FromContext(ctx)
 	logger.Infof("FindTranslationByTextAndPos")
 
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 
 		text := ginhelper.GetStringFromPath(c, "text")
 
@@ -104,7 +113,7 @@ func (h *translationHandler) FindTranslationByTextAndPos(c *gin.Context) {
 func (h *translationHandler) FindTranslationsByText(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 
 		text := ginhelper.GetStringFromPath(c, "text")
 		results, err := h.translatorClient.FindTranslationsByText(ctx, appD.Lang2JA, text)
@@ -125,7 +134,7 @@ func (h *translationHandler) FindTranslationsByText(c *gin.Context) {
 func (h *translationHandler) AddTranslation(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 		param := entity.TranslationAddParameter{}
 		if err := c.ShouldBindJSON(&param); err != nil {
 			c.Status(http.StatusBadRequest)
@@ -148,7 +157,7 @@ func (h *translationHandler) AddTranslation(c *gin.Context) {
 func (h *translationHandler) UpdateTranslation(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 		text := ginhelper.GetStringFromPath(c, "text")
 
 		pos, err := ginhelper.GetIntFromPath(c, "pos")
@@ -182,7 +191,7 @@ func (h *translationHandler) UpdateTranslation(c *gin.Context) {
 func (h *translationHandler) RemoveTranslation(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
 		text := ginhelper.GetStringFromPath(c, "text")
 
 		pos, err := ginhelper.GetIntFromPath(c, "pos")
@@ -204,21 +213,75 @@ func (h *translationHandler) RemoveTranslation(c *gin.Context) {
 }
 
 func (h *translationHandler) ExportTranslations(c *gin.Context) {
-	controllerhelper.HandleRoleFunction(c, "Owner", func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
-		csvStruct := [][]string{
-			{"name", "address", "phone"},
-			{"Ram", "Tokyo", "1236524"},
-			{"Shaym", "Beijing", "8575675484"},
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.TranslationExportParameter{}
+		if err := c.ShouldBindQuery(&param); err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
 		}
-		b := new(bytes.Buffer)
-		w := csv.NewWriter(b)
-		if err := w.WriteAll(csvStruct); err != nil {
-			return err
+
+		parameter, err := converter.ToTranslationExportParameter(ctx, &param)
+		if err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
 		}
-		if _, err := c.Writer.Write(b.Bytes()); err != nil {
+
+		c.Header("Content-Type", parameter.Format.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="translations.%s"`, parameter.Format))
+		c.Status(http.StatusOK)
+
+		if err := h.translationUsecase.ExportTranslations(ctx, *parameter, c.Writer); err != nil {
+			return liberrors.Errorf("failed to ExportTranslations. err: %w", err)
+		}
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *translationHandler) ImportTranslations(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		file, err := c.FormFile("file")
+		if err != nil {
+			if errors.Is(err, http.ErrMissingFile) {
+				logger.Warnf("err: %+v", err)
+				c.Status(http.StatusBadRequest)
+				return nil
+			}
 			return err
 		}
-		c.Status(http.StatusOK)
+
+		formatValue := c.PostForm("format")
+		if formatValue == "" {
+			formatValue = strings.TrimPrefix(filepath.Ext(file.Filename), ".")
+		}
+		format, err := usecase.NewTranslationFormat(formatValue)
+		if err != nil {
+			logger.Warnf("err: %+v", err)
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		dryRun := c.Query("dryRun") == "true"
+
+		multipartFile, err := file.Open()
+		if err != nil {
+			return liberrors.Errorf("failed to file.Open. err: %w", err)
+		}
+		defer multipartFile.Close()
+
+		result, err := h.translationUsecase.ImportTranslations(ctx, format, multipartFile, dryRun)
+		if err != nil {
+			return liberrors.Errorf("failed to ImportTranslations. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToTranslationImportResponse(ctx, result, dryRun))
 		return nil
 	}, h.errorHandle)
 }
@@ -231,6 +294,14 @@ func (h *translationHandler) errorHandle(c *gin.Context, err error) bool {
 		logger.Warnf("translationHandler. err: %v", err)
 		c.JSON(http.StatusConflict, gin.H{"message": "Translation already exists"})
 		return true
+	} else if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("translationHandler. err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid translation"})
+		return true
+	} else if errors.Is(err, service.ErrTranslatorOperationNotSupported) {
+		logger.Warnf("translationHandler. err: %v", err)
+		c.JSON(http.StatusNotImplemented, gin.H{"message": "The translator doesn't support the operation"})
+		return true
 	}
 	logger.Errorf("translationHandler. err: %v", err)
 	return false
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	testing "testing"
+
+	time "time"
 )
 
 // Translation is an autogenerated mock type for the Translation type
@@ -88,6 +90,20 @@ func (_m *Translation) GetTranslated() string {
 	return r0
 }
 
+// GetUpdatedAt provides a mock function with given fields:
+func (_m *Translation) GetUpdatedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
 // NewTranslation creates a new instance of Translation. It also registers a cleanup function to assert the mocks expectations.
 func NewTranslation(t testing.TB) *Translation {
 	mock := &Translation{}
@@ -2,6 +2,8 @@
 package domain
 
 import (
+	"time"
+
 	appD "github.com/kujilabo/cocotola-api/src/app/domain"
 	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 )
@@ -12,6 +14,8 @@ type Translation interface {
 	GetLang2() appD.Lang2
 	GetTranslated() string
 	GetProvider() string
+	// GetUpdatedAt returns the zero time if the translator doesn't provide it
+	GetUpdatedAt() time.Time
 }
 
 type translation struct {
@@ -20,15 +24,21 @@ type translation struct {
 	Lang2      appD.Lang2
 	Translated string
 	Provider   string
+	UpdatedAt  time.Time
 }
 
 func NewTranslation(text string, pos WordPos, lang2 appD.Lang2, translated, provider string) (Translation, error) {
+	return NewTranslationWithUpdatedAt(text, pos, lang2, translated, provider, time.Time{})
+}
+
+func NewTranslationWithUpdatedAt(text string, pos WordPos, lang2 appD.Lang2, translated, provider string, updatedAt time.Time) (Translation, error) {
 	m := &translation{
 		Text:       text,
 		Pos:        pos,
 		Lang2:      lang2,
 		Translated: translated,
 		Provider:   provider,
+		UpdatedAt:  updatedAt,
 	}
 
 	return m, libD.Validator.Struct(m)
@@ -53,3 +63,7 @@ func (t *translation) GetTranslated() string {
 func (t *translation) GetProvider() string {
 	return t.Provider
 }
+
+func (t *translation) GetUpdatedAt() time.Time {
+	return t.UpdatedAt
+}
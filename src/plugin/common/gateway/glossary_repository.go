@@ -0,0 +1,150 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type glossaryEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CreatedBy      uint
+	UpdatedBy      uint
+	OrganizationID uint
+	Lang2          string
+	Text           string
+	Pos            int
+	Translated     string
+}
+
+func (e *glossaryEntity) TableName() string {
+	return "glossary"
+}
+
+func (e *glossaryEntity) toModel() (domain.Translation, error) {
+	pos, err := domain.NewWordPos(e.Pos)
+	if err != nil {
+		return nil, err
+	}
+
+	lang2, err := appD.NewLang2(e.Lang2)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewTranslation(e.Text, pos, lang2, e.Translated, service.GlossaryProvider)
+}
+
+type glossaryRepository struct {
+	db *gorm.DB
+}
+
+func NewGlossaryRepository(db *gorm.DB) service.GlossaryRepository {
+	return &glossaryRepository{
+		db: db,
+	}
+}
+
+func (r *glossaryRepository) FindGlossaryEntries(ctx context.Context, organizationID userD.OrganizationID) ([]domain.Translation, error) {
+	_, span := tracer.Start(ctx, "glossaryRepository.FindGlossaryEntries")
+	defer span.End()
+
+	entities := []glossaryEntity{}
+	if result := r.db.Where("organization_id = ?", uint(organizationID)).
+		Order("lang2, text, pos").
+		Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	results := make([]domain.Translation, len(entities))
+	for i, e := range entities {
+		m, err := e.toModel()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = m
+	}
+
+	return results, nil
+}
+
+func (r *glossaryRepository) FindGlossaryEntriesByTexts(ctx context.Context, organizationID userD.OrganizationID, lang2 appD.Lang2, texts []string) (map[string][]domain.Translation, error) {
+	_, span := tracer.Start(ctx, "glossaryRepository.FindGlossaryEntriesByTexts")
+	defer span.End()
+
+	results := make(map[string][]domain.Translation)
+	if len(texts) == 0 {
+		return results, nil
+	}
+
+	entities := []glossaryEntity{}
+	if result := r.db.Where("organization_id = ?", uint(organizationID)).
+		Where("lang2 = ?", lang2.String()).
+		Where("text in ?", texts).
+		Order("text, pos").
+		Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, e := range entities {
+		m, err := e.toModel()
+		if err != nil {
+			return nil, err
+		}
+		results[e.Text] = append(results[e.Text], m)
+	}
+
+	return results, nil
+}
+
+func (r *glossaryRepository) SetGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param service.TranslationAddParameter) error {
+	_, span := tracer.Start(ctx, "glossaryRepository.SetGlossaryEntry")
+	defer span.End()
+
+	entity := glossaryEntity{
+		CreatedBy:      uint(operatorID),
+		UpdatedBy:      uint(operatorID),
+		OrganizationID: uint(organizationID),
+		Lang2:          param.GetLang2().String(),
+		Text:           param.GetText(),
+		Pos:            int(param.GetPos()),
+		Translated:     param.GetTranslated(),
+	}
+	if result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "organization_id"}, {Name: "lang2"}, {Name: "text"}, {Name: "pos"}},
+		DoUpdates: clause.AssignmentColumns([]string{"translated", "updated_by", "updated_at"}),
+	}).Create(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *glossaryRepository) RemoveGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, lang2 appD.Lang2, text string, pos domain.WordPos) error {
+	_, span := tracer.Start(ctx, "glossaryRepository.RemoveGlossaryEntry")
+	defer span.End()
+
+	result := r.db.Where("organization_id = ?", uint(organizationID)).
+		Where("lang2 = ?", lang2.String()).
+		Where("text = ?", text).
+		Where("pos = ?", int(pos)).
+		Delete(&glossaryEntity{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return service.ErrGlossaryEntryNotFound
+	}
+
+	return nil
+}
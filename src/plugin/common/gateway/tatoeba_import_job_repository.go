@@ -0,0 +1,156 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+// tatoebaImportJobStaleDuration is the duration after which the processing job which hasn't made any progress is regarded as stopped.
+// The job stops without updating the status when the server shuts down
+const tatoebaImportJobStaleDuration = 10 * time.Minute
+
+type tatoebaImportJobEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CreatedBy      uint
+	OrganizationID uint
+	Kind           string
+	FileName       string
+	FileSize       int64
+	ReceivedSize   int64
+	ProcessedLines int
+	Status         string
+	ErrorMessage   string
+}
+
+func (e *tatoebaImportJobEntity) TableName() string {
+	return "tatoeba_import_job"
+}
+
+func (e *tatoebaImportJobEntity) toModel() (service.TatoebaImportJob, error) {
+	kind, err := service.NewTatoebaImportKind(e.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	status := service.TatoebaImportJobStatus(e.Status)
+	errorMessage := e.ErrorMessage
+	if status == service.TatoebaImportJobStatusProcessing && e.UpdatedAt.Before(time.Now().Add(-tatoebaImportJobStaleDuration)) {
+		status = service.TatoebaImportJobStatusFailed
+		errorMessage = "the job has stopped"
+	}
+
+	return service.NewTatoebaImportJob(service.TatoebaImportJobID(e.ID), kind, e.FileName, e.FileSize, e.ReceivedSize, e.ProcessedLines, status, errorMessage, e.CreatedAt, e.UpdatedAt)
+}
+
+type tatoebaImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewTatoebaImportJobRepository(db *gorm.DB) service.TatoebaImportJobRepository {
+	return &tatoebaImportJobRepository{
+		db: db,
+	}
+}
+
+func (r *tatoebaImportJobRepository) AddJob(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *service.TatoebaImportJobAddParameter) (service.TatoebaImportJobID, error) {
+	_, span := tracer.Start(ctx, "tatoebaImportJobRepository.AddJob")
+	defer span.End()
+
+	entity := tatoebaImportJobEntity{
+		CreatedBy:      uint(operatorID),
+		OrganizationID: uint(organizationID),
+		Kind:           string(param.Kind),
+		FileName:       param.FileName,
+		FileSize:       param.FileSize,
+		Status:         string(service.TatoebaImportJobStatusUploading),
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return 0, result.Error
+	}
+
+	return service.TatoebaImportJobID(entity.ID), nil
+}
+
+func (r *tatoebaImportJobRepository) FindJob(ctx context.Context, organizationID userD.OrganizationID, id service.TatoebaImportJobID) (service.TatoebaImportJob, error) {
+	_, span := tracer.Start(ctx, "tatoebaImportJobRepository.FindJob")
+	defer span.End()
+
+	entity := tatoebaImportJobEntity{}
+	if result := r.db.Where("organization_id = ?", uint(organizationID)).
+		Where("id = ?", uint(id)).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrTatoebaImportJobNotFound
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel()
+}
+
+func (r *tatoebaImportJobRepository) UpdateReceivedSize(ctx context.Context, id service.TatoebaImportJobID, from, to int64) error {
+	_, span := tracer.Start(ctx, "tatoebaImportJobRepository.UpdateReceivedSize")
+	defer span.End()
+
+	result := r.db.Model(&tatoebaImportJobEntity{}).
+		Where("id = ?", uint(id)).
+		Where("received_size = ?", from).
+		Where("status = ?", string(service.TatoebaImportJobStatusUploading)).
+		Update("received_size", to)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return service.ErrTatoebaImportJobConflict
+	}
+
+	return nil
+}
+
+func (r *tatoebaImportJobRepository) UpdateProcessedLines(ctx context.Context, id service.TatoebaImportJobID, processedLines int) error {
+	_, span := tracer.Start(ctx, "tatoebaImportJobRepository.UpdateProcessedLines")
+	defer span.End()
+
+	result := r.db.Model(&tatoebaImportJobEntity{}).
+		Where("id = ?", uint(id)).
+		Update("processed_lines", processedLines)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return service.ErrTatoebaImportJobNotFound
+	}
+
+	return nil
+}
+
+func (r *tatoebaImportJobRepository) UpdateStatus(ctx context.Context, id service.TatoebaImportJobID, status service.TatoebaImportJobStatus, errorMessage string) error {
+	_, span := tracer.Start(ctx, "tatoebaImportJobRepository.UpdateStatus")
+	defer span.End()
+
+	result := r.db.Model(&tatoebaImportJobEntity{}).
+		Where("id = ?", uint(id)).
+		Updates(map[string]interface{}{
+			"status":        string(status),
+			"error_message": errorMessage,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return service.ErrTatoebaImportJobNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+)
+
+type translationCacheEntity struct {
+	ID           uint
+	CreatedAt    time.Time
+	CacheKey     string
+	Text         string
+	NotFound     bool
+	Translations string
+	ExpiredAt    time.Time
+}
+
+func (e *translationCacheEntity) TableName() string {
+	return "translation_cache"
+}
+
+type translationCacheRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationCacheRepository(db *gorm.DB) service.TranslationCacheRepository {
+	return &translationCacheRepository{
+		db: db,
+	}
+}
+
+func (r *translationCacheRepository) FindTranslations(ctx context.Context, key string) ([]domain.Translation, time.Time, error) {
+	_, span := tracer.Start(ctx, "translationCacheRepository.FindTranslations")
+	defer span.End()
+
+	entity := translationCacheEntity{}
+	if result := r.db.Where("cache_key = ?", key).
+		Where("expired_at > ?", time.Now()).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, time.Time{}, service.ErrTranslationCacheNotFound
+		}
+		return nil, time.Time{}, result.Error
+	}
+
+	if entity.NotFound {
+		return nil, entity.ExpiredAt, service.ErrTranslationNotFound
+	}
+
+	responses := make([]translationResponse, 0)
+	if err := json.Unmarshal([]byte(entity.Translations), &responses); err != nil {
+		return nil, time.Time{}, liberrors.Errorf("failed to Unmarshal. key: %s, err: %w", key, err)
+	}
+
+	translations, err := (&translationFindResponse{Results: responses}).toModel()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return translations, entity.ExpiredAt, nil
+}
+
+func (r *translationCacheRepository) SetTranslations(ctx context.Context, key, text string, translations []domain.Translation, notFound bool, expiredAt time.Time) error {
+	_, span := tracer.Start(ctx, "translationCacheRepository.SetTranslations")
+	defer span.End()
+
+	responses := make([]translationResponse, len(translations))
+	for i, t := range translations {
+		responses[i] = translationResponse{
+			Text:       t.GetText(),
+			Pos:        int(t.GetPos()),
+			Lang2:      t.GetLang2().String(),
+			Translated: t.GetTranslated(),
+			Provider:   t.GetProvider(),
+		}
+	}
+
+	bytes, err := json.Marshal(responses)
+	if err != nil {
+		return liberrors.Errorf("failed to Marshal. key: %s, err: %w", key, err)
+	}
+
+	entity := translationCacheEntity{
+		CacheKey:     key,
+		Text:         text,
+		NotFound:     notFound,
+		Translations: string(bytes),
+		ExpiredAt:    expiredAt,
+	}
+	if result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cache_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"text", "not_found", "translations", "expired_at"}),
+	}).Create(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *translationCacheRepository) RemoveTranslationsByText(ctx context.Context, text string) error {
+	_, span := tracer.Start(ctx, "translationCacheRepository.RemoveTranslationsByText")
+	defer span.End()
+
+	if result := r.db.Where("text = ?", text).Delete(&translationCacheEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *translationCacheRepository) RemoveExpiredTranslations(ctx context.Context, now time.Time) (int, error) {
+	_, span := tracer.Start(ctx, "translationCacheRepository.RemoveExpiredTranslations")
+	defer span.End()
+
+	result := r.db.Where("expired_at <= ?", now).Delete(&translationCacheEntity{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	grpcMetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
 	pb "github.com/kujilabo/cocotola-api/src/proto"
@@ -19,6 +22,7 @@ type translatorGRPCClient struct {
 	adminClient pb.TranslatorAdminClient
 	username    string
 	password    string
+	timeout     time.Duration
 }
 
 func NewTranslatorGRPCClient(conn *grpc.ClientConn, username, password string, timeout time.Duration) service.TranslatorClient {
@@ -29,11 +33,12 @@ func NewTranslatorGRPCClient(conn *grpc.ClientConn, username, password string, t
 		adminClient: adminClient,
 		username:    username,
 		password:    password,
+		timeout:     timeout,
 	}
 }
 
 func (c *translatorGRPCClient) DictionaryLookup(ctx context.Context, fromLang, toLang appD.Lang2, text string) ([]domain.Translation, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	ctx = grpcMetadata.AppendToOutgoingContext(ctx, "username", c.username)
@@ -47,7 +52,7 @@ func (c *translatorGRPCClient) DictionaryLookup(ctx context.Context, fromLang, t
 
 	resp, err := c.userClient.DictionaryLookup(ctx, &param)
 	if err != nil {
-		return nil, err
+		return nil, toTranslatorError(err)
 	}
 
 	translationList := make([]domain.Translation, len(resp.Results))
@@ -74,7 +79,7 @@ func (c *translatorGRPCClient) DictionaryLookup(ctx context.Context, fromLang, t
 }
 
 func (c *translatorGRPCClient) DictionaryLookupWithPos(ctx context.Context, fromLang, toLang appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	ctx = grpcMetadata.AppendToOutgoingContext(ctx, "authorization", "basic "+base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)))
@@ -88,7 +93,7 @@ func (c *translatorGRPCClient) DictionaryLookupWithPos(ctx context.Context, from
 
 	resp, err := c.userClient.DictionaryLookupWithPos(ctx, &param)
 	if err != nil {
-		return nil, err
+		return nil, toTranslatorError(err)
 	}
 
 	result := resp.Result
@@ -112,21 +117,34 @@ func (c *translatorGRPCClient) DictionaryLookupWithPos(ctx context.Context, from
 	}
 }
 
+// The admin operations are not implemented in the gRPC client yet
 func (c *translatorGRPCClient) FindTranslationsByFirstLetter(ctx context.Context, lang2 appD.Lang2, firstLetter string) ([]domain.Translation, error) {
-	return nil, nil
+	return nil, service.ErrTranslatorOperationNotSupported
 }
 func (c *translatorGRPCClient) FindTranslationByTextAndPos(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
-	return nil, nil
+	return nil, service.ErrTranslatorOperationNotSupported
 }
 func (c *translatorGRPCClient) FindTranslationsByText(ctx context.Context, lang2 appD.Lang2, text string) ([]domain.Translation, error) {
-	return nil, nil
+	return nil, service.ErrTranslatorOperationNotSupported
 }
 func (c *translatorGRPCClient) AddTranslation(ctx context.Context, param service.TranslationAddParameter) error {
-	return nil
+	return service.ErrTranslatorOperationNotSupported
 }
 func (c *translatorGRPCClient) UpdateTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos, param service.TranslationUpdateParameter) error {
-	return nil
+	return service.ErrTranslatorOperationNotSupported
 }
 func (c *translatorGRPCClient) RemoveTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) error {
-	return nil
+	return service.ErrTranslatorOperationNotSupported
+}
+
+// toTranslatorError converts the gRPC status error. Only Unavailable and DeadlineExceeded are temporary failures of the translator
+func toTranslatorError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return service.ErrTranslationNotFound
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return liberrors.Errorf("%w. err: %v", service.ErrTranslatorUnavailable, err)
+	default:
+		return err
+	}
 }
@@ -12,17 +12,19 @@ import (
 	"time"
 
 	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
 	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 type translationResponse struct {
-	Text       string `json:"text"`
-	Pos        int    `json:"pos"`
-	Lang2      string `json:"lang2"`
-	Translated string `json:"translated"`
-	Provider   string `json:"provider"`
+	Text       string    `json:"text"`
+	Pos        int       `json:"pos"`
+	Lang2      string    `json:"lang2"`
+	Translated string    `json:"translated"`
+	Provider   string    `json:"provider"`
+	UpdatedAt  time.Time `json:"updatedAt"`
 }
 
 func (r *translationResponse) toModel() (domain.Translation, error) {
@@ -36,7 +38,7 @@ func (r *translationResponse) toModel() (domain.Translation, error) {
 		return nil, err
 	}
 
-	return domain.NewTranslation(r.Text, pos, lang2, r.Translated, r.Provider)
+	return domain.NewTranslationWithUpdatedAt(r.Text, pos, lang2, r.Translated, r.Provider, r.UpdatedAt)
 }
 
 type translationFindResponse struct {
@@ -376,6 +378,10 @@ func (c *translatorHTTPClient) RemoveTranslation(ctx context.Context, lang2 appD
 func (c *translatorHTTPClient) errorHandle(statusCode int) error {
 	if statusCode == http.StatusOK {
 		return nil
+	} else if statusCode == http.StatusNotFound {
+		return service.ErrTranslationNotFound
+	} else if statusCode >= http.StatusInternalServerError {
+		return liberrors.Errorf("%w. status: %s", service.ErrTranslatorUnavailable, http.StatusText(statusCode))
 	}
 
 	return errors.New(http.StatusText(statusCode))
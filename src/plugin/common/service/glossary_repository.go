@@ -0,0 +1,33 @@
+//go:generate mockery --output mock --name GlossaryRepository
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+const GlossaryProvider = "glossary"
+
+var ErrGlossaryEntryNotFound = errors.New("glossary entry not found")
+
+type GlossaryRepositoryFunc func(ctx context.Context, db *gorm.DB) (GlossaryRepository, error)
+
+// GlossaryRepository stores the translations which each organization prefers to the translations of the translator
+type GlossaryRepository interface {
+	// FindGlossaryEntries returns all the entries of the organization ordered by lang2, text and pos
+	FindGlossaryEntries(ctx context.Context, organizationID userD.OrganizationID) ([]domain.Translation, error)
+
+	// FindGlossaryEntriesByTexts returns the entries of the texts. The texts which have no entries are not contained in the result
+	FindGlossaryEntriesByTexts(ctx context.Context, organizationID userD.OrganizationID, lang2 appD.Lang2, texts []string) (map[string][]domain.Translation, error)
+
+	// SetGlossaryEntry adds the entry, or updates the translated text of the entry which has the same lang2, text and pos
+	SetGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param TranslationAddParameter) error
+
+	RemoveGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, lang2 appD.Lang2, text string, pos domain.WordPos) error
+}
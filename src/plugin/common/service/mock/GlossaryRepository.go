@@ -0,0 +1,106 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	appdomain "github.com/kujilabo/cocotola-api/src/app/domain"
+	commondomain "github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/user/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+
+	testing "testing"
+)
+
+// GlossaryRepository is an autogenerated mock type for the GlossaryRepository type
+type GlossaryRepository struct {
+	mock.Mock
+}
+
+// FindGlossaryEntries provides a mock function with given fields: ctx, organizationID
+func (_m *GlossaryRepository) FindGlossaryEntries(ctx context.Context, organizationID domain.OrganizationID) ([]commondomain.Translation, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	var r0 []commondomain.Translation
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrganizationID) []commondomain.Translation); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]commondomain.Translation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OrganizationID) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindGlossaryEntriesByTexts provides a mock function with given fields: ctx, organizationID, lang2, texts
+func (_m *GlossaryRepository) FindGlossaryEntriesByTexts(ctx context.Context, organizationID domain.OrganizationID, lang2 appdomain.Lang2, texts []string) (map[string][]commondomain.Translation, error) {
+	ret := _m.Called(ctx, organizationID, lang2, texts)
+
+	var r0 map[string][]commondomain.Translation
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrganizationID, appdomain.Lang2, []string) map[string][]commondomain.Translation); ok {
+		r0 = rf(ctx, organizationID, lang2, texts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]commondomain.Translation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OrganizationID, appdomain.Lang2, []string) error); ok {
+		r1 = rf(ctx, organizationID, lang2, texts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveGlossaryEntry provides a mock function with given fields: ctx, organizationID, lang2, text, pos
+func (_m *GlossaryRepository) RemoveGlossaryEntry(ctx context.Context, organizationID domain.OrganizationID, lang2 appdomain.Lang2, text string, pos commondomain.WordPos) error {
+	ret := _m.Called(ctx, organizationID, lang2, text, pos)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrganizationID, appdomain.Lang2, string, commondomain.WordPos) error); ok {
+		r0 = rf(ctx, organizationID, lang2, text, pos)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGlossaryEntry provides a mock function with given fields: ctx, organizationID, operatorID, param
+func (_m *GlossaryRepository) SetGlossaryEntry(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param service.TranslationAddParameter) error {
+	ret := _m.Called(ctx, organizationID, operatorID, param)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrganizationID, domain.AppUserID, service.TranslationAddParameter) error); ok {
+		r0 = rf(ctx, organizationID, operatorID, param)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewGlossaryRepository creates a new instance of GlossaryRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewGlossaryRepository(t testing.TB) *GlossaryRepository {
+	mock := &GlossaryRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
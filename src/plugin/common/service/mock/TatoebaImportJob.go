@@ -0,0 +1,166 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	testing "testing"
+
+	service "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TatoebaImportJob is an autogenerated mock type for the TatoebaImportJob type
+type TatoebaImportJob struct {
+	mock.Mock
+}
+
+// GetCreatedAt provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetCreatedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// GetErrorMessage provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetErrorMessage() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetFileName provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetFileName() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetFileSize provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetFileSize() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// GetID provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetID() service.TatoebaImportJobID {
+	ret := _m.Called()
+
+	var r0 service.TatoebaImportJobID
+	if rf, ok := ret.Get(0).(func() service.TatoebaImportJobID); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.TatoebaImportJobID)
+	}
+
+	return r0
+}
+
+// GetKind provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetKind() service.TatoebaImportKind {
+	ret := _m.Called()
+
+	var r0 service.TatoebaImportKind
+	if rf, ok := ret.Get(0).(func() service.TatoebaImportKind); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.TatoebaImportKind)
+	}
+
+	return r0
+}
+
+// GetProcessedLines provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetProcessedLines() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// GetReceivedSize provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetReceivedSize() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
+// GetStatus provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetStatus() service.TatoebaImportJobStatus {
+	ret := _m.Called()
+
+	var r0 service.TatoebaImportJobStatus
+	if rf, ok := ret.Get(0).(func() service.TatoebaImportJobStatus); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(service.TatoebaImportJobStatus)
+	}
+
+	return r0
+}
+
+// GetUpdatedAt provides a mock function with given fields:
+func (_m *TatoebaImportJob) GetUpdatedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// NewTatoebaImportJob creates a new instance of TatoebaImportJob. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaImportJob(t testing.TB) *TatoebaImportJob {
+	mock := &TatoebaImportJob{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/user/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+
+	testing "testing"
+)
+
+// TatoebaImportJobRepository is an autogenerated mock type for the TatoebaImportJobRepository type
+type TatoebaImportJobRepository struct {
+	mock.Mock
+}
+
+// AddJob provides a mock function with given fields: ctx, organizationID, operatorID, param
+func (_m *TatoebaImportJobRepository) AddJob(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param *service.TatoebaImportJobAddParameter) (service.TatoebaImportJobID, error) {
+	ret := _m.Called(ctx, organizationID, operatorID, param)
+
+	var r0 service.TatoebaImportJobID
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrganizationID, domain.AppUserID, *service.TatoebaImportJobAddParameter) service.TatoebaImportJobID); ok {
+		r0 = rf(ctx, organizationID, operatorID, param)
+	} else {
+		r0 = ret.Get(0).(service.TatoebaImportJobID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OrganizationID, domain.AppUserID, *service.TatoebaImportJobAddParameter) error); ok {
+		r1 = rf(ctx, organizationID, operatorID, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindJob provides a mock function with given fields: ctx, organizationID, id
+func (_m *TatoebaImportJobRepository) FindJob(ctx context.Context, organizationID domain.OrganizationID, id service.TatoebaImportJobID) (service.TatoebaImportJob, error) {
+	ret := _m.Called(ctx, organizationID, id)
+
+	var r0 service.TatoebaImportJob
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrganizationID, service.TatoebaImportJobID) service.TatoebaImportJob); ok {
+		r0 = rf(ctx, organizationID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(service.TatoebaImportJob)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OrganizationID, service.TatoebaImportJobID) error); ok {
+		r1 = rf(ctx, organizationID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProcessedLines provides a mock function with given fields: ctx, id, processedLines
+func (_m *TatoebaImportJobRepository) UpdateProcessedLines(ctx context.Context, id service.TatoebaImportJobID, processedLines int) error {
+	ret := _m.Called(ctx, id, processedLines)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.TatoebaImportJobID, int) error); ok {
+		r0 = rf(ctx, id, processedLines)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateReceivedSize provides a mock function with given fields: ctx, id, from, to
+func (_m *TatoebaImportJobRepository) UpdateReceivedSize(ctx context.Context, id service.TatoebaImportJobID, from int64, to int64) error {
+	ret := _m.Called(ctx, id, from, to)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.TatoebaImportJobID, int64, int64) error); ok {
+		r0 = rf(ctx, id, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, id, status, errorMessage
+func (_m *TatoebaImportJobRepository) UpdateStatus(ctx context.Context, id service.TatoebaImportJobID, status service.TatoebaImportJobStatus, errorMessage string) error {
+	ret := _m.Called(ctx, id, status, errorMessage)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.TatoebaImportJobID, service.TatoebaImportJobStatus, string) error); ok {
+		r0 = rf(ctx, id, status, errorMessage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTatoebaImportJobRepository creates a new instance of TatoebaImportJobRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewTatoebaImportJobRepository(t testing.TB) *TatoebaImportJobRepository {
+	mock := &TatoebaImportJobRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
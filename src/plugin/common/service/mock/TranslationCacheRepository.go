@@ -0,0 +1,107 @@
+// Code generated by mockery v2.11.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	mock "github.com/stretchr/testify/mock"
+
+	testing "testing"
+
+	time "time"
+)
+
+// TranslationCacheRepository is an autogenerated mock type for the TranslationCacheRepository type
+type TranslationCacheRepository struct {
+	mock.Mock
+}
+
+// FindTranslations provides a mock function with given fields: ctx, key
+func (_m *TranslationCacheRepository) FindTranslations(ctx context.Context, key string) ([]domain.Translation, time.Time, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 []domain.Translation
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Translation); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Translation)
+		}
+	}
+
+	var r1 time.Time
+	if rf, ok := ret.Get(1).(func(context.Context, string) time.Time); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, key)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RemoveExpiredTranslations provides a mock function with given fields: ctx, now
+func (_m *TranslationCacheRepository) RemoveExpiredTranslations(ctx context.Context, now time.Time) (int, error) {
+	ret := _m.Called(ctx, now)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveTranslationsByText provides a mock function with given fields: ctx, text
+func (_m *TranslationCacheRepository) RemoveTranslationsByText(ctx context.Context, text string) error {
+	ret := _m.Called(ctx, text)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTranslations provides a mock function with given fields: ctx, key, text, translations, notFound, expiredAt
+func (_m *TranslationCacheRepository) SetTranslations(ctx context.Context, key string, text string, translations []domain.Translation, notFound bool, expiredAt time.Time) error {
+	ret := _m.Called(ctx, key, text, translations, notFound, expiredAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []domain.Translation, bool, time.Time) error); ok {
+		r0 = rf(ctx, key, text, translations, notFound, expiredAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewTranslationCacheRepository creates a new instance of TranslationCacheRepository. It also registers a cleanup function to assert the mocks expectations.
+func NewTranslationCacheRepository(t testing.TB) *TranslationCacheRepository {
+	mock := &TranslationCacheRepository{}
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,122 @@
+//go:generate mockery --output mock --name TatoebaImportJob
+package service
+
+import (
+	"time"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+)
+
+type TatoebaImportJobID uint
+
+type TatoebaImportKind string
+
+const (
+	TatoebaImportKindSentence TatoebaImportKind = "sentence"
+	TatoebaImportKindLink     TatoebaImportKind = "link"
+)
+
+func NewTatoebaImportKind(value string) (TatoebaImportKind, error) {
+	switch kind := TatoebaImportKind(value); kind {
+	case TatoebaImportKindSentence, TatoebaImportKindLink:
+		return kind, nil
+	default:
+		return "", liberrors.Errorf("invalid tatoeba import kind. kind: %s, err: %w", value, libD.ErrInvalidArgument)
+	}
+}
+
+type TatoebaImportJobStatus string
+
+const (
+	// TatoebaImportJobStatusUploading means the job is waiting for the remaining chunks
+	TatoebaImportJobStatusUploading TatoebaImportJobStatus = "uploading"
+	// TatoebaImportJobStatusProcessing means all the chunks have been received and the lines are being sent to the tatoeba api
+	TatoebaImportJobStatusProcessing TatoebaImportJobStatus = "processing"
+	TatoebaImportJobStatusCompleted  TatoebaImportJobStatus = "completed"
+	// TatoebaImportJobStatusFailed means processing has stopped. The job can be resumed from the processed lines
+	TatoebaImportJobStatusFailed TatoebaImportJobStatus = "failed"
+)
+
+type TatoebaImportJob interface {
+	GetID() TatoebaImportJobID
+	GetKind() TatoebaImportKind
+	GetFileName() string
+	GetFileSize() int64
+	GetReceivedSize() int64
+	GetProcessedLines() int
+	GetStatus() TatoebaImportJobStatus
+	GetErrorMessage() string
+	GetCreatedAt() time.Time
+	GetUpdatedAt() time.Time
+}
+
+type tatoebaImportJob struct {
+	ID             TatoebaImportJobID
+	Kind           TatoebaImportKind `validate:"required"`
+	FileName       string
+	FileSize       int64                  `validate:"gte=0"`
+	ReceivedSize   int64                  `validate:"gte=0"`
+	ProcessedLines int                    `validate:"gte=0"`
+	Status         TatoebaImportJobStatus `validate:"required"`
+	ErrorMessage   string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewTatoebaImportJob(id TatoebaImportJobID, kind TatoebaImportKind, fileName string, fileSize, receivedSize int64, processedLines int, status TatoebaImportJobStatus, errorMessage string, createdAt, updatedAt time.Time) (TatoebaImportJob, error) {
+	m := &tatoebaImportJob{
+		ID:             id,
+		Kind:           kind,
+		FileName:       fileName,
+		FileSize:       fileSize,
+		ReceivedSize:   receivedSize,
+		ProcessedLines: processedLines,
+		Status:         status,
+		ErrorMessage:   errorMessage,
+		CreatedAt:      createdAt,
+		UpdatedAt:      updatedAt,
+	}
+
+	return m, libD.Validator.Struct(m)
+}
+
+func (m *tatoebaImportJob) GetID() TatoebaImportJobID {
+	return m.ID
+}
+
+func (m *tatoebaImportJob) GetKind() TatoebaImportKind {
+	return m.Kind
+}
+
+func (m *tatoebaImportJob) GetFileName() string {
+	return m.FileName
+}
+
+func (m *tatoebaImportJob) GetFileSize() int64 {
+	return m.FileSize
+}
+
+func (m *tatoebaImportJob) GetReceivedSize() int64 {
+	return m.ReceivedSize
+}
+
+func (m *tatoebaImportJob) GetProcessedLines() int {
+	return m.ProcessedLines
+}
+
+func (m *tatoebaImportJob) GetStatus() TatoebaImportJobStatus {
+	return m.Status
+}
+
+func (m *tatoebaImportJob) GetErrorMessage() string {
+	return m.ErrorMessage
+}
+
+func (m *tatoebaImportJob) GetCreatedAt() time.Time {
+	return m.CreatedAt
+}
+
+func (m *tatoebaImportJob) GetUpdatedAt() time.Time {
+	return m.UpdatedAt
+}
@@ -0,0 +1,37 @@
+//go:generate mockery --output mock --name TatoebaImportJobRepository
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrTatoebaImportJobNotFound = errors.New("tatoeba import job not found")
+
+// ErrTatoebaImportJobConflict is returned when the job has been changed by another request
+var ErrTatoebaImportJobConflict = errors.New("tatoeba import job conflict")
+
+type TatoebaImportJobRepositoryFunc func(ctx context.Context, db *gorm.DB) (TatoebaImportJobRepository, error)
+
+type TatoebaImportJobAddParameter struct {
+	Kind     TatoebaImportKind
+	FileName string
+	FileSize int64
+}
+
+type TatoebaImportJobRepository interface {
+	AddJob(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *TatoebaImportJobAddParameter) (TatoebaImportJobID, error)
+
+	FindJob(ctx context.Context, organizationID userD.OrganizationID, id TatoebaImportJobID) (TatoebaImportJob, error)
+
+	// UpdateReceivedSize returns ErrTatoebaImportJobConflict if the received size isn't `from` or the job isn't uploading
+	UpdateReceivedSize(ctx context.Context, id TatoebaImportJobID, from, to int64) error
+
+	UpdateProcessedLines(ctx context.Context, id TatoebaImportJobID, processedLines int) error
+
+	UpdateStatus(ctx context.Context, id TatoebaImportJobID, status TatoebaImportJobStatus, errorMessage string) error
+}
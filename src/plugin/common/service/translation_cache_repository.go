@@ -0,0 +1,27 @@
+//go:generate mockery --output mock --name TranslationCacheRepository
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+)
+
+var ErrTranslationCacheNotFound = errors.New("translation cache not found")
+
+type TranslationCacheRepository interface {
+	// FindTranslations returns the cached translations of the key and the time when the cache expires.
+	// It returns ErrTranslationNotFound if it is cached that the translation doesn't exist, and ErrTranslationCacheNotFound if nothing is cached
+	FindTranslations(ctx context.Context, key string) ([]domain.Translation, time.Time, error)
+
+	// SetTranslations caches the translations of the key. `notFound` means that the translation doesn't exist
+	SetTranslations(ctx context.Context, key, text string, translations []domain.Translation, notFound bool, expiredAt time.Time) error
+
+	// RemoveTranslationsByText removes all the caches of the text
+	RemoveTranslationsByText(ctx context.Context, text string) error
+
+	// RemoveExpiredTranslations removes the caches which expired before `now` and returns the number of them
+	RemoveExpiredTranslations(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+)
+
+type translationCacheEntry struct {
+	key          string
+	text         string
+	translations []domain.Translation
+	notFound     bool
+	expiredAt    time.Time
+}
+
+// translationLRUCache is an in-memory LRU cache of the dictionary lookup results
+type translationLRUCache struct {
+	mu    sync.Mutex
+	size  int
+	list  *list.List
+	items map[string]*list.Element
+}
+
+func newTranslationLRUCache(size int) *translationLRUCache {
+	return &translationLRUCache{
+		size:  size,
+		list:  list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *translationLRUCache) get(key string, now time.Time) (*translationCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*translationCacheEntry)
+	if !now.Before(entry.expiredAt) {
+		c.list.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.list.MoveToFront(elem)
+	return entry, true
+}
+
+func (c *translationLRUCache) set(entry *translationCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[entry.key]; ok {
+		elem.Value = entry
+		c.list.MoveToFront(elem)
+		return
+	}
+
+	c.items[entry.key] = c.list.PushFront(entry)
+	for c.list.Len() > c.size {
+		oldest := c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.items, oldest.Value.(*translationCacheEntry).key)
+	}
+}
+
+func (c *translationLRUCache) removeByText(text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.list.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*translationCacheEntry)
+		if entry.text == text {
+			c.list.Remove(elem)
+			delete(c.items, entry.key)
+		}
+		elem = next
+	}
+}
+
+type translatorCacheClient struct {
+	client      TranslatorClient
+	cache       *translationLRUCache
+	cacheRepo   TranslationCacheRepository
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// NewTranslatorCacheClient returns the TranslatorClient which caches the dictionary lookup results of `client`.
+// The results are cached in memory, and also in `cacheRepo` unless it is nil.
+// ErrTranslationNotFound is cached for `negativeTTL` unless it is zero.
+// The caches of the text are removed when the translation of the text is added, updated or removed.
+// The in-memory cache isn't shared among the instances. When the translation is changed through one instance,
+// the other instances keep returning the old translations from their in-memory caches until they expire,
+// so `ttl` should be short when several instances run. The expired caches in `cacheRepo` are removed by RemoveExpiredTranslations.
+func NewTranslatorCacheClient(client TranslatorClient, cacheRepo TranslationCacheRepository, size int, ttl, negativeTTL time.Duration) TranslatorClient {
+	return &translatorCacheClient{
+		client:      client,
+		cache:       newTranslationLRUCache(size),
+		cacheRepo:   cacheRepo,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+func (c *translatorCacheClient) DictionaryLookup(ctx context.Context, fromLang, toLang appD.Lang2, text string) ([]domain.Translation, error) {
+	key := dictionaryLookupCacheKey(fromLang, toLang, text)
+	return c.lookup(ctx, key, text, func() ([]domain.Translation, error) {
+		return c.client.DictionaryLookup(ctx, fromLang, toLang, text)
+	})
+}
+
+func (c *translatorCacheClient) DictionaryLookupWithPos(ctx context.Context, fromLang, toLang appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
+	key := strings.Join([]string{"lookupWithPos", fromLang.String(), toLang.String(), strconv.Itoa(int(pos)), hashCacheKeyText(text)}, ":")
+	translations, err := c.lookup(ctx, key, text, func() ([]domain.Translation, error) {
+		translation, err := c.client.DictionaryLookupWithPos(ctx, fromLang, toLang, text, pos)
+		if err != nil {
+			return nil, err
+		}
+		return []domain.Translation{translation}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(translations) == 0 {
+		return nil, ErrTranslationNotFound
+	}
+	return translations[0], nil
+}
+
+func (c *translatorCacheClient) FindTranslationsByFirstLetter(ctx context.Context, lang2 appD.Lang2, firstLetter string) ([]domain.Translation, error) {
+	return c.client.FindTranslationsByFirstLetter(ctx, lang2, firstLetter)
+}
+
+func (c *translatorCacheClient) FindTranslationByTextAndPos(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
+	return c.client.FindTranslationByTextAndPos(ctx, lang2, text, pos)
+}
+
+func (c *translatorCacheClient) FindTranslationsByText(ctx context.Context, lang2 appD.Lang2, text string) ([]domain.Translation, error) {
+	return c.client.FindTranslationsByText(ctx, lang2, text)
+}
+
+func (c *translatorCacheClient) AddTranslation(ctx context.Context, param TranslationAddParameter) error {
+	if err := c.client.AddTranslation(ctx, param); err != nil {
+		return err
+	}
+	return c.invalidate(ctx, param.GetText())
+}
+
+func (c *translatorCacheClient) UpdateTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos, param TranslationUpdateParameter) error {
+	if err := c.client.UpdateTranslation(ctx, lang2, text, pos, param); err != nil {
+		return err
+	}
+	return c.invalidate(ctx, text)
+}
+
+func (c *translatorCacheClient) RemoveTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) error {
+	if err := c.client.RemoveTranslation(ctx, lang2, text, pos); err != nil {
+		return err
+	}
+	return c.invalidate(ctx, text)
+}
+
+func (c *translatorCacheClient) lookup(ctx context.Context, key, text string, fn func() ([]domain.Translation, error)) ([]domain.Translation, error) {
+	now := time.Now()
+
+	if translations, ok, err := c.find(ctx, key, text, now); ok {
+		return translations, err
+	}
+
+	translations, err := fn()
+	if err != nil {
+		if errors.Is(err, ErrTranslationNotFound) && c.negativeTTL > 0 {
+			c.set(ctx, &translationCacheEntry{key: key, text: text, notFound: true, expiredAt: now.Add(c.negativeTTL)})
+		}
+		return nil, err
+	}
+
+	c.set(ctx, &translationCacheEntry{key: key, text: text, translations: translations, expiredAt: now.Add(c.ttl)})
+	return translations, nil
+}
+
+// find returns the cached translations of the key. `ok` is false if nothing is cached
+func (c *translatorCacheClient) find(ctx context.Context, key, text string, now time.Time) ([]domain.Translation, bool, error) {
+	logger := log.FromContext(ctx)
+
+	if entry, ok := c.cache.get(key, now); ok {
+		if entry.notFound {
+			return nil, true, ErrTranslationNotFound
+		}
+		return entry.translations, true, nil
+	}
+
+	if c.cacheRepo != nil {
+		// the memory cache expires when the DB cache expires
+		translations, expiredAt, err := c.cacheRepo.FindTranslations(ctx, key)
+		if err == nil {
+			c.cache.set(&translationCacheEntry{key: key, text: text, translations: translations, expiredAt: expiredAt})
+			return translations, true, nil
+		} else if errors.Is(err, ErrTranslationNotFound) {
+			c.cache.set(&translationCacheEntry{key: key, text: text, notFound: true, expiredAt: expiredAt})
+			return nil, true, ErrTranslationNotFound
+		} else if !errors.Is(err, ErrTranslationCacheNotFound) {
+			// the dictionary lookup should not fail even if the cache is unavailable
+			logger.Warnf("failed to FindTranslations. key: %s, err: %v", key, err)
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (c *translatorCacheClient) set(ctx context.Context, entry *translationCacheEntry) {
+	logger := log.FromContext(ctx)
+
+	c.cache.set(entry)
+
+	if c.cacheRepo != nil {
+		if err := c.cacheRepo.SetTranslations(ctx, entry.key, entry.text, entry.translations, entry.notFound, entry.expiredAt); err != nil {
+			logger.Warnf("failed to SetTranslations. key: %s, err: %v", entry.key, err)
+		}
+	}
+}
+
+func (c *translatorCacheClient) invalidate(ctx context.Context, text string) error {
+	c.cache.removeByText(text)
+
+	if c.cacheRepo != nil {
+		if err := c.cacheRepo.RemoveTranslationsByText(ctx, text); err != nil {
+			return liberrors.Errorf("failed to RemoveTranslationsByText. text: %s, err: %w", text, err)
+		}
+	}
+	return nil
+}
+
+func dictionaryLookupCacheKey(fromLang, toLang appD.Lang2, text string) string {
+	return strings.Join([]string{"lookup", fromLang.String(), toLang.String(), hashCacheKeyText(text)}, ":")
+}
+
+// hashCacheKeyText hashes the text so that the cache key consists of ascii characters and its length is fixed
+func hashCacheKeyText(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
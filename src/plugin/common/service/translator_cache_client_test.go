@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	service_mock "github.com/kujilabo/cocotola-api/src/plugin/common/service/mock"
+)
+
+func TestTranslatorCacheClient_DictionaryLookupWithPos(t *testing.T) {
+	ctx := context.Background()
+	translation, err := domain.NewTranslation("book", domain.PosNoun, appD.Lang2JA, "本", "custom")
+	require.NoError(t, err)
+
+	t.Run("cache the translation", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil)
+		cacheClient := service.NewTranslatorCacheClient(client, nil, 10, time.Hour, time.Hour)
+
+		for i := 0; i < 3; i++ {
+			result, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			require.NoError(t, err)
+			assert.Equal(t, "本", result.GetTranslated())
+		}
+		client.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 1)
+	})
+
+	t.Run("cache ErrTranslationNotFound", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, service.ErrTranslationNotFound)
+		cacheClient := service.NewTranslatorCacheClient(client, nil, 10, time.Hour, time.Hour)
+
+		for i := 0; i < 3; i++ {
+			_, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			assert.ErrorIs(t, err, service.ErrTranslationNotFound)
+		}
+		client.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 1)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil)
+		cacheClient := service.NewTranslatorCacheClient(client, nil, 10, time.Nanosecond, time.Nanosecond)
+
+		for i := 0; i < 2; i++ {
+			_, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			require.NoError(t, err)
+			time.Sleep(time.Millisecond)
+		}
+		client.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 2)
+	})
+
+	t.Run("evict the least recently used translation", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, mock.Anything, domain.PosNoun).Return(translation, nil)
+		cacheClient := service.NewTranslatorCacheClient(client, nil, 2, time.Hour, time.Hour)
+
+		for _, text := range []string{"book", "pen", "book", "desk", "book", "pen"} {
+			_, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, text, domain.PosNoun)
+			require.NoError(t, err)
+		}
+		// "pen" is evicted when "desk" is added
+		client.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 4)
+	})
+
+	t.Run("invalidate the cache when the translation is updated", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil)
+		client.On("UpdateTranslation", mock.Anything, appD.Lang2JA, "book", domain.PosNoun, mock.Anything).Return(nil)
+		cacheRepo := new(service_mock.TranslationCacheRepository)
+		cacheRepo.On("FindTranslations", mock.Anything, mock.Anything).Return(nil, time.Time{}, service.ErrTranslationCacheNotFound)
+		cacheRepo.On("SetTranslations", mock.Anything, mock.Anything, "book", mock.Anything, false, mock.Anything).Return(nil)
+		cacheRepo.On("RemoveTranslationsByText", mock.Anything, "book").Return(nil)
+		cacheClient := service.NewTranslatorCacheClient(client, cacheRepo, 10, time.Hour, time.Hour)
+
+		_, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		require.NoError(t, err)
+
+		param, err := service.NewTransaltionUpdateParameter("書籍")
+		require.NoError(t, err)
+		err = cacheClient.UpdateTranslation(ctx, appD.Lang2JA, "book", domain.PosNoun, param)
+		require.NoError(t, err)
+
+		_, err = cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		require.NoError(t, err)
+
+		client.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 2)
+		cacheRepo.AssertNumberOfCalls(t, "RemoveTranslationsByText", 1)
+		cacheRepo.AssertNumberOfCalls(t, "SetTranslations", 2)
+	})
+
+	t.Run("the memory cache expires when the DB cache expires", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		cacheRepo := new(service_mock.TranslationCacheRepository)
+		cacheRepo.On("FindTranslations", mock.Anything, mock.Anything).Return([]domain.Translation{translation}, time.Now().Add(time.Millisecond), nil)
+		cacheClient := service.NewTranslatorCacheClient(client, cacheRepo, 10, time.Hour, time.Hour)
+
+		for i := 0; i < 2; i++ {
+			_, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			require.NoError(t, err)
+			time.Sleep(2 * time.Millisecond)
+		}
+		cacheRepo.AssertNumberOfCalls(t, "FindTranslations", 2)
+		client.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 0)
+	})
+
+	t.Run("the cache key consists of ascii characters", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "café", domain.PosNoun).Return(translation, nil)
+		cacheRepo := new(service_mock.TranslationCacheRepository)
+		cacheRepo.On("FindTranslations", mock.Anything, mock.Anything).Return(nil, time.Time{}, service.ErrTranslationCacheNotFound)
+		cacheRepo.On("SetTranslations", mock.Anything, mock.MatchedBy(func(key string) bool {
+			for _, r := range key {
+				if r > unicode.MaxASCII {
+					return false
+				}
+			}
+			return true
+		}), "café", mock.Anything, false, mock.Anything).Return(nil)
+		cacheClient := service.NewTranslatorCacheClient(client, cacheRepo, 10, time.Hour, time.Hour)
+
+		_, err := cacheClient.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "café", domain.PosNoun)
+		require.NoError(t, err)
+		cacheRepo.AssertNumberOfCalls(t, "SetTranslations", 1)
+	})
+}
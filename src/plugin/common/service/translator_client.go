@@ -12,6 +12,7 @@ import (
 
 var ErrTranslationNotFound = errors.New("translation not found")
 var ErrTranslationAlreadyExists = errors.New("custsomtranslation already exists")
+var ErrTranslatorOperationNotSupported = errors.New("translator operation not supported")
 
 type TranslatorClient interface {
 	DictionaryLookup(ctx context.Context, fromLang, toLang appD.Lang2, text string) ([]domain.Translation, error)
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type translatorGlossaryClient struct {
+	client         TranslatorClient
+	glossaryRepo   GlossaryRepository
+	organizationID userD.OrganizationID
+}
+
+// NewTranslatorGlossaryClient returns the TranslatorClient which prefers the glossary entries of the organization to the dictionary lookup results of `client`.
+// The translations of `client` are replaced with the glossary entries which have the same part of speech.
+func NewTranslatorGlossaryClient(client TranslatorClient, glossaryRepo GlossaryRepository, organizationID userD.OrganizationID) TranslatorClient {
+	return &translatorGlossaryClient{
+		client:         client,
+		glossaryRepo:   glossaryRepo,
+		organizationID: organizationID,
+	}
+}
+
+func (c *translatorGlossaryClient) DictionaryLookup(ctx context.Context, fromLang, toLang appD.Lang2, text string) ([]domain.Translation, error) {
+	entries, err := c.glossaryRepo.FindGlossaryEntriesByTexts(ctx, c.organizationID, toLang, []string{text})
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindGlossaryEntriesByTexts. err: %w", err)
+	}
+
+	translations, err := c.client.DictionaryLookup(ctx, fromLang, toLang, text)
+	if err != nil && !(errors.Is(err, ErrTranslationNotFound) && len(entries[text]) > 0) {
+		return nil, err
+	}
+
+	return mergeGlossaryEntries(entries[text], translations), nil
+}
+
+func (c *translatorGlossaryClient) DictionaryLookupWithPos(ctx context.Context, fromLang, toLang appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
+	entries, err := c.glossaryRepo.FindGlossaryEntriesByTexts(ctx, c.organizationID, toLang, []string{text})
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindGlossaryEntriesByTexts. err: %w", err)
+	}
+
+	for _, entry := range entries[text] {
+		if entry.GetPos() == pos {
+			return entry, nil
+		}
+	}
+
+	return c.client.DictionaryLookupWithPos(ctx, fromLang, toLang, text, pos)
+}
+
+func (c *translatorGlossaryClient) FindTranslationsByFirstLetter(ctx context.Context, lang2 appD.Lang2, firstLetter string) ([]domain.Translation, error) {
+	return c.client.FindTranslationsByFirstLetter(ctx, lang2, firstLetter)
+}
+
+func (c *translatorGlossaryClient) FindTranslationByTextAndPos(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
+	return c.client.FindTranslationByTextAndPos(ctx, lang2, text, pos)
+}
+
+func (c *translatorGlossaryClient) FindTranslationsByText(ctx context.Context, lang2 appD.Lang2, text string) ([]domain.Translation, error) {
+	return c.client.FindTranslationsByText(ctx, lang2, text)
+}
+
+func (c *translatorGlossaryClient) AddTranslation(ctx context.Context, param TranslationAddParameter) error {
+	return c.client.AddTranslation(ctx, param)
+}
+
+func (c *translatorGlossaryClient) UpdateTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos, param TranslationUpdateParameter) error {
+	return c.client.UpdateTranslation(ctx, lang2, text, pos, param)
+}
+
+func (c *translatorGlossaryClient) RemoveTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) error {
+	return c.client.RemoveTranslation(ctx, lang2, text, pos)
+}
+
+func mergeGlossaryEntries(entries, translations []domain.Translation) []domain.Translation {
+	if len(entries) == 0 {
+		return translations
+	}
+
+	posSet := make(map[domain.WordPos]bool)
+	results := make([]domain.Translation, 0, len(entries)+len(translations))
+	for _, entry := range entries {
+		posSet[entry.GetPos()] = true
+		results = append(results, entry)
+	}
+	for _, translation := range translations {
+		if !posSet[translation.GetPos()] {
+			results = append(results, translation)
+		}
+	}
+	return results
+}
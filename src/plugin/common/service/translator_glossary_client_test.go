@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	service_mock "github.com/kujilabo/cocotola-api/src/plugin/common/service/mock"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+func TestTranslatorGlossaryClient_DictionaryLookup(t *testing.T) {
+	ctx := context.Background()
+	orgID := userD.OrganizationID(1)
+	glossaryNoun, err := domain.NewTranslation("book", domain.PosNoun, appD.Lang2JA, "書籍", service.GlossaryProvider)
+	require.NoError(t, err)
+	translatorNoun, err := domain.NewTranslation("book", domain.PosNoun, appD.Lang2JA, "本", "custom")
+	require.NoError(t, err)
+	translatorVerb, err := domain.NewTranslation("book", domain.PosVerb, appD.Lang2JA, "予約する", "custom")
+	require.NoError(t, err)
+
+	t.Run("glossary entry replaces the translation which has the same pos", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookup", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book").Return([]domain.Translation{translatorNoun, translatorVerb}, nil)
+		glossaryRepo := new(service_mock.GlossaryRepository)
+		glossaryRepo.On("FindGlossaryEntriesByTexts", mock.Anything, orgID, appD.Lang2JA, []string{"book"}).Return(map[string][]domain.Translation{"book": {glossaryNoun}}, nil)
+		glossaryClient := service.NewTranslatorGlossaryClient(client, glossaryRepo, orgID)
+
+		results, err := glossaryClient.DictionaryLookup(ctx, appD.Lang2EN, appD.Lang2JA, "book")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "書籍", results[0].GetTranslated())
+		assert.Equal(t, "予約する", results[1].GetTranslated())
+	})
+
+	t.Run("glossary entry is returned when the translator has no translations", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookup", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book").Return(nil, service.ErrTranslationNotFound)
+		glossaryRepo := new(service_mock.GlossaryRepository)
+		glossaryRepo.On("FindGlossaryEntriesByTexts", mock.Anything, orgID, appD.Lang2JA, []string{"book"}).Return(map[string][]domain.Translation{"book": {glossaryNoun}}, nil)
+		glossaryClient := service.NewTranslatorGlossaryClient(client, glossaryRepo, orgID)
+
+		results, err := glossaryClient.DictionaryLookup(ctx, appD.Lang2EN, appD.Lang2JA, "book")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, service.GlossaryProvider, results[0].GetProvider())
+	})
+
+	t.Run("ErrTranslationNotFound when neither has translations", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("DictionaryLookup", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book").Return(nil, service.ErrTranslationNotFound)
+		glossaryRepo := new(service_mock.GlossaryRepository)
+		glossaryRepo.On("FindGlossaryEntriesByTexts", mock.Anything, orgID, appD.Lang2JA, []string{"book"}).Return(map[string][]domain.Translation{}, nil)
+		glossaryClient := service.NewTranslatorGlossaryClient(client, glossaryRepo, orgID)
+
+		_, err := glossaryClient.DictionaryLookup(ctx, appD.Lang2EN, appD.Lang2JA, "book")
+		assert.ErrorIs(t, err, service.ErrTranslationNotFound)
+	})
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+)
+
+var ErrTranslatorUnavailable = errors.New("translator unavailable")
+
+var (
+	translatorRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cocotola_translator_client_requests_total",
+		Help: "The total number of the requests to the translator",
+	}, []string{"transport", "method", "result"})
+	translatorFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cocotola_translator_client_failures_total",
+		Help: "The total number of the failed requests to the translator",
+	}, []string{"transport", "method", "reason"})
+	translatorCircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cocotola_translator_client_circuit_breaker_open",
+		Help: "Whether the circuit breaker of the translator is open(1) or not(0)",
+	}, []string{"transport"})
+)
+
+type circuitBreakerState int
+
+const (
+	circuitBreakerClosed circuitBreakerState = iota
+	circuitBreakerOpen
+	circuitBreakerHalfOpen
+)
+
+// circuitBreaker opens after `failureThreshold` consecutive failures, and allows a trial request after `openTimeout`
+type circuitBreaker struct {
+	mu               sync.Mutex
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+	state            circuitBreakerState
+	failures         int
+	openedAt         time.Time
+}
+
+func newCircuitBreaker(name string, failureThreshold int, openTimeout time.Duration) *circuitBreaker {
+	translatorCircuitBreakerState.WithLabelValues(name).Set(0)
+	return &circuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+	}
+}
+
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitBreakerOpen:
+		if now.Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = circuitBreakerHalfOpen
+		return true
+	case circuitBreakerHalfOpen:
+		// only one trial request is allowed
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) succeed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = circuitBreakerClosed
+	b.failures = 0
+	translatorCircuitBreakerState.WithLabelValues(b.name).Set(0)
+}
+
+// release releases the trial request without recording the result, so that the next request can try again
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitBreakerHalfOpen {
+		b.state = circuitBreakerOpen
+	}
+}
+
+func (b *circuitBreaker) fail(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == circuitBreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = circuitBreakerOpen
+		b.openedAt = now
+		translatorCircuitBreakerState.WithLabelValues(b.name).Set(1)
+	}
+}
+
+type translatorTransport struct {
+	name    string
+	client  TranslatorClient
+	breaker *circuitBreaker
+}
+
+type translatorResilientClient struct {
+	transports    []*translatorTransport
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+// NewTranslatorResilientClient returns the TranslatorClient which calls `primary`, and falls back on `secondary` when `primary` fails.
+// Lookups are retried up to `maxRetries` times with exponential backoff and jitter.
+// Each transport has a circuit breaker which stops calling it for `openTimeout` after `failureThreshold` consecutive failures.
+// `secondary` can be nil.
+func NewTranslatorResilientClient(primary, secondary TranslatorClient, maxRetries int, retryInterval time.Duration, failureThreshold int, openTimeout time.Duration) TranslatorClient {
+	transports := []*translatorTransport{
+		{name: "primary", client: primary, breaker: newCircuitBreaker("primary", failureThreshold, openTimeout)},
+	}
+	if secondary != nil {
+		transports = append(transports, &translatorTransport{name: "secondary", client: secondary, breaker: newCircuitBreaker("secondary", failureThreshold, openTimeout)})
+	}
+
+	return &translatorResilientClient{
+		transports:    transports,
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+	}
+}
+
+func (c *translatorResilientClient) DictionaryLookup(ctx context.Context, fromLang, toLang appD.Lang2, text string) ([]domain.Translation, error) {
+	var result []domain.Translation
+	if err := c.call(ctx, "DictionaryLookup", true, func(client TranslatorClient) error {
+		tmpResult, err := client.DictionaryLookup(ctx, fromLang, toLang, text)
+		if err != nil {
+			return err
+		}
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *translatorResilientClient) DictionaryLookupWithPos(ctx context.Context, fromLang, toLang appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
+	var result domain.Translation
+	if err := c.call(ctx, "DictionaryLookupWithPos", true, func(client TranslatorClient) error {
+		tmpResult, err := client.DictionaryLookupWithPos(ctx, fromLang, toLang, text, pos)
+		if err != nil {
+			return err
+		}
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *translatorResilientClient) FindTranslationsByFirstLetter(ctx context.Context, lang2 appD.Lang2, firstLetter string) ([]domain.Translation, error) {
+	var result []domain.Translation
+	if err := c.call(ctx, "FindTranslationsByFirstLetter", true, func(client TranslatorClient) error {
+		tmpResult, err := client.FindTranslationsByFirstLetter(ctx, lang2, firstLetter)
+		if err != nil {
+			return err
+		}
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *translatorResilientClient) FindTranslationByTextAndPos(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) (domain.Translation, error) {
+	var result domain.Translation
+	if err := c.call(ctx, "FindTranslationByTextAndPos", true, func(client TranslatorClient) error {
+		tmpResult, err := client.FindTranslationByTextAndPos(ctx, lang2, text, pos)
+		if err != nil {
+			return err
+		}
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *translatorResilientClient) FindTranslationsByText(ctx context.Context, lang2 appD.Lang2, text string) ([]domain.Translation, error) {
+	var result []domain.Translation
+	if err := c.call(ctx, "FindTranslationsByText", true, func(client TranslatorClient) error {
+		tmpResult, err := client.FindTranslationsByText(ctx, lang2, text)
+		if err != nil {
+			return err
+		}
+		result = tmpResult
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *translatorResilientClient) AddTranslation(ctx context.Context, param TranslationAddParameter) error {
+	return c.call(ctx, "AddTranslation", false, func(client TranslatorClient) error {
+		return client.AddTranslation(ctx, param)
+	})
+}
+
+func (c *translatorResilientClient) UpdateTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos, param TranslationUpdateParameter) error {
+	return c.call(ctx, "UpdateTranslation", false, func(client TranslatorClient) error {
+		return client.UpdateTranslation(ctx, lang2, text, pos, param)
+	})
+}
+
+func (c *translatorResilientClient) RemoveTranslation(ctx context.Context, lang2 appD.Lang2, text string, pos domain.WordPos) error {
+	return c.call(ctx, "RemoveTranslation", false, func(client TranslatorClient) error {
+		return client.RemoveTranslation(ctx, lang2, text, pos)
+	})
+}
+
+// call calls `fn` with the transports in order until it succeeds.
+// Requests which are not idempotent are not retried. The transports which don't support the operation are skipped
+func (c *translatorResilientClient) call(ctx context.Context, method string, idempotent bool, fn func(client TranslatorClient) error) error {
+	logger := log.FromContext(ctx)
+
+	maxRetries := 0
+	if idempotent {
+		maxRetries = c.maxRetries
+	}
+
+	var lastErr, unsupportedErr error
+	for _, transport := range c.transports {
+		if !transport.breaker.allow(time.Now()) {
+			translatorFailuresTotal.WithLabelValues(transport.name, method, "circuit_open").Inc()
+			continue
+		}
+
+		err := c.callWithRetry(ctx, transport, method, maxRetries, fn)
+		if err != nil && (errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled)) {
+			// the caller gave up, which says nothing about the health of the translator
+			transport.breaker.release()
+			translatorRequestsTotal.WithLabelValues(transport.name, method, "canceled").Inc()
+			return err
+		}
+
+		if err == nil {
+			transport.breaker.succeed()
+			translatorRequestsTotal.WithLabelValues(transport.name, method, "success").Inc()
+			return nil
+		}
+
+		if errors.Is(err, ErrTranslatorOperationNotSupported) {
+			// the transport doesn't support the operation, but it is working
+			transport.breaker.succeed()
+			unsupportedErr = err
+			continue
+		}
+
+		if !isTranslatorRetriableError(err) {
+			// the translator is working, but the request is invalid
+			transport.breaker.succeed()
+			translatorRequestsTotal.WithLabelValues(transport.name, method, "client_error").Inc()
+			return err
+		}
+
+		transport.breaker.fail(time.Now())
+		translatorRequestsTotal.WithLabelValues(transport.name, method, "failure").Inc()
+		logger.Warnf("translator failed. transport: %s, method: %s, err: %v", transport.name, method, err)
+		lastErr = err
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if lastErr == nil && unsupportedErr != nil {
+		return unsupportedErr
+	} else if lastErr == nil {
+		return liberrors.Errorf("all circuit breakers are open. method: %s, err: %w", method, ErrTranslatorUnavailable)
+	}
+	return liberrors.Errorf("%w. method: %s, err: %v", ErrTranslatorUnavailable, method, lastErr)
+}
+
+func (c *translatorResilientClient) callWithRetry(ctx context.Context, transport *translatorTransport, method string, maxRetries int, fn func(client TranslatorClient) error) error {
+	var err error
+	for i := 0; i <= maxRetries; i++ {
+		if i > 0 {
+			// exponential backoff with jitter
+			interval := c.retryInterval << (i - 1)
+			interval += time.Duration(rand.Int63n(int64(c.retryInterval) + 1))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		err = fn(transport.client)
+		if err == nil || !isTranslatorRetriableError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		translatorFailuresTotal.WithLabelValues(transport.name, method, translatorFailureReason(err)).Inc()
+	}
+	return err
+}
+
+// isTranslatorRetriableError returns true if the translator is temporarily unavailable.
+// The transports wrap ErrTranslatorUnavailable in the errors such as gRPC Unavailable and HTTP 5xx.
+// The other errors such as invalid arguments are neither retried nor counted as failures of the circuit breaker
+func isTranslatorRetriableError(err error) bool {
+	if errors.Is(err, ErrTranslatorUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func translatorFailureReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "error"
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	service_mock "github.com/kujilabo/cocotola-api/src/plugin/common/service/mock"
+)
+
+func TestTranslatorResilientClient_DictionaryLookupWithPos(t *testing.T) {
+	ctx := context.Background()
+	translation, err := domain.NewTranslation("book", domain.PosNoun, appD.Lang2JA, "本", "custom")
+	require.NoError(t, err)
+	errUnavailable := fmt.Errorf("%w. err: connection refused", service.ErrTranslatorUnavailable)
+
+	t.Run("retry the primary", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errUnavailable).Once()
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil).Once()
+		client := service.NewTranslatorResilientClient(primary, nil, 2, time.Millisecond, 5, time.Minute)
+
+		result, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		require.NoError(t, err)
+		assert.Equal(t, "本", result.GetTranslated())
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 2)
+	})
+
+	t.Run("fall back on the secondary", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errUnavailable)
+		secondary := new(service_mock.TranslatorClient)
+		secondary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil)
+		client := service.NewTranslatorResilientClient(primary, secondary, 1, time.Millisecond, 5, time.Minute)
+
+		result, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		require.NoError(t, err)
+		assert.Equal(t, "本", result.GetTranslated())
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 2)
+		secondary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 1)
+	})
+
+	t.Run("ErrTranslationNotFound is neither retried nor fallen back", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, service.ErrTranslationNotFound)
+		secondary := new(service_mock.TranslatorClient)
+		client := service.NewTranslatorResilientClient(primary, secondary, 2, time.Millisecond, 5, time.Minute)
+
+		_, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		assert.ErrorIs(t, err, service.ErrTranslationNotFound)
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 1)
+		secondary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 0)
+	})
+
+	t.Run("open the circuit breaker", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errUnavailable)
+		client := service.NewTranslatorResilientClient(primary, nil, 0, time.Millisecond, 2, time.Minute)
+
+		for i := 0; i < 4; i++ {
+			_, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			assert.ErrorIs(t, err, service.ErrTranslatorUnavailable)
+		}
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 2)
+	})
+
+	t.Run("close the circuit breaker after the trial request succeeds", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errUnavailable).Once()
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil)
+		client := service.NewTranslatorResilientClient(primary, nil, 0, time.Millisecond, 1, 10*time.Millisecond)
+
+		_, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		assert.ErrorIs(t, err, service.ErrTranslatorUnavailable)
+		_, err = client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		assert.ErrorIs(t, err, service.ErrTranslatorUnavailable)
+
+		time.Sleep(20 * time.Millisecond)
+		for i := 0; i < 2; i++ {
+			_, err = client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			require.NoError(t, err)
+		}
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 3)
+	})
+
+	t.Run("client errors are neither retried nor counted as failures", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errors.New("Bad Request"))
+		secondary := new(service_mock.TranslatorClient)
+		client := service.NewTranslatorResilientClient(primary, secondary, 2, time.Millisecond, 1, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			_, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, service.ErrTranslatorUnavailable)
+		}
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 3)
+		secondary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 0)
+	})
+
+	t.Run("canceled requests are not counted", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errUnavailable).Once()
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, context.Canceled).Once()
+		primary.On("DictionaryLookupWithPos", mock.Anything, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun).Return(nil, errUnavailable)
+		client := service.NewTranslatorResilientClient(primary, nil, 0, time.Millisecond, 2, time.Minute)
+
+		_, err := client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		assert.ErrorIs(t, err, service.ErrTranslatorUnavailable)
+		_, err = client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+		assert.ErrorIs(t, err, context.Canceled)
+		// the canceled request doesn't reset the failures, so the circuit breaker opens after the second failure
+		for i := 0; i < 2; i++ {
+			_, err = client.DictionaryLookupWithPos(ctx, appD.Lang2EN, appD.Lang2JA, "book", domain.PosNoun)
+			assert.ErrorIs(t, err, service.ErrTranslatorUnavailable)
+		}
+		primary.AssertNumberOfCalls(t, "DictionaryLookupWithPos", 3)
+	})
+
+	t.Run("skip the transport which doesn't support the operation", func(t *testing.T) {
+		primary := new(service_mock.TranslatorClient)
+		primary.On("FindTranslationByTextAndPos", mock.Anything, appD.Lang2JA, "book", domain.PosNoun).Return(nil, service.ErrTranslatorOperationNotSupported)
+		secondary := new(service_mock.TranslatorClient)
+		secondary.On("FindTranslationByTextAndPos", mock.Anything, appD.Lang2JA, "book", domain.PosNoun).Return(translation, nil)
+		client := service.NewTranslatorResilientClient(primary, secondary, 2, time.Millisecond, 1, time.Minute)
+
+		result, err := client.FindTranslationByTextAndPos(ctx, appD.Lang2JA, "book", domain.PosNoun)
+		require.NoError(t, err)
+		assert.Equal(t, "本", result.GetTranslated())
+		primary.AssertNumberOfCalls(t, "FindTranslationByTextAndPos", 1)
+
+		client = service.NewTranslatorResilientClient(primary, nil, 2, time.Millisecond, 1, time.Minute)
+		_, err = client.FindTranslationByTextAndPos(ctx, appD.Lang2JA, "book", domain.PosNoun)
+		assert.ErrorIs(t, err, service.ErrTranslatorOperationNotSupported)
+	})
+}
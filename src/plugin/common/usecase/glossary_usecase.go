@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+// GlossaryCSVHeader is the header of the CSV file which is imported and exported
+var GlossaryCSVHeader = []string{"lang2", "text", "pos", "translated"}
+
+type GlossaryUsecase interface {
+	FindGlossaryEntries(ctx context.Context, organizationID userD.OrganizationID) ([]domain.Translation, error)
+
+	SetGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param service.TranslationAddParameter) error
+
+	RemoveGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, lang2 appD.Lang2, text string, pos domain.WordPos) error
+
+	// ImportGlossary adds or updates the entries in the CSV. Nothing is imported if the CSV contains an invalid line
+	ImportGlossary(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, reader io.Reader) (int, error)
+
+	ExportGlossary(ctx context.Context, organizationID userD.OrganizationID, writer io.Writer) error
+}
+
+type glossaryUsecase struct {
+	db               *gorm.DB
+	glossaryRepoFunc service.GlossaryRepositoryFunc
+}
+
+func NewGlossaryUsecase(db *gorm.DB, glossaryRepoFunc service.GlossaryRepositoryFunc) GlossaryUsecase {
+	return &glossaryUsecase{
+		db:               db,
+		glossaryRepoFunc: glossaryRepoFunc,
+	}
+}
+
+func (s *glossaryUsecase) FindGlossaryEntries(ctx context.Context, organizationID userD.OrganizationID) ([]domain.Translation, error) {
+	glossaryRepo, err := s.glossaryRepoFunc(ctx, s.db)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to glossaryRepoFunc. err: %w", err)
+	}
+
+	return glossaryRepo.FindGlossaryEntries(ctx, organizationID)
+}
+
+func (s *glossaryUsecase) SetGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param service.TranslationAddParameter) error {
+	glossaryRepo, err := s.glossaryRepoFunc(ctx, s.db)
+	if err != nil {
+		return liberrors.Errorf("failed to glossaryRepoFunc. err: %w", err)
+	}
+
+	return glossaryRepo.SetGlossaryEntry(ctx, organizationID, operatorID, param)
+}
+
+func (s *glossaryUsecase) RemoveGlossaryEntry(ctx context.Context, organizationID userD.OrganizationID, lang2 appD.Lang2, text string, pos domain.WordPos) error {
+	glossaryRepo, err := s.glossaryRepoFunc(ctx, s.db)
+	if err != nil {
+		return liberrors.Errorf("failed to glossaryRepoFunc. err: %w", err)
+	}
+
+	return glossaryRepo.RemoveGlossaryEntry(ctx, organizationID, lang2, text, pos)
+}
+
+func (s *glossaryUsecase) ImportGlossary(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, reader io.Reader) (int, error) {
+	params, err := readGlossaryCSV(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		glossaryRepo, err := s.glossaryRepoFunc(ctx, tx)
+		if err != nil {
+			return liberrors.Errorf("failed to glossaryRepoFunc. err: %w", err)
+		}
+
+		for _, param := range params {
+			if err := glossaryRepo.SetGlossaryEntry(ctx, organizationID, operatorID, param); err != nil {
+				return liberrors.Errorf("failed to SetGlossaryEntry. text: %s, err: %w", param.GetText(), err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(params), nil
+}
+
+func (s *glossaryUsecase) ExportGlossary(ctx context.Context, organizationID userD.OrganizationID, writer io.Writer) error {
+	entries, err := s.FindGlossaryEntries(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(GlossaryCSVHeader); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := csvWriter.Write([]string{
+			entry.GetLang2().String(),
+			entry.GetText(),
+			strconv.Itoa(int(entry.GetPos())),
+			entry.GetTranslated(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func readGlossaryCSV(reader io.Reader) ([]service.TranslationAddParameter, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = len(GlossaryCSVHeader)
+
+	params := make([]service.TranslationAddParameter, 0)
+	for i := 1; ; i++ {
+		line, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, liberrors.Errorf("invalid csv. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+
+		if i == 1 && line[0] == GlossaryCSVHeader[0] {
+			continue
+		}
+
+		param, err := toGlossaryEntryParameter(line)
+		if err != nil {
+			return nil, liberrors.Errorf("invalid csv. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+func toGlossaryEntryParameter(line []string) (service.TranslationAddParameter, error) {
+	lang2, err := appD.NewLang2(line[0])
+	if err != nil {
+		return nil, err
+	}
+
+	posValue, err := strconv.Atoi(line[2])
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := domain.NewWordPos(posValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if line[3] == "" {
+		return nil, errors.New("translated is empty")
+	}
+
+	return service.NewTransalationAddParameter(line[1], pos, lang2, line[3])
+}
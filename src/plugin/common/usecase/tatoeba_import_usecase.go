@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+// ErrTatoebaImportOffsetMismatch is returned when the offset of the chunk isn't the received size of the job.
+// The client should resume uploading from the received size
+var ErrTatoebaImportOffsetMismatch = errors.New("offset of the chunk doesn't match the received size")
+
+// ErrTatoebaImportJobNotResumable is returned when the job isn't failed
+var ErrTatoebaImportJobNotResumable = errors.New("tatoeba import job is not resumable")
+
+const tatoebaImportErrorMessageMaxLength = 400
+
+type TatoebaImportUsecase interface {
+	// StartImport creates the job which receives the file in chunks
+	StartImport(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *service.TatoebaImportJobAddParameter) (service.TatoebaImportJob, error)
+
+	// UploadChunk appends the chunk at the offset. The lines start being sent to the tatoeba api in the background when the last chunk is received
+	UploadChunk(ctx context.Context, organizationID userD.OrganizationID, jobID service.TatoebaImportJobID, offset int64, reader io.Reader) (service.TatoebaImportJob, error)
+
+	FindJob(ctx context.Context, organizationID userD.OrganizationID, jobID service.TatoebaImportJobID) (service.TatoebaImportJob, error)
+
+	// ResumeJob restarts the failed job from the line after the processed lines.
+	// The processing job which has stopped, e.g. because the server has restarted, is regarded as failed
+	ResumeJob(ctx context.Context, organizationID userD.OrganizationID, jobID service.TatoebaImportJobID) (service.TatoebaImportJob, error)
+}
+
+type tatoebaImportUsecase struct {
+	db            *gorm.DB
+	jobRepoFunc   service.TatoebaImportJobRepositoryFunc
+	tatoebaClient service.TatoebaClient
+	workDir       string
+	batchLines    int
+}
+
+// NewTatoebaImportUsecase returns the usecase which stores the uploaded chunks under workDir and sends them to the tatoeba api every `batchLines` lines.
+// The processed lines of the job are updated after each batch
+func NewTatoebaImportUsecase(db *gorm.DB, jobRepoFunc service.TatoebaImportJobRepositoryFunc, tatoebaClient service.TatoebaClient, workDir string, batchLines int) TatoebaImportUsecase {
+	return &tatoebaImportUsecase{
+		db:            db,
+		jobRepoFunc:   jobRepoFunc,
+		tatoebaClient: tatoebaClient,
+		workDir:       workDir,
+		batchLines:    batchLines,
+	}
+}
+
+func (s *tatoebaImportUsecase) StartImport(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *service.TatoebaImportJobAddParameter) (service.TatoebaImportJob, error) {
+	if param.FileSize <= 0 {
+		return nil, liberrors.Errorf("file size must be positive. fileSize: %d, err: %w", param.FileSize, libD.ErrInvalidArgument)
+	}
+
+	jobRepo, err := s.jobRepoFunc(ctx, s.db)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to jobRepoFunc. err: %w", err)
+	}
+
+	jobID, err := jobRepo.AddJob(ctx, organizationID, operatorID, param)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to AddJob. err: %w", err)
+	}
+
+	if err := os.MkdirAll(s.workDir, 0o700); err != nil {
+		return nil, liberrors.Errorf("failed to MkdirAll. err: %w", err)
+	}
+
+	file, err := os.Create(s.jobFilePath(jobID))
+	if err != nil {
+		return nil, liberrors.Errorf("failed to Create. err: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	return jobRepo.FindJob(ctx, organizationID, jobID)
+}
+
+func (s *tatoebaImportUsecase) UploadChunk(ctx context.Context, organizationID userD.OrganizationID, jobID service.TatoebaImportJobID, offset int64, reader io.Reader) (service.TatoebaImportJob, error) {
+	jobRepo, err := s.jobRepoFunc(ctx, s.db)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to jobRepoFunc. err: %w", err)
+	}
+
+	job, err := jobRepo.FindJob(ctx, organizationID, jobID)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindJob. err: %w", err)
+	}
+
+	if job.GetStatus() != service.TatoebaImportJobStatusUploading || job.GetReceivedSize() != offset {
+		return job, ErrTatoebaImportOffsetMismatch
+	}
+
+	file, err := os.OpenFile(s.jobFilePath(jobID), os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to OpenFile. err: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// read one more byte to detect the chunk which exceeds the file size
+	remaining := job.GetFileSize() - offset
+	written, err := io.Copy(file, io.LimitReader(reader, remaining+1))
+	if err != nil {
+		return nil, liberrors.Errorf("failed to write the chunk. err: %w", err)
+	}
+	if written > remaining {
+		return nil, liberrors.Errorf("chunk exceeds the file size. fileSize: %d, err: %w", job.GetFileSize(), libD.ErrInvalidArgument)
+	}
+	if err := file.Sync(); err != nil {
+		return nil, err
+	}
+
+	if err := jobRepo.UpdateReceivedSize(ctx, jobID, offset, offset+written); errors.Is(err, service.ErrTatoebaImportJobConflict) {
+		return nil, ErrTatoebaImportOffsetMismatch
+	} else if err != nil {
+		return nil, liberrors.Errorf("failed to UpdateReceivedSize. err: %w", err)
+	}
+
+	if offset+written == job.GetFileSize() {
+		if err := s.startProcessing(ctx, jobRepo, job.GetKind(), jobID, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return jobRepo.FindJob(ctx, organizationID, jobID)
+}
+
+func (s *tatoebaImportUsecase) FindJob(ctx context.Context, organizationID userD.OrganizationID, jobID service.TatoebaImportJobID) (service.TatoebaImportJob, error) {
+	jobRepo, err := s.jobRepoFunc(ctx, s.db)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to jobRepoFunc. err: %w", err)
+	}
+
+	return jobRepo.FindJob(ctx, organizationID, jobID)
+}
+
+func (s *tatoebaImportUsecase) ResumeJob(ctx context.Context, organizationID userD.OrganizationID, jobID service.TatoebaImportJobID) (service.TatoebaImportJob, error) {
+	jobRepo, err := s.jobRepoFunc(ctx, s.db)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to jobRepoFunc. err: %w", err)
+	}
+
+	job, err := jobRepo.FindJob(ctx, organizationID, jobID)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindJob. err: %w", err)
+	}
+
+	if job.GetStatus() != service.TatoebaImportJobStatusFailed {
+		return nil, ErrTatoebaImportJobNotResumable
+	}
+
+	if err := s.startProcessing(ctx, jobRepo, job.GetKind(), jobID, job.GetProcessedLines()); err != nil {
+		return nil, err
+	}
+
+	return jobRepo.FindJob(ctx, organizationID, jobID)
+}
+
+func (s *tatoebaImportUsecase) startProcessing(ctx context.Context, jobRepo service.TatoebaImportJobRepository, kind service.TatoebaImportKind, jobID service.TatoebaImportJobID, processedLines int) error {
+	if err := jobRepo.UpdateStatus(ctx, jobID, service.TatoebaImportJobStatusProcessing, ""); err != nil {
+		return liberrors.Errorf("failed to UpdateStatus. err: %w", err)
+	}
+
+	// the request context is canceled when the response is written
+	bgCtx := log.With(context.Background(), log.Str("tatoebaImportJobID", strconv.Itoa(int(jobID))))
+	go func() {
+		logger := log.FromContext(bgCtx)
+		status := service.TatoebaImportJobStatusCompleted
+		errorMessage := ""
+		if err := s.process(bgCtx, jobRepo, kind, jobID, processedLines); err != nil {
+			logger.Errorf("failed to process tatoeba import job. jobID: %d, err: %v", jobID, err)
+			status = service.TatoebaImportJobStatusFailed
+			errorMessage = truncateErrorMessage(err.Error())
+		}
+
+		if err := jobRepo.UpdateStatus(bgCtx, jobID, status, errorMessage); err != nil {
+			logger.Errorf("failed to UpdateStatus. jobID: %d, err: %v", jobID, err)
+			return
+		}
+
+		if status == service.TatoebaImportJobStatusCompleted {
+			if err := os.Remove(s.jobFilePath(jobID)); err != nil {
+				logger.Warnf("failed to Remove. jobID: %d, err: %v", jobID, err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *tatoebaImportUsecase) process(ctx context.Context, jobRepo service.TatoebaImportJobRepository, kind service.TatoebaImportKind, jobID service.TatoebaImportJobID, processedLines int) error {
+	file, err := os.Open(s.jobFilePath(jobID))
+	if err != nil {
+		return liberrors.Errorf("failed to Open. err: %w", err)
+	}
+	defer file.Close()
+
+	importFunc := s.tatoebaClient.ImportSentences
+	if kind == service.TatoebaImportKindLink {
+		importFunc = s.tatoebaClient.ImportLinks
+	}
+
+	reader := bufio.NewReader(file)
+	for i := 0; i < processedLines; i++ {
+		if _, err := reader.ReadBytes('\n'); err != nil {
+			return liberrors.Errorf("failed to skip the processed lines. processedLines: %d, err: %w", processedLines, err)
+		}
+	}
+
+	batch := bytes.Buffer{}
+	lines := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			batch.Write(line)
+			lines++
+		}
+		eof := errors.Is(err, io.EOF)
+		if err != nil && !eof {
+			return err
+		}
+
+		if lines == s.batchLines || (eof && lines > 0) {
+			if err := importFunc(ctx, &batch); err != nil {
+				return liberrors.Errorf("failed to import. processedLines: %d, err: %w", processedLines, err)
+			}
+			processedLines += lines
+			if err := jobRepo.UpdateProcessedLines(ctx, jobID, processedLines); err != nil {
+				return liberrors.Errorf("failed to UpdateProcessedLines. err: %w", err)
+			}
+			batch.Reset()
+			lines = 0
+		}
+
+		if eof {
+			return nil
+		}
+	}
+}
+
+func (s *tatoebaImportUsecase) jobFilePath(jobID service.TatoebaImportJobID) string {
+	return filepath.Join(s.workDir, fmt.Sprintf("tatoeba-import-%d.tsv", jobID))
+}
+
+func truncateErrorMessage(message string) string {
+	runes := []rune(message)
+	if len(runes) > tatoebaImportErrorMessageMaxLength {
+		return string(runes[:tatoebaImportErrorMessageMaxLength])
+	}
+	return message
+}
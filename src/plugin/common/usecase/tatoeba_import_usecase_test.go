@@ -0,0 +1,183 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	service_mock "github.com/kujilabo/cocotola-api/src/plugin/common/service/mock"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type inMemoryTatoebaImportJobRepository struct {
+	mu   sync.Mutex
+	jobs map[service.TatoebaImportJobID]*service.TatoebaImportJobAddParameter
+	// received, processed, status and message of the jobs
+	received  map[service.TatoebaImportJobID]int64
+	processed map[service.TatoebaImportJobID]int
+	status    map[service.TatoebaImportJobID]service.TatoebaImportJobStatus
+	message   map[service.TatoebaImportJobID]string
+}
+
+func newInMemoryTatoebaImportJobRepository() *inMemoryTatoebaImportJobRepository {
+	return &inMemoryTatoebaImportJobRepository{
+		jobs:      make(map[service.TatoebaImportJobID]*service.TatoebaImportJobAddParameter),
+		received:  make(map[service.TatoebaImportJobID]int64),
+		processed: make(map[service.TatoebaImportJobID]int),
+		status:    make(map[service.TatoebaImportJobID]service.TatoebaImportJobStatus),
+		message:   make(map[service.TatoebaImportJobID]string),
+	}
+}
+
+func (r *inMemoryTatoebaImportJobRepository) AddJob(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, param *service.TatoebaImportJobAddParameter) (service.TatoebaImportJobID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := service.TatoebaImportJobID(len(r.jobs) + 1)
+	r.jobs[id] = param
+	r.status[id] = service.TatoebaImportJobStatusUploading
+	return id, nil
+}
+
+func (r *inMemoryTatoebaImportJobRepository) FindJob(ctx context.Context, organizationID userD.OrganizationID, id service.TatoebaImportJobID) (service.TatoebaImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	param, ok := r.jobs[id]
+	if !ok {
+		return nil, service.ErrTatoebaImportJobNotFound
+	}
+	return service.NewTatoebaImportJob(id, param.Kind, param.FileName, param.FileSize, r.received[id], r.processed[id], r.status[id], r.message[id], time.Now(), time.Now())
+}
+
+func (r *inMemoryTatoebaImportJobRepository) UpdateReceivedSize(ctx context.Context, id service.TatoebaImportJobID, from, to int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.received[id] != from || r.status[id] != service.TatoebaImportJobStatusUploading {
+		return service.ErrTatoebaImportJobConflict
+	}
+	r.received[id] = to
+	return nil
+}
+
+func (r *inMemoryTatoebaImportJobRepository) UpdateProcessedLines(ctx context.Context, id service.TatoebaImportJobID, processedLines int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processed[id] = processedLines
+	return nil
+}
+
+func (r *inMemoryTatoebaImportJobRepository) UpdateStatus(ctx context.Context, id service.TatoebaImportJobID, status service.TatoebaImportJobStatus, errorMessage string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status[id] = status
+	r.message[id] = errorMessage
+	return nil
+}
+
+func waitForTatoebaImportJob(t *testing.T, s usecase.TatoebaImportUsecase, jobID service.TatoebaImportJobID) service.TatoebaImportJob {
+	var job service.TatoebaImportJob
+	require.Eventually(t, func() bool {
+		j, err := s.FindJob(context.Background(), userD.OrganizationID(1), jobID)
+		require.NoError(t, err)
+		job = j
+		return j.GetStatus() != service.TatoebaImportJobStatusProcessing
+	}, time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestTatoebaImportUsecase(t *testing.T) {
+	ctx := context.Background()
+	orgID := userD.OrganizationID(1)
+	content := "1\teng\tA\n2\teng\tB\n3\teng\tC\n"
+
+	newUsecase := func(tatoebaClient service.TatoebaClient) usecase.TatoebaImportUsecase {
+		jobRepo := newInMemoryTatoebaImportJobRepository()
+		return usecase.NewTatoebaImportUsecase(nil, func(ctx context.Context, db *gorm.DB) (service.TatoebaImportJobRepository, error) {
+			return jobRepo, nil
+		}, tatoebaClient, t.TempDir(), 2)
+	}
+	readAll := func(reader io.Reader) string {
+		b, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("upload the chunks and import the lines in batches", func(t *testing.T) {
+		batches := make([]string, 0)
+		tatoebaClient := new(service_mock.TatoebaClient)
+		tatoebaClient.On("ImportSentences", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			batches = append(batches, readAll(args.Get(1).(io.Reader)))
+		}).Return(nil)
+		s := newUsecase(tatoebaClient)
+
+		job, err := s.StartImport(ctx, orgID, userD.AppUserID(1), &service.TatoebaImportJobAddParameter{Kind: service.TatoebaImportKindSentence, FileSize: int64(len(content))})
+		require.NoError(t, err)
+
+		job, err = s.UploadChunk(ctx, orgID, job.GetID(), 0, strings.NewReader(content[:10]))
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), job.GetReceivedSize())
+		assert.Equal(t, service.TatoebaImportJobStatusUploading, job.GetStatus())
+
+		// the chunk is uploaded again after the connection is dropped
+		job, err = s.UploadChunk(ctx, orgID, job.GetID(), 0, strings.NewReader(content[:10]))
+		assert.True(t, errors.Is(err, usecase.ErrTatoebaImportOffsetMismatch))
+		assert.Equal(t, int64(10), job.GetReceivedSize())
+
+		_, err = s.UploadChunk(ctx, orgID, job.GetID(), 10, strings.NewReader(content[10:]))
+		require.NoError(t, err)
+
+		job = waitForTatoebaImportJob(t, s, job.GetID())
+		assert.Equal(t, service.TatoebaImportJobStatusCompleted, job.GetStatus())
+		assert.Equal(t, 3, job.GetProcessedLines())
+		assert.Equal(t, []string{"1\teng\tA\n2\teng\tB\n", "3\teng\tC\n"}, batches)
+	})
+
+	t.Run("resume the failed job from the processed lines", func(t *testing.T) {
+		batches := make([]string, 0)
+		tatoebaClient := new(service_mock.TatoebaClient)
+		tatoebaClient.On("ImportLinks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			batches = append(batches, readAll(args.Get(1).(io.Reader)))
+		}).Return(nil).Once()
+		tatoebaClient.On("ImportLinks", mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+		tatoebaClient.On("ImportLinks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			batches = append(batches, readAll(args.Get(1).(io.Reader)))
+		}).Return(nil)
+		s := newUsecase(tatoebaClient)
+
+		job, err := s.StartImport(ctx, orgID, userD.AppUserID(1), &service.TatoebaImportJobAddParameter{Kind: service.TatoebaImportKindLink, FileSize: int64(len(content))})
+		require.NoError(t, err)
+		_, err = s.UploadChunk(ctx, orgID, job.GetID(), 0, strings.NewReader(content))
+		require.NoError(t, err)
+
+		job = waitForTatoebaImportJob(t, s, job.GetID())
+		assert.Equal(t, service.TatoebaImportJobStatusFailed, job.GetStatus())
+		assert.Equal(t, 2, job.GetProcessedLines())
+
+		_, err = s.ResumeJob(ctx, orgID, job.GetID())
+		require.NoError(t, err)
+
+		job = waitForTatoebaImportJob(t, s, job.GetID())
+		assert.Equal(t, service.TatoebaImportJobStatusCompleted, job.GetStatus())
+		assert.Equal(t, 3, job.GetProcessedLines())
+		assert.Equal(t, []string{"1\teng\tA\n2\teng\tB\n", "3\teng\tC\n"}, batches)
+	})
+
+	t.Run("chunk exceeding the file size", func(t *testing.T) {
+		s := newUsecase(new(service_mock.TatoebaClient))
+
+		job, err := s.StartImport(ctx, orgID, userD.AppUserID(1), &service.TatoebaImportJobAddParameter{Kind: service.TatoebaImportKindSentence, FileSize: 5})
+		require.NoError(t, err)
+		_, err = s.UploadChunk(ctx, orgID, job.GetID(), 0, strings.NewReader(content))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+)
+
+type TranslationFormat string
+
+const (
+	TranslationFormatCSV   TranslationFormat = "csv"
+	TranslationFormatTSV   TranslationFormat = "tsv"
+	TranslationFormatJSONL TranslationFormat = "jsonl"
+)
+
+// TranslationCSVHeader is the header of the CSV and TSV files. provider and updatedAt are ignored when they are imported
+var TranslationCSVHeader = []string{"lang2", "text", "pos", "translated", "provider", "updatedAt"}
+
+func NewTranslationFormat(value string) (TranslationFormat, error) {
+	switch format := TranslationFormat(strings.ToLower(value)); format {
+	case TranslationFormatCSV, TranslationFormatTSV, TranslationFormatJSONL:
+		return format, nil
+	default:
+		return "", liberrors.Errorf("unsupported format. format: %s, err: %w", value, libD.ErrInvalidArgument)
+	}
+}
+
+func (f TranslationFormat) ContentType() string {
+	switch f {
+	case TranslationFormatTSV:
+		return "text/tab-separated-values"
+	case TranslationFormatJSONL:
+		return "application/x-ndjson"
+	default:
+		return "text/csv"
+	}
+}
+
+type translationLine struct {
+	Lang2      string `json:"lang2"`
+	Text       string `json:"text"`
+	Pos        int    `json:"pos"`
+	Translated string `json:"translated"`
+	Provider   string `json:"provider,omitempty"`
+	UpdatedAt  string `json:"updatedAt,omitempty"`
+}
+
+func toTranslationLine(translation domain.Translation) translationLine {
+	updatedAt := ""
+	if !translation.GetUpdatedAt().IsZero() {
+		updatedAt = translation.GetUpdatedAt().Format(time.RFC3339)
+	}
+
+	return translationLine{
+		Lang2:      translation.GetLang2().String(),
+		Text:       translation.GetText(),
+		Pos:        int(translation.GetPos()),
+		Translated: translation.GetTranslated(),
+		Provider:   translation.GetProvider(),
+		UpdatedAt:  updatedAt,
+	}
+}
+
+func (l *translationLine) toParameter() (service.TranslationAddParameter, error) {
+	lang2, err := appD.NewLang2(l.Lang2)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := domain.NewWordPos(l.Pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.Translated == "" {
+		return nil, errors.New("translated is empty")
+	}
+
+	return service.NewTransalationAddParameter(l.Text, pos, lang2, l.Translated)
+}
+
+type translationWriter interface {
+	Write(translation domain.Translation) error
+	Flush() error
+}
+
+func newTranslationWriter(format TranslationFormat, writer io.Writer) (translationWriter, error) {
+	switch format {
+	case TranslationFormatCSV:
+		return newTranslationCSVWriter(writer, ',')
+	case TranslationFormatTSV:
+		return newTranslationCSVWriter(writer, '\t')
+	case TranslationFormatJSONL:
+		return &translationJSONLWriter{encoder: json.NewEncoder(writer)}, nil
+	default:
+		return nil, liberrors.Errorf("unsupported format. format: %s, err: %w", format, libD.ErrInvalidArgument)
+	}
+}
+
+type translationCSVWriter struct {
+	writer *csv.Writer
+}
+
+func newTranslationCSVWriter(writer io.Writer, comma rune) (translationWriter, error) {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = comma
+	if err := csvWriter.Write(TranslationCSVHeader); err != nil {
+		return nil, err
+	}
+	return &translationCSVWriter{writer: csvWriter}, nil
+}
+
+func (w *translationCSVWriter) Write(translation domain.Translation) error {
+	line := toTranslationLine(translation)
+	return w.writer.Write([]string{line.Lang2, line.Text, strconv.Itoa(line.Pos), line.Translated, line.Provider, line.UpdatedAt})
+}
+
+func (w *translationCSVWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type translationJSONLWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *translationJSONLWriter) Write(translation domain.Translation) error {
+	return w.encoder.Encode(toTranslationLine(translation))
+}
+
+func (w *translationJSONLWriter) Flush() error {
+	return nil
+}
+
+// readTranslations reads all the lines and returns ErrInvalidArgument with the line number if one of them is invalid
+func readTranslations(format TranslationFormat, reader io.Reader) ([]service.TranslationAddParameter, error) {
+	switch format {
+	case TranslationFormatCSV:
+		return readTranslationsCSV(reader, ',')
+	case TranslationFormatTSV:
+		return readTranslationsCSV(reader, '\t')
+	case TranslationFormatJSONL:
+		return readTranslationsJSONL(reader)
+	default:
+		return nil, liberrors.Errorf("unsupported format. format: %s, err: %w", format, libD.ErrInvalidArgument)
+	}
+}
+
+func readTranslationsCSV(reader io.Reader, comma rune) ([]service.TranslationAddParameter, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = comma
+	csvReader.FieldsPerRecord = -1
+	if comma == '\t' {
+		csvReader.LazyQuotes = true
+	}
+
+	params := make([]service.TranslationAddParameter, 0)
+	for i := 1; ; i++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, liberrors.Errorf("invalid line. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+
+		if i == 1 && record[0] == TranslationCSVHeader[0] {
+			continue
+		}
+
+		if len(record) < 4 {
+			return nil, liberrors.Errorf("invalid line. line: %d, err: the number of fields is less than 4. %w", i, libD.ErrInvalidArgument)
+		}
+
+		pos, err := strconv.Atoi(record[2])
+		if err != nil {
+			return nil, liberrors.Errorf("invalid line. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+
+		line := translationLine{Lang2: record[0], Text: record[1], Pos: pos, Translated: record[3]}
+		param, err := line.toParameter()
+		if err != nil {
+			return nil, liberrors.Errorf("invalid line. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
+
+func readTranslationsJSONL(reader io.Reader) ([]service.TranslationAddParameter, error) {
+	decoder := json.NewDecoder(reader)
+
+	params := make([]service.TranslationAddParameter, 0)
+	for i := 1; ; i++ {
+		line := translationLine{}
+		if err := decoder.Decode(&line); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, liberrors.Errorf("invalid line. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+
+		param, err := line.toParameter()
+		if err != nil {
+			return nil, liberrors.Errorf("invalid line. line: %d, err: %v. %w", i, err, libD.ErrInvalidArgument)
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+)
+
+// translationExportLetters are the first letters which are exported when the first letter is not specified
+const translationExportLetters = "abcdefghijklmnopqrstuvwxyz"
+
+type TranslationExportParameter struct {
+	Format      TranslationFormat
+	Lang2       appD.Lang2
+	Pos         *domain.WordPos
+	FirstLetter string
+	// UpdatedSince excludes the translations which were updated before it. The translations whose updated time is unknown are also excluded
+	UpdatedSince time.Time
+}
+
+type TranslationDiff struct {
+	Lang2  string
+	Text   string
+	Pos    domain.WordPos
+	Before string
+	After  string
+}
+
+type TranslationImportResult struct {
+	Added     []TranslationDiff
+	Updated   []TranslationDiff
+	Unchanged int
+}
+
+type TranslationUsecase interface {
+	// ExportTranslations writes the translations to the writer letter by letter. The writer is flushed after each letter if it implements Flush()
+	ExportTranslations(ctx context.Context, param TranslationExportParameter, writer io.Writer) error
+
+	// ImportTranslations adds the new translations and updates the changed ones. Nothing is imported if the file contains an invalid line.
+	// The translations are only compared if dryRun is true
+	ImportTranslations(ctx context.Context, format TranslationFormat, reader io.Reader, dryRun bool) (*TranslationImportResult, error)
+}
+
+type translationUsecase struct {
+	translatorClient service.TranslatorClient
+}
+
+func NewTranslationUsecase(translatorClient service.TranslatorClient) TranslationUsecase {
+	return &translationUsecase{
+		translatorClient: translatorClient,
+	}
+}
+
+func (s *translationUsecase) ExportTranslations(ctx context.Context, param TranslationExportParameter, writer io.Writer) error {
+	translationWriter, err := newTranslationWriter(param.Format, writer)
+	if err != nil {
+		return err
+	}
+
+	letters := strings.Split(translationExportLetters, "")
+	if param.FirstLetter != "" {
+		letters = []string{param.FirstLetter}
+	}
+
+	for _, letter := range letters {
+		translations, err := s.translatorClient.FindTranslationsByFirstLetter(ctx, param.Lang2, letter)
+		if errors.Is(err, service.ErrTranslationNotFound) {
+			continue
+		} else if err != nil {
+			return liberrors.Errorf("failed to FindTranslationsByFirstLetter. letter: %s, err: %w", letter, err)
+		}
+
+		for _, translation := range translations {
+			if !matchTranslationExportParameter(&param, translation) {
+				continue
+			}
+			if err := translationWriter.Write(translation); err != nil {
+				return err
+			}
+		}
+
+		if err := translationWriter.Flush(); err != nil {
+			return err
+		}
+		if flusher, ok := writer.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+	}
+
+	return nil
+}
+
+func matchTranslationExportParameter(param *TranslationExportParameter, translation domain.Translation) bool {
+	if param.Pos != nil && translation.GetPos() != *param.Pos {
+		return false
+	}
+	if !param.UpdatedSince.IsZero() && translation.GetUpdatedAt().Before(param.UpdatedSince) {
+		return false
+	}
+	return true
+}
+
+func (s *translationUsecase) ImportTranslations(ctx context.Context, format TranslationFormat, reader io.Reader, dryRun bool) (*TranslationImportResult, error) {
+	params, err := readTranslations(format, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	result := TranslationImportResult{
+		Added:   make([]TranslationDiff, 0),
+		Updated: make([]TranslationDiff, 0),
+	}
+	for _, param := range params {
+		diff := TranslationDiff{
+			Lang2: param.GetLang2().String(),
+			Text:  param.GetText(),
+			Pos:   param.GetPos(),
+			After: param.GetTranslated(),
+		}
+
+		current, err := s.translatorClient.FindTranslationByTextAndPos(ctx, param.GetLang2(), param.GetText(), param.GetPos())
+		if errors.Is(err, service.ErrTranslationNotFound) {
+			if !dryRun {
+				if err := s.translatorClient.AddTranslation(ctx, param); err != nil {
+					return nil, liberrors.Errorf("failed to AddTranslation. text: %s, err: %w", param.GetText(), err)
+				}
+			}
+			result.Added = append(result.Added, diff)
+			continue
+		} else if err != nil {
+			return nil, liberrors.Errorf("failed to FindTranslationByTextAndPos. text: %s, err: %w", param.GetText(), err)
+		}
+
+		if current.GetTranslated() == param.GetTranslated() {
+			result.Unchanged++
+			continue
+		}
+
+		if !dryRun {
+			updateParam, err := service.NewTransaltionUpdateParameter(param.GetTranslated())
+			if err != nil {
+				return nil, err
+			}
+			if err := s.translatorClient.UpdateTranslation(ctx, param.GetLang2(), param.GetText(), param.GetPos(), updateParam); err != nil {
+				return nil, liberrors.Errorf("failed to UpdateTranslation. text: %s, err: %w", param.GetText(), err)
+			}
+		}
+		diff.Before = current.GetTranslated()
+		result.Updated = append(result.Updated, diff)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,130 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	service_mock "github.com/kujilabo/cocotola-api/src/plugin/common/service/mock"
+	"github.com/kujilabo/cocotola-api/src/plugin/common/usecase"
+)
+
+func TestTranslationUsecase_ExportTranslations(t *testing.T) {
+	ctx := context.Background()
+	updatedAt := time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC)
+	bookNoun, err := domain.NewTranslationWithUpdatedAt("book", domain.PosNoun, appD.Lang2JA, "本", "custom", updatedAt)
+	require.NoError(t, err)
+	bookVerb, err := domain.NewTranslation("book", domain.PosVerb, appD.Lang2JA, "予約する", "azure")
+	require.NoError(t, err)
+
+	newClient := func() *service_mock.TranslatorClient {
+		client := new(service_mock.TranslatorClient)
+		client.On("FindTranslationsByFirstLetter", mock.Anything, appD.Lang2JA, "b").Return([]domain.Translation{bookNoun, bookVerb}, nil)
+		client.On("FindTranslationsByFirstLetter", mock.Anything, appD.Lang2JA, mock.Anything).Return(nil, service.ErrTranslationNotFound)
+		return client
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		client := newClient()
+		buf := new(bytes.Buffer)
+		err := usecase.NewTranslationUsecase(client).ExportTranslations(ctx, usecase.TranslationExportParameter{
+			Format: usecase.TranslationFormatCSV,
+			Lang2:  appD.Lang2JA,
+		}, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "lang2,text,pos,translated,provider,updatedAt\nja,book,6,本,custom,2022-09-01T00:00:00Z\nja,book,9,予約する,azure,\n", buf.String())
+		client.AssertNumberOfCalls(t, "FindTranslationsByFirstLetter", 26)
+	})
+
+	t.Run("jsonl filtered by pos and letter", func(t *testing.T) {
+		client := newClient()
+		pos := domain.PosVerb
+		buf := new(bytes.Buffer)
+		err := usecase.NewTranslationUsecase(client).ExportTranslations(ctx, usecase.TranslationExportParameter{
+			Format:      usecase.TranslationFormatJSONL,
+			Lang2:       appD.Lang2JA,
+			Pos:         &pos,
+			FirstLetter: "b",
+		}, buf)
+		require.NoError(t, err)
+		assert.Equal(t, `{"lang2":"ja","text":"book","pos":9,"translated":"予約する","provider":"azure"}`+"\n", buf.String())
+		client.AssertNumberOfCalls(t, "FindTranslationsByFirstLetter", 1)
+	})
+
+	t.Run("tsv filtered by updatedSince", func(t *testing.T) {
+		client := newClient()
+		buf := new(bytes.Buffer)
+		err := usecase.NewTranslationUsecase(client).ExportTranslations(ctx, usecase.TranslationExportParameter{
+			Format:       usecase.TranslationFormatTSV,
+			Lang2:        appD.Lang2JA,
+			FirstLetter:  "b",
+			UpdatedSince: updatedAt.Add(-time.Hour),
+		}, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "lang2\ttext\tpos\ttranslated\tprovider\tupdatedAt\nja\tbook\t6\t本\tcustom\t2022-09-01T00:00:00Z\n", buf.String())
+	})
+}
+
+func TestTranslationUsecase_ImportTranslations(t *testing.T) {
+	ctx := context.Background()
+	bookNoun, err := domain.NewTranslation("book", domain.PosNoun, appD.Lang2JA, "本", "custom")
+	require.NoError(t, err)
+	bookVerb, err := domain.NewTranslation("book", domain.PosVerb, appD.Lang2JA, "予約する", "azure")
+	require.NoError(t, err)
+	input := "lang2,text,pos,translated\nja,book,6,本\nja,book,9,予約\nja,pen,6,ペン\n"
+
+	newClient := func() *service_mock.TranslatorClient {
+		client := new(service_mock.TranslatorClient)
+		client.On("FindTranslationByTextAndPos", mock.Anything, appD.Lang2JA, "book", domain.PosNoun).Return(bookNoun, nil)
+		client.On("FindTranslationByTextAndPos", mock.Anything, appD.Lang2JA, "book", domain.PosVerb).Return(bookVerb, nil)
+		client.On("FindTranslationByTextAndPos", mock.Anything, appD.Lang2JA, "pen", domain.PosNoun).Return(nil, service.ErrTranslationNotFound)
+		client.On("AddTranslation", mock.Anything, mock.Anything).Return(nil)
+		client.On("UpdateTranslation", mock.Anything, appD.Lang2JA, "book", domain.PosVerb, mock.Anything).Return(nil)
+		return client
+	}
+
+	t.Run("import", func(t *testing.T) {
+		client := newClient()
+		result, err := usecase.NewTranslationUsecase(client).ImportTranslations(ctx, usecase.TranslationFormatCSV, strings.NewReader(input), false)
+		require.NoError(t, err)
+		assert.Equal(t, []usecase.TranslationDiff{{Lang2: "ja", Text: "pen", Pos: domain.PosNoun, After: "ペン"}}, result.Added)
+		assert.Equal(t, []usecase.TranslationDiff{{Lang2: "ja", Text: "book", Pos: domain.PosVerb, Before: "予約する", After: "予約"}}, result.Updated)
+		assert.Equal(t, 1, result.Unchanged)
+		client.AssertNumberOfCalls(t, "AddTranslation", 1)
+		client.AssertNumberOfCalls(t, "UpdateTranslation", 1)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := newClient()
+		result, err := usecase.NewTranslationUsecase(client).ImportTranslations(ctx, usecase.TranslationFormatCSV, strings.NewReader(input), true)
+		require.NoError(t, err)
+		assert.Len(t, result.Added, 1)
+		assert.Len(t, result.Updated, 1)
+		client.AssertNotCalled(t, "AddTranslation", mock.Anything, mock.Anything)
+		client.AssertNotCalled(t, "UpdateTranslation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		client := newClient()
+		_, err := usecase.NewTranslationUsecase(client).ImportTranslations(ctx, usecase.TranslationFormatJSONL, strings.NewReader(`{"lang2":"ja","text":"book","pos":6,"translated":""}`), false)
+		assert.ErrorIs(t, err, libD.ErrInvalidArgument)
+		client.AssertNotCalled(t, "FindTranslationByTextAndPos", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("the translator doesn't support the operation", func(t *testing.T) {
+		client := new(service_mock.TranslatorClient)
+		client.On("FindTranslationByTextAndPos", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, service.ErrTranslatorOperationNotSupported)
+		_, err := usecase.NewTranslationUsecase(client).ImportTranslations(ctx, usecase.TranslationFormatCSV, strings.NewReader(input), true)
+		assert.ErrorIs(t, err, service.ErrTranslatorOperationNotSupported)
+	})
+}
@@ -0,0 +1,53 @@
+package converter
+
+import (
+	"context"
+
+	pluginCommonS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/usecase"
+)
+
+func ToTatoebaSentenceDifficultySearchCondition(ctx context.Context, param *entity.SentenceFindParameter) *usecase.TatoebaSentenceDifficultySearchCondition {
+	maxDifficulty := param.MaxDifficulty
+	if maxDifficulty == 0 {
+		maxDifficulty = domain.SentenceDifficultyMax
+	}
+
+	return &usecase.TatoebaSentenceDifficultySearchCondition{
+		PageNo:        param.PageNo,
+		PageSize:      param.PageSize,
+		Keyword:       param.Keyword,
+		Random:        param.Random,
+		MinDifficulty: param.MinDifficulty,
+		MaxDifficulty: maxDifficulty,
+	}
+}
+
+func ToSentenceFindResponse(ctx context.Context, results []usecase.TatoebaSentencePairWithDifficulty) *entity.SentenceFindResponse {
+	toSentence := func(m pluginCommonS.TatoebaSentence) entity.Sentence {
+		return entity.Sentence{
+			SentenceNumber: m.GetSentenceNumber(),
+			Lang2:          m.GetLang2().String(),
+			Text:           m.GetText(),
+			Author:         m.GetAuthor(),
+		}
+	}
+
+	entities := make([]entity.SentencePairWithDifficulty, len(results))
+	for i, r := range results {
+		entities[i] = entity.SentencePairWithDifficulty{
+			Src:          toSentence(r.Pair.GetSrc()),
+			Dst:          toSentence(r.Pair.GetDst()),
+			Difficulty:   r.Difficulty.Score,
+			WordCount:    r.Difficulty.WordCount,
+			MaxRank:      r.Difficulty.MaxRank,
+			ContentWords: r.Difficulty.ContentWords,
+		}
+	}
+
+	return &entity.SentenceFindResponse{
+		Results: entities,
+	}
+}
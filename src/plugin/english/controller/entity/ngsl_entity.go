@@ -0,0 +1,5 @@
+package entity
+
+type NGSLWorkbookGenerateParameter struct {
+	Size int `json:"size" binding:"omitempty,gte=1,lte=100"`
+}
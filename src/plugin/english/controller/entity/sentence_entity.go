@@ -0,0 +1,30 @@
+package entity
+
+type SentenceFindParameter struct {
+	PageNo        int    `json:"pageNo" binding:"required,gte=1"`
+	PageSize      int    `json:"pageSize" binding:"required,gte=1,lte=100"`
+	Keyword       string `json:"keyword"`
+	Random        bool   `json:"random"`
+	MinDifficulty int    `json:"minDifficulty" binding:"gte=0,lte=100"`
+	MaxDifficulty int    `json:"maxDifficulty" binding:"omitempty,gte=0,lte=100"`
+}
+
+type Sentence struct {
+	SentenceNumber int    `json:"sentenceNumber"`
+	Lang2          string `json:"lang2"`
+	Text           string `json:"text"`
+	Author         string `json:"author"`
+}
+
+type SentencePairWithDifficulty struct {
+	Src          Sentence `json:"src"`
+	Dst          Sentence `json:"dst"`
+	Difficulty   int      `json:"difficulty"`
+	WordCount    int      `json:"wordCount"`
+	MaxRank      int      `json:"maxRank"`
+	ContentWords []string `json:"contentWords"`
+}
+
+type SentenceFindResponse struct {
+	Results []SentencePairWithDifficulty `json:"results"`
+}
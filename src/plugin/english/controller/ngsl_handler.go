@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/usecase"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type NGSLHandler interface {
+	GenerateWorkbook(c *gin.Context)
+}
+
+type ngslHandler struct {
+	studentUsecaseNGSL usecase.StudentUsecaseNGSL
+}
+
+func NewNGSLHandler(studentUsecaseNGSL usecase.StudentUsecaseNGSL) NGSLHandler {
+	return &ngslHandler{
+		studentUsecaseNGSL: studentUsecaseNGSL,
+	}
+}
+
+// GenerateWorkbook godoc
+// @Summary Create new workbook which contains the next NGSL words the user doesn't have yet
+// @Produce json
+// @Param param body entity.NGSLWorkbookGenerateParameter true "parameter to generate new workbook"
+// @Success 200 {object} controllerhelper.IDResponse
+// @Failure 400
+// @Router /plugin/english/ngsl/workbook [post]
+func (h *ngslHandler) GenerateWorkbook(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.NGSLWorkbookGenerateParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			logger.Warnf("failed to BindJSON. err: %v", err)
+			return nil
+		}
+
+		size := param.Size
+		if size == 0 {
+			size = usecase.DefaultNGSLWorkbookSize
+		}
+
+		workbookID, err := h.studentUsecaseNGSL.GenerateNGSLWorkbook(ctx, organizationID, operatorID, size)
+		if err != nil {
+			return liberrors.Errorf("failed to GenerateNGSLWorkbook. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, controllerhelper.IDResponse{ID: uint(workbookID)})
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *ngslHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("ngslHandler err: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	} else if errors.Is(err, appS.ErrWorkbookAlreadyExists) {
+		logger.Warnf("ngslHandler err: %+v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Workbook already exists"})
+		return true
+	} else if errors.Is(err, appS.ErrQuotaExceeded) {
+		logger.Warnf("ngslHandler err: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Quota exceeded"})
+		return true
+	}
+	logger.Errorf("ngslHandler err: %+v", err)
+	return false
+}
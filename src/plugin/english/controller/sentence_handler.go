@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/controller/converter"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/controller/entity"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/usecase"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+type SentenceHandler interface {
+	FindSentencePairs(c *gin.Context)
+	RecommendSentences(c *gin.Context)
+}
+
+type sentenceHandler struct {
+	studentUsecaseTatoeba usecase.StudentUsecaseTatoeba
+}
+
+func NewSentenceHandler(studentUsecaseTatoeba usecase.StudentUsecaseTatoeba) SentenceHandler {
+	return &sentenceHandler{
+		studentUsecaseTatoeba: studentUsecaseTatoeba,
+	}
+}
+
+// FindSentencePairs godoc
+// @Summary Find the tatoeba sentence pairs whose english sentences have the difficulty between minDifficulty and maxDifficulty
+// @Produce json
+// @Param param body entity.SentenceFindParameter true "parameter to find sentences"
+// @Success 200 {object} entity.SentenceFindResponse
+// @Failure 400
+// @Router /plugin/english/sentence/find [post]
+func (h *sentenceHandler) FindSentencePairs(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		param := entity.SentenceFindParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			logger.Warnf("failed to BindJSON. err: %v", err)
+			return nil
+		}
+
+		results, err := h.studentUsecaseTatoeba.FindSentencePairsByDifficulty(ctx, converter.ToTatoebaSentenceDifficultySearchCondition(ctx, &param))
+		if err != nil {
+			return liberrors.Errorf("failed to FindSentencePairsByDifficulty. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToSentenceFindResponse(ctx, results))
+		return nil
+	}, h.errorHandle)
+}
+
+// RecommendSentences godoc
+// @Summary Find the tatoeba sentence pairs whose english sentences consist of the words the user has memorized
+// @Produce json
+// @Param size query int false "number of the sentences"
+// @Success 200 {object} entity.SentenceFindResponse
+// @Failure 400
+// @Router /plugin/english/sentence/recommendation [get]
+func (h *sentenceHandler) RecommendSentences(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID userD.OrganizationID, operatorID userD.AppUserID) error {
+		size := usecase.DefaultSentenceRecommendationSize
+		if c.Query("size") != "" {
+			s, err := ginhelper.GetIntFromQuery(c, "size")
+			if err != nil {
+				c.Status(http.StatusBadRequest)
+				return nil
+			}
+			size = s
+		}
+
+		results, err := h.studentUsecaseTatoeba.RecommendSentences(ctx, organizationID, operatorID, size)
+		if err != nil {
+			return liberrors.Errorf("failed to RecommendSentences. err: %w", err)
+		}
+
+		c.JSON(http.StatusOK, converter.ToSentenceFindResponse(ctx, results))
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *sentenceHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("sentenceHandler err: %+v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return true
+	}
+	logger.Errorf("sentenceHandler err: %+v", err)
+	return false
+}
@@ -75,6 +75,8 @@ type EnglishWordProblemModel interface {
 	GetTranslated() string
 	GetPhrases() []EnglishPhraseProblemModel
 	GetSentences() []EnglishWordSentenceProblemModel
+	GetNGSLRank() int
+	GetCEFRLevel() CEFRLevel
 }
 
 type englishWordProblemModel struct {
@@ -91,9 +93,11 @@ type englishWordProblemModel struct {
 	Translated        string
 	Phrases           []EnglishPhraseProblemModel
 	Sentences         []EnglishWordSentenceProblemModel
+	NGSLRank          int
+	CEFRLevel         CEFRLevel
 }
 
-func NewEnglishWordProblemModel(problemModel appD.ProblemModel, audioID appD.AudioID, text string, pos int, phonetic string, presentThird, presentParticiple, pastTense, pastParticiple string, lang2 appD.Lang2, translated string, phrases []EnglishPhraseProblemModel, sentences []EnglishWordSentenceProblemModel) (EnglishWordProblemModel, error) {
+func NewEnglishWordProblemModel(problemModel appD.ProblemModel, audioID appD.AudioID, text string, pos int, phonetic string, presentThird, presentParticiple, pastTense, pastParticiple string, lang2 appD.Lang2, translated string, phrases []EnglishPhraseProblemModel, sentences []EnglishWordSentenceProblemModel, ngslRank int, cefrLevel CEFRLevel) (EnglishWordProblemModel, error) {
 	return &englishWordProblemModel{
 		ProblemModel:      problemModel,
 		AudioID:           audioID,
@@ -108,6 +112,8 @@ func NewEnglishWordProblemModel(problemModel appD.ProblemModel, audioID appD.Aud
 		Translated:        translated,
 		Phrases:           phrases,
 		Sentences:         sentences,
+		NGSLRank:          ngslRank,
+		CEFRLevel:         cefrLevel,
 	}, nil
 }
 
@@ -159,6 +165,14 @@ func (m *englishWordProblemModel) GetSentences() []EnglishWordSentenceProblemMod
 	return m.Sentences
 }
 
+func (m *englishWordProblemModel) GetNGSLRank() int {
+	return m.NGSLRank
+}
+
+func (m *englishWordProblemModel) GetCEFRLevel() CEFRLevel {
+	return m.CEFRLevel
+}
+
 func (m *englishWordProblemModel) GetProperties(cxt context.Context) map[string]interface{} {
 	// fmt.Printf("m.sentences: %v\n", m.sentences[0])
 
@@ -182,6 +196,8 @@ func (m *englishWordProblemModel) GetProperties(cxt context.Context) map[string]
 		"translated": m.Translated,
 		"audioId":    m.AudioID,
 		"sentences":  sentences,
+		"ngslRank":   m.NGSLRank,
+		"cefrLevel":  m.CEFRLevel.String(),
 	}
 }
 
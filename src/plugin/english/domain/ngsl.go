@@ -0,0 +1,140 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strconv"
+	"strings"
+
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+)
+
+type CEFRLevel string
+
+const (
+	CEFRLevelA1 CEFRLevel = "A1"
+	CEFRLevelA2 CEFRLevel = "A2"
+	CEFRLevelB1 CEFRLevel = "B1"
+	CEFRLevelB2 CEFRLevel = "B2"
+	CEFRLevelC1 CEFRLevel = "C1"
+	CEFRLevelC2 CEFRLevel = "C2"
+)
+
+func NewCEFRLevel(v string) (CEFRLevel, error) {
+	level := CEFRLevel(strings.ToUpper(v))
+	switch level {
+	case CEFRLevelA1, CEFRLevelA2, CEFRLevelB1, CEFRLevelB2, CEFRLevelC1, CEFRLevelC2:
+		return level, nil
+	default:
+		return "", liberrors.Errorf("invalid cefr level. %s", v)
+	}
+}
+
+func (l CEFRLevel) String() string {
+	return string(l)
+}
+
+// ngsl.csv lists the words of the New General Service List 1.01 (Browne, Culligan and Phillips, 2013) as "rank,word,cefr_level".
+// The first 40 words, which are function words such as "the" and "be", are omitted.
+// The NGSL doesn't define CEFR levels, so cefr_level is copied from a published CEFR word list such as the CEFR-J Wordlist
+// and is left empty for the words whose level hasn't been imported. The words without the level aren't tagged with a CEFR level.
+// The english words which were added before the list is changed are tagged by a migration generated by tools/ngsl_migration.
+//
+//go:embed ngsl.csv
+var ngslCSV string
+
+type NGSLWord interface {
+	GetRank() int
+	GetText() string
+	// GetCEFRLevel returns the empty level if the level of the word isn't listed
+	GetCEFRLevel() CEFRLevel
+}
+
+type ngslWord struct {
+	Rank      int
+	Text      string
+	CEFRLevel CEFRLevel
+}
+
+func (w *ngslWord) GetRank() int {
+	return w.Rank
+}
+
+func (w *ngslWord) GetText() string {
+	return w.Text
+}
+
+func (w *ngslWord) GetCEFRLevel() CEFRLevel {
+	return w.CEFRLevel
+}
+
+var ngslWords []NGSLWord
+var ngslWordMap map[string]NGSLWord
+
+// ngslMaxRank is the rank of the rarest word in the bundled NGSL words
+var ngslMaxRank int
+
+func init() {
+	words, err := loadNGSLWords(ngslCSV)
+	if err != nil {
+		panic(err)
+	}
+
+	ngslWords = words
+	ngslWordMap = make(map[string]NGSLWord)
+	for _, w := range words {
+		ngslWordMap[w.GetText()] = w
+		if w.GetRank() > ngslMaxRank {
+			ngslMaxRank = w.GetRank()
+		}
+	}
+}
+
+func loadNGSLWords(text string) ([]NGSLWord, error) {
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, liberrors.Errorf("failed to ReadAll. err: %w", err)
+	}
+
+	words := make([]NGSLWord, len(records))
+	for i, record := range records {
+		if len(record) != 2 && len(record) != 3 {
+			return nil, liberrors.Errorf("invalid ngsl record. %v", record)
+		}
+
+		rank, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, liberrors.Errorf("failed to Atoi. value: %s, err: %w", record[0], err)
+		}
+
+		var level CEFRLevel
+		if len(record) == 3 && record[2] != "" {
+			l, err := NewCEFRLevel(record[2])
+			if err != nil {
+				return nil, liberrors.Errorf("failed to NewCEFRLevel. value: %s, err: %w", record[2], err)
+			}
+			level = l
+		}
+
+		words[i] = &ngslWord{
+			Rank:      rank,
+			Text:      record[1],
+			CEFRLevel: level,
+		}
+	}
+
+	return words, nil
+}
+
+// GetNGSLWords returns the bundled NGSL words ordered by rank
+func GetNGSLWords() []NGSLWord {
+	return ngslWords
+}
+
+// FindNGSLWord returns the NGSL word of the text
+func FindNGSLWord(text string) (NGSLWord, bool) {
+	w, ok := ngslWordMap[strings.ToLower(text)]
+	return w, ok
+}
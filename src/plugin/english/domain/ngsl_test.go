@@ -0,0 +1,25 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+)
+
+func TestFindNGSLWord(t *testing.T) {
+	words := domain.GetNGSLWords()
+	assert.Greater(t, len(words), 0)
+	for i := 1; i < len(words); i++ {
+		assert.Less(t, words[i-1].GetRank(), words[i].GetRank())
+	}
+
+	word, ok := domain.FindNGSLWord("Know")
+	assert.True(t, ok)
+	assert.Equal(t, "know", word.GetText())
+	assert.Equal(t, 41, word.GetRank())
+
+	_, ok = domain.FindNGSLWord("cocotola")
+	assert.False(t, ok)
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+const (
+	// SentenceDifficultyMax is the score of the hardest sentences
+	SentenceDifficultyMax = 100
+
+	// sentenceDifficultyMaxLength is the number of words which is regarded as the longest
+	sentenceDifficultyMaxLength  = 25
+	sentenceDifficultyRankWeight = 0.7
+)
+
+// ngslFunctionWords are the first 40 words of the NGSL which are omitted from ngsl.csv
+var ngslFunctionWords = map[string]bool{
+	"the": true, "be": true, "and": true, "of": true, "to": true, "a": true, "in": true, "have": true, "it": true, "you": true,
+	"he": true, "for": true, "they": true, "not": true, "that": true, "we": true, "on": true, "with": true, "this": true, "i": true,
+	"do": true, "as": true, "at": true, "she": true, "but": true, "from": true, "by": true, "will": true, "or": true, "say": true,
+	"go": true, "so": true, "all": true, "if": true, "one": true, "would": true, "about": true, "can": true, "which": true, "there": true,
+	// the inflected forms of "be", "have" and "do" and the pronouns aren't derived by the suffix rules
+	"am": true, "is": true, "are": true, "was": true, "were": true, "been": true, "has": true, "had": true, "did": true, "does": true,
+	"me": true, "my": true, "him": true, "his": true, "her": true, "its": true, "us": true, "our": true, "them": true, "their": true, "your": true, "an": true,
+}
+
+var sentenceWordRegexp = regexp.MustCompile(`[A-Za-z]+(?:'[A-Za-z]+)?`)
+
+var negativeContractions = map[string]string{
+	"ca":  "can",
+	"wo":  "will",
+	"sha": "shall",
+}
+
+var inflectionSuffixes = []struct {
+	suffix      string
+	replacement string
+}{
+	{suffix: "ies", replacement: "y"},
+	{suffix: "ied", replacement: "y"},
+	{suffix: "es", replacement: ""},
+	{suffix: "s", replacement: ""},
+	{suffix: "ed", replacement: ""},
+	{suffix: "ed", replacement: "e"},
+	{suffix: "ing", replacement: ""},
+	{suffix: "ing", replacement: "e"},
+}
+
+// SentenceDifficulty is the difficulty of an English sentence computed from its length and the NGSL ranks of its words
+type SentenceDifficulty struct {
+	// Score is between 0 and SentenceDifficultyMax
+	Score     int
+	WordCount int
+	// MaxRank is the NGSL rank of the rarest word. It is sentenceDifficultyMaxRank() if the sentence contains a word which is not in the NGSL words
+	MaxRank int
+	// ContentWords are the lemmas of the words except the function words
+	ContentWords []string
+}
+
+// NewSentenceDifficulty scores the sentence.
+// 70% of the score comes from the average rank of the content words and 30% comes from the number of the words
+func NewSentenceDifficulty(text string) SentenceDifficulty {
+	words := sentenceWordRegexp.FindAllString(text, -1)
+
+	contentWords := make([]string, 0, len(words))
+	rankSum := 0
+	maxRank := 0
+	for _, word := range words {
+		lemma, rank := ToLemma(word)
+		if ngslFunctionWords[lemma] {
+			continue
+		}
+
+		contentWords = append(contentWords, lemma)
+		rankSum += rank
+		if rank > maxRank {
+			maxRank = rank
+		}
+	}
+
+	rankScore := 0.0
+	if len(contentWords) > 0 {
+		rankScore = float64(rankSum) / float64(len(contentWords)) / float64(sentenceDifficultyMaxRank())
+	}
+	lengthScore := math.Min(float64(len(words)), sentenceDifficultyMaxLength) / sentenceDifficultyMaxLength
+	score := (sentenceDifficultyRankWeight*rankScore + (1-sentenceDifficultyRankWeight)*lengthScore) * SentenceDifficultyMax
+
+	return SentenceDifficulty{
+		Score:        int(math.Round(score)),
+		WordCount:    len(words),
+		MaxRank:      maxRank,
+		ContentWords: contentWords,
+	}
+}
+
+// ToLemma returns the base form of the word and its NGSL rank.
+// The inflectional suffixes are removed if the word itself is not in the NGSL words.
+// The rank is sentenceDifficultyMaxRank() if neither the word nor its base form is in the NGSL words
+func ToLemma(word string) (string, int) {
+	forms := toWordForms(word)
+	for _, form := range forms {
+		if ngslFunctionWords[form] {
+			return form, 0
+		}
+		if w, ok := FindNGSLWord(form); ok {
+			return form, w.GetRank()
+		}
+	}
+
+	return forms[0], sentenceDifficultyMaxRank()
+}
+
+// sentenceDifficultyMaxRank returns the rank which is regarded as the rarest.
+// The words which are not in the bundled NGSL words are ranked next to the rarest bundled word
+func sentenceDifficultyMaxRank() int {
+	return ngslMaxRank + 1
+}
+
+// toWordForms returns the lower case word followed by the candidates of its base form
+func toWordForms(word string) []string {
+	word = strings.ToLower(word)
+	if strings.HasSuffix(word, "n't") {
+		word = strings.TrimSuffix(word, "n't")
+		if w, ok := negativeContractions[word]; ok {
+			word = w
+		}
+	} else if i := strings.Index(word, "'"); i >= 0 {
+		word = word[:i]
+	}
+
+	forms := []string{word}
+	for _, s := range inflectionSuffixes {
+		if strings.HasSuffix(word, s.suffix) && len(word) > len(s.suffix)+1 {
+			forms = append(forms, strings.TrimSuffix(word, s.suffix)+s.replacement)
+		}
+	}
+	return forms
+}
+
+// IsCoveredBy returns whether all the content words of the sentence or their base forms are in `knownWords`
+func (d *SentenceDifficulty) IsCoveredBy(knownWords map[string]bool) bool {
+	for _, word := range d.ContentWords {
+		known := false
+		for _, form := range toWordForms(word) {
+			if knownWords[form] {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+)
+
+// rarestRank is the rank of the words which are not in the bundled NGSL words
+func rarestRank() int {
+	maxRank := 0
+	for _, w := range domain.GetNGSLWords() {
+		if w.GetRank() > maxRank {
+			maxRank = w.GetRank()
+		}
+	}
+	return maxRank + 1
+}
+
+func TestToLemma(t *testing.T) {
+	tests := []struct {
+		word      string
+		wantLemma string
+		wantRank  int
+	}{
+		{word: "Know", wantLemma: "know", wantRank: 41},
+		{word: "thinking", wantLemma: "think", wantRank: 47},
+		{word: "makes", wantLemma: "make", wantRank: 48},
+		{word: "don't", wantLemma: "do", wantRank: 0},
+		{word: "zyxwv", wantLemma: "zyxwv", wantRank: rarestRank()},
+	}
+	for _, tt := range tests {
+		lemma, rank := domain.ToLemma(tt.word)
+		assert.Equal(t, tt.wantLemma, lemma, tt.word)
+		assert.Equal(t, tt.wantRank, rank, tt.word)
+	}
+}
+
+func TestNewSentenceDifficulty(t *testing.T) {
+	easy := domain.NewSentenceDifficulty("I know you.")
+	assert.Equal(t, 3, easy.WordCount)
+	assert.Equal(t, 41, easy.MaxRank)
+	assert.Equal(t, []string{"know"}, easy.ContentWords)
+	assert.Equal(t, 12, easy.Score)
+
+	hard := domain.NewSentenceDifficulty("The epistemological ramifications of quantum decoherence remain thoroughly contested.")
+	assert.Equal(t, rarestRank(), hard.MaxRank)
+	assert.Greater(t, hard.Score, easy.Score)
+	assert.LessOrEqual(t, hard.Score, domain.SentenceDifficultyMax)
+
+	empty := domain.NewSentenceDifficulty("")
+	assert.Equal(t, 0, empty.Score)
+}
+
+func TestSentenceDifficulty_IsCoveredBy(t *testing.T) {
+	difficulty := domain.NewSentenceDifficulty("She makes time to think.")
+	assert.True(t, difficulty.IsCoveredBy(map[string]bool{"make": true, "time": true, "think": true}))
+	assert.False(t, difficulty.IsCoveredBy(map[string]bool{"make": true, "time": true}))
+}
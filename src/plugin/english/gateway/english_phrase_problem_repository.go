@@ -326,6 +326,24 @@ func (r *englishPhraseProblemRepository) UpdateProblem(ctx context.Context, oper
 	return errors.New("not implemented")
 }
 
+func (r *englishPhraseProblemRepository) UpdateAudioID(ctx context.Context, operator appD.StudentModel, id appS.ProblemSelectParameter1, audioID appD.AudioID) error {
+	_, span := tracer.Start(ctx, "englishPhraseProblemRepository.UpdateAudioID")
+	defer span.End()
+
+	result := r.db.Model(&englishPhraseProblemEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("workbook_id = ?", uint(id.GetWorkbookID())).
+		Where("id = ?", uint(id.GetProblemID())).
+		Update("audio_id", uint(audioID))
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return appS.ErrProblemNotFound
+	}
+
+	return nil
+}
+
 func (r *englishPhraseProblemRepository) RemoveProblem(ctx context.Context, operator appD.StudentModel, id appS.ProblemSelectParameter2) error {
 	ctx, span := tracer.Start(ctx, "englishPhraseProblemRepository.RemoveProblem")
 	defer span.End()
@@ -443,6 +443,24 @@ func (r *englishSentenceProblemRepository) UpdateProblem(ctx context.Context, op
 	return errors.New("not implemented")
 }
 
+func (r *englishSentenceProblemRepository) UpdateAudioID(ctx context.Context, operator appD.StudentModel, id appS.ProblemSelectParameter1, audioID appD.AudioID) error {
+	_, span := tracer.Start(ctx, "englishSentenceProblemRepository.UpdateAudioID")
+	defer span.End()
+
+	result := r.db.Model(&englishSentenceProblemEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("workbook_id = ?", uint(id.GetWorkbookID())).
+		Where("id = ?", uint(id.GetProblemID())).
+		Update("audio_id", uint(audioID))
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return appS.ErrProblemNotFound
+	}
+
+	return nil
+}
+
 func (r *englishSentenceProblemRepository) RemoveProblem(ctx context.Context, operator appD.StudentModel, id appS.ProblemSelectParameter2) error {
 	_, span := tracer.Start(ctx, "englishSentenceProblemRepository.RemoveProblem")
 	defer span.End()
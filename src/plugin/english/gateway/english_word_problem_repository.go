@@ -49,6 +49,8 @@ type englishWordProblemEntity struct {
 	PhraseID2         uint
 	SentenceID1       uint
 	SentenceID2       uint
+	NgslRank          int
+	CefrLevel         string
 	// joined columns
 	SentenceText1       string `gorm:"->"` // readonly
 	SentenceTranslated1 string `gorm:"->"` // readonly
@@ -91,7 +93,7 @@ func (e *englishWordProblemEntity) toProblem(ctx context.Context, synthesizerCli
 		sentences = append(sentences, sentence)
 	}
 
-	englishWordProblemModel, err := domain.NewEnglishWordProblemModel(problemModel, appD.AudioID(e.AudioID), e.Text, e.Pos, e.Phonetic, e.PresentThird, e.PresentParticiple, e.PastTense, e.PastParticiple, lang2, e.Translated, phrases, sentences)
+	englishWordProblemModel, err := domain.NewEnglishWordProblemModel(problemModel, appD.AudioID(e.AudioID), e.Text, e.Pos, e.Phonetic, e.PresentThird, e.PresentParticiple, e.PastTense, e.PastParticiple, lang2, e.Translated, phrases, sentences, e.NgslRank, domain.CEFRLevel(e.CefrLevel))
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +124,8 @@ type englishWordProblemAddParemeter struct {
 	PhraseID2         uint
 	SentenceID1       uint
 	SentenceID2       uint
+	NgslRank          int
+	CefrLevel         string
 }
 
 func toEnglishWordProblemAddParameter(param appS.ProblemAddParameter) (*englishWordProblemAddParemeter, error) {
@@ -151,12 +155,31 @@ func toEnglishWordProblemAddParameter(param appS.ProblemAddParameter) (*englishW
 		return nil, err
 	}
 
+	sentenceID1, err := toOptionalID(param.GetProperties(), service.EnglishWordProblemAddPropertySentenceID1)
+	if err != nil {
+		return nil, err
+	}
+
+	sentenceID2, err := toOptionalID(param.GetProperties(), service.EnglishWordProblemAddPropertySentenceID2)
+	if err != nil {
+		return nil, err
+	}
+
+	ngslRank, err := toOptionalID(param.GetProperties(), service.EnglishWordProblemAddPropertyNGSLRank)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &englishWordProblemAddParemeter{
-		AudioID:    uint(audioID),
-		Lang2:      param.GetProperties()["lang2"],
-		Text:       param.GetProperties()["text"],
-		Pos:        pos,
-		Translated: param.GetProperties()["translated"],
+		AudioID:     uint(audioID),
+		Lang2:       param.GetProperties()["lang2"],
+		Text:        param.GetProperties()["text"],
+		Pos:         pos,
+		Translated:  param.GetProperties()["translated"],
+		SentenceID1: sentenceID1,
+		SentenceID2: sentenceID2,
+		NgslRank:    int(ngslRank),
+		CefrLevel:   param.GetProperties()[service.EnglishWordProblemAddPropertyCEFRLevel],
 	}
 	return m, libD.Validator.Struct(m)
 }
@@ -174,6 +197,8 @@ type englishWordProblemUpdateParemeter struct {
 	PhraseID2         uint
 	SentenceID1       uint
 	SentenceID2       uint
+	NGSLRank          uint
+	CEFRLevel         string
 }
 
 func toEnglishWordProblemUpdateParameter(param appS.ProblemUpdateParameter) (*englishWordProblemUpdateParemeter, error) {
@@ -196,15 +221,41 @@ func toEnglishWordProblemUpdateParameter(param appS.ProblemUpdateParameter) (*en
 		return nil, err
 	}
 
+	sentenceID2, err := toOptionalID(param.GetProperties(), service.EnglishWordProblemUpdatePropertySentenceID2)
+	if err != nil {
+		return nil, err
+	}
+
+	ngslRank, err := toOptionalID(param.GetProperties(), service.EnglishWordProblemUpdatePropertyNGSLRank)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &englishWordProblemUpdateParemeter{
 		AudioID:     uint(audioID),
 		Text:        text,
 		Translated:  param.GetProperties()[service.EnglishWordProblemUpdatePropertyTranslated],
 		SentenceID1: uint(sentenceID),
+		SentenceID2: sentenceID2,
+		NGSLRank:    ngslRank,
+		CEFRLevel:   param.GetProperties()[service.EnglishWordProblemUpdatePropertyCEFRLevel],
 	}
 	return m, libD.Validator.Struct(m)
 }
 
+func toOptionalID(properties map[string]string, key string) (uint, error) {
+	value, ok := properties[key]
+	if !ok || value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, liberrors.Errorf("%s is invalid. err: %w", key, libD.ErrInvalidArgument)
+	}
+	return uint(id), nil
+}
+
 type englishWordProblemRepository struct {
 	db                *gorm.DB
 	synthesizerClient appS.SynthesizerClient
@@ -226,10 +277,23 @@ func (r *englishWordProblemRepository) FindProblems(ctx context.Context, operato
 	limit := param.GetPageSize()
 	offset := (param.GetPageNo() - 1) * param.GetPageSize()
 
+	var cefrLevel domain.CEFRLevel
+	if value := param.GetProperties()[service.EnglishWordProblemSearchPropertyCEFRLevel]; value != "" {
+		level, err := domain.NewCEFRLevel(value)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to NewCEFRLevel. err: %w", libD.ErrInvalidArgument)
+		}
+		cefrLevel = level
+	}
+
 	where := func() *gorm.DB {
-		return r.db.
+		db := r.db.
 			Where("organization_id = ?", uint(operator.GetOrganizationID())).
 			Where("workbook_id = ?", uint(param.GetWorkbookID()))
+		if cefrLevel != "" {
+			db = db.Where("cefr_level = ?", cefrLevel.String())
+		}
+		return db
 	}
 
 	var problemEntities []englishWordProblemEntity
@@ -409,6 +473,10 @@ func (r *englishWordProblemRepository) AddProblem(ctx context.Context, operator
 		PastParticiple:    problemParam.PastParticiple,
 		Lang2:             problemParam.Lang2,
 		Translated:        problemParam.Translated,
+		SentenceID1:       problemParam.SentenceID1,
+		SentenceID2:       problemParam.SentenceID2,
+		NgslRank:          problemParam.NgslRank,
+		CefrLevel:         problemParam.CefrLevel,
 	}
 
 	logger.Infof("englishWordProblemRepository.AddProblem. text: %s", problemParam.Text)
@@ -435,6 +503,7 @@ func (r *englishWordProblemRepository) UpdateProblem(ctx context.Context, operat
 		UpdatedBy:         operator.GetID(),
 		AudioID:           problemParam.AudioID,
 		Number:            param.GetNumber(),
+		Text:              problemParam.Text,
 		Phonetic:          problemParam.Phonetic,
 		PresentThird:      problemParam.PresentThird,
 		PresentParticiple: problemParam.PresentParticiple,
@@ -442,6 +511,7 @@ func (r *englishWordProblemRepository) UpdateProblem(ctx context.Context, operat
 		PastParticiple:    problemParam.PastParticiple,
 		Translated:        problemParam.Translated,
 		SentenceID1:       problemParam.SentenceID1,
+		SentenceID2:       problemParam.SentenceID2,
 	}
 
 	logger.Infof("englishWordProblemRepository.UpdateProblem. text: %s", problemParam.Text)
@@ -461,6 +531,36 @@ func (r *englishWordProblemRepository) UpdateProblem(ctx context.Context, operat
 		return appS.ErrProblemOtherError
 	}
 
+	// UpdateColumns with the struct skips the zero values, so the rank and the level are updated with the map to clear them when the text is out of the NGSL words
+	if result := r.db.Model(&englishWordProblemEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("workbook_id = ?", uint(id.GetWorkbookID())).
+		Where("id = ?", uint(id.GetProblemID())).
+		UpdateColumns(map[string]interface{}{
+			"ngsl_rank":  problemParam.NGSLRank,
+			"cefr_level": problemParam.CEFRLevel,
+		}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *englishWordProblemRepository) UpdateAudioID(ctx context.Context, operator appD.StudentModel, id appS.ProblemSelectParameter1, audioID appD.AudioID) error {
+	_, span := tracer.Start(ctx, "englishWordProblemRepository.UpdateAudioID")
+	defer span.End()
+
+	result := r.db.Model(&englishWordProblemEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("workbook_id = ?", uint(id.GetWorkbookID())).
+		Where("id = ?", uint(id.GetProblemID())).
+		Update("audio_id", uint(audioID))
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return appS.ErrProblemNotFound
+	}
+
 	return nil
 }
 
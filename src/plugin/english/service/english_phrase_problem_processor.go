@@ -43,6 +43,7 @@ func toEnglishPhraseProblemAddParemeter(param appS.ProblemAddParameter) (*englis
 
 type EnglishPhraseProblemProcessor interface {
 	appS.ProblemAddProcessor
+	appS.ProblemAudioProcessor
 	appS.ProblemRemoveProcessor
 }
 
@@ -111,6 +112,13 @@ func (p *englishPhraseProblemProcessor) addSingleProblem(ctx context.Context, op
 
 }
 
+func (p *englishPhraseProblemProcessor) GenerateAudio(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, workbook appD.WorkbookModel, problemID appD.ProblemID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "englishPhraseProblemProcessor.GenerateAudio")
+	defer span.End()
+
+	return generateAudio(ctx, p.synthesizerClient, rf, domain.EnglishPhraseProblemType, operator, workbook, problemID)
+}
+
 func (p *englishPhraseProblemProcessor) RemoveProblem(ctx context.Context, repo appS.RepositoryFactory, operator appD.StudentModel, id appS.ProblemSelectParameter2) error {
 	problemRepo, err := repo.NewProblemRepository(ctx, domain.EnglishPhraseProblemType)
 	if err != nil {
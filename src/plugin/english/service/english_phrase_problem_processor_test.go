@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	appDM "github.com/kujilabo/cocotola-api/src/app/domain/mock"
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	appSM "github.com/kujilabo/cocotola-api/src/app/service/mock"
+	pluginSM "github.com/kujilabo/cocotola-api/src/plugin/common/service/mock"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	domainM "github.com/kujilabo/cocotola-api/src/plugin/english/domain/mock"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/service"
+)
+
+func Test_englishPhraseProblemProcessor_GenerateAudio(t *testing.T) {
+	ctx := context.Background()
+	audioModel, err := appD.NewAudioModel(200, appD.Lang2EN, "good morning", "Y29udGVudA==")
+	require.NoError(t, err)
+	audio, err := appS.NewAudio(audioModel)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		audioID       appD.AudioID
+		wantGenerated bool
+	}{
+		{
+			name:          "the problem doesn't have any audio",
+			audioID:       0,
+			wantGenerated: true,
+		},
+		{
+			name:          "the problem already has the audio",
+			audioID:       100,
+			wantGenerated: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			synthesizerClient := new(appSM.SynthesizerClient)
+			synthesizerClient.On("Synthesize", anythingOfContext, appD.Lang2EN, "good morning").Return(audio, nil)
+			operator := new(appDM.StudentModel)
+			workbookModel := new(appDM.WorkbookModel)
+			workbookModel.On("GetID").Return(uint(1))
+			problem := new(domainM.EnglishPhraseProblemModel)
+			problem.On("GetAudioID").Return(tt.audioID)
+			problem.On("GetText").Return("good morning")
+			problemRepo := new(appSM.ProblemRepository)
+			problemRepo.On("FindProblemByID", anythingOfContext, operator, mock.Anything).Return(problem, nil)
+			problemRepo.On("UpdateAudioID", anythingOfContext, operator, mock.Anything, appD.AudioID(200)).Return(nil)
+			rf := new(appSM.RepositoryFactory)
+			rf.On("NewProblemRepository", anythingOfContext, domain.EnglishPhraseProblemType).Return(problemRepo, nil)
+			processor := service.NewEnglishPhraseProblemProcessor(synthesizerClient, new(pluginSM.TranslatorClient))
+
+			generated, err := processor.GenerateAudio(ctx, rf, operator, workbookModel, appD.ProblemID(10))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGenerated, generated)
+			if tt.wantGenerated {
+				problemRepo.AssertNumberOfCalls(t, "UpdateAudioID", 1)
+			} else {
+				synthesizerClient.AssertNotCalled(t, "Synthesize", mock.Anything, mock.Anything, mock.Anything)
+				problemRepo.AssertNotCalled(t, "UpdateAudioID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
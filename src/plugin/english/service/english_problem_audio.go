@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+)
+
+// englishProblemWithAudio is implemented by the word, phrase and sentence problems
+type englishProblemWithAudio interface {
+	GetAudioID() appD.AudioID
+	GetText() string
+}
+
+// generateAudio synthesizes the audio of the problem and saves the audio id if the problem doesn't have any audio
+func generateAudio(ctx context.Context, synthesizerClient appS.SynthesizerClient, rf appS.RepositoryFactory, problemType string, operator appD.StudentModel, workbook appD.WorkbookModel, problemID appD.ProblemID) (bool, error) {
+	problemRepo, err := rf.NewProblemRepository(ctx, problemType)
+	if err != nil {
+		return false, liberrors.Errorf("failed to NewProblemRepository. err: %w", err)
+	}
+
+	id, err := appS.NewProblemSelectParameter1(appD.WorkbookID(workbook.GetID()), problemID)
+	if err != nil {
+		return false, liberrors.Errorf("failed to NewProblemSelectParameter1. err: %w", err)
+	}
+
+	problem, err := problemRepo.FindProblemByID(ctx, operator, id)
+	if err != nil {
+		return false, liberrors.Errorf("failed to FindProblemByID. problemID: %d, err: %w", problemID, err)
+	}
+
+	problemWithAudio, ok := problem.(englishProblemWithAudio)
+	if !ok {
+		return false, liberrors.Errorf("invalid problem. problemID: %d", problemID)
+	}
+
+	if problemWithAudio.GetAudioID() != 0 {
+		return false, nil
+	}
+
+	audio, err := synthesizerClient.Synthesize(ctx, appD.Lang2EN, problemWithAudio.GetText())
+	if err != nil {
+		return false, liberrors.Errorf("failed to Synthesize. problemID: %d, err: %w", problemID, err)
+	}
+
+	if err := problemRepo.UpdateAudioID(ctx, operator, id, appD.AudioID(audio.GetAudioModel().GetID())); err != nil {
+		return false, liberrors.Errorf("failed to UpdateAudioID. problemID: %d, err: %w", problemID, err)
+	}
+
+	return true, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	pluginS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
 	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
 var (
@@ -83,6 +84,7 @@ func toEnglishSentenceProblemAddParemeter(param appS.ProblemAddParameter) (*engl
 
 type EnglishSentenceProblemProcessor interface {
 	appS.ProblemAddProcessor
+	appS.ProblemAudioProcessor
 	appS.ProblemRemoveProcessor
 	appS.ProblemImportProcessor
 	appS.ProblemQuotaProcessor
@@ -156,6 +158,13 @@ func (p *englishSentenceProblemProcessor) addSingleProblem(ctx context.Context,
 	return problemID, nil
 
 }
+func (p *englishSentenceProblemProcessor) GenerateAudio(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, workbook appD.WorkbookModel, problemID appD.ProblemID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "englishSentenceProblemProcessor.GenerateAudio")
+	defer span.End()
+
+	return generateAudio(ctx, p.synthesizerClient, rf, domain.EnglishSentenceProblemType, operator, workbook, problemID)
+}
+
 func (p *englishSentenceProblemProcessor) RemoveProblem(ctx context.Context, repo appS.RepositoryFactory, operator appD.StudentModel, id appS.ProblemSelectParameter2) error {
 	problemRepo, err := repo.NewProblemRepository(ctx, domain.EnglishSentenceProblemType)
 	if err != nil {
@@ -169,7 +178,7 @@ func (p *englishSentenceProblemProcessor) RemoveProblem(ctx context.Context, rep
 	return nil
 }
 
-func (p *englishSentenceProblemProcessor) CreateCSVReader(ctx context.Context, workbookID appD.WorkbookID, reader io.Reader) (appS.ProblemAddParameterIterator, error) {
+func (p *englishSentenceProblemProcessor) CreateCSVReader(ctx context.Context, organizationID userD.OrganizationID, workbookID appD.WorkbookID, reader io.Reader) (appS.ProblemAddParameterIterator, error) {
 	return p.newProblemAddParameterCSVReader(workbookID, reader), nil
 }
 
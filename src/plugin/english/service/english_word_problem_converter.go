@@ -11,6 +11,7 @@ import (
 	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/lib/log"
 	pluginS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
 )
 
 type ToEnglishWordProblemAddParameter interface {
@@ -27,15 +28,17 @@ type toSingleEnglishWordProblemAddParameter struct {
 	number           int
 	param            *EnglishWordProblemAddParemeter
 	audioID          appD.AudioID
+	sentenceIDs      []appD.ProblemID
 }
 
-func NewToSingleEnglishWordProblemAddParameter(translatorClient pluginS.TranslatorClient, workbookID appD.WorkbookID, number int, param *EnglishWordProblemAddParemeter, audioID appD.AudioID) ToEnglishWordProblemAddParameter {
+func NewToSingleEnglishWordProblemAddParameter(translatorClient pluginS.TranslatorClient, workbookID appD.WorkbookID, number int, param *EnglishWordProblemAddParemeter, audioID appD.AudioID, sentenceIDs []appD.ProblemID) ToEnglishWordProblemAddParameter {
 	return &toSingleEnglishWordProblemAddParameter{
 		translatorClient: translatorClient,
 		workbookID:       workbookID,
 		number:           number,
 		param:            param,
 		audioID:          audioID,
+		sentenceIDs:      sentenceIDs,
 	}
 }
 
@@ -55,6 +58,7 @@ func (c *toSingleEnglishWordProblemAddParameter) Run(ctx context.Context) ([]app
 	properties := c.param.toProperties()
 	properties[EnglishSentenceProblemAddPropertyTranslated] = translated
 	properties[EnglishWordProblemAddPropertyAudioID] = strconv.Itoa(int(uint(c.audioID)))
+	setSentenceIDs(properties, EnglishWordProblemAddPropertySentenceID1, EnglishWordProblemAddPropertySentenceID2, c.sentenceIDs)
 
 	param, err := appS.NewProblemAddParameter(c.workbookID, c.number, properties)
 	if err != nil {
@@ -70,15 +74,17 @@ type toMultipleEnglishWordProblemAddParameter struct {
 	number           int
 	param            *EnglishWordProblemAddParemeter
 	audioID          appD.AudioID
+	sentenceIDs      []appD.ProblemID
 }
 
-func NewToMultipleEnglishWordProblemAddParameter(translatorClient pluginS.TranslatorClient, workbookID appD.WorkbookID, number int, param *EnglishWordProblemAddParemeter, audioID appD.AudioID) ToEnglishWordProblemAddParameter {
+func NewToMultipleEnglishWordProblemAddParameter(translatorClient pluginS.TranslatorClient, workbookID appD.WorkbookID, number int, param *EnglishWordProblemAddParemeter, audioID appD.AudioID, sentenceIDs []appD.ProblemID) ToEnglishWordProblemAddParameter {
 	return &toMultipleEnglishWordProblemAddParameter{
 		translatorClient: translatorClient,
 		workbookID:       workbookID,
 		number:           number,
 		param:            param,
 		audioID:          audioID,
+		sentenceIDs:      sentenceIDs,
 	}
 }
 
@@ -86,7 +92,7 @@ func (c *toMultipleEnglishWordProblemAddParameter) Run(ctx context.Context) ([]a
 	logger := log.FromContext(ctx)
 
 	translated, err := c.translatorClient.DictionaryLookup(ctx, appD.Lang2EN, c.param.Lang2, c.param.Text)
-	if errors.Is(err, pluginS.ErrTranslationNotFound) {
+	if errors.Is(err, pluginS.ErrTranslationNotFound) || errors.Is(err, pluginS.ErrTranslatorUnavailable) {
 		return nil, err
 	}
 
@@ -95,6 +101,7 @@ func (c *toMultipleEnglishWordProblemAddParameter) Run(ctx context.Context) ([]a
 
 		properties := c.param.toProperties()
 		properties[EnglishWordProblemAddPropertyAudioID] = strconv.Itoa(int(uint(c.audioID)))
+		setSentenceIDs(properties, EnglishWordProblemAddPropertySentenceID1, EnglishWordProblemAddPropertySentenceID2, c.sentenceIDs)
 
 		param, err := appS.NewProblemAddParameter(c.workbookID, c.number, properties)
 		if err != nil {
@@ -113,6 +120,7 @@ func (c *toMultipleEnglishWordProblemAddParameter) Run(ctx context.Context) ([]a
 		properties[EnglishWordProblemAddPropertyAudioID] = strconv.Itoa(int(uint(c.audioID)))
 		properties[EnglishWordProblemAddPropertyTranslated] = t.GetTranslated()
 		properties[EnglishWordProblemAddPropertyPos] = strconv.Itoa(int(t.GetPos()))
+		setSentenceIDs(properties, EnglishWordProblemAddPropertySentenceID1, EnglishWordProblemAddPropertySentenceID2, c.sentenceIDs)
 
 		param, err := appS.NewProblemAddParameter(c.workbookID, c.number, properties)
 		if err != nil {
@@ -125,6 +133,16 @@ func (c *toMultipleEnglishWordProblemAddParameter) Run(ctx context.Context) ([]a
 	return params, nil
 }
 
+// setSentenceIDs sets the IDs of the example sentences to the properties
+func setSentenceIDs(properties map[string]string, key1, key2 string, sentenceIDs []appD.ProblemID) {
+	if len(sentenceIDs) >= 1 {
+		properties[key1] = strconv.Itoa(int(sentenceIDs[0]))
+	}
+	if len(sentenceIDs) >= 2 {
+		properties[key2] = strconv.Itoa(int(sentenceIDs[1]))
+	}
+}
+
 type toSingleEnglishWordProblemUpdateParameter struct {
 	translatorClient pluginS.TranslatorClient
 	number           int
@@ -161,6 +179,14 @@ func (c *toSingleEnglishWordProblemUpdateParameter) Run(ctx context.Context) ([]
 		EnglishWordProblemUpdatePropertyTranslated:  c.param.Translated,
 		EnglishWordProblemUpdatePropertyAudioID:     strconv.Itoa(int(c.audioID)),
 		EnglishWordProblemUpdatePropertySentenceID1: strconv.Itoa(int(c.sentenceID1)),
+		EnglishWordProblemUpdatePropertyNGSLRank:    "0",
+		EnglishWordProblemUpdatePropertyCEFRLevel:   "",
+	}
+
+	// the rank and the level are recomputed because the text may be changed
+	if ngslWord, ok := domain.FindNGSLWord(c.param.Text); ok {
+		properties[EnglishWordProblemUpdatePropertyNGSLRank] = strconv.Itoa(ngslWord.GetRank())
+		properties[EnglishWordProblemUpdatePropertyCEFRLevel] = ngslWord.GetCEFRLevel().String()
 	}
 
 	param, err := appS.NewProblemUpdateParameter(c.number, properties)
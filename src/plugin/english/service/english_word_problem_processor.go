@@ -14,6 +14,7 @@ import (
 	plugin "github.com/kujilabo/cocotola-api/src/plugin/common/domain"
 	pluginS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
 	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
 var (
@@ -27,29 +28,54 @@ var (
 	// EnglishWordProblemUpdatePropertyTatoebaSentenceNumber1 = "tatoebaSentenceNumber1"
 	// EnglishWordProblemUpdatePropertyTatoebaSentenceNumber2 = "tatoebaSentenceNumber2"
 	EnglishWordProblemUpdatePropertySentenceID1 = "sentenceId1"
-
-	EnglishWordProblemAddPropertyAudioID    = "audioId"
-	EnglishWordProblemAddPropertyLang2      = "lang2"
-	EnglishWordProblemAddPropertyText       = "text"
-	EnglishWordProblemAddPropertyTranslated = "translated"
-	EnglishWordProblemAddPropertyPos        = "pos"
+	EnglishWordProblemUpdatePropertySentenceID2 = "sentenceId2"
+	EnglishWordProblemUpdatePropertyNGSLRank    = "ngslRank"
+	EnglishWordProblemUpdatePropertyCEFRLevel   = "cefrLevel"
+
+	EnglishWordProblemAddPropertyAudioID     = "audioId"
+	EnglishWordProblemAddPropertyLang2       = "lang2"
+	EnglishWordProblemAddPropertyText        = "text"
+	EnglishWordProblemAddPropertyTranslated  = "translated"
+	EnglishWordProblemAddPropertyPos         = "pos"
+	EnglishWordProblemAddPropertySentenceID1 = "sentenceId1"
+	EnglishWordProblemAddPropertySentenceID2 = "sentenceId2"
+	EnglishWordProblemAddPropertyNGSLRank    = "ngslRank"
+	EnglishWordProblemAddPropertyCEFRLevel   = "cefrLevel"
+
+	EnglishWordProblemSearchPropertyCEFRLevel = "cefrLevel"
+
+	EnglishWordProblemPropertySentenceProvider = "sentenceProvider"
+	EnglishWordSentenceProviderTatoeba         = "tatoeba"
+
+	// EnglishWordSentenceSearchPageSize is the number of sentence pairs fetched from tatoeba to pick example sentences
+	EnglishWordSentenceSearchPageSize = 100
+	// EnglishWordSentenceMaxNumber is the number of example sentences attached to a word
+	EnglishWordSentenceMaxNumber = 2
 )
 
 type EnglishWordProblemAddParemeter struct {
-	Lang2      appD.Lang2     `validate:"required"`
-	Text       string         `validate:"required"`
-	Pos        plugin.WordPos `validate:"required"`
-	Translated string
+	Lang2            appD.Lang2     `validate:"required"`
+	Text             string         `validate:"required"`
+	Pos              plugin.WordPos `validate:"required"`
+	Translated       string
+	SentenceProvider string
 }
 
 func (p *EnglishWordProblemAddParemeter) toProperties() map[string]string {
-	return map[string]string{
+	properties := map[string]string{
 		// EnglishWordProblemAddPropertyAudioID:    strconv.Itoa(int(uint(audioID))),
 		EnglishWordProblemAddPropertyLang2:      p.Lang2.String(),
 		EnglishWordProblemAddPropertyText:       p.Text,
 		EnglishWordProblemAddPropertyTranslated: p.Translated,
 		EnglishWordProblemAddPropertyPos:        strconv.Itoa(int(p.Pos)),
 	}
+
+	if ngslWord, ok := domain.FindNGSLWord(p.Text); ok {
+		properties[EnglishWordProblemAddPropertyNGSLRank] = strconv.Itoa(ngslWord.GetRank())
+		properties[EnglishWordProblemAddPropertyCEFRLevel] = ngslWord.GetCEFRLevel().String()
+	}
+
+	return properties
 }
 
 func NewEnglishWordProblemAddParemeter(param appS.ProblemAddParameter) (*EnglishWordProblemAddParemeter, error) {
@@ -78,10 +104,11 @@ func NewEnglishWordProblemAddParemeter(param appS.ProblemAddParameter) (*English
 	}
 
 	m := &EnglishWordProblemAddParemeter{
-		Lang2:      lang2,
-		Text:       param.GetProperties()["text"],
-		Pos:        plugin.WordPos(pos),
-		Translated: translated,
+		Lang2:            lang2,
+		Text:             param.GetProperties()["text"],
+		Pos:              plugin.WordPos(pos),
+		Translated:       translated,
+		SentenceProvider: param.GetProperties()[EnglishWordProblemPropertySentenceProvider],
 	}
 	return m, libD.Validator.Struct(m)
 }
@@ -151,23 +178,29 @@ func NewEnglishWordProblemUpdateParemeter(param appS.ProblemUpdateParameter) (*E
 
 type EnglishWordProblemProcessor interface {
 	appS.ProblemAddProcessor
+	appS.ProblemAudioProcessor
 	appS.ProblemUpdateProcessor
 	appS.ProblemRemoveProcessor
 	appS.ProblemImportProcessor
 	appS.ProblemQuotaProcessor
+	appS.ProblemSentenceProcessor
 }
 
 type englishWordProblemProcessor struct {
 	synthesizerClient               appS.SynthesizerClient
 	translatorClient                pluginS.TranslatorClient
+	glossaryRepo                    pluginS.GlossaryRepository
 	tatoebaClient                   pluginS.TatoebaClient
 	newProblemAddParameterCSVReader func(workbookID appD.WorkbookID, reader io.Reader) appS.ProblemAddParameterIterator
 }
 
-func NewEnglishWordProblemProcessor(synthesizerClient appS.SynthesizerClient, translatorClient pluginS.TranslatorClient, tatoebaClient pluginS.TatoebaClient, newProblemAddParameterCSVReader func(workbookID appD.WorkbookID, reader io.Reader) appS.ProblemAddParameterIterator) EnglishWordProblemProcessor {
+// NewEnglishWordProblemProcessor returns the processor of english words.
+// The glossary entries of the organization are preferred to the translations of the translator unless `glossaryRepo` is nil
+func NewEnglishWordProblemProcessor(synthesizerClient appS.SynthesizerClient, translatorClient pluginS.TranslatorClient, glossaryRepo pluginS.GlossaryRepository, tatoebaClient pluginS.TatoebaClient, newProblemAddParameterCSVReader func(workbookID appD.WorkbookID, reader io.Reader) appS.ProblemAddParameterIterator) EnglishWordProblemProcessor {
 	return &englishWordProblemProcessor{
 		synthesizerClient:               synthesizerClient,
 		translatorClient:                translatorClient,
+		glossaryRepo:                    glossaryRepo,
 		tatoebaClient:                   tatoebaClient,
 		newProblemAddParameterCSVReader: newProblemAddParameterCSVReader,
 	}
@@ -197,11 +230,20 @@ func (p *englishWordProblemProcessor) AddProblem(ctx context.Context, rf appS.Re
 
 	logger.Debug("audioID: %d", audioID)
 
+	sentenceIDs := make([]appD.ProblemID, 0)
+	if extractedParam.SentenceProvider == EnglishWordSentenceProviderTatoeba {
+		sentenceIDsTmp, err := p.findOrAddSentencesFromTatoeba(ctx, rf, operator, extractedParam.Text, extractedParam.Lang2)
+		if err != nil {
+			return nil, liberrors.Errorf("failed to findOrAddSentencesFromTatoeba. err: %w", err)
+		}
+		sentenceIDs = sentenceIDsTmp
+	}
+
 	var converter ToEnglishWordProblemAddParameter
 	if extractedParam.Translated == "" && extractedParam.Pos == plugin.PosOther {
-		converter = NewToMultipleEnglishWordProblemAddParameter(p.translatorClient, param.GetWorkbookID(), param.GetNumber(), extractedParam, audioID)
+		converter = NewToMultipleEnglishWordProblemAddParameter(p.newOperatorTranslatorClient(operator), param.GetWorkbookID(), param.GetNumber(), extractedParam, audioID, sentenceIDs)
 	} else {
-		converter = NewToSingleEnglishWordProblemAddParameter(p.translatorClient, param.GetWorkbookID(), param.GetNumber(), extractedParam, audioID)
+		converter = NewToSingleEnglishWordProblemAddParameter(p.newOperatorTranslatorClient(operator), param.GetWorkbookID(), param.GetNumber(), extractedParam, audioID, sentenceIDs)
 	}
 
 	toAddParams, err := converter.Run(ctx)
@@ -209,6 +251,9 @@ func (p *englishWordProblemProcessor) AddProblem(ctx context.Context, rf appS.Re
 		if errors.Is(err, pluginS.ErrTranslationNotFound) {
 			message := "Translation not found"
 			return nil, appD.NewPluginError("client", message, []string{message}, err)
+		} else if errors.Is(err, pluginS.ErrTranslatorUnavailable) {
+			message := "Translator is unavailable"
+			return nil, appD.NewPluginError("server", message, []string{message}, err)
 		}
 		return nil, err
 	}
@@ -260,7 +305,7 @@ func (p *englishWordProblemProcessor) UpdateProblem(ctx context.Context, rf appS
 		sentenceID = sentenceIDtmp
 	}
 
-	converter := NewToSingleEnglishWordProblemUpdateParameter(p.translatorClient, param.GetNumber(), extractedParam, audioID, sentenceID)
+	converter := NewToSingleEnglishWordProblemUpdateParameter(p.newOperatorTranslatorClient(operator), param.GetNumber(), extractedParam, audioID, sentenceID)
 	toUpdateParams, err := converter.Run(ctx)
 	if err != nil {
 		return 0, 0, err
@@ -280,6 +325,13 @@ func (p *englishWordProblemProcessor) UpdateProblem(ctx context.Context, rf appS
 	return 1, 1, nil
 }
 
+func (p *englishWordProblemProcessor) GenerateAudio(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, workbook appD.WorkbookModel, problemID appD.ProblemID) (bool, error) {
+	ctx, span := tracer.Start(ctx, "englishWordProblemProcessor.GenerateAudio")
+	defer span.End()
+
+	return generateAudio(ctx, p.synthesizerClient, rf, domain.EnglishWordProblemType, operator, workbook, problemID)
+}
+
 func (p *englishWordProblemProcessor) RemoveProblem(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, id appS.ProblemSelectParameter2) error {
 	problemRepo, err := rf.NewProblemRepository(ctx, domain.EnglishWordProblemType)
 	if err != nil {
@@ -293,10 +345,185 @@ func (p *englishWordProblemProcessor) RemoveProblem(ctx context.Context, rf appS
 	return nil
 }
 
-func (p *englishWordProblemProcessor) CreateCSVReader(ctx context.Context, workbookID appD.WorkbookID, reader io.Reader) (appS.ProblemAddParameterIterator, error) {
+type englishWordProblemSentences struct {
+	problemID appD.ProblemID
+	version   int
+	pairs     []pluginS.TatoebaSentencePair
+}
+
+func (s *englishWordProblemSentences) GetProblemID() appD.ProblemID {
+	return s.problemID
+}
+
+func (p *englishWordProblemProcessor) FindProblemsWithoutSentences(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, workbook appD.WorkbookModel, size int) ([]appD.ProblemModel, error) {
+	ctx, span := tracer.Start(ctx, "englishWordProblemProcessor.FindProblemsWithoutSentences")
+	defer span.End()
+
+	problems, err := p.findAllWordProblems(ctx, rf, operator, workbook)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]appD.ProblemModel, 0)
+	for _, wordProblem := range problems {
+		if len(results) >= size {
+			break
+		}
+		if len(wordProblem.GetSentences()) != 0 {
+			continue
+		}
+		results = append(results, wordProblem)
+	}
+
+	return results, nil
+}
+
+func (p *englishWordProblemProcessor) FetchSentences(ctx context.Context, problems []appD.ProblemModel) ([]appS.ProblemSentences, error) {
+	ctx, span := tracer.Start(ctx, "englishWordProblemProcessor.FetchSentences")
+	defer span.End()
+
+	logger := log.FromContext(ctx)
+
+	results := make([]appS.ProblemSentences, 0)
+	for _, problem := range problems {
+		wordProblem, ok := problem.(domain.EnglishWordProblemModel)
+		if !ok {
+			return nil, liberrors.Errorf("invalid problem. problemID: %d", problem.GetID())
+		}
+
+		pairs, err := p.findSentencePairsFromTatoeba(ctx, wordProblem.GetText(), wordProblem.GetLang2())
+		if err != nil {
+			return nil, liberrors.Errorf("failed to findSentencePairsFromTatoeba. err: %w", err)
+		}
+
+		if len(pairs) == 0 {
+			logger.Debugf("sentence not found. text: %s", wordProblem.GetText())
+			continue
+		}
+
+		results = append(results, &englishWordProblemSentences{
+			problemID: appD.ProblemID(wordProblem.GetID()),
+			version:   wordProblem.GetVersion(),
+			pairs:     pairs,
+		})
+	}
+
+	return results, nil
+}
+
+func (p *englishWordProblemProcessor) AttachSentences(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, workbook appD.WorkbookModel, sentences []appS.ProblemSentences) (appS.Updated, error) {
+	ctx, span := tracer.Start(ctx, "englishWordProblemProcessor.AttachSentences")
+	defer span.End()
+
+	logger := log.FromContext(ctx)
+
+	problemRepo, err := rf.NewProblemRepository(ctx, domain.EnglishWordProblemType)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to NewProblemRepository. err: %w", err)
+	}
+
+	problems, err := p.findAllWordProblems(ctx, rf, operator, workbook)
+	if err != nil {
+		return 0, err
+	}
+
+	problemMap := make(map[appD.ProblemID]domain.EnglishWordProblemModel)
+	for _, wordProblem := range problems {
+		problemMap[appD.ProblemID(wordProblem.GetID())] = wordProblem
+	}
+
+	workbookID := appD.WorkbookID(workbook.GetID())
+	updated := 0
+	for _, s := range sentences {
+		wordSentences, ok := s.(*englishWordProblemSentences)
+		if !ok {
+			return 0, liberrors.Errorf("invalid sentences. problemID: %d", s.GetProblemID())
+		}
+
+		// the problem which has been changed since the sentences were fetched is skipped
+		wordProblem, ok := problemMap[wordSentences.problemID]
+		if !ok || wordProblem.GetVersion() != wordSentences.version || len(wordProblem.GetSentences()) != 0 {
+			logger.Debugf("problem has been changed. problemID: %d", wordSentences.problemID)
+			continue
+		}
+
+		sentenceIDs := make([]appD.ProblemID, len(wordSentences.pairs))
+		for i, pair := range wordSentences.pairs {
+			sentenceID, err := p.findOrAddSentence(ctx, rf, operator, pair.GetSrc(), pair.GetDst(), wordProblem.GetLang2())
+			if err != nil {
+				return 0, err
+			}
+			sentenceIDs[i] = sentenceID
+		}
+
+		id, err := appS.NewProblemSelectParameter2(workbookID, appD.ProblemID(wordProblem.GetID()), wordProblem.GetVersion())
+		if err != nil {
+			return 0, liberrors.Errorf("failed to NewProblemSelectParameter2. err: %w", err)
+		}
+
+		properties := map[string]string{
+			EnglishWordProblemUpdatePropertyText:       wordProblem.GetText(),
+			EnglishWordProblemUpdatePropertyTranslated: wordProblem.GetTranslated(),
+			EnglishWordProblemUpdatePropertyAudioID:    strconv.Itoa(int(wordProblem.GetAudioID())),
+		}
+		setSentenceIDs(properties, EnglishWordProblemUpdatePropertySentenceID1, EnglishWordProblemUpdatePropertySentenceID2, sentenceIDs)
+
+		param, err := appS.NewProblemUpdateParameter(wordProblem.GetNumber(), properties)
+		if err != nil {
+			return 0, liberrors.Errorf("failed to NewProblemUpdateParameter. err: %w", err)
+		}
+
+		if err := problemRepo.UpdateProblem(ctx, operator, id, param); err != nil {
+			return 0, liberrors.Errorf("failed to problemRepo.UpdateProblem. param: %+v, err: %w", param, err)
+		}
+
+		updated++
+	}
+
+	return appS.Updated(updated), nil
+}
+
+func (p *englishWordProblemProcessor) findAllWordProblems(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, workbook appD.WorkbookModel) ([]domain.EnglishWordProblemModel, error) {
+	problemRepo, err := rf.NewProblemRepository(ctx, domain.EnglishWordProblemType)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to NewProblemRepository. err: %w", err)
+	}
+
+	problems, err := problemRepo.FindAllProblems(ctx, operator, appD.WorkbookID(workbook.GetID()))
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindAllProblems. err: %w", err)
+	}
+
+	wordProblems := make([]domain.EnglishWordProblemModel, len(problems.GetResults()))
+	for i, problem := range problems.GetResults() {
+		wordProblem, ok := problem.(domain.EnglishWordProblemModel)
+		if !ok {
+			return nil, liberrors.Errorf("invalid problem. problemID: %d", problem.GetID())
+		}
+		wordProblems[i] = wordProblem
+	}
+
+	return wordProblems, nil
+}
+
+func (p *englishWordProblemProcessor) CreateCSVReader(ctx context.Context, organizationID userD.OrganizationID, workbookID appD.WorkbookID, reader io.Reader) (appS.ProblemAddParameterIterator, error) {
 	return p.newProblemAddParameterCSVReader(workbookID, reader), nil
 }
 
+func (p *englishWordProblemProcessor) newOperatorTranslatorClient(operator appD.StudentModel) pluginS.TranslatorClient {
+	if p.glossaryRepo == nil {
+		return p.translatorClient
+	}
+	return p.newTranslatorClient(operator.GetOrganizationID())
+}
+
+func (p *englishWordProblemProcessor) newTranslatorClient(organizationID userD.OrganizationID) pluginS.TranslatorClient {
+	if p.glossaryRepo == nil {
+		return p.translatorClient
+	}
+	return pluginS.NewTranslatorGlossaryClient(p.translatorClient, p.glossaryRepo, organizationID)
+}
+
 func (p *englishWordProblemProcessor) GetUnitForSizeQuota() appS.QuotaUnit {
 	return EnglishWordProblemQuotaSizeUnit
 }
@@ -313,18 +540,41 @@ func (p *englishWordProblemProcessor) GetLimitForUpdateQuota() int {
 	return EnglishWordProblemQuotaUpdateLimit
 }
 
-func (p *englishWordProblemProcessor) findOrAddSentenceFromTatoeba(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, tatoebaSentenceNumberFrom, tatoebaSentenceNumberTo int, lang2 appD.Lang2) (appD.ProblemID, error) {
-	systemSpaceID := appS.GetSystemSpaceID()
-	workbookRepo, err := rf.NewWorkbookRepository(ctx)
+// findOrAddSentencesFromTatoeba picks example sentences of the word from tatoeba and returns the IDs of the sentence problems
+func (p *englishWordProblemProcessor) findOrAddSentencesFromTatoeba(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, text string, lang2 appD.Lang2) ([]appD.ProblemID, error) {
+	pairs, err := p.findSentencePairsFromTatoeba(ctx, text, lang2)
 	if err != nil {
-		return 0, liberrors.Errorf("failed to NewWorkbookRepository. err: %w", err)
+		return nil, err
 	}
 
-	tatoebaWorkbook, err := workbookRepo.FindWorkbookByName(ctx, operator, systemSpaceID, appS.TatoebaWorkbookName)
+	sentenceIDs := make([]appD.ProblemID, len(pairs))
+	for i, pair := range pairs {
+		sentenceID, err := p.findOrAddSentence(ctx, rf, operator, pair.GetSrc(), pair.GetDst(), lang2)
+		if err != nil {
+			return nil, err
+		}
+		sentenceIDs[i] = sentenceID
+	}
+
+	return sentenceIDs, nil
+}
+
+// findSentencePairsFromTatoeba picks example sentences of the word from tatoeba without accessing the database
+func (p *englishWordProblemProcessor) findSentencePairsFromTatoeba(ctx context.Context, text string, lang2 appD.Lang2) ([]pluginS.TatoebaSentencePair, error) {
+	condition, err := pluginS.NewTatoebaSentenceSearchCondition(1, EnglishWordSentenceSearchPageSize, text, false)
 	if err != nil {
-		return 0, liberrors.Errorf("failed to FindWorkbookByName. name: %s, err: %w", appS.TatoebaWorkbookName, err)
+		return nil, liberrors.Errorf("failed to NewTatoebaSentenceSearchCondition. err: %w", err)
+	}
+
+	result, err := p.tatoebaClient.FindSentencePairs(ctx, condition)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindSentencePairs. err: %w", err)
 	}
 
+	return PickTatoebaSentencePairs(text, lang2, result.Results, EnglishWordSentenceMaxNumber), nil
+}
+
+func (p *englishWordProblemProcessor) findOrAddSentenceFromTatoeba(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, tatoebaSentenceNumberFrom, tatoebaSentenceNumberTo int, lang2 appD.Lang2) (appD.ProblemID, error) {
 	tatoebaSentenceFrom, err := p.tatoebaClient.FindSentenceBySentenceNumber(ctx, tatoebaSentenceNumberFrom)
 	if err != nil {
 		return 0, liberrors.Errorf("failed to FindTatoebaSentenceBySentenceNumber. err: %w", err)
@@ -335,6 +585,21 @@ func (p *englishWordProblemProcessor) findOrAddSentenceFromTatoeba(ctx context.C
 		return 0, liberrors.Errorf("failed to FindTatoebaSentenceBySentenceNumber. err: %w", err)
 	}
 
+	return p.findOrAddSentence(ctx, rf, operator, tatoebaSentenceFrom, tatoebaSentenceTo, lang2)
+}
+
+func (p *englishWordProblemProcessor) findOrAddSentence(ctx context.Context, rf appS.RepositoryFactory, operator appD.StudentModel, tatoebaSentenceFrom, tatoebaSentenceTo pluginS.TatoebaSentence, lang2 appD.Lang2) (appD.ProblemID, error) {
+	systemSpaceID := appS.GetSystemSpaceID()
+	workbookRepo, err := rf.NewWorkbookRepository(ctx)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to NewWorkbookRepository. err: %w", err)
+	}
+
+	tatoebaWorkbook, err := workbookRepo.FindWorkbookByName(ctx, operator, systemSpaceID, appS.TatoebaWorkbookName)
+	if err != nil {
+		return 0, liberrors.Errorf("failed to FindWorkbookByName. name: %s, err: %w", appS.TatoebaWorkbookName, err)
+	}
+
 	sentenceProblemRepo, err := rf.NewProblemRepository(ctx, domain.EnglishSentenceProblemType)
 	if err != nil {
 		return 0, liberrors.Errorf("failed to NewProblemRepository. err: %w", err)
@@ -42,7 +42,7 @@ func englishWordProblemProcessor_Init(t *testing.T) (
 	rf = new(appSM.RepositoryFactory)
 	rf.On("NewProblemRepository", anythingOfContext, domain.EnglishWordProblemType).Return(problemRepo, nil)
 	workbookModel = new(appDM.WorkbookModel)
-	englishWordProblemProcessor = service.NewEnglishWordProblemProcessor(synthesizerClient, translatorClient, tatoebaClient, nil)
+	englishWordProblemProcessor = service.NewEnglishWordProblemProcessor(synthesizerClient, translatorClient, nil, tatoebaClient, nil)
 	return
 }
 
@@ -188,7 +188,43 @@ func Test_englishWordProblemProcessor_UpdateProblem(t *testing.T) {
 		assert.Equal(t, "pen", param.GetProperties()["text"])
 		assert.Equal(t, "0", param.GetProperties()["audioId"])
 		assert.Equal(t, "0", param.GetProperties()["sentenceId1"])
-		assert.Len(t, param.GetProperties(), 4)
+		assert.Equal(t, "0", param.GetProperties()["ngslRank"])
+		assert.Equal(t, "", param.GetProperties()["cefrLevel"])
+		assert.Len(t, param.GetProperties(), 6)
+	}
+}
+
+func Test_englishWordProblemProcessor_UpdateProblem_ngsl(t *testing.T) {
+	ctx := context.Background()
+	_, _, _, operator, workbookModel, rf, problemRepo, processor := englishWordProblemProcessor_Init(t)
+
+	// given
+	// - workbook
+	workbookModel.On("GetProperties").Return(map[string]string{
+		"audioEnabled": "false",
+	})
+	// - problemRepo
+	problemRepo.On("UpdateProblem", anythingOfContext, operator, mock.Anything, mock.Anything).Return(nil)
+	// when
+	// - the text is changed to the NGSL word
+	paramSelect := new(appSM.ProblemSelectParameter2)
+	param := new(appSM.ProblemUpdateParameter)
+	param.On("GetNumber").Return(2)
+	param.On("GetProperties").Return(map[string]string{
+		"pos":        "6",
+		"text":       "know",
+		"translated": "知る",
+		"lang2":      "ja",
+	})
+	_, _, err := processor.UpdateProblem(ctx, rf, operator, workbookModel, paramSelect, param)
+	require.NoError(t, err)
+	// then
+	// - the rank of the new text is set
+	problemRepo.AssertNumberOfCalls(t, "UpdateProblem", 1)
+	{
+		param := (problemRepo.Calls[0].Arguments[3]).(appS.ProblemUpdateParameter)
+		assert.Equal(t, "know", param.GetProperties()["text"])
+		assert.Equal(t, "41", param.GetProperties()["ngslRank"])
 	}
 }
 
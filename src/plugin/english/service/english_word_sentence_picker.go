@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	pluginS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+)
+
+var (
+	// EnglishWordSentenceIdealLength is the number of words of the sentence which is preferred when picking example sentences
+	EnglishWordSentenceIdealLength = 8
+	// EnglishWordSentenceMaxLength is the number of words of the sentence which is too long to be an example sentence
+	EnglishWordSentenceMaxLength = 20
+	// EnglishWordSentenceNoAuthorPenalty is added to the score of the sentence whose author is unknown
+	EnglishWordSentenceNoAuthorPenalty = 5
+)
+
+func splitEnglishWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
+
+func containsEnglishWord(text, word string) bool {
+	for _, w := range splitEnglishWords(text) {
+		if strings.EqualFold(w, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// scoreTatoebaSentencePair returns the score of the sentence pair. A lower score is better.
+func scoreTatoebaSentencePair(pair pluginS.TatoebaSentencePair) int {
+	length := len(splitEnglishWords(pair.GetSrc().GetText()))
+	score := length - EnglishWordSentenceIdealLength
+	if score < 0 {
+		score = -score
+	}
+
+	if pair.GetSrc().GetAuthor() == "" {
+		score += EnglishWordSentenceNoAuthorPenalty
+	}
+	if pair.GetDst().GetAuthor() == "" {
+		score += EnglishWordSentenceNoAuthorPenalty
+	}
+
+	return score
+}
+
+// PickTatoebaSentencePairs picks at most `size` sentence pairs which are suitable as example sentences of the word.
+// Sentences which don't contain the word itself, are too long, or whose translation is not written in lang2 are excluded.
+func PickTatoebaSentencePairs(word string, lang2 appD.Lang2, pairs []pluginS.TatoebaSentencePair, size int) []pluginS.TatoebaSentencePair {
+	candidates := make([]pluginS.TatoebaSentencePair, 0)
+	for _, pair := range pairs {
+		// the sentences have their own Lang2 values, so they are compared by the codes
+		if pair.GetDst().GetLang2().String() != lang2.String() {
+			continue
+		}
+		if !containsEnglishWord(pair.GetSrc().GetText(), word) {
+			continue
+		}
+		if len(splitEnglishWords(pair.GetSrc().GetText())) > EnglishWordSentenceMaxLength {
+			continue
+		}
+		candidates = append(candidates, pair)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return scoreTatoebaSentencePair(candidates[i]) < scoreTatoebaSentencePair(candidates[j])
+	})
+
+	if len(candidates) > size {
+		return candidates[:size]
+	}
+	return candidates
+}
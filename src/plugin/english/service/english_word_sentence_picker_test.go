@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	pluginS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/service"
+)
+
+func testNewTatoebaSentencePair(t *testing.T, srcNumber int, srcText, srcAuthor string, dstLang2 appD.Lang2, dstText, dstAuthor string) pluginS.TatoebaSentencePair {
+	src, err := pluginS.NewTatoebaSentence(srcNumber, appD.Lang2EN, srcText, srcAuthor, time.Now())
+	require.NoError(t, err)
+	dst, err := pluginS.NewTatoebaSentence(srcNumber+1, dstLang2, dstText, dstAuthor, time.Now())
+	require.NoError(t, err)
+	pair, err := pluginS.NewTatoebaSentencePair(src, dst)
+	require.NoError(t, err)
+	return pair
+}
+
+func TestPickTatoebaSentencePairs(t *testing.T) {
+	// the tatoeba client makes the Lang2 of each sentence
+	ja, err := appD.NewLang2("ja")
+	require.NoError(t, err)
+	en, err := appD.NewLang2("en")
+	require.NoError(t, err)
+
+	pairs := []pluginS.TatoebaSentencePair{
+		// the word is a part of another word
+		testNewTatoebaSentencePair(t, 10, "I like books.", "alice", ja, "私は本が好きです。", "bob"),
+		// the translation is not written in Japanese
+		testNewTatoebaSentencePair(t, 20, "This is a book.", "alice", en, "This is a book.", "bob"),
+		// too long
+		testNewTatoebaSentencePair(t, 30, "The book that I bought at the store near the station yesterday afternoon was much more interesting than I had expected.", "alice", ja, "昨日の午後に駅の近くの店で買った本は思っていたよりずっと面白かった。", "bob"),
+		// too short
+		testNewTatoebaSentencePair(t, 40, "A book.", "alice", ja, "本。", "bob"),
+		// ideal length
+		testNewTatoebaSentencePair(t, 50, "I read a book in the library yesterday.", "alice", ja, "昨日図書館で本を読みました。", "bob"),
+		// ideal length, but the author is unknown
+		testNewTatoebaSentencePair(t, 60, "He gave me a book on my birthday.", "", ja, "彼は誕生日に本をくれた。", ""),
+	}
+
+	t.Run("pick two sentences", func(t *testing.T) {
+		picked := service.PickTatoebaSentencePairs("book", appD.Lang2JA, pairs, 2)
+		require.Len(t, picked, 2)
+		assert.Equal(t, 50, picked[0].GetSrc().GetSentenceNumber())
+		assert.Equal(t, 40, picked[1].GetSrc().GetSentenceNumber())
+	})
+
+	t.Run("pick all sentences", func(t *testing.T) {
+		picked := service.PickTatoebaSentencePairs("Book", appD.Lang2JA, pairs, 10)
+		require.Len(t, picked, 3)
+		assert.Equal(t, 50, picked[0].GetSrc().GetSentenceNumber())
+		assert.Equal(t, 40, picked[1].GetSrc().GetSentenceNumber())
+		assert.Equal(t, 60, picked[2].GetSrc().GetSentenceNumber())
+	})
+
+	t.Run("no sentences", func(t *testing.T) {
+		picked := service.PickTatoebaSentencePairs("pen", appD.Lang2JA, pairs, 2)
+		assert.Len(t, picked, 0)
+	})
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	appU "github.com/kujilabo/cocotola-api/src/app/usecase"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	pluginCommonDomain "github.com/kujilabo/cocotola-api/src/plugin/common/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/service"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+const (
+	DefaultNGSLWorkbookSize = 50
+	MaxNGSLWorkbookSize     = 100
+)
+
+type StudentUsecaseNGSL interface {
+	// GenerateNGSLWorkbook adds a workbook to the personal space which contains the next `size` NGSL words the student doesn't have yet
+	GenerateNGSLWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, size int) (appD.WorkbookID, error)
+}
+
+type studentUsecaseNGSL struct {
+	db         *gorm.DB
+	pf         appS.ProcessorFactory
+	rfFunc     appS.RepositoryFactoryFunc
+	userRfFunc userS.RepositoryFactoryFunc
+}
+
+func NewStudentUsecaseNGSL(db *gorm.DB, pf appS.ProcessorFactory, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc) StudentUsecaseNGSL {
+	return &studentUsecaseNGSL{
+		db:         db,
+		pf:         pf,
+		rfFunc:     rfFunc,
+		userRfFunc: userRfFunc,
+	}
+}
+
+func (s *studentUsecaseNGSL) GenerateNGSLWorkbook(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, size int) (appD.WorkbookID, error) {
+	logger := log.FromContext(ctx)
+
+	if size <= 0 || size > MaxNGSLWorkbookSize {
+		return 0, liberrors.Errorf("invalid size. size: %d, err: %w", size, libD.ErrInvalidArgument)
+	}
+
+	var result appD.WorkbookID
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		student, err := appU.FindStudent(ctx, s.pf, rf, userRf, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to findStudent. err: %w", err)
+		}
+
+		problemType := domain.EnglishWordProblemType
+		remaining, err := s.findRemainingQuota(ctx, student, problemType)
+		if err != nil {
+			return liberrors.Errorf("failed to findRemainingQuota. err: %w", err)
+		}
+		if remaining == 0 {
+			return appS.ErrQuotaExceeded
+		}
+
+		knownTexts, err := s.findKnownTexts(ctx, student)
+		if err != nil {
+			return liberrors.Errorf("failed to findKnownTexts. err: %w", err)
+		}
+
+		if size > remaining {
+			size = remaining
+		}
+		words := nextNGSLWords(knownTexts, size)
+		if len(words) == 0 {
+			return liberrors.Errorf("all NGSL words have already been added. err: %w", libD.ErrInvalidArgument)
+		}
+
+		workbookNames, err := s.findWorkbookNames(ctx, student)
+		if err != nil {
+			return liberrors.Errorf("failed to findWorkbookNames. err: %w", err)
+		}
+
+		workbookName := uniqueWorkbookName(fmt.Sprintf("NGSL %d-%d", words[0].GetRank(), words[len(words)-1].GetRank()), workbookNames)
+		workbookProperties := map[string]string{
+			"audioEnabled": "false",
+		}
+		workbookParam, err := appS.NewWorkbookAddParameter(problemType, workbookName, appD.Lang2JA, "", workbookProperties)
+		if err != nil {
+			return liberrors.Errorf("failed to NewWorkbookAddParameter. err: %w", err)
+		}
+
+		workbookID, err := student.AddWorkbookToPersonalSpace(ctx, workbookParam)
+		if err != nil {
+			return liberrors.Errorf("failed to AddWorkbookToPersonalSpace. err: %w", err)
+		}
+
+		workbook, err := student.FindWorkbookByID(ctx, workbookID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindWorkbookByID. err: %w", err)
+		}
+
+		added := 0
+		for i, word := range words {
+			// a word may be added as more than one problem, so the quota is checked for each problem
+			if added >= remaining {
+				break
+			}
+
+			properties := map[string]string{
+				service.EnglishWordProblemAddPropertyText:  word.GetText(),
+				service.EnglishWordProblemAddPropertyLang2: appD.Lang2JA.String(),
+				service.EnglishWordProblemAddPropertyPos:   strconv.Itoa(int(pluginCommonDomain.PosOther)),
+			}
+			problemParam, err := appS.NewProblemAddParameter(workbookID, i+1, properties)
+			if err != nil {
+				return liberrors.Errorf("failed to NewProblemAddParameter. err: %w", err)
+			}
+
+			problemIDs, err := workbook.AddProblem(ctx, student, problemParam)
+			if err != nil {
+				return liberrors.Errorf("failed to AddProblem. err: %w", err)
+			}
+			added += len(problemIDs)
+		}
+
+		if err := student.IncrementQuotaUsage(ctx, problemType, appS.QuotaNameSize, added); err != nil {
+			return liberrors.Errorf("student.IncrementQuotaUsage(Size). err: %w", err)
+		}
+		if err := student.IncrementQuotaUsage(ctx, problemType, appS.QuotaNameUpdate, added); err != nil {
+			return liberrors.Errorf("student.IncrementQuotaUsage(Update). err: %w", err)
+		}
+
+		logger.Infof("NGSL workbook. workbookID: %d, added: %d", workbookID, added)
+		result = workbookID
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// findRemainingQuota returns the number of the problems which the student can add without exceeding the size and the update quotas
+func (s *studentUsecaseNGSL) findRemainingQuota(ctx context.Context, student appS.Student, problemType string) (int, error) {
+	remainingSize, err := student.FindRemainingQuota(ctx, problemType, appS.QuotaNameSize)
+	if err != nil {
+		return 0, liberrors.Errorf("student.FindRemainingQuota(Size). err: %w", err)
+	}
+	remainingUpdate, err := student.FindRemainingQuota(ctx, problemType, appS.QuotaNameUpdate)
+	if err != nil {
+		return 0, liberrors.Errorf("student.FindRemainingQuota(Update). err: %w", err)
+	}
+
+	if remainingSize < remainingUpdate {
+		return remainingSize, nil
+	}
+	return remainingUpdate, nil
+}
+
+// findWorkbookNames returns the names of the workbooks in the personal space of the student
+func (s *studentUsecaseNGSL) findWorkbookNames(ctx context.Context, student appS.Student) (map[string]bool, error) {
+	names := make(map[string]bool)
+	if err := forEachWorkbookModel(ctx, student, func(workbookModel appD.WorkbookModel) error {
+		names[workbookModel.GetName()] = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// uniqueWorkbookName returns `name` with the smallest number suffix which isn't in `names`, because the name of the workbook must be unique for the owner
+func uniqueWorkbookName(name string, names map[string]bool) string {
+	if !names[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if !names[candidate] {
+			return candidate
+		}
+	}
+}
+
+// findKnownTexts returns the texts of the english word problems in the personal space of the student
+func (s *studentUsecaseNGSL) findKnownTexts(ctx context.Context, student appS.Student) (map[string]bool, error) {
+	knownTexts := make(map[string]bool)
+	if err := forEachEnglishWordWorkbook(ctx, student, func(workbook appS.Workbook) error {
+		problems, err := workbook.FindAllProblems(ctx, student)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAllProblems. err: %w", err)
+		}
+
+		for _, problem := range problems.GetResults() {
+			englishWordProblem, ok := problem.(domain.EnglishWordProblemModel)
+			if !ok {
+				return liberrors.Errorf("invalid problem. problemID: %d", problem.GetID())
+			}
+			knownTexts[strings.ToLower(englishWordProblem.GetText())] = true
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return knownTexts, nil
+}
+
+func nextNGSLWords(knownTexts map[string]bool, size int) []domain.NGSLWord {
+	words := make([]domain.NGSLWord, 0, size)
+	for _, w := range domain.GetNGSLWords() {
+		if len(words) >= size {
+			break
+		}
+		if knownTexts[w.GetText()] {
+			continue
+		}
+		words = append(words, w)
+	}
+	return words
+}
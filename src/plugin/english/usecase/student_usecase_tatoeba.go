@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	appU "github.com/kujilabo/cocotola-api/src/app/usecase"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	pluginCommonS "github.com/kujilabo/cocotola-api/src/plugin/common/service"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+	userS "github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+const (
+	DefaultSentenceRecommendationSize = 10
+	MaxSentenceRecommendationSize     = 50
+
+	memorizationStudyType = "memorization"
+
+	// the tatoeba api doesn't know the difficulty, so the sentences are filtered after they are fetched.
+	// tatoebaScanMaxPages limits the number of the requests for a search
+	tatoebaScanPageSize = 100
+	tatoebaScanMaxPages = 5
+	// recommendationMaxKeywords is the number of the memorized words which are used to search the sentences
+	recommendationMaxKeywords = 10
+)
+
+type TatoebaSentenceDifficultySearchCondition struct {
+	PageNo        int `validate:"required,gte=1"`
+	PageSize      int `validate:"required,gte=1,lte=100"`
+	Keyword       string
+	Random        bool
+	MinDifficulty int `validate:"gte=0,lte=100"`
+	MaxDifficulty int `validate:"gte=0,lte=100,gtefield=MinDifficulty"`
+}
+
+type TatoebaSentencePairWithDifficulty struct {
+	Pair       pluginCommonS.TatoebaSentencePair
+	Difficulty domain.SentenceDifficulty
+}
+
+type StudentUsecaseTatoeba interface {
+	// FindSentencePairsByDifficulty returns the sentence pairs whose english sentences have the difficulty between MinDifficulty and MaxDifficulty.
+	// Only the first tatoebaScanMaxPages pages of the tatoeba search results are scanned
+	FindSentencePairsByDifficulty(ctx context.Context, param *TatoebaSentenceDifficultySearchCondition) ([]TatoebaSentencePairWithDifficulty, error)
+
+	// RecommendSentences returns the sentence pairs whose english sentences consist of the words the student has memorized, from the easiest
+	RecommendSentences(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, size int) ([]TatoebaSentencePairWithDifficulty, error)
+}
+
+type studentUsecaseTatoeba struct {
+	db            *gorm.DB
+	pf            appS.ProcessorFactory
+	rfFunc        appS.RepositoryFactoryFunc
+	userRfFunc    userS.RepositoryFactoryFunc
+	tatoebaClient pluginCommonS.TatoebaClient
+}
+
+func NewStudentUsecaseTatoeba(db *gorm.DB, pf appS.ProcessorFactory, rfFunc appS.RepositoryFactoryFunc, userRfFunc userS.RepositoryFactoryFunc, tatoebaClient pluginCommonS.TatoebaClient) StudentUsecaseTatoeba {
+	return &studentUsecaseTatoeba{
+		db:            db,
+		pf:            pf,
+		rfFunc:        rfFunc,
+		userRfFunc:    userRfFunc,
+		tatoebaClient: tatoebaClient,
+	}
+}
+
+func (s *studentUsecaseTatoeba) FindSentencePairsByDifficulty(ctx context.Context, param *TatoebaSentenceDifficultySearchCondition) ([]TatoebaSentencePairWithDifficulty, error) {
+	if err := libD.Validator.Struct(param); err != nil {
+		return nil, liberrors.Errorf("invalid search condition. err: %v. %w", err, libD.ErrInvalidArgument)
+	}
+
+	skip := (param.PageNo - 1) * param.PageSize
+	results := make([]TatoebaSentencePairWithDifficulty, 0, param.PageSize)
+	for pageNo := 1; pageNo <= tatoebaScanMaxPages; pageNo++ {
+		pairs, err := s.findSentencePairs(ctx, pageNo, param.Keyword, param.Random)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pair := range pairs.Results {
+			difficulty := domain.NewSentenceDifficulty(pair.GetSrc().GetText())
+			if difficulty.Score < param.MinDifficulty || param.MaxDifficulty < difficulty.Score {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			results = append(results, TatoebaSentencePairWithDifficulty{Pair: pair, Difficulty: difficulty})
+			if len(results) == param.PageSize {
+				return results, nil
+			}
+		}
+
+		if len(pairs.Results) < tatoebaScanPageSize || int64(pageNo*tatoebaScanPageSize) >= pairs.TotalCount {
+			break
+		}
+	}
+
+	return results, nil
+}
+
+func (s *studentUsecaseTatoeba) RecommendSentences(ctx context.Context, organizationID userD.OrganizationID, operatorID userD.AppUserID, size int) ([]TatoebaSentencePairWithDifficulty, error) {
+	if size <= 0 || size > MaxSentenceRecommendationSize {
+		return nil, liberrors.Errorf("invalid size. size: %d, err: %w", size, libD.ErrInvalidArgument)
+	}
+
+	var memorizedTexts map[string]bool
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		rf, err := s.rfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		userRf, err := s.userRfFunc(ctx, tx)
+		if err != nil {
+			return err
+		}
+		student, err := appU.FindStudent(ctx, s.pf, rf, userRf, organizationID, operatorID)
+		if err != nil {
+			return liberrors.Errorf("failed to findStudent. err: %w", err)
+		}
+
+		texts, err := findMemorizedTexts(ctx, student)
+		if err != nil {
+			return liberrors.Errorf("failed to findMemorizedTexts. err: %w", err)
+		}
+		memorizedTexts = texts
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	results := make([]TatoebaSentencePairWithDifficulty, 0)
+	found := make(map[int]bool)
+	for _, keyword := range sampleKeywords(memorizedTexts, recommendationMaxKeywords) {
+		pairs, err := s.findSentencePairs(ctx, 1, keyword, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pair := range pairs.Results {
+			sentenceNumber := pair.GetSrc().GetSentenceNumber()
+			if found[sentenceNumber] {
+				continue
+			}
+
+			difficulty := domain.NewSentenceDifficulty(pair.GetSrc().GetText())
+			if !difficulty.IsCoveredBy(memorizedTexts) {
+				continue
+			}
+
+			found[sentenceNumber] = true
+			results = append(results, TatoebaSentencePairWithDifficulty{Pair: pair, Difficulty: difficulty})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Difficulty.Score < results[j].Difficulty.Score
+	})
+	if len(results) > size {
+		results = results[:size]
+	}
+
+	return results, nil
+}
+
+func (s *studentUsecaseTatoeba) findSentencePairs(ctx context.Context, pageNo int, keyword string, random bool) (*pluginCommonS.TatoebaSentencePairSearchResult, error) {
+	condition, err := pluginCommonS.NewTatoebaSentenceSearchCondition(pageNo, tatoebaScanPageSize, keyword, random)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to NewTatoebaSentenceSearchCondition. err: %w", err)
+	}
+
+	pairs, err := s.tatoebaClient.FindSentencePairs(ctx, condition)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindSentencePairs. err: %w", err)
+	}
+	return pairs, nil
+}
+
+// findMemorizedTexts returns the texts of the english word problems which the student has memorized
+func findMemorizedTexts(ctx context.Context, student appS.Student) (map[string]bool, error) {
+	memorizedTexts := make(map[string]bool)
+	if err := forEachEnglishWordWorkbook(ctx, student, func(workbook appS.Workbook) error {
+		recordbook, err := student.FindRecordbook(ctx, appD.WorkbookID(workbook.GetID()), memorizationStudyType)
+		if err != nil {
+			return liberrors.Errorf("failed to FindRecordbook. err: %w", err)
+		}
+
+		records, err := recordbook.GetResults(ctx)
+		if err != nil {
+			return liberrors.Errorf("failed to GetResults. err: %w", err)
+		}
+
+		memorizedIDs := make([]appD.ProblemID, 0)
+		for problemID, record := range records {
+			if record.Memorized {
+				memorizedIDs = append(memorizedIDs, problemID)
+			}
+		}
+		if len(memorizedIDs) == 0 {
+			return nil
+		}
+
+		condition, err := appS.NewProblemIDsCondition(appD.WorkbookID(workbook.GetID()), memorizedIDs)
+		if err != nil {
+			return liberrors.Errorf("failed to NewProblemIDsCondition. err: %w", err)
+		}
+
+		problems, err := workbook.FindProblemsByProblemIDs(ctx, student, condition)
+		if err != nil {
+			return liberrors.Errorf("failed to FindProblemsByProblemIDs. err: %w", err)
+		}
+
+		for _, problem := range problems.GetResults() {
+			englishWordProblem, ok := problem.(domain.EnglishWordProblemModel)
+			if !ok {
+				return liberrors.Errorf("invalid problem. problemID: %d", problem.GetID())
+			}
+			memorizedTexts[strings.ToLower(englishWordProblem.GetText())] = true
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return memorizedTexts, nil
+}
+
+// sampleKeywords returns at most `size` texts at random. The texts which are not single words are excluded
+func sampleKeywords(texts map[string]bool, size int) []string {
+	keywords := make([]string, 0, len(texts))
+	for text := range texts {
+		if !strings.Contains(text, " ") {
+			keywords = append(keywords, text)
+		}
+	}
+	sort.Strings(keywords)
+	rand.Shuffle(len(keywords), func(i, j int) {
+		keywords[i], keywords[j] = keywords[j], keywords[i]
+	})
+
+	if len(keywords) > size {
+		return keywords[:size]
+	}
+	return keywords
+}
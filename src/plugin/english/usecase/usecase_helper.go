@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+
+	appD "github.com/kujilabo/cocotola-api/src/app/domain"
+	appS "github.com/kujilabo/cocotola-api/src/app/service"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+	userD "github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+const workbookSearchPageSize = 100
+
+// forEachEnglishWordWorkbook calls fn with each english word workbook in the personal space of the student
+func forEachEnglishWordWorkbook(ctx context.Context, student appS.Student, fn func(workbook appS.Workbook) error) error {
+	return forEachWorkbookModel(ctx, student, func(workbookModel appD.WorkbookModel) error {
+		if workbookModel.GetProblemType() != domain.EnglishWordProblemType {
+			return nil
+		}
+
+		workbook, err := student.FindWorkbookByID(ctx, appD.WorkbookID(workbookModel.GetID()))
+		if err != nil {
+			return liberrors.Errorf("failed to FindWorkbookByID. err: %w", err)
+		}
+
+		return fn(workbook)
+	})
+}
+
+// forEachWorkbookModel calls fn with each workbook in the personal space of the student
+func forEachWorkbookModel(ctx context.Context, student appS.Student, fn func(workbookModel appD.WorkbookModel) error) error {
+	for pageNo := 1; ; pageNo++ {
+		condition, err := appS.NewWorkbookSearchCondition(pageNo, workbookSearchPageSize, []userD.SpaceID{})
+		if err != nil {
+			return liberrors.Errorf("failed to NewWorkbookSearchCondition. err: %w", err)
+		}
+
+		workbooks, err := student.FindWorkbooksFromPersonalSpace(ctx, condition)
+		if err != nil {
+			return liberrors.Errorf("failed to FindWorkbooksFromPersonalSpace. err: %w", err)
+		}
+
+		for _, workbookModel := range workbooks.GetResults() {
+			if err := fn(workbookModel); err != nil {
+				return err
+			}
+		}
+
+		if len(workbooks.GetResults()) < workbookSearchPageSize || pageNo*workbookSearchPageSize >= workbooks.GetTotalCount() {
+			return nil
+		}
+	}
+}
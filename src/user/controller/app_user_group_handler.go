@@ -0,0 +1,385 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/user/controller/entity"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+	"github.com/kujilabo/cocotola-api/src/user/usecase"
+)
+
+type AppUserGroupHandler interface {
+	FindAppUserGroups(c *gin.Context)
+
+	FindAppUserGroupByID(c *gin.Context)
+
+	AddAppUserGroup(c *gin.Context)
+
+	UpdateAppUserGroup(c *gin.Context)
+
+	RemoveAppUserGroup(c *gin.Context)
+
+	FindGroupUsers(c *gin.Context)
+
+	AddGroupUser(c *gin.Context)
+
+	RemoveGroupUser(c *gin.Context)
+}
+
+type appUserGroupHandler struct {
+	appUserGroupUsecase usecase.AppUserGroupUsecase
+}
+
+func NewAppUserGroupHandler(appUserGroupUsecase usecase.AppUserGroupUsecase) AppUserGroupHandler {
+	return &appUserGroupHandler{
+		appUserGroupUsecase: appUserGroupUsecase,
+	}
+}
+
+// FindAppUserGroups godoc
+// @Summary Find the groups of the organization
+// @Produce json
+// @Param pageNo query int true "page number"
+// @Param pageSize query int true "page size"
+// @Success 200 {object} entity.AppUserGroupFindResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /v1/admin/group [get]
+func (h *appUserGroupHandler) FindAppUserGroups(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.AppUserGroupFindParameter{}
+		if err := c.ShouldBindQuery(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		appUserGroups, totalCount, err := h.appUserGroupUsecase.FindAppUserGroups(ctx, organizationID, operatorID, param.PageNo, param.PageSize)
+		if err != nil {
+			return err
+		}
+
+		results := make([]*entity.AppUserGroupResponse, len(appUserGroups))
+		for i, appUserGroup := range appUserGroups {
+			results[i] = toAppUserGroupResponse(appUserGroup)
+		}
+
+		c.JSON(http.StatusOK, entity.AppUserGroupFindResponse{
+			TotalCount: totalCount,
+			Results:    results,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// FindAppUserGroupByID godoc
+// @Summary Find the group of the organization
+// @Produce json
+// @Param appUserGroupID path int true "App user group ID"
+// @Success 200 {object} entity.AppUserGroupResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/group/{appUserGroupID} [get]
+func (h *appUserGroupHandler) FindAppUserGroupByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUserGroup, err := h.appUserGroupUsecase.FindAppUserGroupByID(ctx, organizationID, operatorID, domain.AppUserGroupID(appUserGroupID))
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, toAppUserGroupResponse(appUserGroup))
+		return nil
+	}, h.errorHandle)
+}
+
+// AddAppUserGroup godoc
+// @Summary Add the group. The key must be unique in the organization
+// @Produce json
+// @Param param body entity.AppUserGroupAddParameter true "parameter to add the group"
+// @Success 200 {object} controllerhelper.IDResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Router /v1/admin/group [post]
+func (h *appUserGroupHandler) AddAppUserGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.AppUserGroupAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		parameter, err := service.NewAppUserGroupAddParameter(param.Key, param.Name, param.Description)
+		if err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		appUserGroupID, err := h.appUserGroupUsecase.AddAppUserGroup(ctx, organizationID, operatorID, parameter)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, controllerhelper.IDResponse{ID: uint(appUserGroupID)})
+		return nil
+	}, h.errorHandle)
+}
+
+// UpdateAppUserGroup godoc
+// @Summary Update the name and the description of the group. The public group can't be updated
+// @Produce json
+// @Param appUserGroupID path int true "App user group ID"
+// @Param version query int true "version of the group"
+// @Param param body entity.AppUserGroupUpdateParameter true "parameter to update the group"
+// @Success 200 {object} controllerhelper.IDResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/group/{appUserGroupID} [put]
+func (h *appUserGroupHandler) UpdateAppUserGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.AppUserGroupUpdateParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		version, err := ginhelper.GetIntFromQuery(c, "version")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		parameter, err := service.NewAppUserGroupUpdateParameter(param.Name, param.Description)
+		if err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		if err := h.appUserGroupUsecase.UpdateAppUserGroup(ctx, organizationID, operatorID, domain.AppUserGroupID(appUserGroupID), version, parameter); err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, controllerhelper.IDResponse{ID: appUserGroupID})
+		return nil
+	}, h.errorHandle)
+}
+
+// RemoveAppUserGroup godoc
+// @Summary Remove the group. The members lose the policies given to the group
+// @Param appUserGroupID path int true "App user group ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/group/{appUserGroupID} [delete]
+func (h *appUserGroupHandler) RemoveAppUserGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.appUserGroupUsecase.RemoveAppUserGroup(ctx, organizationID, operatorID, domain.AppUserGroupID(appUserGroupID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// FindGroupUsers godoc
+// @Summary Find the members of the group
+// @Produce json
+// @Param appUserGroupID path int true "App user group ID"
+// @Param pageNo query int true "page number"
+// @Param pageSize query int true "page size"
+// @Success 200 {object} entity.AppUserFindResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/group/{appUserGroupID}/user [get]
+func (h *appUserGroupHandler) FindGroupUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.GroupUserFindParameter{}
+		if err := c.ShouldBindQuery(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUsers, totalCount, err := h.appUserGroupUsecase.FindGroupUsers(ctx, organizationID, operatorID, domain.AppUserGroupID(appUserGroupID), param.PageNo, param.PageSize)
+		if err != nil {
+			return err
+		}
+
+		results := make([]*entity.AppUserResponse, len(appUsers))
+		for i, appUser := range appUsers {
+			results[i] = toAppUserResponse(appUser)
+		}
+
+		c.JSON(http.StatusOK, entity.AppUserFindResponse{
+			TotalCount: totalCount,
+			Results:    results,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// AddGroupUser godoc
+// @Summary Add the user to the group
+// @Param appUserGroupID path int true "App user group ID"
+// @Param appUserID path int true "App user ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Router /v1/admin/group/{appUserGroupID}/user/{appUserID} [post]
+func (h *appUserGroupHandler) AddGroupUser(c *gin.Context) {
+	h.updateGroupUser(c, h.appUserGroupUsecase.AddGroupUser)
+}
+
+// RemoveGroupUser godoc
+// @Summary Remove the user from the group
+// @Param appUserGroupID path int true "App user group ID"
+// @Param appUserID path int true "App user ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/group/{appUserGroupID}/user/{appUserID} [delete]
+func (h *appUserGroupHandler) RemoveGroupUser(c *gin.Context) {
+	h.updateGroupUser(c, h.appUserGroupUsecase.RemoveGroupUser)
+}
+
+func (h *appUserGroupHandler) updateGroupUser(c *gin.Context, fn func(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUserID, err := ginhelper.GetUintFromPath(c, "appUserID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := fn(ctx, organizationID, operatorID, domain.AppUserGroupID(appUserGroupID), domain.AppUserID(appUserID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *appUserGroupHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return true
+	} else if errors.Is(err, service.ErrPermissionDenied) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusForbidden, gin.H{"message": http.StatusText(http.StatusForbidden)})
+		return true
+	} else if errors.Is(err, service.ErrAppUserGroupNotFound) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Group not found"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserNotFound) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "User not found"})
+		return true
+	} else if errors.Is(err, service.ErrGroupUserNotFound) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "The user isn't a member of the group"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserGroupAlreadyExists) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Group already exists"})
+		return true
+	} else if errors.Is(err, service.ErrGroupUserAlreadyExists) {
+		logger.Warnf("appUserGroupHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "The user is already a member of the group"})
+		return true
+	}
+	logger.Errorf("appUserGroupHandler err: %+v", err)
+	return false
+}
+
+func toAppUserGroupResponse(appUserGroup service.AppUserGroup) *entity.AppUserGroupResponse {
+	return &entity.AppUserGroupResponse{
+		ID:          appUserGroup.GetID(),
+		Version:     appUserGroup.GetVersion(),
+		CreatedAt:   appUserGroup.GetCreatedAt(),
+		UpdatedAt:   appUserGroup.GetUpdatedAt(),
+		Key:         appUserGroup.GetKey(),
+		Name:        appUserGroup.GetName(),
+		Description: appUserGroup.GetDescription(),
+	}
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+type AppUserGroupFindParameter struct {
+	PageNo   int `form:"pageNo" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
+}
+
+type AppUserGroupResponse struct {
+	ID          uint      `json:"id"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type AppUserGroupFindResponse struct {
+	TotalCount int                     `json:"totalCount"`
+	Results    []*AppUserGroupResponse `json:"results"`
+}
+
+type AppUserGroupAddParameter struct {
+	Key         string `json:"key" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type AppUserGroupUpdateParameter struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type GroupUserFindParameter struct {
+	PageNo   int `form:"pageNo" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+type OrganizationAddParameter struct {
+	Name       string                 `json:"name" binding:"required"`
+	FirstOwner FirstOwnerAddParameter `json:"firstOwner" binding:"required"`
+}
+
+type FirstOwnerAddParameter struct {
+	LoginID  string `json:"loginId" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type OrganizationAddResponse struct {
+	ID uint `json:"id"`
+}
+
+type AppUserFindParameter struct {
+	PageNo   int `form:"pageNo" binding:"required,gte=1"`
+	PageSize int `form:"pageSize" binding:"required,gte=1,lte=100"`
+}
+
+type AppUserResponse struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	LoginID   string    `json:"loginId"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	Provider  string    `json:"provider"`
+	Disabled  bool      `json:"disabled"`
+}
+
+type AppUserFindResponse struct {
+	TotalCount int                `json:"totalCount"`
+	Results    []*AppUserResponse `json:"results"`
+}
+
+type AppUserInviteParameter struct {
+	LoginID  string `json:"loginId" binding:"required"`
+	Username string `json:"username" binding:"required"`
+}
+
+type AppUserInviteResponse struct {
+	ID uint `json:"id"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+type SpaceResponse struct {
+	ID          uint      `json:"id"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Key         string    `json:"key"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type SpaceFindResponse struct {
+	Results []*SpaceResponse `json:"results"`
+}
+
+type SharedSpaceAddParameter struct {
+	Key         string `json:"key" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type SpaceMemberResponse struct {
+	AppUserID uint   `json:"appUserId"`
+	LoginID   string `json:"loginId"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+}
+
+type SpaceMemberFindResponse struct {
+	Results []*SpaceMemberResponse `json:"results"`
+}
+
+type SpaceMemberAddParameter struct {
+	AppUserID uint   `json:"appUserId" binding:"required,gte=1"`
+	Role      string `json:"role" binding:"required"`
+}
+
+type SpaceMemberUpdateParameter struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type SpaceGroupUpdateParameter struct {
+	Role string `json:"role" binding:"required"`
+}
@@ -0,0 +1,285 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/user/controller/entity"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+	"github.com/kujilabo/cocotola-api/src/user/usecase"
+)
+
+type OrganizationAdminHandler interface {
+	AddOrganization(c *gin.Context)
+
+	FindAppUsers(c *gin.Context)
+
+	FindAppUser(c *gin.Context)
+
+	InviteAppUser(c *gin.Context)
+
+	DisableAppUser(c *gin.Context)
+
+	EnableAppUser(c *gin.Context)
+
+	RemoveAppUser(c *gin.Context)
+}
+
+type organizationAdminHandler struct {
+	organizationAdminUsecase usecase.OrganizationAdminUsecase
+}
+
+func NewOrganizationAdminHandler(organizationAdminUsecase usecase.OrganizationAdminUsecase) OrganizationAdminHandler {
+	return &organizationAdminHandler{
+		organizationAdminUsecase: organizationAdminUsecase,
+	}
+}
+
+// AddOrganization godoc
+// @Summary Add the organization and the first owner of it
+// @Produce json
+// @Param param body entity.OrganizationAddParameter true "parameter to add the organization"
+// @Success 200 {object} entity.OrganizationAddResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Router /v1/admin/organization [post]
+func (h *organizationAdminHandler) AddOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.OrganizationAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		newOrganizationID, err := h.organizationAdminUsecase.AddOrganization(ctx, &usecase.OrganizationAddParameter{
+			Name:               param.Name,
+			FirstOwnerLoginID:  param.FirstOwner.LoginID,
+			FirstOwnerUsername: param.FirstOwner.Username,
+			FirstOwnerPassword: param.FirstOwner.Password,
+		})
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.OrganizationAddResponse{ID: uint(newOrganizationID)})
+		return nil
+	}, h.errorHandle)
+}
+
+// FindAppUsers godoc
+// @Summary Find the users of the organization including the disabled users
+// @Produce json
+// @Param pageNo query int true "page number"
+// @Param pageSize query int true "page size"
+// @Success 200 {object} entity.AppUserFindResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /v1/admin/user [get]
+func (h *organizationAdminHandler) FindAppUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.AppUserFindParameter{}
+		if err := c.ShouldBindQuery(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		appUsers, totalCount, err := h.organizationAdminUsecase.FindAppUsers(ctx, organizationID, operatorID, param.PageNo, param.PageSize)
+		if err != nil {
+			return err
+		}
+
+		results := make([]*entity.AppUserResponse, len(appUsers))
+		for i, appUser := range appUsers {
+			results[i] = toAppUserResponse(appUser)
+		}
+
+		c.JSON(http.StatusOK, entity.AppUserFindResponse{
+			TotalCount: totalCount,
+			Results:    results,
+		})
+		return nil
+	}, h.errorHandle)
+}
+
+// FindAppUser godoc
+// @Summary Find the user of the organization
+// @Produce json
+// @Param appUserID path int true "App user ID"
+// @Success 200 {object} entity.AppUserResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/user/{appUserID} [get]
+func (h *organizationAdminHandler) FindAppUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		appUserID, err := ginhelper.GetUintFromPath(c, "appUserID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUser, err := h.organizationAdminUsecase.FindAppUser(ctx, organizationID, operatorID, domain.AppUserID(appUserID))
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, toAppUserResponse(appUser))
+		return nil
+	}, h.errorHandle)
+}
+
+// InviteAppUser godoc
+// @Summary Add the user and send the mail to set the password
+// @Produce json
+// @Param param body entity.AppUserInviteParameter true "parameter to invite the user"
+// @Success 200 {object} entity.AppUserInviteResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 409
+// @Router /v1/admin/user [post]
+func (h *organizationAdminHandler) InviteAppUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.AppUserInviteParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		appUserID, err := h.organizationAdminUsecase.InviteAppUser(ctx, organizationID, operatorID, &usecase.AppUserInviteParameter{
+			LoginID:  param.LoginID,
+			Username: param.Username,
+		})
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, entity.AppUserInviteResponse{ID: uint(appUserID)})
+		return nil
+	}, h.errorHandle)
+}
+
+// DisableAppUser godoc
+// @Summary Disable the user and revoke the refresh tokens of the user
+// @Param appUserID path int true "App user ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/user/{appUserID}/disable [post]
+func (h *organizationAdminHandler) DisableAppUser(c *gin.Context) {
+	h.updateAppUser(c, h.organizationAdminUsecase.DisableAppUser)
+}
+
+// EnableAppUser godoc
+// @Summary Enable the disabled user
+// @Param appUserID path int true "App user ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/user/{appUserID}/enable [post]
+func (h *organizationAdminHandler) EnableAppUser(c *gin.Context) {
+	h.updateAppUser(c, h.organizationAdminUsecase.EnableAppUser)
+}
+
+// RemoveAppUser godoc
+// @Summary Remove the user and revoke the refresh tokens of the user
+// @Param appUserID path int true "App user ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/admin/user/{appUserID} [delete]
+func (h *organizationAdminHandler) RemoveAppUser(c *gin.Context) {
+	h.updateAppUser(c, h.organizationAdminUsecase.RemoveAppUser)
+}
+
+func (h *organizationAdminHandler) updateAppUser(c *gin.Context, fn func(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		appUserID, err := ginhelper.GetUintFromPath(c, "appUserID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := fn(ctx, organizationID, operatorID, domain.AppUserID(appUserID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *organizationAdminHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("organizationAdminHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return true
+	} else if errors.Is(err, service.ErrPermissionDenied) {
+		logger.Warnf("organizationAdminHandler err: %v", err)
+		c.JSON(http.StatusForbidden, gin.H{"message": http.StatusText(http.StatusForbidden)})
+		return true
+	} else if errors.Is(err, service.ErrAppUserNotFound) {
+		logger.Warnf("organizationAdminHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "User not found"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserAlreadyExists) {
+		logger.Warnf("organizationAdminHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "User already exists"})
+		return true
+	} else if errors.Is(err, service.ErrOrganizationAlreadyExists) {
+		logger.Warnf("organizationAdminHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Organization already exists"})
+		return true
+	}
+	logger.Errorf("organizationAdminHandler err: %+v", err)
+	return false
+}
+
+func toAppUserResponse(appUser *service.AppUserInfo) *entity.AppUserResponse {
+	return &entity.AppUserResponse{
+		ID:        uint(appUser.ID),
+		CreatedAt: appUser.CreatedAt,
+		UpdatedAt: appUser.UpdatedAt,
+		LoginID:   appUser.LoginID,
+		Username:  appUser.Username,
+		Role:      appUser.Role,
+		Provider:  appUser.Provider,
+		Disabled:  appUser.Disabled,
+	}
+}
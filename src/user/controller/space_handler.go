@@ -0,0 +1,443 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/lib/ginhelper"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/user/controller/entity"
+	controllerhelper "github.com/kujilabo/cocotola-api/src/user/controller/helper"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+	"github.com/kujilabo/cocotola-api/src/user/usecase"
+)
+
+type SpaceHandler interface {
+	FindSharedSpaces(c *gin.Context)
+
+	FindSharedSpaceByID(c *gin.Context)
+
+	AddSharedSpace(c *gin.Context)
+
+	FindSpaceMembers(c *gin.Context)
+
+	AddSpaceMember(c *gin.Context)
+
+	UpdateSpaceMember(c *gin.Context)
+
+	RemoveSpaceMember(c *gin.Context)
+
+	SetSpaceGroupRole(c *gin.Context)
+
+	RemoveSpaceGroup(c *gin.Context)
+}
+
+type spaceHandler struct {
+	spaceUsecase usecase.SpaceUsecase
+}
+
+func NewSpaceHandler(spaceUsecase usecase.SpaceUsecase) SpaceHandler {
+	return &spaceHandler{
+		spaceUsecase: spaceUsecase,
+	}
+}
+
+// FindSharedSpaces godoc
+// @Summary Find the shared spaces which the user is a member of
+// @Produce json
+// @Success 200 {object} entity.SpaceFindResponse
+// @Failure 401
+// @Router /v1/space [get]
+func (h *spaceHandler) FindSharedSpaces(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		spaces, err := h.spaceUsecase.FindSharedSpaces(ctx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		results := make([]*entity.SpaceResponse, len(spaces))
+		for i, space := range spaces {
+			results[i] = toSpaceResponse(space)
+		}
+
+		c.JSON(http.StatusOK, entity.SpaceFindResponse{Results: results})
+		return nil
+	}, h.errorHandle)
+}
+
+// FindSharedSpaceByID godoc
+// @Summary Find the shared space
+// @Produce json
+// @Param spaceID path int true "Space ID"
+// @Success 200 {object} entity.SpaceResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/space/{spaceID} [get]
+func (h *spaceHandler) FindSharedSpaceByID(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		space, err := h.spaceUsecase.FindSharedSpaceByID(ctx, organizationID, operatorID, domain.SpaceID(spaceID))
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, toSpaceResponse(space))
+		return nil
+	}, h.errorHandle)
+}
+
+// AddSharedSpace godoc
+// @Summary Add the shared space. The user becomes the admin of it
+// @Produce json
+// @Param param body entity.SharedSpaceAddParameter true "parameter to add the shared space"
+// @Success 200 {object} controllerhelper.IDResponse
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Router /v1/space [post]
+func (h *spaceHandler) AddSharedSpace(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.SharedSpaceAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		parameter, err := service.NewSharedSpaceAddParameter(param.Key, param.Name, param.Description)
+		if err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		spaceID, err := h.spaceUsecase.AddSharedSpace(ctx, organizationID, operatorID, parameter)
+		if err != nil {
+			return err
+		}
+
+		c.JSON(http.StatusOK, controllerhelper.IDResponse{ID: uint(spaceID)})
+		return nil
+	}, h.errorHandle)
+}
+
+// FindSpaceMembers godoc
+// @Summary Find the members of the shared space
+// @Produce json
+// @Param spaceID path int true "Space ID"
+// @Success 200 {object} entity.SpaceMemberFindResponse
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/space/{spaceID}/member [get]
+func (h *spaceHandler) FindSpaceMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		members, err := h.spaceUsecase.FindSpaceMembers(ctx, organizationID, operatorID, domain.SpaceID(spaceID))
+		if err != nil {
+			return err
+		}
+
+		results := make([]*entity.SpaceMemberResponse, len(members))
+		for i, member := range members {
+			results[i] = &entity.SpaceMemberResponse{
+				AppUserID: uint(member.AppUserID),
+				LoginID:   member.LoginID,
+				Username:  member.Username,
+				Role:      string(member.Role),
+			}
+		}
+
+		c.JSON(http.StatusOK, entity.SpaceMemberFindResponse{Results: results})
+		return nil
+	}, h.errorHandle)
+}
+
+// AddSpaceMember godoc
+// @Summary Add the user to the shared space with the role. Only the admins can add the members
+// @Param spaceID path int true "Space ID"
+// @Param param body entity.SpaceMemberAddParameter true "parameter to add the member"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Router /v1/space/{spaceID}/member [post]
+func (h *spaceHandler) AddSpaceMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.SpaceMemberAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		role, err := domain.NewSpaceRole(param.Role)
+		if err != nil {
+			return err
+		}
+
+		if err := h.spaceUsecase.AddSpaceMember(ctx, organizationID, operatorID, domain.SpaceID(spaceID), domain.AppUserID(param.AppUserID), role); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// UpdateSpaceMember godoc
+// @Summary Change the role of the member. The admin can't change the own role
+// @Param spaceID path int true "Space ID"
+// @Param appUserID path int true "App user ID"
+// @Param param body entity.SpaceMemberUpdateParameter true "parameter to change the role"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/space/{spaceID}/member/{appUserID} [put]
+func (h *spaceHandler) UpdateSpaceMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.SpaceMemberUpdateParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUserID, err := ginhelper.GetUintFromPath(c, "appUserID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		role, err := domain.NewSpaceRole(param.Role)
+		if err != nil {
+			return err
+		}
+
+		if err := h.spaceUsecase.UpdateSpaceMember(ctx, organizationID, operatorID, domain.SpaceID(spaceID), domain.AppUserID(appUserID), role); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// RemoveSpaceMember godoc
+// @Summary Remove the member from the shared space. The admin can't remove oneself
+// @Param spaceID path int true "Space ID"
+// @Param appUserID path int true "App user ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/space/{spaceID}/member/{appUserID} [delete]
+func (h *spaceHandler) RemoveSpaceMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUserID, err := ginhelper.GetUintFromPath(c, "appUserID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.spaceUsecase.RemoveSpaceMember(ctx, organizationID, operatorID, domain.SpaceID(spaceID), domain.AppUserID(appUserID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// SetSpaceGroupRole godoc
+// @Summary Share the shared space with all the users of the group. The group can be the reader or the writer
+// @Param spaceID path int true "Space ID"
+// @Param appUserGroupID path int true "App user group ID"
+// @Param param body entity.SpaceGroupUpdateParameter true "parameter to set the role of the group"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/space/{spaceID}/group/{appUserGroupID} [put]
+func (h *spaceHandler) SetSpaceGroupRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		param := entity.SpaceGroupUpdateParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			logger.Warnf("invalid parameter. err: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+			return nil
+		}
+
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		role, err := domain.NewSpaceRole(param.Role)
+		if err != nil {
+			return err
+		}
+
+		if err := h.spaceUsecase.SetSpaceGroupRole(ctx, organizationID, operatorID, domain.SpaceID(spaceID), domain.AppUserGroupID(appUserGroupID), role); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+// RemoveSpaceGroup godoc
+// @Summary Stop sharing the shared space with the group
+// @Param spaceID path int true "Space ID"
+// @Param appUserGroupID path int true "App user group ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Router /v1/space/{spaceID}/group/{appUserGroupID} [delete]
+func (h *spaceHandler) RemoveSpaceGroup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	controllerhelper.HandleSecuredFunction(c, func(organizationID domain.OrganizationID, operatorID domain.AppUserID) error {
+		spaceID, err := ginhelper.GetUintFromPath(c, "spaceID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		appUserGroupID, err := ginhelper.GetUintFromPath(c, "appUserGroupID")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return nil
+		}
+
+		if err := h.spaceUsecase.RemoveSpaceGroup(ctx, organizationID, operatorID, domain.SpaceID(spaceID), domain.AppUserGroupID(appUserGroupID)); err != nil {
+			return err
+		}
+
+		c.Status(http.StatusNoContent)
+		return nil
+	}, h.errorHandle)
+}
+
+func (h *spaceHandler) errorHandle(c *gin.Context, err error) bool {
+	ctx := c.Request.Context()
+	logger := log.FromContext(ctx)
+	if errors.Is(err, libD.ErrInvalidArgument) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"message": http.StatusText(http.StatusBadRequest)})
+		return true
+	} else if errors.Is(err, service.ErrPermissionDenied) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusForbidden, gin.H{"message": http.StatusText(http.StatusForbidden)})
+		return true
+	} else if errors.Is(err, service.ErrSpaceNotFound) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Space not found"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserNotFound) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "User not found"})
+		return true
+	} else if errors.Is(err, service.ErrAppUserGroupNotFound) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "Group not found"})
+		return true
+	} else if errors.Is(err, service.ErrSpaceMemberNotFound) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"message": "The user isn't a member of the space"})
+		return true
+	} else if errors.Is(err, service.ErrSpaceAlreadyExists) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "Space already exists"})
+		return true
+	} else if errors.Is(err, service.ErrSpaceMemberAlreadyExists) {
+		logger.Warnf("spaceHandler err: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"message": "The user is already a member of the space"})
+		return true
+	}
+	logger.Errorf("spaceHandler err: %+v", err)
+	return false
+}
+
+func toSpaceResponse(space service.Space) *entity.SpaceResponse {
+	return &entity.SpaceResponse{
+		ID:          space.GetID(),
+		Version:     space.GetVersion(),
+		CreatedAt:   space.GetCreatedAt(),
+		UpdatedAt:   space.GetUpdatedAt(),
+		Key:         space.GetKey(),
+		Name:        space.GetName(),
+		Description: space.GetDescription(),
+	}
+}
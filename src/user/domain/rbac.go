@@ -11,6 +11,14 @@ func NewSpaceWriterRole(spaceID SpaceID) RBACRole {
 	return RBACRole(fmt.Sprintf("space_%d_writer", uint(spaceID)))
 }
 
+func NewSpaceReaderRole(spaceID SpaceID) RBACRole {
+	return RBACRole(fmt.Sprintf("space_%d_reader", uint(spaceID)))
+}
+
+func NewSpaceAdminRole(spaceID SpaceID) RBACRole {
+	return RBACRole(fmt.Sprintf("space_%d_admin", uint(spaceID)))
+}
+
 func NewSpaceObject(spaceID SpaceID) RBACObject {
 	return RBACObject(fmt.Sprintf("space_%d", uint(spaceID)))
 }
@@ -18,3 +26,13 @@ func NewSpaceObject(spaceID SpaceID) RBACObject {
 func NewUserObject(appUserID AppUserID) RBACUser {
 	return RBACUser(fmt.Sprintf("user_%d", uint(appUserID)))
 }
+
+// NewAppUserGroupRole returns the role which the members of the group have
+func NewAppUserGroupRole(appUserGroupID AppUserGroupID) RBACRole {
+	return RBACRole(fmt.Sprintf("group_%d", uint(appUserGroupID)))
+}
+
+// NewAppUserGroupSubject returns the group as the subject of the grouping policies. e.g. the group is given the role of the workbook
+func NewAppUserGroupSubject(appUserGroupID AppUserGroupID) RBACUser {
+	return RBACUser(NewAppUserGroupRole(appUserGroupID))
+}
@@ -1,11 +1,60 @@
 //go:generate mockery --output mock --name SpaceModel
 package domain
 
-import libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+import (
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+)
 
 type SpaceID uint
 type SpaceTypeID int
 
+// SpaceRole is the role of the member of the shared space
+type SpaceRole string
+
+const (
+	SpaceRoleReader SpaceRole = "reader"
+	SpaceRoleWriter SpaceRole = "writer"
+	SpaceRoleAdmin  SpaceRole = "admin"
+)
+
+var SpacePrivilegeRead = RBACAction("read")
+var SpacePrivilegeWrite = RBACAction("write")
+var SpacePrivilegeAdmin = RBACAction("admin")
+
+func NewSpaceRole(value string) (SpaceRole, error) {
+	switch SpaceRole(value) {
+	case SpaceRoleReader, SpaceRoleWriter, SpaceRoleAdmin:
+		return SpaceRole(value), nil
+	default:
+		return "", liberrors.Errorf("invalid space role. role: %s, err: %w", value, libD.ErrInvalidArgument)
+	}
+}
+
+// ToRBACRole returns the casbin role of the space
+func (r SpaceRole) ToRBACRole(spaceID SpaceID) RBACRole {
+	switch r {
+	case SpaceRoleAdmin:
+		return NewSpaceAdminRole(spaceID)
+	case SpaceRoleWriter:
+		return NewSpaceWriterRole(spaceID)
+	default:
+		return NewSpaceReaderRole(spaceID)
+	}
+}
+
+// GetPrivileges returns the privileges on the space. The higher role has the privileges of the lower roles
+func (r SpaceRole) GetPrivileges() []RBACAction {
+	switch r {
+	case SpaceRoleAdmin:
+		return []RBACAction{SpacePrivilegeRead, SpacePrivilegeWrite, SpacePrivilegeAdmin}
+	case SpaceRoleWriter:
+		return []RBACAction{SpacePrivilegeRead, SpacePrivilegeWrite}
+	default:
+		return []RBACAction{SpacePrivilegeRead}
+	}
+}
+
 type SpaceModel interface {
 	Model
 	GetOrganizationID() OrganizationID
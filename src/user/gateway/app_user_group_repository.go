@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -63,7 +64,7 @@ func (r *appUserGroupRepository) FindPublicGroup(ctx context.Context, operator d
 	appUserGroup := appUserGroupEntity{}
 	if result := r.db.Where(&appUserGroupEntity{
 		OrganizationID: uint(operator.GetOrganizationID()),
-		Key:            "public",
+		Key:            service.PublicGroupKey,
 	}).Find(&appUserGroup); result.Error != nil {
 		return nil, result.Error
 	}
@@ -79,7 +80,7 @@ func (r *appUserGroupRepository) AddPublicGroup(ctx context.Context, operator do
 		CreatedBy:      operator.GetID(),
 		UpdatedBy:      operator.GetID(),
 		OrganizationID: uint(operator.GetOrganizationID()),
-		Key:            "public",
+		Key:            service.PublicGroupKey,
 		Name:           "Public group",
 	}
 	if result := r.db.Create(&appUserGroup); result.Error != nil {
@@ -88,6 +89,122 @@ func (r *appUserGroupRepository) AddPublicGroup(ctx context.Context, operator do
 	return domain.AppUserGroupID(appUserGroup.ID), nil
 }
 
+func (r *appUserGroupRepository) FindAppUserGroups(ctx context.Context, operator domain.OwnerModel, pageNo, pageSize int) ([]service.AppUserGroup, int, error) {
+	_, span := tracer.Start(ctx, "appUserGroupRepository.FindAppUserGroups")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&appUserGroupEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Count(&count); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	entities := []appUserGroupEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Order("id").
+		Limit(pageSize).Offset((pageNo - 1) * pageSize).
+		Find(&entities); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	appUserGroups := make([]service.AppUserGroup, len(entities))
+	for i, entity := range entities {
+		appUserGroup, err := entity.toAppUserGroup()
+		if err != nil {
+			return nil, 0, err
+		}
+		appUserGroups[i] = appUserGroup
+	}
+
+	return appUserGroups, int(count), nil
+}
+
+func (r *appUserGroupRepository) FindAppUserGroupByID(ctx context.Context, operator domain.AppUserModel, id domain.AppUserGroupID) (service.AppUserGroup, error) {
+	_, span := tracer.Start(ctx, "appUserGroupRepository.FindAppUserGroupByID")
+	defer span.End()
+
+	appUserGroup := appUserGroupEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ?", uint(id)).
+		First(&appUserGroup); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrAppUserGroupNotFound
+		}
+		return nil, result.Error
+	}
+	return appUserGroup.toAppUserGroup()
+}
+
+func (r *appUserGroupRepository) AddAppUserGroup(ctx context.Context, operator domain.OwnerModel, param service.AppUserGroupAddParameter) (domain.AppUserGroupID, error) {
+	_, span := tracer.Start(ctx, "appUserGroupRepository.AddAppUserGroup")
+	defer span.End()
+
+	appUserGroup := appUserGroupEntity{
+		Version:        1,
+		CreatedBy:      operator.GetID(),
+		UpdatedBy:      operator.GetID(),
+		OrganizationID: uint(operator.GetOrganizationID()),
+		Key:            param.GetKey(),
+		Name:           param.GetName(),
+		Description:    param.GetDescription(),
+	}
+	if result := r.db.Create(&appUserGroup); result.Error != nil {
+		return 0, libG.ConvertDuplicatedError(result.Error, service.ErrAppUserGroupAlreadyExists)
+	}
+	return domain.AppUserGroupID(appUserGroup.ID), nil
+}
+
+func (r *appUserGroupRepository) UpdateAppUserGroup(ctx context.Context, operator domain.OwnerModel, id domain.AppUserGroupID, version int, param service.AppUserGroupUpdateParameter) error {
+	_, span := tracer.Start(ctx, "appUserGroupRepository.UpdateAppUserGroup")
+	defer span.End()
+
+	result := r.db.Model(&appUserGroupEntity{}).
+		Where("organization_id = ? and id = ? and version = ?",
+			uint(operator.GetOrganizationID()), uint(id), version).
+		Updates(map[string]interface{}{
+			"version":     gorm.Expr("version + 1"),
+			"updated_by":  operator.GetID(),
+			"name":        param.GetName(),
+			"description": param.GetDescription(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrAppUserGroupNotFound
+	}
+
+	return nil
+}
+
+func (r *appUserGroupRepository) RemoveAppUserGroup(ctx context.Context, operator domain.OwnerModel, id domain.AppUserGroupID) error {
+	_, span := tracer.Start(ctx, "appUserGroupRepository.RemoveAppUserGroup")
+	defer span.End()
+
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("app_user_group_id = ?", uint(id)).
+		Delete(&groupUserEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ?", uint(id)).
+		Delete(&appUserGroupEntity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrAppUserGroupNotFound
+	}
+
+	return nil
+}
+
 func (r *appUserGroupRepository) AddPersonalGroup(ctx context.Context, operator domain.AppUserModel) (uint, error) {
 	_, span := tracer.Start(ctx, "appUserGroupRepository.AddPersonalGroup")
 	defer span.End()
@@ -0,0 +1,92 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/gateway"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+func Test_appUserGroupRepository(t *testing.T) {
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return gateway.NewRepositoryFactory(db)
+	}
+
+	service.InitSystemAdmin(userRfFunc)
+	for _, db := range dbList() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		defer sqlDB.Close()
+
+		_, firstOwner := testInitOrganization(t, db)
+		require.NoError(t, gateway.NewRBACRepository(db).Init())
+		rf, err := gateway.NewRepositoryFactory(db)
+		require.NoError(t, err)
+		owner := service.NewOwner(rf, firstOwner)
+
+		appUserID, err := rf.NewAppUserRepository().AddAppUser(bg, owner, testNewAppUserAddParameter(t, "LOGIN_ID", "USERNAME"))
+		require.NoError(t, err)
+
+		addParam, err := service.NewAppUserGroupAddParameter("class_a", "Class A", "")
+		require.NoError(t, err)
+		appUserGroupID, err := owner.AddAppUserGroup(bg, addParam)
+		require.NoError(t, err)
+		_, err = owner.AddAppUserGroup(bg, addParam)
+		assert.True(t, errors.Is(err, service.ErrAppUserGroupAlreadyExists))
+
+		updateParam, err := service.NewAppUserGroupUpdateParameter("Class B", "DESCRIPTION")
+		require.NoError(t, err)
+		require.NoError(t, owner.UpdateAppUserGroup(bg, appUserGroupID, 1, updateParam))
+		err = owner.UpdateAppUserGroup(bg, appUserGroupID, 1, updateParam)
+		assert.True(t, errors.Is(err, service.ErrAppUserGroupNotFound))
+		appUserGroup, err := owner.FindAppUserGroupByID(bg, appUserGroupID)
+		require.NoError(t, err)
+		assert.Equal(t, "Class B", appUserGroup.GetName())
+		assert.Equal(t, 2, appUserGroup.GetVersion())
+
+		require.NoError(t, owner.AddGroupUser(bg, appUserGroupID, appUserID))
+		err = owner.AddGroupUser(bg, appUserGroupID, appUserID)
+		assert.True(t, errors.Is(err, service.ErrGroupUserAlreadyExists))
+		appUsers, totalCount, err := owner.FindGroupUsers(bg, appUserGroupID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, totalCount)
+		require.Len(t, appUsers, 1)
+		assert.Equal(t, appUserID, appUsers[0].ID)
+
+		// the member is given the policy of the group
+		rbacRepo := rf.NewRBACRepository()
+		groupReader := domain.RBACRole("group_test_reader")
+		groupObject := domain.RBACObject("group_test")
+		require.NoError(t, rbacRepo.AddNamedPolicy(groupReader, groupObject, "read"))
+		require.NoError(t, rbacRepo.AddNamedGroupingPolicy(domain.NewAppUserGroupSubject(appUserGroupID), groupReader))
+		canRead := func() bool {
+			userObject := domain.NewUserObject(appUserID)
+			e, err := rbacRepo.NewEnforcerWithRolesAndUsers([]domain.RBACRole{groupReader}, []domain.RBACUser{userObject})
+			require.NoError(t, err)
+			ok, err := e.Enforce(string(userObject), string(groupObject), "read")
+			require.NoError(t, err)
+			return ok
+		}
+		assert.True(t, canRead())
+
+		require.NoError(t, owner.RemoveGroupUser(bg, appUserGroupID, appUserID))
+		assert.False(t, canRead())
+		err = owner.RemoveGroupUser(bg, appUserGroupID, appUserID)
+		assert.True(t, errors.Is(err, service.ErrGroupUserNotFound))
+
+		require.NoError(t, owner.AddGroupUser(bg, appUserGroupID, appUserID))
+		require.NoError(t, owner.RemoveAppUserGroup(bg, appUserGroupID))
+		assert.False(t, canRead())
+		_, err = owner.FindAppUserGroupByID(bg, appUserGroupID)
+		assert.True(t, errors.Is(err, service.ErrAppUserGroupNotFound))
+	}
+}
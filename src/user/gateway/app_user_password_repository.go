@@ -0,0 +1,187 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/lib/passwordhelper"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type appUserPasswordRepository struct {
+	db *gorm.DB
+}
+
+type appUserCredentialEntity struct {
+	ID               uint
+	HashedPassword   string
+	Provider         string
+	FailedLoginCount int
+	LockedUntil      *time.Time
+}
+
+type appUserPasswordResetTokenEntity struct {
+	ID             uint
+	CreatedAt      time.Time
+	OrganizationID uint
+	AppUserID      uint
+	HashedToken    string
+	ExpiresAt      time.Time
+	Used           bool
+}
+
+func (e *appUserPasswordResetTokenEntity) TableName() string {
+	return "app_user_password_reset_token"
+}
+
+func NewAppUserPasswordRepository(db *gorm.DB) service.AppUserPasswordRepository {
+	return &appUserPasswordRepository{
+		db: db,
+	}
+}
+
+func (r *appUserPasswordRepository) FindPasswordCredential(ctx context.Context, operator domain.SystemOwnerModel, loginID string) (*service.PasswordCredential, error) {
+	_, span := tracer.Start(ctx, "appUserPasswordRepository.FindPasswordCredential")
+	defer span.End()
+
+	entity := appUserCredentialEntity{}
+	if result := r.db.Table(AppUserTableName).
+		Select("id, hashed_password, provider, failed_login_count, locked_until").
+		Where("organization_id = ? and removed = 0", uint(operator.GetOrganizationID())).
+		Where("login_id = ?", loginID).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrAppUserNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &service.PasswordCredential{
+		AppUserID:        domain.AppUserID(entity.ID),
+		HashedPassword:   entity.HashedPassword,
+		Provider:         entity.Provider,
+		FailedLoginCount: entity.FailedLoginCount,
+		LockedUntil:      entity.LockedUntil,
+	}, nil
+}
+
+func (r *appUserPasswordRepository) RecordLoginFailure(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, maxFailures int, lockDuration time.Duration) error {
+	_, span := tracer.Start(ctx, "appUserPasswordRepository.RecordLoginFailure")
+	defer span.End()
+
+	if result := r.db.Table(AppUserTableName).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ?", uint(appUserID)).
+		Update("failed_login_count", gorm.Expr("failed_login_count + 1")); result.Error != nil {
+		return result.Error
+	}
+
+	// the count is reset when the user is locked so that the user can try again after the lock expires
+	if result := r.db.Table(AppUserTableName).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ?", uint(appUserID)).
+		Where("failed_login_count >= ?", maxFailures).
+		Updates(map[string]interface{}{
+			"failed_login_count": 0,
+			"locked_until":       time.Now().Add(lockDuration),
+		}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *appUserPasswordRepository) ResetLoginFailure(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID) error {
+	_, span := tracer.Start(ctx, "appUserPasswordRepository.ResetLoginFailure")
+	defer span.End()
+
+	if result := r.db.Table(AppUserTableName).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ?", uint(appUserID)).
+		Updates(map[string]interface{}{
+			"failed_login_count": 0,
+			"locked_until":       nil,
+		}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *appUserPasswordRepository) UpdatePassword(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, password string) error {
+	_, span := tracer.Start(ctx, "appUserPasswordRepository.UpdatePassword")
+	defer span.End()
+
+	hashedPassword, err := passwordhelper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	result := r.db.Table(AppUserTableName).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ? and removed = 0", uint(appUserID)).
+		Updates(map[string]interface{}{
+			"hashed_password": hashedPassword,
+			"version":         gorm.Expr("version + 1"),
+			"updated_by":      operator.GetID(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrAppUserNotFound
+	}
+
+	return nil
+}
+
+func (r *appUserPasswordRepository) AddPasswordResetToken(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, hashedToken string, expiresAt time.Time) error {
+	_, span := tracer.Start(ctx, "appUserPasswordRepository.AddPasswordResetToken")
+	defer span.End()
+
+	entity := appUserPasswordResetTokenEntity{
+		OrganizationID: uint(operator.GetOrganizationID()),
+		AppUserID:      uint(appUserID),
+		HashedToken:    hashedToken,
+		ExpiresAt:      expiresAt,
+	}
+	if result := r.db.Create(&entity); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *appUserPasswordRepository) UsePasswordResetToken(ctx context.Context, operator domain.SystemOwnerModel, hashedToken string) (domain.AppUserID, error) {
+	_, span := tracer.Start(ctx, "appUserPasswordRepository.UsePasswordResetToken")
+	defer span.End()
+
+	entity := appUserPasswordResetTokenEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("hashed_token = ? and used = 0", hashedToken).
+		Where("expires_at > ?", time.Now()).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return 0, service.ErrPasswordResetTokenNotFound
+		}
+		return 0, result.Error
+	}
+
+	// the condition on `used` prevents the token from being used twice by the concurrent requests
+	result := r.db.Model(&appUserPasswordResetTokenEntity{}).
+		Where("id = ? and used = 0", entity.ID).
+		Update("used", true)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, service.ErrPasswordResetTokenNotFound
+	}
+
+	return domain.AppUserID(entity.AppUserID), nil
+}
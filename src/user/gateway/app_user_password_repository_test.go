@@ -0,0 +1,88 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/lib/passwordhelper"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/gateway"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+func Test_appUserPasswordRepository(t *testing.T) {
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return gateway.NewRepositoryFactory(db)
+	}
+
+	service.InitSystemAdmin(userRfFunc)
+	for _, db := range dbList() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		defer sqlDB.Close()
+
+		db.Exec("delete from app_user_password_reset_token")
+		_, owner := testInitOrganization(t, db)
+		sysAd, err := service.NewSystemAdminFromDB(bg, db)
+		require.NoError(t, err)
+		sysOwner, err := gateway.NewAppUserRepository(nil, db).FindSystemOwnerByOrganizationName(bg, sysAd, "ORG_NAME")
+		require.NoError(t, err)
+		ownerID := domain.AppUserID(owner.GetID())
+		repo := gateway.NewAppUserPasswordRepository(db)
+
+		// find the credential
+		_, err = repo.FindPasswordCredential(bg, sysOwner, "NOT_FOUND")
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+		credential, err := repo.FindPasswordCredential(bg, sysOwner, "OWNER_ID")
+		require.NoError(t, err)
+		assert.Equal(t, ownerID, credential.AppUserID)
+		assert.False(t, credential.IsLocked(time.Now()))
+
+		// update the password
+		require.NoError(t, repo.UpdatePassword(bg, sysOwner, ownerID, "NEW_PASSWORD"))
+		credential, err = repo.FindPasswordCredential(bg, sysOwner, "OWNER_ID")
+		require.NoError(t, err)
+		assert.True(t, passwordhelper.ComparePasswords(credential.HashedPassword, "NEW_PASSWORD"))
+
+		// the user is locked after 3 failures
+		for i := 0; i < 2; i++ {
+			require.NoError(t, repo.RecordLoginFailure(bg, sysOwner, ownerID, 3, time.Hour))
+		}
+		credential, err = repo.FindPasswordCredential(bg, sysOwner, "OWNER_ID")
+		require.NoError(t, err)
+		assert.Equal(t, 2, credential.FailedLoginCount)
+		assert.False(t, credential.IsLocked(time.Now()))
+
+		require.NoError(t, repo.RecordLoginFailure(bg, sysOwner, ownerID, 3, time.Hour))
+		credential, err = repo.FindPasswordCredential(bg, sysOwner, "OWNER_ID")
+		require.NoError(t, err)
+		assert.Equal(t, 0, credential.FailedLoginCount)
+		assert.True(t, credential.IsLocked(time.Now()))
+
+		require.NoError(t, repo.ResetLoginFailure(bg, sysOwner, ownerID))
+		credential, err = repo.FindPasswordCredential(bg, sysOwner, "OWNER_ID")
+		require.NoError(t, err)
+		assert.False(t, credential.IsLocked(time.Now()))
+
+		// the reset token can be used only once
+		require.NoError(t, repo.AddPasswordResetToken(bg, sysOwner, ownerID, "TOKEN", time.Now().Add(time.Hour)))
+		appUserID, err := repo.UsePasswordResetToken(bg, sysOwner, "TOKEN")
+		require.NoError(t, err)
+		assert.Equal(t, ownerID, appUserID)
+		_, err = repo.UsePasswordResetToken(bg, sysOwner, "TOKEN")
+		assert.True(t, errors.Is(err, service.ErrPasswordResetTokenNotFound))
+
+		// the expired token can't be used
+		require.NoError(t, repo.AddPasswordResetToken(bg, sysOwner, ownerID, "EXPIRED_TOKEN", time.Now().Add(-time.Minute)))
+		_, err = repo.UsePasswordResetToken(bg, sysOwner, "EXPIRED_TOKEN")
+		assert.True(t, errors.Is(err, service.ErrPasswordResetTokenNotFound))
+	}
+}
@@ -3,6 +3,8 @@ package gateway
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"gorm.io/gorm"
@@ -20,6 +22,8 @@ var (
 	SystemOwnerLoginID   = "system-owner"
 	SystemStudentLoginID = "system-student"
 	GuestLoginID         = "guest"
+	// RemovedLoginIDPrefix is the prefix of the login IDs of the removed users. The login IDs which the users choose can't contain '#'
+	RemovedLoginIDPrefix = "#removed-"
 
 	AdministratorRole = "Administrator"
 	OwnerRole         = "Owner"
@@ -51,12 +55,37 @@ type appUserEntity struct {
 	ProviderAccessToken  string
 	ProviderRefreshToken string
 	Removed              bool
+	Disabled             bool
 }
 
 func (e *appUserEntity) TableName() string {
 	return AppUserTableName
 }
 
+// toProperties returns the identity provider of the user. The users who sign in with the password have no properties
+func (e *appUserEntity) toProperties() map[string]string {
+	if e.Provider == "" {
+		return map[string]string{}
+	}
+	return map[string]string{
+		"provider":   e.Provider,
+		"providerId": e.ProviderID,
+	}
+}
+
+func (e *appUserEntity) toAppUserInfo() *service.AppUserInfo {
+	return &service.AppUserInfo{
+		ID:        domain.AppUserID(e.ID),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		LoginID:   e.LoginID,
+		Username:  e.Username,
+		Role:      e.Role,
+		Provider:  e.Provider,
+		Disabled:  e.Disabled,
+	}
+}
+
 // func toRole(role string) domain.Role {
 // 	if role == "administrator" {
 // 		return domain.AdministratorRole
@@ -200,16 +229,19 @@ func (r *appUserRepository) FindAppUserByID(ctx context.Context, operator domain
 	if result := r.db.Where(&appUserEntity{
 		OrganizationID: uint(operator.GetOrganizationID()),
 		ID:             uint(id),
-	}).First(&appUser); result.Error != nil {
+	}).Where("removed = 0").First(&appUser); result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, service.ErrAppUserNotFound
 		}
 
 		return nil, result.Error
 	}
+	if appUser.Disabled {
+		return nil, service.ErrAppUserDisabled
+	}
 
 	roles := []string{appUser.Role}
-	properties := map[string]string{}
+	properties := appUser.toProperties()
 
 	return appUser.toAppUser(r.rf, roles, properties)
 }
@@ -225,16 +257,19 @@ func (r *appUserRepository) FindAppUserByLoginID(ctx context.Context, operator d
 	if result := r.db.Where(&appUserEntity{
 		OrganizationID: uint(operator.GetOrganizationID()),
 		LoginID:        loginID,
-	}).First(&appUser); result.Error != nil {
+	}).Where("removed = 0").First(&appUser); result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, service.ErrAppUserNotFound
 		}
 
 		return nil, result.Error
 	}
+	if appUser.Disabled {
+		return nil, service.ErrAppUserDisabled
+	}
 
 	roles := []string{appUser.Role}
-	properties := map[string]string{}
+	properties := appUser.toProperties()
 
 	return appUser.toAppUser(r.rf, roles, properties)
 }
@@ -256,7 +291,7 @@ func (r *appUserRepository) FindOwnerByLoginID(ctx context.Context, operator dom
 	}
 
 	roles := []string{appUser.Role}
-	properties := map[string]string{}
+	properties := appUser.toProperties()
 
 	return appUser.toOwner(r.rf, roles, properties)
 }
@@ -283,6 +318,13 @@ func (r *appUserRepository) AddAppUser(ctx context.Context, operator domain.Owne
 		hashedPassword = hashed
 	}
 
+	// the other roles are given by the owner after the user is added
+	role := UserRole
+	if roles := param.GetRoles(); len(roles) > 0 && roles[0] == GuestRole {
+		role = GuestRole
+	}
+
+	// the users of the external identity providers can't sign in with the password
 	appUserEntity := appUserEntity{
 		Version:        1,
 		CreatedBy:      operator.GetID(),
@@ -291,7 +333,9 @@ func (r *appUserRepository) AddAppUser(ctx context.Context, operator domain.Owne
 		LoginID:        param.GetLoginID(),
 		Username:       param.GetUsername(),
 		HashedPassword: hashedPassword,
-		Role:           UserRole,
+		Role:           role,
+		Provider:       param.GetProperties()["provider"],
+		ProviderID:     param.GetProperties()["providerId"],
 	}
 	return r.addAppUser(ctx, &appUserEntity)
 }
@@ -333,3 +377,144 @@ func (r *appUserRepository) AddFirstOwner(ctx context.Context, operator domain.S
 	}
 	return r.addAppUser(ctx, &appUserEntity)
 }
+
+func (r *appUserRepository) FindAppUserInfos(ctx context.Context, operator domain.OwnerModel, pageNo, pageSize int) ([]*service.AppUserInfo, int, error) {
+	_, span := tracer.Start(ctx, "appUserRepository.FindAppUserInfos")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&appUserEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("login_id <> ? and removed = 0", SystemOwnerLoginID).
+		Count(&count); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	entities := []appUserEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("login_id <> ? and removed = 0", SystemOwnerLoginID).
+		Order("id").
+		Limit(pageSize).Offset((pageNo - 1) * pageSize).
+		Find(&entities); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	appUsers := make([]*service.AppUserInfo, len(entities))
+	for i, entity := range entities {
+		appUsers[i] = entity.toAppUserInfo()
+	}
+
+	return appUsers, int(count), nil
+}
+
+func (r *appUserRepository) FindAppUserInfo(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID) (*service.AppUserInfo, error) {
+	_, span := tracer.Start(ctx, "appUserRepository.FindAppUserInfo")
+	defer span.End()
+
+	entity := appUserEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ? and login_id <> ? and removed = 0", uint(id), SystemOwnerLoginID).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrAppUserNotFound
+		}
+		return nil, result.Error
+	}
+
+	return entity.toAppUserInfo(), nil
+}
+
+// UpdateAppUserDisabled disables or enables the user. The removed user can't be enabled
+func (r *appUserRepository) UpdateAppUserDisabled(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID, disabled bool) error {
+	_, span := tracer.Start(ctx, "appUserRepository.UpdateAppUserDisabled")
+	defer span.End()
+
+	result := r.db.Model(&appUserEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ? and login_id <> ? and removed = 0", uint(id), SystemOwnerLoginID).
+		Updates(map[string]interface{}{
+			"version":    gorm.Expr("version + 1"),
+			"updated_by": operator.GetID(),
+			"disabled":   disabled,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrAppUserNotFound
+	}
+
+	return nil
+}
+
+// RemoveAppUser marks the user as removed instead of deleting the row, because the workbooks and the problems of the other users refer to the user as the creator or the updater.
+// The login ID and the credentials are cleared so that the login ID can be used again
+func (r *appUserRepository) RemoveAppUser(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID) error {
+	_, span := tracer.Start(ctx, "appUserRepository.RemoveAppUser")
+	defer span.End()
+
+	removed, err := removeAppUser(r.db, operator.GetOrganizationID(), operator.GetID(), id, func(db *gorm.DB) *gorm.DB {
+		return db.Where("login_id <> ?", SystemOwnerLoginID)
+	})
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return service.ErrAppUserNotFound
+	}
+
+	return nil
+}
+
+// removeAppUser removes the policies of the user and marks the user as removed if the user matches `scope`.
+// It returns false if the user isn't found
+func removeAppUser(db *gorm.DB, organizationID domain.OrganizationID, operatorID uint, id domain.AppUserID, scope func(db *gorm.DB) *gorm.DB) (bool, error) {
+	if err := removeAppUserPolicies(db, organizationID, id); err != nil {
+		return false, err
+	}
+
+	result := db.Model(&appUserEntity{}).
+		Scopes(scope).
+		Where("organization_id = ?", uint(organizationID)).
+		Where("id = ? and removed = 0", uint(id)).
+		Updates(map[string]interface{}{
+			"version":                gorm.Expr("version + 1"),
+			"updated_by":             operatorID,
+			"login_id":               fmt.Sprintf("%s%d", RemovedLoginIDPrefix, uint(id)),
+			"hashed_password":        "",
+			"provider_id":            "",
+			"provider_access_token":  "",
+			"provider_refresh_token": "",
+			"removed":                true,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected != 0, nil
+}
+
+// removeAppUserPolicies removes the policies of the user and the personal space of the user.
+// The policies of the workbooks are left because the IDs of the workbooks aren't reused
+func removeAppUserPolicies(db *gorm.DB, organizationID domain.OrganizationID, appUserID domain.AppUserID) error {
+	space := spaceEntity{}
+	if result := db.Where(&spaceEntity{
+		OrganizationID: uint(organizationID),
+		Type:           SpaceTypePersonal,
+		Key:            strconv.Itoa(int(appUserID)),
+	}).Find(&space); result.Error != nil {
+		return result.Error
+	}
+
+	subjects := []string{string(domain.NewUserObject(appUserID))}
+	if space.ID != 0 {
+		subjects = append(subjects, string(domain.NewSpaceWriterRole(domain.SpaceID(space.ID))))
+	}
+	if result := db.Table("casbin_rule").Where("v0 in ?", subjects).Delete(nil); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
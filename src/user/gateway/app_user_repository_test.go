@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
 	"github.com/kujilabo/cocotola-api/src/user/domain"
@@ -131,3 +132,105 @@ func Test_appUserRepository_AddAppUser(t *testing.T) {
 		}
 	}
 }
+
+func Test_appUserRepository_UpdateAppUserDisabled(t *testing.T) {
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return gateway.NewRepositoryFactory(db)
+	}
+
+	service.InitSystemAdmin(userRfFunc)
+	for i, db := range dbList() {
+		log.Printf("%d", i)
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		defer sqlDB.Close()
+
+		_, owner := testInitOrganization(t, db)
+		require.NoError(t, gateway.NewRBACRepository(db).Init())
+
+		appUserRepo := gateway.NewAppUserRepository(nil, db)
+		appUserID, err := appUserRepo.AddAppUser(bg, owner, testNewAppUserAddParameter(t, "LOGIN_ID", "USERNAME"))
+		require.NoError(t, err)
+
+		// the system owner isn't listed
+		appUsers, totalCount, err := appUserRepo.FindAppUserInfos(bg, owner, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, totalCount)
+		require.Len(t, appUsers, 2)
+		assert.Equal(t, "OWNER_ID", appUsers[0].LoginID)
+		assert.Equal(t, "LOGIN_ID", appUsers[1].LoginID)
+
+		// the disabled user can't be found by the ID
+		err = appUserRepo.UpdateAppUserDisabled(bg, owner, appUserID, true)
+		require.NoError(t, err)
+		_, err = appUserRepo.FindAppUserByID(bg, owner, appUserID)
+		assert.True(t, errors.Is(err, service.ErrAppUserDisabled))
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+		appUser, err := appUserRepo.FindAppUserInfo(bg, owner, appUserID)
+		require.NoError(t, err)
+		assert.True(t, appUser.Disabled)
+
+		err = appUserRepo.UpdateAppUserDisabled(bg, owner, appUserID, false)
+		require.NoError(t, err)
+		_, err = appUserRepo.FindAppUserByID(bg, owner, appUserID)
+		assert.NoError(t, err)
+
+		err = appUserRepo.RemoveAppUser(bg, owner, appUserID)
+		require.NoError(t, err)
+		_, err = appUserRepo.FindAppUserInfo(bg, owner, appUserID)
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+		err = appUserRepo.RemoveAppUser(bg, owner, appUserID)
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+
+		// the removed user can't be enabled
+		err = appUserRepo.UpdateAppUserDisabled(bg, owner, appUserID, false)
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+		_, err = appUserRepo.FindAppUserByID(bg, owner, appUserID)
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+	}
+}
+
+func Test_appUserRepository_RemoveAppUser(t *testing.T) {
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return gateway.NewRepositoryFactory(db)
+	}
+
+	service.InitSystemAdmin(userRfFunc)
+	for i, db := range dbList() {
+		log.Printf("%d", i)
+		sqlDB, err := db.DB()
+		assert.NoError(t, err)
+		defer sqlDB.Close()
+
+		_, owner := testInitOrganization(t, db)
+		require.NoError(t, gateway.NewRBACRepository(db).Init())
+
+		appUserRepo := gateway.NewAppUserRepository(nil, db)
+		writerID, err := appUserRepo.AddAppUser(bg, owner, testNewAppUserAddParameter(t, "WRITER_ID", "WRITER_NAME"))
+		require.NoError(t, err)
+
+		// the writer has edited the workbook of the owner
+		space, err := gateway.NewSpaceRepository(db).FindPersonalSpace(bg, owner)
+		require.NoError(t, err)
+		var problemTypeID int
+		require.NoError(t, db.Raw("select id from problem_type order by id limit 1").Scan(&problemTypeID).Error)
+		require.NoError(t, db.Exec("insert into workbook (created_by, updated_by, organization_id, owner_id, space_id, problem_type_id, name, lang2, properties) values (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			owner.GetID(), uint(writerID), uint(owner.GetOrganizationID()), owner.GetID(), space.GetID(), problemTypeID, "WORKBOOK", "ja", "{}").Error)
+
+		err = appUserRepo.RemoveAppUser(bg, owner, writerID)
+		require.NoError(t, err)
+
+		// the workbook of the owner is left
+		var count int64
+		require.NoError(t, db.Table("workbook").Where("owner_id = ?", owner.GetID()).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+
+		// the login ID of the removed user can be used again
+		_, err = appUserRepo.AddAppUser(bg, owner, testNewAppUserAddParameter(t, "WRITER_ID", "WRITER_NAME"))
+		assert.NoError(t, err)
+	}
+}
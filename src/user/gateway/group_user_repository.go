@@ -51,7 +51,57 @@ func (r *groupUserRepository) AddGroupUser(ctx context.Context, operator domain.
 		AppUserID:      uint(appUserID),
 	}
 	if result := r.db.Create(&groupUser); result.Error != nil {
-		return libG.ConvertDuplicatedError(result.Error, service.ErrAppUserAlreadyExists)
+		return libG.ConvertDuplicatedError(result.Error, service.ErrGroupUserAlreadyExists)
 	}
 	return nil
 }
+
+func (r *groupUserRepository) FindGroupUsers(ctx context.Context, operator domain.OwnerModel, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*service.AppUserInfo, int, error) {
+	_, span := tracer.Start(ctx, "groupUserRepository.FindGroupUsers")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&appUserEntity{}).
+		Joins("inner join "+GroupUserTableName+" on "+AppUserTableName+".id = "+GroupUserTableName+".app_user_id").
+		Where(AppUserTableName+".organization_id = ?", uint(operator.GetOrganizationID())).
+		Where(GroupUserTableName+".app_user_group_id = ?", uint(appUserGroupID)).
+		Count(&count); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	entities := []appUserEntity{}
+	if result := r.db.Model(&appUserEntity{}).
+		Joins("inner join "+GroupUserTableName+" on "+AppUserTableName+".id = "+GroupUserTableName+".app_user_id").
+		Where(AppUserTableName+".organization_id = ?", uint(operator.GetOrganizationID())).
+		Where(GroupUserTableName+".app_user_group_id = ?", uint(appUserGroupID)).
+		Order(AppUserTableName + ".id").
+		Limit(pageSize).Offset((pageNo - 1) * pageSize).
+		Find(&entities); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	appUsers := make([]*service.AppUserInfo, len(entities))
+	for i, entity := range entities {
+		appUsers[i] = entity.toAppUserInfo()
+	}
+
+	return appUsers, int(count), nil
+}
+
+func (r *groupUserRepository) RemoveGroupUser(ctx context.Context, operator domain.OwnerModel, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error {
+	_, span := tracer.Start(ctx, "groupUserRepository.RemoveGroupUser")
+	defer span.End()
+
+	result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("app_user_group_id = ? and app_user_id = ?", uint(appUserGroupID), uint(appUserID)).
+		Delete(&groupUserEntity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrGroupUserNotFound
+	}
+
+	return nil
+}
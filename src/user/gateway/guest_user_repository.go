@@ -0,0 +1,176 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	libG "github.com/kujilabo/cocotola-api/src/lib/gateway"
+	"github.com/kujilabo/cocotola-api/src/lib/passwordhelper"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type guestUserEntity struct {
+	AppUserID      uint
+	CreatedAt      time.Time
+	OrganizationID uint
+	ExpiresAt      time.Time
+}
+
+func (e *guestUserEntity) TableName() string {
+	return "guest_user"
+}
+
+func (e *guestUserEntity) toModel() *service.GuestUser {
+	return &service.GuestUser{
+		OrganizationID: domain.OrganizationID(e.OrganizationID),
+		AppUserID:      domain.AppUserID(e.AppUserID),
+		ExpiresAt:      e.ExpiresAt,
+	}
+}
+
+type guestUserRepository struct {
+	db *gorm.DB
+}
+
+func NewGuestUserRepository(db *gorm.DB) service.GuestUserRepository {
+	return &guestUserRepository{
+		db: db,
+	}
+}
+
+func (r *guestUserRepository) AddGuestUser(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, expiresAt time.Time) error {
+	_, span := tracer.Start(ctx, "guestUserRepository.AddGuestUser")
+	defer span.End()
+
+	if result := r.db.Create(&guestUserEntity{
+		AppUserID:      uint(appUserID),
+		OrganizationID: uint(operator.GetOrganizationID()),
+		ExpiresAt:      expiresAt,
+	}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *guestUserRepository) FindGuestUser(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID) (*service.GuestUser, error) {
+	_, span := tracer.Start(ctx, "guestUserRepository.FindGuestUser")
+	defer span.End()
+
+	entity := guestUserEntity{}
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("app_user_id = ?", uint(appUserID)).
+		First(&entity); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrGuestUserNotFound
+		}
+		return nil, result.Error
+	}
+
+	return entity.toModel(), nil
+}
+
+func (r *guestUserRepository) CountGuestUsers(ctx context.Context, operator domain.SystemOwnerModel, now time.Time) (int, error) {
+	_, span := tracer.Start(ctx, "guestUserRepository.CountGuestUsers")
+	defer span.End()
+
+	var count int64
+	if result := r.db.Model(&guestUserEntity{}).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("expires_at > ?", now).
+		Count(&count); result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+func (r *guestUserRepository) UpgradeGuestUser(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, param *service.GuestUserUpgradeParameter) error {
+	_, span := tracer.Start(ctx, "guestUserRepository.UpgradeGuestUser")
+	defer span.End()
+
+	hashedPassword := ""
+	if param.Password != "" {
+		hashed, err := passwordhelper.HashPassword(param.Password)
+		if err != nil {
+			return err
+		}
+		hashedPassword = hashed
+	}
+
+	result := r.db.Table(AppUserTableName).
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("id = ? and role = ?", uint(appUserID), GuestRole).
+		Updates(map[string]interface{}{
+			"version":         gorm.Expr("version + 1"),
+			"updated_by":      uint(appUserID),
+			"login_id":        param.LoginID,
+			"username":        param.Username,
+			"hashed_password": hashedPassword,
+			"role":            UserRole,
+			"provider":        param.Provider,
+			"provider_id":     param.ProviderID,
+		})
+	if result.Error != nil {
+		return libG.ConvertDuplicatedError(result.Error, service.ErrAppUserAlreadyExists)
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrGuestUserNotFound
+	}
+
+	if result := r.db.
+		Where("organization_id = ?", uint(operator.GetOrganizationID())).
+		Where("app_user_id = ?", uint(appUserID)).
+		Delete(&guestUserEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *guestUserRepository) FindExpiredGuestUsers(ctx context.Context, operator domain.SystemAdminModel, now time.Time, limit int) ([]*service.GuestUser, error) {
+	_, span := tracer.Start(ctx, "guestUserRepository.FindExpiredGuestUsers")
+	defer span.End()
+
+	entities := []guestUserEntity{}
+	if result := r.db.
+		Where("expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	guestUsers := make([]*service.GuestUser, len(entities))
+	for i, entity := range entities {
+		guestUsers[i] = entity.toModel()
+	}
+
+	return guestUsers, nil
+}
+
+func (r *guestUserRepository) RemoveGuestUser(ctx context.Context, operator domain.SystemAdminModel, guestUser *service.GuestUser) error {
+	_, span := tracer.Start(ctx, "guestUserRepository.RemoveGuestUser")
+	defer span.End()
+
+	if result := r.db.
+		Where("organization_id = ?", uint(guestUser.OrganizationID)).
+		Where("app_user_id = ?", uint(guestUser.AppUserID)).
+		Delete(&guestUserEntity{}); result.Error != nil {
+		return result.Error
+	}
+
+	// the guest is marked as removed like the other users because the guest may have edited the workbooks of the other users
+	if _, err := removeAppUser(r.db, guestUser.OrganizationID, operator.GetID(), guestUser.AppUserID, func(db *gorm.DB) *gorm.DB {
+		return db.Where("role = ?", GuestRole)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
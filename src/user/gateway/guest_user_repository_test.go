@@ -0,0 +1,98 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/gateway"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+func Test_guestUserRepository(t *testing.T) {
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return gateway.NewRepositoryFactory(db)
+	}
+
+	service.InitSystemAdmin(userRfFunc)
+	for _, db := range dbList() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		defer sqlDB.Close()
+
+		testInitOrganization(t, db)
+		require.NoError(t, gateway.NewRBACRepository(db).Init())
+		sysAd, err := service.NewSystemAdminFromDB(bg, db)
+		require.NoError(t, err)
+		appUserRepo := gateway.NewAppUserRepository(nil, db)
+		sysOwner, err := appUserRepo.FindSystemOwnerByOrganizationName(bg, sysAd, "ORG_NAME")
+		require.NoError(t, err)
+		repo := gateway.NewGuestUserRepository(db)
+
+		addGuest := func(loginID string, expiresAt time.Time) domain.AppUserID {
+			param, err := service.NewAppUserAddParameter(loginID, "Guest", []string{domain.GuestRole.GetName()}, map[string]string{})
+			require.NoError(t, err)
+			guestID, err := appUserRepo.AddAppUser(bg, sysOwner, param)
+			require.NoError(t, err)
+			require.NoError(t, repo.AddGuestUser(bg, sysOwner, guestID, expiresAt))
+			return guestID
+		}
+
+		expiredID := addGuest("GUEST_EXPIRED", time.Now().Add(-time.Minute))
+		activeID := addGuest("GUEST_ACTIVE", time.Now().Add(time.Hour))
+
+		// find the guests
+		guest, err := repo.FindGuestUser(bg, sysOwner, activeID)
+		require.NoError(t, err)
+		assert.False(t, guest.IsExpired(time.Now()))
+		guests, err := repo.FindExpiredGuestUsers(bg, sysAd, time.Now(), 10)
+		require.NoError(t, err)
+		require.Len(t, guests, 1)
+		assert.Equal(t, expiredID, guests[0].AppUserID)
+
+		// count the guests who haven't expired
+		count, err := repo.CountGuestUsers(bg, sysOwner, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		// upgrade the guest
+		require.NoError(t, repo.UpgradeGuestUser(bg, sysOwner, activeID, &service.GuestUserUpgradeParameter{
+			LoginID:  "LOGIN_ID",
+			Username: "USERNAME",
+			Password: "PASSWORD",
+		}))
+		_, err = repo.FindGuestUser(bg, sysOwner, activeID)
+		assert.True(t, errors.Is(err, service.ErrGuestUserNotFound))
+		appUser, err := appUserRepo.FindAppUserByLoginID(bg, sysOwner, "LOGIN_ID")
+		require.NoError(t, err)
+		assert.Equal(t, activeID, domain.AppUserID(appUser.GetID()))
+		assert.Equal(t, []string{"User"}, appUser.GetRoles())
+
+		// the upgraded user isn't the guest anymore
+		err = repo.UpgradeGuestUser(bg, sysOwner, activeID, &service.GuestUserUpgradeParameter{LoginID: "LOGIN_ID2", Username: "USERNAME"})
+		assert.True(t, errors.Is(err, service.ErrGuestUserNotFound))
+		count, err = repo.CountGuestUsers(bg, sysOwner, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		// remove the expired guest
+		require.NoError(t, repo.RemoveGuestUser(bg, sysAd, guests[0]))
+		_, err = appUserRepo.FindAppUserByID(bg, sysOwner, expiredID)
+		assert.True(t, errors.Is(err, service.ErrAppUserNotFound))
+		// the guest is marked as removed instead of being deleted
+		var removed int64
+		require.NoError(t, db.Table(gateway.AppUserTableName).Where("id = ? and removed = 1", uint(expiredID)).Count(&removed).Error)
+		assert.Equal(t, int64(1), removed)
+		guests, err = repo.FindExpiredGuestUsers(bg, sysAd, time.Now(), 10)
+		require.NoError(t, err)
+		assert.Len(t, guests, 0)
+	}
+}
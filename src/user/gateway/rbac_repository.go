@@ -1,6 +1,8 @@
 package gateway
 
 import (
+	"strings"
+
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
 	gormadapter "github.com/casbin/gorm-adapter/v3"
@@ -27,6 +29,8 @@ e = some(where (p.eft == allow))
 m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
 `
 
+const groupRolePrefix = "group_"
+
 type rbacRepository struct {
 	db *gorm.DB
 }
@@ -95,6 +99,37 @@ func (r *rbacRepository) AddNamedGroupingPolicy(subject domain.RBACUser, object
 	return nil
 }
 
+func (r *rbacRepository) RemoveNamedGroupingPolicy(subject domain.RBACUser, object domain.RBACRole) error {
+	e, err := r.initEnforcer()
+	if err != nil {
+		return err
+	}
+
+	if _, err := e.RemoveNamedGroupingPolicy("g", string(subject), string(object)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *rbacRepository) RemoveRole(role domain.RBACRole) error {
+	e, err := r.initEnforcer()
+	if err != nil {
+		return err
+	}
+
+	if _, err := e.DeleteRole(string(role)); err != nil {
+		return err
+	}
+
+	// the group role is also the subject of the grouping policies
+	if _, err := e.DeleteUser(string(role)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *rbacRepository) NewEnforcerWithRolesAndUsers(roles []domain.RBACRole, users []domain.RBACUser) (*casbin.Enforcer, error) {
 	subjects := make([]string, 0)
 	for _, s := range roles {
@@ -103,6 +138,13 @@ func (r *rbacRepository) NewEnforcerWithRolesAndUsers(roles []domain.RBACRole, u
 	for _, s := range users {
 		subjects = append(subjects, string(s))
 	}
+	if len(users) > 0 {
+		groups, err := r.findGroupRoles(users)
+		if err != nil {
+			return nil, err
+		}
+		subjects = append(subjects, groups...)
+	}
 	e, err := r.initEnforcer()
 	if err != nil {
 		return nil, err
@@ -112,3 +154,27 @@ func (r *rbacRepository) NewEnforcerWithRolesAndUsers(roles []domain.RBACRole, u
 	}
 	return e, nil
 }
+
+// findGroupRoles returns the roles of the groups which the users belong to
+func (r *rbacRepository) findGroupRoles(users []domain.RBACUser) ([]string, error) {
+	userNames := make([]string, len(users))
+	for i, user := range users {
+		userNames[i] = string(user)
+	}
+
+	roles := make([]string, 0)
+	if result := r.db.Table("casbin_rule").
+		Where("ptype = ? and v0 in ?", "g", userNames).
+		Pluck("v1", &roles); result.Error != nil {
+		return nil, result.Error
+	}
+
+	groups := make([]string, 0)
+	for _, role := range roles {
+		if strings.HasPrefix(role, groupRolePrefix) {
+			groups = append(groups, role)
+		}
+	}
+
+	return groups, nil
+}
@@ -28,6 +28,14 @@ func (f *repositoryFactory) NewAppUserRepository() service.AppUserRepository {
 	return NewAppUserRepository(f, f.db)
 }
 
+func (f *repositoryFactory) NewAppUserPasswordRepository() service.AppUserPasswordRepository {
+	return NewAppUserPasswordRepository(f.db)
+}
+
+func (f *repositoryFactory) NewGuestUserRepository() service.GuestUserRepository {
+	return NewGuestUserRepository(f.db)
+}
+
 func (f *repositoryFactory) NewAppUserGroupRepository() service.AppUserGroupRepository {
 	return NewAppUserGroupRepository(f.db)
 }
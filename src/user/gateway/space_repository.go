@@ -16,6 +16,7 @@ import (
 const SpaceTypeDefault = 1
 const SpaceTypePersonal = 2
 const SpaceTypeSystem = 3
+const SpaceTypeShared = 4
 
 type spaceEntity struct {
 	ID             uint
@@ -178,3 +179,68 @@ func (r *spaceRepository) AddSystemSpace(ctx context.Context, operator domain.Sy
 	}
 	return domain.SpaceID(space.ID), nil
 }
+
+func (r *spaceRepository) FindSharedSpaces(ctx context.Context, operator domain.AppUserModel) ([]service.Space, error) {
+	_, span := tracer.Start(ctx, "spaceRepository.FindSharedSpaces")
+	defer span.End()
+
+	entities := []spaceEntity{}
+	if result := r.db.Model(&spaceEntity{}).
+		Joins("inner join user_space on space.id = user_space.space_id").
+		Where("space.organization_id = ? and space.type = ?", uint(operator.GetOrganizationID()), SpaceTypeShared).
+		Where("user_space.app_user_id = ?", operator.GetID()).
+		Order("space.name").
+		Find(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	spaces := make([]service.Space, len(entities))
+	for i, entity := range entities {
+		space, err := entity.toSpace()
+		if err != nil {
+			return nil, err
+		}
+		spaces[i] = space
+	}
+
+	return spaces, nil
+}
+
+func (r *spaceRepository) FindSharedSpaceByID(ctx context.Context, operator domain.AppUserModel, id domain.SpaceID) (service.Space, error) {
+	_, span := tracer.Start(ctx, "spaceRepository.FindSharedSpaceByID")
+	defer span.End()
+
+	space := spaceEntity{}
+	if result := r.db.
+		Where("organization_id = ? and type = ?", uint(operator.GetOrganizationID()), SpaceTypeShared).
+		Where("id = ?", uint(id)).
+		First(&space); result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, service.ErrSpaceNotFound
+		}
+		return nil, result.Error
+	}
+
+	return space.toSpace()
+}
+
+func (r *spaceRepository) AddSharedSpace(ctx context.Context, operator domain.AppUserModel, param service.SharedSpaceAddParameter) (domain.SpaceID, error) {
+	_, span := tracer.Start(ctx, "spaceRepository.AddSharedSpace")
+	defer span.End()
+
+	space := spaceEntity{
+		Version:        1,
+		CreatedBy:      operator.GetID(),
+		UpdatedBy:      operator.GetID(),
+		OrganizationID: uint(operator.GetOrganizationID()),
+		Type:           SpaceTypeShared,
+		Key:            param.GetKey(),
+		Name:           param.GetName(),
+		Description:    param.GetDescription(),
+	}
+
+	if result := r.db.Create(&space); result.Error != nil {
+		return 0, libG.ConvertDuplicatedError(result.Error, service.ErrSpaceAlreadyExists)
+	}
+	return domain.SpaceID(space.ID), nil
+}
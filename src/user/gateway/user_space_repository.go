@@ -25,6 +25,7 @@ type userSpaceEntity struct {
 	OrganizationID uint
 	AppUserID      uint
 	SpaceID        uint
+	Role           string
 }
 
 func (e *userSpaceEntity) TableName() string {
@@ -45,6 +46,7 @@ func (r *userSpaceRepository) Add(ctx context.Context, operator domain.AppUserMo
 		OrganizationID: uint(operator.GetOrganizationID()),
 		AppUserID:      operator.GetID(),
 		SpaceID:        uint(spaceID),
+		Role:           string(domain.SpaceRoleReader),
 	}); result.Error != nil {
 		return libG.ConvertDuplicatedError(result.Error, service.ErrAppUserAlreadyExists)
 	}
@@ -79,3 +81,117 @@ func (r *userSpaceRepository) IsBelongedTo(ctx context.Context, operator domain.
 
 	return true, nil
 }
+
+type spaceMemberEntity struct {
+	AppUserID uint
+	LoginID   string
+	Username  string
+	Role      string
+}
+
+func (e *spaceMemberEntity) toSpaceMember() *service.SpaceMember {
+	return &service.SpaceMember{
+		AppUserID: domain.AppUserID(e.AppUserID),
+		LoginID:   e.LoginID,
+		Username:  e.Username,
+		Role:      domain.SpaceRole(e.Role),
+	}
+}
+
+func (r *userSpaceRepository) findSpaceMembers(operator domain.AppUserModel, spaceID domain.SpaceID) *gorm.DB {
+	return r.db.Table("user_space").
+		Select("user_space.app_user_id, app_user.login_id, app_user.username, user_space.role").
+		Joins("inner join app_user on user_space.app_user_id = app_user.id").
+		Where("user_space.organization_id = ? and user_space.space_id = ?", uint(operator.GetOrganizationID()), uint(spaceID))
+}
+
+func (r *userSpaceRepository) FindSpaceMembers(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID) ([]*service.SpaceMember, error) {
+	_, span := tracer.Start(ctx, "userSpaceRepository.FindSpaceMembers")
+	defer span.End()
+
+	entities := []spaceMemberEntity{}
+	if result := r.findSpaceMembers(operator, spaceID).
+		Order("app_user.id").
+		Scan(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+
+	members := make([]*service.SpaceMember, len(entities))
+	for i, entity := range entities {
+		members[i] = entity.toSpaceMember()
+	}
+
+	return members, nil
+}
+
+func (r *userSpaceRepository) FindSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID) (*service.SpaceMember, error) {
+	_, span := tracer.Start(ctx, "userSpaceRepository.FindSpaceMember")
+	defer span.End()
+
+	entities := []spaceMemberEntity{}
+	if result := r.findSpaceMembers(operator, spaceID).
+		Where("user_space.app_user_id = ?", uint(appUserID)).
+		Scan(&entities); result.Error != nil {
+		return nil, result.Error
+	}
+	if len(entities) == 0 {
+		return nil, service.ErrSpaceMemberNotFound
+	}
+
+	return entities[0].toSpaceMember(), nil
+}
+
+func (r *userSpaceRepository) AddSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	_, span := tracer.Start(ctx, "userSpaceRepository.AddSpaceMember")
+	defer span.End()
+
+	if result := r.db.Create(&userSpaceEntity{
+		CreatedBy:      operator.GetID(),
+		UpdatedBy:      operator.GetID(),
+		OrganizationID: uint(operator.GetOrganizationID()),
+		AppUserID:      uint(appUserID),
+		SpaceID:        uint(spaceID),
+		Role:           string(role),
+	}); result.Error != nil {
+		return libG.ConvertDuplicatedError(result.Error, service.ErrSpaceMemberAlreadyExists)
+	}
+
+	return nil
+}
+
+func (r *userSpaceRepository) UpdateSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	_, span := tracer.Start(ctx, "userSpaceRepository.UpdateSpaceMember")
+	defer span.End()
+
+	result := r.db.Model(&userSpaceEntity{}).
+		Where("organization_id = ? and space_id = ? and app_user_id = ?", uint(operator.GetOrganizationID()), uint(spaceID), uint(appUserID)).
+		Updates(map[string]interface{}{
+			"updated_by": operator.GetID(),
+			"role":       string(role),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrSpaceMemberNotFound
+	}
+
+	return nil
+}
+
+func (r *userSpaceRepository) RemoveSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID) error {
+	_, span := tracer.Start(ctx, "userSpaceRepository.RemoveSpaceMember")
+	defer span.End()
+
+	result := r.db.
+		Where("organization_id = ? and space_id = ? and app_user_id = ?", uint(operator.GetOrganizationID()), uint(spaceID), uint(appUserID)).
+		Delete(&userSpaceEntity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return service.ErrSpaceMemberNotFound
+	}
+
+	return nil
+}
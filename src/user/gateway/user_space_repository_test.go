@@ -0,0 +1,120 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/gateway"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+func Test_userSpaceRepository_SharedSpace(t *testing.T) {
+	bg := context.Background()
+
+	userRfFunc := func(ctx context.Context, db *gorm.DB) (service.RepositoryFactory, error) {
+		return gateway.NewRepositoryFactory(db)
+	}
+
+	service.InitSystemAdmin(userRfFunc)
+	for _, db := range dbList() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		defer sqlDB.Close()
+
+		db.Exec("delete from user_space")
+		_, firstOwner := testInitOrganization(t, db)
+		require.NoError(t, gateway.NewRBACRepository(db).Init())
+		rf, err := gateway.NewRepositoryFactory(db)
+		require.NoError(t, err)
+		owner, err := service.NewAppUser(rf, firstOwner)
+		require.NoError(t, err)
+
+		appUserID, err := rf.NewAppUserRepository().AddAppUser(bg, owner, testNewAppUserAddParameter(t, "LOGIN_ID", "USERNAME"))
+		require.NoError(t, err)
+		member, err := rf.NewAppUserRepository().FindAppUserByID(bg, owner, appUserID)
+		require.NoError(t, err)
+
+		// the creator becomes the admin
+		addParam, err := service.NewSharedSpaceAddParameter("team_a", "Team A", "")
+		require.NoError(t, err)
+		spaceID, err := owner.AddSharedSpace(bg, addParam)
+		require.NoError(t, err)
+		_, err = owner.AddSharedSpace(bg, addParam)
+		assert.True(t, errors.Is(err, service.ErrSpaceAlreadyExists))
+		privs, err := owner.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.True(t, privs.HasPrivilege(domain.SpacePrivilegeAdmin))
+
+		// the user who isn't a member can't read the space
+		_, err = member.FindSharedSpaceByID(bg, spaceID)
+		assert.True(t, errors.Is(err, service.ErrPermissionDenied))
+		err = member.AddSpaceMember(bg, spaceID, appUserID, domain.SpaceRoleAdmin)
+		assert.True(t, errors.Is(err, service.ErrPermissionDenied))
+
+		require.NoError(t, owner.AddSpaceMember(bg, spaceID, appUserID, domain.SpaceRoleReader))
+		err = owner.AddSpaceMember(bg, spaceID, appUserID, domain.SpaceRoleReader)
+		assert.True(t, errors.Is(err, service.ErrSpaceMemberAlreadyExists))
+		spaces, err := member.FindSharedSpaces(bg)
+		require.NoError(t, err)
+		require.Len(t, spaces, 1)
+		assert.Equal(t, "team_a", spaces[0].GetKey())
+		members, err := member.FindSpaceMembers(bg, spaceID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		privs, err = member.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.True(t, privs.HasPrivilege(domain.SpacePrivilegeRead))
+		assert.False(t, privs.HasPrivilege(domain.SpacePrivilegeWrite))
+
+		// the role is replaced
+		require.NoError(t, owner.UpdateSpaceMember(bg, spaceID, appUserID, domain.SpaceRoleWriter))
+		privs, err = member.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.True(t, privs.HasPrivilege(domain.SpacePrivilegeWrite))
+		assert.False(t, privs.HasPrivilege(domain.SpacePrivilegeAdmin))
+		err = owner.UpdateSpaceMember(bg, spaceID, domain.AppUserID(owner.GetID()), domain.SpaceRoleReader)
+		assert.True(t, errors.Is(err, libD.ErrInvalidArgument))
+
+		require.NoError(t, owner.RemoveSpaceMember(bg, spaceID, appUserID))
+		privs, err = member.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.False(t, privs.HasPrivilege(domain.SpacePrivilegeRead))
+		err = owner.RemoveSpaceMember(bg, spaceID, appUserID)
+		assert.True(t, errors.Is(err, service.ErrSpaceMemberNotFound))
+
+		// the users of the group are given the role of the group
+		groupParam, err := service.NewAppUserGroupAddParameter("team_a_group", "Team A", "")
+		require.NoError(t, err)
+		appUserGroupID, err := service.NewOwner(rf, firstOwner).AddAppUserGroup(bg, groupParam)
+		require.NoError(t, err)
+		require.NoError(t, service.NewOwner(rf, firstOwner).AddGroupUser(bg, appUserGroupID, appUserID))
+		err = member.SetSpaceGroupRole(bg, spaceID, appUserGroupID, domain.SpaceRoleWriter)
+		assert.True(t, errors.Is(err, service.ErrPermissionDenied))
+		err = owner.SetSpaceGroupRole(bg, spaceID, appUserGroupID, domain.SpaceRoleAdmin)
+		assert.True(t, errors.Is(err, libD.ErrInvalidArgument))
+
+		require.NoError(t, owner.SetSpaceGroupRole(bg, spaceID, appUserGroupID, domain.SpaceRoleWriter))
+		privs, err = member.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.True(t, privs.HasPrivilege(domain.SpacePrivilegeWrite))
+		assert.False(t, privs.HasPrivilege(domain.SpacePrivilegeAdmin))
+
+		require.NoError(t, owner.SetSpaceGroupRole(bg, spaceID, appUserGroupID, domain.SpaceRoleReader))
+		privs, err = member.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.True(t, privs.HasPrivilege(domain.SpacePrivilegeRead))
+		assert.False(t, privs.HasPrivilege(domain.SpacePrivilegeWrite))
+
+		require.NoError(t, owner.RemoveSpaceGroup(bg, spaceID, appUserGroupID))
+		privs, err = member.GetSpacePrivileges(bg, spaceID)
+		require.NoError(t, err)
+		assert.False(t, privs.HasPrivilege(domain.SpacePrivilegeRead))
+	}
+}
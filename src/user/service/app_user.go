@@ -4,6 +4,7 @@ import (
 	"context"
 
 	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
 	"github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
@@ -14,6 +15,31 @@ type AppUser interface {
 
 	GetDefaultSpace(ctx context.Context) (Space, error)
 	GetPersonalSpace(ctx context.Context) (Space, error)
+
+	// FindSharedSpaces returns the shared spaces which the user is a member of
+	FindSharedSpaces(ctx context.Context) ([]Space, error)
+
+	FindSharedSpaceByID(ctx context.Context, id domain.SpaceID) (Space, error)
+
+	// AddSharedSpace adds the shared space. The user becomes the admin of it
+	AddSharedSpace(ctx context.Context, param SharedSpaceAddParameter) (domain.SpaceID, error)
+
+	// GetSpacePrivileges returns the privileges of the user on the space
+	GetSpacePrivileges(ctx context.Context, spaceID domain.SpaceID) (domain.Privileges, error)
+
+	FindSpaceMembers(ctx context.Context, spaceID domain.SpaceID) ([]*SpaceMember, error)
+
+	AddSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error
+
+	UpdateSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error
+
+	RemoveSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID) error
+
+	// SetSpaceGroupRole shares the space with all the users of the group. The previous role of the group is replaced
+	SetSpaceGroupRole(ctx context.Context, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID, role domain.SpaceRole) error
+
+	// RemoveSpaceGroup stops sharing the space with the group
+	RemoveSpaceGroup(ctx context.Context, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID) error
 }
 
 type appUser struct {
@@ -37,3 +63,196 @@ func (a *appUser) GetDefaultSpace(ctx context.Context) (Space, error) {
 func (a *appUser) GetPersonalSpace(ctx context.Context) (Space, error) {
 	return a.rf.NewSpaceRepository().FindPersonalSpace(ctx, a)
 }
+
+func (a *appUser) FindSharedSpaces(ctx context.Context) ([]Space, error) {
+	return a.rf.NewSpaceRepository().FindSharedSpaces(ctx, a)
+}
+
+func (a *appUser) FindSharedSpaceByID(ctx context.Context, id domain.SpaceID) (Space, error) {
+	if err := a.checkSpacePrivilege(ctx, id, domain.SpacePrivilegeRead); err != nil {
+		return nil, err
+	}
+
+	return a.rf.NewSpaceRepository().FindSharedSpaceByID(ctx, a, id)
+}
+
+func (a *appUser) AddSharedSpace(ctx context.Context, param SharedSpaceAddParameter) (domain.SpaceID, error) {
+	spaceID, err := a.rf.NewSpaceRepository().AddSharedSpace(ctx, a, param)
+	if err != nil {
+		return 0, err
+	}
+
+	rbacRepo := a.rf.NewRBACRepository()
+	spaceObject := domain.NewSpaceObject(spaceID)
+	for _, role := range []domain.SpaceRole{domain.SpaceRoleReader, domain.SpaceRoleWriter, domain.SpaceRoleAdmin} {
+		for _, priv := range role.GetPrivileges() {
+			if err := rbacRepo.AddNamedPolicy(role.ToRBACRole(spaceID), spaceObject, priv); err != nil {
+				return 0, liberrors.Errorf("failed to AddNamedPolicy. role: %s, priv: %s, err: %w", role, priv, err)
+			}
+		}
+	}
+
+	if err := a.addSpaceMember(ctx, spaceID, domain.AppUserID(a.GetID()), domain.SpaceRoleAdmin); err != nil {
+		return 0, err
+	}
+
+	return spaceID, nil
+}
+
+func (a *appUser) GetSpacePrivileges(ctx context.Context, spaceID domain.SpaceID) (domain.Privileges, error) {
+	spaceRoles := []domain.RBACRole{
+		domain.NewSpaceReaderRole(spaceID),
+		domain.NewSpaceWriterRole(spaceID),
+		domain.NewSpaceAdminRole(spaceID),
+	}
+	userObject := domain.NewUserObject(domain.AppUserID(a.GetID()))
+	e, err := a.rf.NewRBACRepository().NewEnforcerWithRolesAndUsers(spaceRoles, []domain.RBACUser{userObject})
+	if err != nil {
+		return nil, liberrors.Errorf("failed to NewEnforcerWithRolesAndUsers. err: %w", err)
+	}
+
+	spaceObject := domain.NewSpaceObject(spaceID)
+	actions := make([]domain.RBACAction, 0)
+	for _, priv := range domain.SpaceRoleAdmin.GetPrivileges() {
+		ok, err := e.Enforce(string(userObject), string(spaceObject), string(priv))
+		if err != nil {
+			return nil, liberrors.Errorf("failed to Enforce. err: %w", err)
+		}
+		if ok {
+			actions = append(actions, priv)
+		}
+	}
+	return domain.NewPrivileges(actions), nil
+}
+
+func (a *appUser) FindSpaceMembers(ctx context.Context, spaceID domain.SpaceID) ([]*SpaceMember, error) {
+	if err := a.checkSpacePrivilege(ctx, spaceID, domain.SpacePrivilegeRead); err != nil {
+		return nil, err
+	}
+
+	return a.rf.NewUserSpaceRepository().FindSpaceMembers(ctx, a, spaceID)
+}
+
+func (a *appUser) AddSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	if err := a.checkSpacePrivilege(ctx, spaceID, domain.SpacePrivilegeAdmin); err != nil {
+		return err
+	}
+
+	if _, err := a.rf.NewAppUserRepository().FindAppUserByID(ctx, a, appUserID); err != nil {
+		return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+	}
+
+	return a.addSpaceMember(ctx, spaceID, appUserID, role)
+}
+
+func (a *appUser) UpdateSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	member, err := a.findSpaceMemberForAdmin(ctx, spaceID, appUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.rf.NewUserSpaceRepository().UpdateSpaceMember(ctx, a, spaceID, appUserID, role); err != nil {
+		return err
+	}
+
+	rbacRepo := a.rf.NewRBACRepository()
+	userObject := domain.NewUserObject(appUserID)
+	if err := rbacRepo.RemoveNamedGroupingPolicy(userObject, member.Role.ToRBACRole(spaceID)); err != nil {
+		return liberrors.Errorf("failed to RemoveNamedGroupingPolicy. err: %w", err)
+	}
+	if err := rbacRepo.AddNamedGroupingPolicy(userObject, role.ToRBACRole(spaceID)); err != nil {
+		return liberrors.Errorf("failed to AddNamedGroupingPolicy. err: %w", err)
+	}
+
+	return nil
+}
+
+func (a *appUser) RemoveSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID) error {
+	member, err := a.findSpaceMemberForAdmin(ctx, spaceID, appUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.rf.NewUserSpaceRepository().RemoveSpaceMember(ctx, a, spaceID, appUserID); err != nil {
+		return err
+	}
+
+	if err := a.rf.NewRBACRepository().RemoveNamedGroupingPolicy(domain.NewUserObject(appUserID), member.Role.ToRBACRole(spaceID)); err != nil {
+		return liberrors.Errorf("failed to RemoveNamedGroupingPolicy. err: %w", err)
+	}
+
+	return nil
+}
+
+func (a *appUser) SetSpaceGroupRole(ctx context.Context, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID, role domain.SpaceRole) error {
+	// the admins are the members so that the space doesn't lose the admin when the group is changed
+	if role == domain.SpaceRoleAdmin {
+		return liberrors.Errorf("the group can't be the admin of the space. appUserGroupID: %d, err: %w", appUserGroupID, libD.ErrInvalidArgument)
+	}
+
+	if err := a.RemoveSpaceGroup(ctx, spaceID, appUserGroupID); err != nil {
+		return err
+	}
+
+	if err := a.rf.NewRBACRepository().AddNamedGroupingPolicy(domain.NewAppUserGroupSubject(appUserGroupID), role.ToRBACRole(spaceID)); err != nil {
+		return liberrors.Errorf("failed to AddNamedGroupingPolicy. err: %w", err)
+	}
+
+	return nil
+}
+
+func (a *appUser) RemoveSpaceGroup(ctx context.Context, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID) error {
+	if err := a.checkSpacePrivilege(ctx, spaceID, domain.SpacePrivilegeAdmin); err != nil {
+		return err
+	}
+
+	if _, err := a.rf.NewAppUserGroupRepository().FindAppUserGroupByID(ctx, a, appUserGroupID); err != nil {
+		return liberrors.Errorf("failed to FindAppUserGroupByID. err: %w", err)
+	}
+
+	rbacRepo := a.rf.NewRBACRepository()
+	groupSubject := domain.NewAppUserGroupSubject(appUserGroupID)
+	for _, role := range []domain.SpaceRole{domain.SpaceRoleReader, domain.SpaceRoleWriter} {
+		if err := rbacRepo.RemoveNamedGroupingPolicy(groupSubject, role.ToRBACRole(spaceID)); err != nil {
+			return liberrors.Errorf("failed to RemoveNamedGroupingPolicy. err: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findSpaceMemberForAdmin rejects the operation on the user so that the space doesn't lose the admin
+func (a *appUser) findSpaceMemberForAdmin(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID) (*SpaceMember, error) {
+	if appUserID == domain.AppUserID(a.GetID()) {
+		return nil, liberrors.Errorf("the operator can't change the own membership. appUserID: %d, err: %w", appUserID, libD.ErrInvalidArgument)
+	}
+
+	if err := a.checkSpacePrivilege(ctx, spaceID, domain.SpacePrivilegeAdmin); err != nil {
+		return nil, err
+	}
+
+	return a.rf.NewUserSpaceRepository().FindSpaceMember(ctx, a, spaceID, appUserID)
+}
+
+func (a *appUser) addSpaceMember(ctx context.Context, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	if err := a.rf.NewUserSpaceRepository().AddSpaceMember(ctx, a, spaceID, appUserID, role); err != nil {
+		return err
+	}
+
+	if err := a.rf.NewRBACRepository().AddNamedGroupingPolicy(domain.NewUserObject(appUserID), role.ToRBACRole(spaceID)); err != nil {
+		return liberrors.Errorf("failed to AddNamedGroupingPolicy. err: %w", err)
+	}
+
+	return nil
+}
+
+func (a *appUser) checkSpacePrivilege(ctx context.Context, spaceID domain.SpaceID, privilege domain.RBACAction) error {
+	privs, err := a.GetSpacePrivileges(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+	if !privs.HasPrivilege(privilege) {
+		return liberrors.Errorf("the user doesn't have the privilege on the space. spaceID: %d, privilege: %s, err: %w", spaceID, privilege, ErrPermissionDenied)
+	}
+	return nil
+}
@@ -2,13 +2,89 @@ package service
 
 import (
 	"context"
+	"errors"
 
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 	"github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
+var ErrAppUserGroupNotFound = errors.New("app user group not found")
+var ErrAppUserGroupAlreadyExists = errors.New("app user group already exists")
+
+// PublicGroupKey is the key of the group which all the users of the organization belong to
+const PublicGroupKey = "public"
+
+type AppUserGroupAddParameter interface {
+	GetKey() string
+	GetName() string
+	GetDescription() string
+}
+
+type appUserGroupAddParameter struct {
+	Key         string `validate:"required,max=20"`
+	Name        string `validate:"required,max=20"`
+	Description string `validate:"max=40"`
+}
+
+func NewAppUserGroupAddParameter(key, name, description string) (AppUserGroupAddParameter, error) {
+	m := &appUserGroupAddParameter{
+		Key:         key,
+		Name:        name,
+		Description: description,
+	}
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *appUserGroupAddParameter) GetKey() string {
+	return p.Key
+}
+func (p *appUserGroupAddParameter) GetName() string {
+	return p.Name
+}
+func (p *appUserGroupAddParameter) GetDescription() string {
+	return p.Description
+}
+
+type AppUserGroupUpdateParameter interface {
+	GetName() string
+	GetDescription() string
+}
+
+type appUserGroupUpdateParameter struct {
+	Name        string `validate:"required,max=20"`
+	Description string `validate:"max=40"`
+}
+
+func NewAppUserGroupUpdateParameter(name, description string) (AppUserGroupUpdateParameter, error) {
+	m := &appUserGroupUpdateParameter{
+		Name:        name,
+		Description: description,
+	}
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *appUserGroupUpdateParameter) GetName() string {
+	return p.Name
+}
+func (p *appUserGroupUpdateParameter) GetDescription() string {
+	return p.Description
+}
+
 type AppUserGroupRepository interface {
 	FindPublicGroup(ctx context.Context, operator domain.SystemOwnerModel) (AppUserGroup, error)
 
 	AddPublicGroup(ctx context.Context, operator domain.SystemOwnerModel) (domain.AppUserGroupID, error)
 	// AddPersonalGroup(operator SystemOwner, studentID uint) (uint, error)
+
+	// FindAppUserGroups returns the groups of the organization including the public group, and the number of them
+	FindAppUserGroups(ctx context.Context, operator domain.OwnerModel, pageNo, pageSize int) ([]AppUserGroup, int, error)
+
+	FindAppUserGroupByID(ctx context.Context, operator domain.AppUserModel, id domain.AppUserGroupID) (AppUserGroup, error)
+
+	AddAppUserGroup(ctx context.Context, operator domain.OwnerModel, param AppUserGroupAddParameter) (domain.AppUserGroupID, error)
+
+	UpdateAppUserGroup(ctx context.Context, operator domain.OwnerModel, id domain.AppUserGroupID, version int, param AppUserGroupUpdateParameter) error
+
+	// RemoveAppUserGroup removes the group and the members of it
+	RemoveAppUserGroup(ctx context.Context, operator domain.OwnerModel, id domain.AppUserGroupID) error
 }
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrPasswordResetTokenNotFound = errors.New("password reset token not found")
+
+// PasswordCredential is the password and the lockout state of the app user
+type PasswordCredential struct {
+	AppUserID      domain.AppUserID
+	HashedPassword string
+	// Provider is not empty if the user signs in with an external identity provider
+	Provider         string
+	FailedLoginCount int
+	LockedUntil      *time.Time
+}
+
+// IsLocked returns whether the user can't sign in with the password at `now`
+func (c *PasswordCredential) IsLocked(now time.Time) bool {
+	return c.LockedUntil != nil && now.Before(*c.LockedUntil)
+}
+
+type AppUserPasswordRepository interface {
+	FindPasswordCredential(ctx context.Context, operator domain.SystemOwnerModel, loginID string) (*PasswordCredential, error)
+
+	// RecordLoginFailure increments the number of the failures and locks the user for `lockDuration` when the number reaches `maxFailures`
+	RecordLoginFailure(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, maxFailures int, lockDuration time.Duration) error
+
+	// ResetLoginFailure clears the number of the failures and unlocks the user
+	ResetLoginFailure(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID) error
+
+	UpdatePassword(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, password string) error
+
+	AddPasswordResetToken(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, hashedToken string, expiresAt time.Time) error
+
+	// UsePasswordResetToken marks the token as used and returns the user of the token.
+	// It returns ErrPasswordResetTokenNotFound if the token has expired or has been used
+	UsePasswordResetToken(ctx context.Context, operator domain.SystemOwnerModel, hashedToken string) (domain.AppUserID, error)
+}
@@ -3,12 +3,17 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 	"github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
 var ErrAppUserNotFound = errors.New("AppUser not found")
+
+// ErrAppUserDisabled is ErrAppUserNotFound for the disabled user, so that the disabled user can't sign in but isn't added again
+var ErrAppUserDisabled = fmt.Errorf("AppUser disabled. err: %w", ErrAppUserNotFound)
 var ErrAppUserAlreadyExists = errors.New("AppUser already exists")
 
 var ErrSystemOwnerNotFound = errors.New("SystemOwner not found")
@@ -50,6 +55,18 @@ func (p *appUserAddParameter) GetProperties() map[string]string {
 	return p.Properties
 }
 
+// AppUserInfo is the app user shown to the owner of the organization. Disabled is true if the user can't sign in
+type AppUserInfo struct {
+	ID        domain.AppUserID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	LoginID   string
+	Username  string
+	Role      string
+	Provider  string
+	Disabled  bool
+}
+
 type AppUserRepository interface {
 	FindSystemOwnerByOrganizationID(ctx context.Context, operator domain.SystemAdminModel, organizationID domain.OrganizationID) (SystemOwner, error)
 
@@ -66,4 +83,16 @@ type AppUserRepository interface {
 	AddSystemOwner(ctx context.Context, operator domain.SystemAdminModel, organizationID domain.OrganizationID) (domain.AppUserID, error)
 
 	AddFirstOwner(ctx context.Context, operator domain.SystemOwnerModel, param FirstOwnerAddParameter) (domain.AppUserID, error)
+
+	// FindAppUserInfos returns the app users of the organization except the system owner and the removed users, and the number of them.
+	// The disabled users are included
+	FindAppUserInfos(ctx context.Context, operator domain.OwnerModel, pageNo, pageSize int) ([]*AppUserInfo, int, error)
+
+	FindAppUserInfo(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID) (*AppUserInfo, error)
+
+	// UpdateAppUserDisabled disables or enables the user. FindAppUserByID and FindAppUserByLoginID return ErrAppUserDisabled for the disabled users
+	UpdateAppUserDisabled(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID, disabled bool) error
+
+	// RemoveAppUser removes the user. The data of the user are left because the other users may refer to them
+	RemoveAppUser(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID) error
 }
@@ -2,10 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
+var ErrGroupUserNotFound = errors.New("group user not found")
+var ErrGroupUserAlreadyExists = errors.New("group user already exists")
+
 type GroupUserRepository interface {
 	AddGroupUser(ctx context.Context, operator domain.AppUserModel, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error
+
+	// FindGroupUsers returns the members of the group and the number of them
+	FindGroupUsers(ctx context.Context, operator domain.OwnerModel, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*AppUserInfo, int, error)
+
+	RemoveGroupUser(ctx context.Context, operator domain.OwnerModel, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error
 }
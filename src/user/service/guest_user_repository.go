@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrGuestUserNotFound = errors.New("guest user not found")
+var ErrGuestUserLimitExceeded = errors.New("guest user limit exceeded")
+
+// GuestUser is the time-limited app user who hasn't registered any credentials
+type GuestUser struct {
+	OrganizationID domain.OrganizationID
+	AppUserID      domain.AppUserID
+	ExpiresAt      time.Time
+}
+
+func (g *GuestUser) IsExpired(now time.Time) bool {
+	return !now.Before(g.ExpiresAt)
+}
+
+// GuestUserUpgradeParameter is the credential of the guest who registers.
+// Password is empty if the user signs in with an external identity provider
+type GuestUserUpgradeParameter struct {
+	LoginID    string
+	Username   string
+	Password   string
+	Provider   string
+	ProviderID string
+}
+
+type GuestUserRepository interface {
+	AddGuestUser(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, expiresAt time.Time) error
+
+	FindGuestUser(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID) (*GuestUser, error)
+
+	// CountGuestUsers returns the number of the guests of the organization who haven't expired at `now`
+	CountGuestUsers(ctx context.Context, operator domain.SystemOwnerModel, now time.Time) (int, error)
+
+	// UpgradeGuestUser replaces the login ID and the credential of the guest and gives the user role.
+	// The workbooks and the recordbooks are kept because the app user ID doesn't change
+	UpgradeGuestUser(ctx context.Context, operator domain.SystemOwnerModel, appUserID domain.AppUserID, param *GuestUserUpgradeParameter) error
+
+	FindExpiredGuestUsers(ctx context.Context, operator domain.SystemAdminModel, now time.Time, limit int) ([]*GuestUser, error)
+
+	// RemoveGuestUser removes the guest. The guest is marked as removed like the other users, so the data of the guest are left
+	RemoveGuestUser(ctx context.Context, operator domain.SystemAdminModel, guestUser *GuestUser) error
+}
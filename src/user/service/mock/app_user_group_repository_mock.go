@@ -22,3 +22,28 @@ func (m *AppUserGroupRepositoryMock) AddPublicGroup(ctx context.Context, operato
 	args := m.Called(ctx, operator)
 	return args.Get(0).(domain.AppUserGroupID), args.Error(1)
 }
+
+func (m *AppUserGroupRepositoryMock) FindAppUserGroups(ctx context.Context, operator domain.OwnerModel, pageNo, pageSize int) ([]service.AppUserGroup, int, error) {
+	args := m.Called(ctx, operator, pageNo, pageSize)
+	return args.Get(0).([]service.AppUserGroup), args.Int(1), args.Error(2)
+}
+
+func (m *AppUserGroupRepositoryMock) FindAppUserGroupByID(ctx context.Context, operator domain.AppUserModel, id domain.AppUserGroupID) (service.AppUserGroup, error) {
+	args := m.Called(ctx, operator, id)
+	return args.Get(0).(service.AppUserGroup), args.Error(1)
+}
+
+func (m *AppUserGroupRepositoryMock) AddAppUserGroup(ctx context.Context, operator domain.OwnerModel, param service.AppUserGroupAddParameter) (domain.AppUserGroupID, error) {
+	args := m.Called(ctx, operator, param)
+	return args.Get(0).(domain.AppUserGroupID), args.Error(1)
+}
+
+func (m *AppUserGroupRepositoryMock) UpdateAppUserGroup(ctx context.Context, operator domain.OwnerModel, id domain.AppUserGroupID, version int, param service.AppUserGroupUpdateParameter) error {
+	args := m.Called(ctx, operator, id, version, param)
+	return args.Error(0)
+}
+
+func (m *AppUserGroupRepositoryMock) RemoveAppUserGroup(ctx context.Context, operator domain.OwnerModel, id domain.AppUserGroupID) error {
+	args := m.Called(ctx, operator, id)
+	return args.Error(0)
+}
@@ -44,3 +44,19 @@ func (m *AppUserRepositoryMock) AddFirstOwner(ctx context.Context, operator doma
 	args := m.Called(ctx, operator, param)
 	return args.Get(0).(domain.AppUserID), args.Error(1)
 }
+func (m *AppUserRepositoryMock) FindAppUserInfos(ctx context.Context, operator domain.OwnerModel, pageNo, pageSize int) ([]*service.AppUserInfo, int, error) {
+	args := m.Called(ctx, operator, pageNo, pageSize)
+	return args.Get(0).([]*service.AppUserInfo), args.Int(1), args.Error(2)
+}
+func (m *AppUserRepositoryMock) FindAppUserInfo(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID) (*service.AppUserInfo, error) {
+	args := m.Called(ctx, operator, id)
+	return args.Get(0).(*service.AppUserInfo), args.Error(1)
+}
+func (m *AppUserRepositoryMock) UpdateAppUserDisabled(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID, disabled bool) error {
+	args := m.Called(ctx, operator, id, disabled)
+	return args.Error(0)
+}
+func (m *AppUserRepositoryMock) RemoveAppUser(ctx context.Context, operator domain.OwnerModel, id domain.AppUserID) error {
+	args := m.Called(ctx, operator, id)
+	return args.Error(0)
+}
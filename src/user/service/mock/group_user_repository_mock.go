@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
 )
 
 type GroupUserRepositoryMock struct {
@@ -16,3 +17,13 @@ func (m *GroupUserRepositoryMock) AddGroupUser(ctx context.Context, operator dom
 	args := m.Called(ctx, operator, appUserGroupID, appUserID)
 	return args.Error(0)
 }
+
+func (m *GroupUserRepositoryMock) FindGroupUsers(ctx context.Context, operator domain.OwnerModel, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*service.AppUserInfo, int, error) {
+	args := m.Called(ctx, operator, appUserGroupID, pageNo, pageSize)
+	return args.Get(0).([]*service.AppUserInfo), args.Int(1), args.Error(2)
+}
+
+func (m *GroupUserRepositoryMock) RemoveGroupUser(ctx context.Context, operator domain.OwnerModel, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error {
+	args := m.Called(ctx, operator, appUserGroupID, appUserID)
+	return args.Error(0)
+}
@@ -26,6 +26,16 @@ func (m *RBACRepositoryyMock) AddNamedGroupingPolicy(subject domain.RBACUser, ob
 	return args.Error(0)
 }
 
+func (m *RBACRepositoryyMock) RemoveNamedGroupingPolicy(subject domain.RBACUser, object domain.RBACRole) error {
+	args := m.Called(subject, object)
+	return args.Error(0)
+}
+
+func (m *RBACRepositoryyMock) RemoveRole(role domain.RBACRole) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
 func (m *RBACRepositoryyMock) NewEnforcerWithRolesAndUsers(roles []domain.RBACRole, users []domain.RBACUser) (*casbin.Enforcer, error) {
 	args := m.Called(roles, users)
 	return args.Get(0).(*casbin.Enforcer), args.Error(1)
@@ -22,6 +22,14 @@ func (m *RepositoryFactoryMock) NewAppUserRepository() service.AppUserRepository
 	args := m.Called()
 	return args.Get(0).(service.AppUserRepository)
 }
+func (m *RepositoryFactoryMock) NewAppUserPasswordRepository() service.AppUserPasswordRepository {
+	args := m.Called()
+	return args.Get(0).(service.AppUserPasswordRepository)
+}
+func (m *RepositoryFactoryMock) NewGuestUserRepository() service.GuestUserRepository {
+	args := m.Called()
+	return args.Get(0).(service.GuestUserRepository)
+}
 func (m *RepositoryFactoryMock) NewAppUserGroupRepository() service.AppUserGroupRepository {
 	args := m.Called()
 	return args.Get(0).(service.AppUserGroupRepository)
@@ -42,3 +42,18 @@ func (m *SpaceRepositoryMock) AddSystemSpace(ctx context.Context, operator domai
 	args := m.Called(ctx, operator)
 	return args.Get(0).(domain.SpaceID), args.Error(1)
 }
+
+func (m *SpaceRepositoryMock) FindSharedSpaces(ctx context.Context, operator domain.AppUserModel) ([]service.Space, error) {
+	args := m.Called(ctx, operator)
+	return args.Get(0).([]service.Space), args.Error(1)
+}
+
+func (m *SpaceRepositoryMock) FindSharedSpaceByID(ctx context.Context, operator domain.AppUserModel, id domain.SpaceID) (service.Space, error) {
+	args := m.Called(ctx, operator, id)
+	return args.Get(0).(service.Space), args.Error(1)
+}
+
+func (m *SpaceRepositoryMock) AddSharedSpace(ctx context.Context, operator domain.AppUserModel, param service.SharedSpaceAddParameter) (domain.SpaceID, error) {
+	args := m.Called(ctx, operator, param)
+	return args.Get(0).(domain.SpaceID), args.Error(1)
+}
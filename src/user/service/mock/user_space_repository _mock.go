@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
 )
 
 type UserSpaceRepositoryMock struct {
@@ -16,3 +17,28 @@ func (m *UserSpaceRepositoryMock) Add(ctx context.Context, operator domain.AppUs
 	args := m.Called(ctx, operator, spaceID)
 	return args.Error(0)
 }
+
+func (m *UserSpaceRepositoryMock) FindSpaceMembers(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID) ([]*service.SpaceMember, error) {
+	args := m.Called(ctx, operator, spaceID)
+	return args.Get(0).([]*service.SpaceMember), args.Error(1)
+}
+
+func (m *UserSpaceRepositoryMock) FindSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID) (*service.SpaceMember, error) {
+	args := m.Called(ctx, operator, spaceID, appUserID)
+	return args.Get(0).(*service.SpaceMember), args.Error(1)
+}
+
+func (m *UserSpaceRepositoryMock) AddSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	args := m.Called(ctx, operator, spaceID, appUserID, role)
+	return args.Error(0)
+}
+
+func (m *UserSpaceRepositoryMock) UpdateSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	args := m.Called(ctx, operator, spaceID, appUserID, role)
+	return args.Error(0)
+}
+
+func (m *UserSpaceRepositoryMock) RemoveSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID) error {
+	args := m.Called(ctx, operator, spaceID, appUserID)
+	return args.Error(0)
+}
@@ -1,7 +1,45 @@
 package service
 
+import (
+	"context"
+	"errors"
+
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
 type Owner interface {
 	AppUser
+
+	FindAppUserInfos(ctx context.Context, pageNo, pageSize int) ([]*AppUserInfo, int, error)
+
+	FindAppUserInfo(ctx context.Context, id domain.AppUserID) (*AppUserInfo, error)
+
+	DisableAppUser(ctx context.Context, id domain.AppUserID) error
+
+	EnableAppUser(ctx context.Context, id domain.AppUserID) error
+
+	RemoveAppUser(ctx context.Context, id domain.AppUserID) error
+
+	FindAppUserGroups(ctx context.Context, pageNo, pageSize int) ([]AppUserGroup, int, error)
+
+	FindAppUserGroupByID(ctx context.Context, id domain.AppUserGroupID) (AppUserGroup, error)
+
+	AddAppUserGroup(ctx context.Context, param AppUserGroupAddParameter) (domain.AppUserGroupID, error)
+
+	UpdateAppUserGroup(ctx context.Context, id domain.AppUserGroupID, version int, param AppUserGroupUpdateParameter) error
+
+	// RemoveAppUserGroup removes the group and the policies given to the group
+	RemoveAppUserGroup(ctx context.Context, id domain.AppUserGroupID) error
+
+	FindGroupUsers(ctx context.Context, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*AppUserInfo, int, error)
+
+	// AddGroupUser adds the user to the group. The user is given the policies of the group
+	AddGroupUser(ctx context.Context, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error
+
+	RemoveGroupUser(ctx context.Context, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error
 }
 
 type owner struct {
@@ -15,3 +53,87 @@ func NewOwner(rf RepositoryFactory, appUser AppUser) Owner {
 		AppUser: appUser,
 	}
 }
+
+func (s *owner) FindAppUserInfos(ctx context.Context, pageNo, pageSize int) ([]*AppUserInfo, int, error) {
+	return s.rf.NewAppUserRepository().FindAppUserInfos(ctx, s, pageNo, pageSize)
+}
+
+func (s *owner) FindAppUserInfo(ctx context.Context, id domain.AppUserID) (*AppUserInfo, error) {
+	return s.rf.NewAppUserRepository().FindAppUserInfo(ctx, s, id)
+}
+
+func (s *owner) DisableAppUser(ctx context.Context, id domain.AppUserID) error {
+	return s.rf.NewAppUserRepository().UpdateAppUserDisabled(ctx, s, id, true)
+}
+
+func (s *owner) EnableAppUser(ctx context.Context, id domain.AppUserID) error {
+	return s.rf.NewAppUserRepository().UpdateAppUserDisabled(ctx, s, id, false)
+}
+
+func (s *owner) RemoveAppUser(ctx context.Context, id domain.AppUserID) error {
+	return s.rf.NewAppUserRepository().RemoveAppUser(ctx, s, id)
+}
+
+func (s *owner) FindAppUserGroups(ctx context.Context, pageNo, pageSize int) ([]AppUserGroup, int, error) {
+	return s.rf.NewAppUserGroupRepository().FindAppUserGroups(ctx, s, pageNo, pageSize)
+}
+
+func (s *owner) FindAppUserGroupByID(ctx context.Context, id domain.AppUserGroupID) (AppUserGroup, error) {
+	return s.rf.NewAppUserGroupRepository().FindAppUserGroupByID(ctx, s, id)
+}
+
+func (s *owner) AddAppUserGroup(ctx context.Context, param AppUserGroupAddParameter) (domain.AppUserGroupID, error) {
+	return s.rf.NewAppUserGroupRepository().AddAppUserGroup(ctx, s, param)
+}
+
+func (s *owner) UpdateAppUserGroup(ctx context.Context, id domain.AppUserGroupID, version int, param AppUserGroupUpdateParameter) error {
+	return s.rf.NewAppUserGroupRepository().UpdateAppUserGroup(ctx, s, id, version, param)
+}
+
+func (s *owner) RemoveAppUserGroup(ctx context.Context, id domain.AppUserGroupID) error {
+	if err := s.rf.NewAppUserGroupRepository().RemoveAppUserGroup(ctx, s, id); err != nil {
+		return err
+	}
+
+	if err := s.rf.NewRBACRepository().RemoveRole(domain.NewAppUserGroupRole(id)); err != nil {
+		return liberrors.Errorf("failed to RemoveRole. err: %w", err)
+	}
+
+	return nil
+}
+
+func (s *owner) FindGroupUsers(ctx context.Context, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*AppUserInfo, int, error) {
+	return s.rf.NewGroupUserRepository().FindGroupUsers(ctx, s, appUserGroupID, pageNo, pageSize)
+}
+
+func (s *owner) AddGroupUser(ctx context.Context, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error {
+	if _, err := s.rf.NewAppUserGroupRepository().FindAppUserGroupByID(ctx, s, appUserGroupID); err != nil {
+		return err
+	}
+
+	if _, err := s.rf.NewAppUserRepository().FindAppUserInfo(ctx, s, appUserID); err != nil {
+		return err
+	}
+
+	if err := s.rf.NewGroupUserRepository().AddGroupUser(ctx, s, appUserGroupID, appUserID); err != nil {
+		return err
+	}
+
+	if err := s.rf.NewRBACRepository().AddNamedGroupingPolicy(domain.NewUserObject(appUserID), domain.NewAppUserGroupRole(appUserGroupID)); err != nil {
+		return liberrors.Errorf("failed to AddNamedGroupingPolicy. err: %w", err)
+	}
+
+	return nil
+}
+
+func (s *owner) RemoveGroupUser(ctx context.Context, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error {
+	if err := s.rf.NewGroupUserRepository().RemoveGroupUser(ctx, s, appUserGroupID, appUserID); err != nil {
+		return err
+	}
+
+	if err := s.rf.NewRBACRepository().RemoveNamedGroupingPolicy(domain.NewUserObject(appUserID), domain.NewAppUserGroupRole(appUserGroupID)); err != nil {
+		return liberrors.Errorf("failed to RemoveNamedGroupingPolicy. err: %w", err)
+	}
+
+	return nil
+}
@@ -12,5 +12,12 @@ type RBACRepository interface {
 
 	AddNamedGroupingPolicy(subject domain.RBACUser, object domain.RBACRole) error
 
+	RemoveNamedGroupingPolicy(subject domain.RBACUser, object domain.RBACRole) error
+
+	// RemoveRole removes the policies of the role, the grouping policies to the role and the grouping policies from the role
+	RemoveRole(role domain.RBACRole) error
+
+	// NewEnforcerWithRolesAndUsers returns the enforcer loaded with the policies of the roles, the users and the groups which the users belong to
+
 	NewEnforcerWithRolesAndUsers(roles []domain.RBACRole, users []domain.RBACUser) (*casbin.Enforcer, error)
 }
@@ -4,6 +4,8 @@ type RepositoryFactory interface {
 	NewOrganizationRepository() OrganizationRepository
 	NewSpaceRepository() SpaceRepository
 	NewAppUserRepository() AppUserRepository
+	NewAppUserPasswordRepository() AppUserPasswordRepository
+	NewGuestUserRepository() GuestUserRepository
 	NewAppUserGroupRepository() AppUserGroupRepository
 
 	NewGroupUserRepository() GroupUserRepository
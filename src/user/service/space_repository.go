@@ -4,12 +4,44 @@ import (
 	"context"
 	"errors"
 
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
 	"github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
 var ErrSpaceNotFound = errors.New("space not found")
 var ErrSpaceAlreadyExists = errors.New("space already exists")
 
+type SharedSpaceAddParameter interface {
+	GetKey() string
+	GetName() string
+	GetDescription() string
+}
+
+type sharedSpaceAddParameter struct {
+	Key         string `validate:"required,max=20"`
+	Name        string `validate:"required,max=20"`
+	Description string `validate:"max=40"`
+}
+
+func NewSharedSpaceAddParameter(key, name, description string) (SharedSpaceAddParameter, error) {
+	m := &sharedSpaceAddParameter{
+		Key:         key,
+		Name:        name,
+		Description: description,
+	}
+	return m, libD.Validator.Struct(m)
+}
+
+func (p *sharedSpaceAddParameter) GetKey() string {
+	return p.Key
+}
+func (p *sharedSpaceAddParameter) GetName() string {
+	return p.Name
+}
+func (p *sharedSpaceAddParameter) GetDescription() string {
+	return p.Description
+}
+
 type SpaceRepository interface {
 	FindDefaultSpace(ctx context.Context, operator domain.AppUserModel) (Space, error)
 
@@ -22,4 +54,11 @@ type SpaceRepository interface {
 	AddPersonalSpace(ctx context.Context, operator domain.SystemOwnerModel, appUser domain.AppUserModel) (domain.SpaceID, error)
 
 	AddSystemSpace(ctx context.Context, operator domain.SystemOwnerModel) (domain.SpaceID, error)
+
+	// FindSharedSpaces returns the shared spaces which the operator is a member of
+	FindSharedSpaces(ctx context.Context, operator domain.AppUserModel) ([]Space, error)
+
+	FindSharedSpaceByID(ctx context.Context, operator domain.AppUserModel, id domain.SpaceID) (Space, error)
+
+	AddSharedSpace(ctx context.Context, operator domain.AppUserModel, param SharedSpaceAddParameter) (domain.SpaceID, error)
 }
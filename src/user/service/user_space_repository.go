@@ -2,11 +2,33 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
+var ErrSpaceMemberNotFound = errors.New("space member not found")
+var ErrSpaceMemberAlreadyExists = errors.New("space member already exists")
+
+// SpaceMember is the member of the shared space
+type SpaceMember struct {
+	AppUserID domain.AppUserID
+	LoginID   string
+	Username  string
+	Role      domain.SpaceRole
+}
+
 // UserSpaceRepository mangages relationship between AppUser and Space
 type UserSpaceRepository interface {
 	Add(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID) error
+
+	FindSpaceMembers(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID) ([]*SpaceMember, error)
+
+	FindSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID) (*SpaceMember, error)
+
+	AddSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error
+
+	UpdateSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error
+
+	RemoveSpaceMember(ctx context.Context, operator domain.AppUserModel, spaceID domain.SpaceID, appUserID domain.AppUserID) error
 }
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type AppUserGroupUsecase interface {
+	FindAppUserGroups(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, pageNo, pageSize int) ([]service.AppUserGroup, int, error)
+
+	FindAppUserGroupByID(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID) (service.AppUserGroup, error)
+
+	AddAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param service.AppUserGroupAddParameter) (domain.AppUserGroupID, error)
+
+	UpdateAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, version int, param service.AppUserGroupUpdateParameter) error
+
+	RemoveAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID) error
+
+	FindGroupUsers(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*service.AppUserInfo, int, error)
+
+	AddGroupUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error
+
+	RemoveGroupUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error
+}
+
+type appUserGroupUsecase struct {
+	db         *gorm.DB
+	userRfFunc service.RepositoryFactoryFunc
+}
+
+func NewAppUserGroupUsecase(db *gorm.DB, userRfFunc service.RepositoryFactoryFunc) AppUserGroupUsecase {
+	return &appUserGroupUsecase{
+		db:         db,
+		userRfFunc: userRfFunc,
+	}
+}
+
+func (s *appUserGroupUsecase) FindAppUserGroups(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, pageNo, pageSize int) ([]service.AppUserGroup, int, error) {
+	var appUserGroups []service.AppUserGroup
+	var totalCount int
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpAppUserGroups, tmpTotalCount, err := owner.FindAppUserGroups(ctx, pageNo, pageSize)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserGroups. err: %w", err)
+		}
+
+		appUserGroups = tmpAppUserGroups
+		totalCount = tmpTotalCount
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return appUserGroups, totalCount, nil
+}
+
+func (s *appUserGroupUsecase) FindAppUserGroupByID(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID) (service.AppUserGroup, error) {
+	var appUserGroup service.AppUserGroup
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpAppUserGroup, err := owner.FindAppUserGroupByID(ctx, appUserGroupID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserGroupByID. err: %w", err)
+		}
+
+		appUserGroup = tmpAppUserGroup
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return appUserGroup, nil
+}
+
+func (s *appUserGroupUsecase) AddAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param service.AppUserGroupAddParameter) (domain.AppUserGroupID, error) {
+	logger := log.FromContext(ctx)
+
+	var appUserGroupID domain.AppUserGroupID
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpAppUserGroupID, err := owner.AddAppUserGroup(ctx, param)
+		if err != nil {
+			return liberrors.Errorf("failed to AddAppUserGroup. err: %w", err)
+		}
+
+		appUserGroupID = tmpAppUserGroupID
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	logger.Infof("app user group added. organizationID: %d, operatorID: %d, appUserGroupID: %d", organizationID, operatorID, appUserGroupID)
+	return appUserGroupID, nil
+}
+
+func (s *appUserGroupUsecase) UpdateAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, version int, param service.AppUserGroupUpdateParameter) error {
+	return s.updateAppUserGroup(ctx, organizationID, operatorID, appUserGroupID, func(owner service.Owner) error {
+		return owner.UpdateAppUserGroup(ctx, appUserGroupID, version, param)
+	})
+}
+
+func (s *appUserGroupUsecase) RemoveAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID) error {
+	logger := log.FromContext(ctx)
+
+	if err := s.updateAppUserGroup(ctx, organizationID, operatorID, appUserGroupID, func(owner service.Owner) error {
+		return owner.RemoveAppUserGroup(ctx, appUserGroupID)
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("app user group removed. organizationID: %d, operatorID: %d, appUserGroupID: %d", organizationID, operatorID, appUserGroupID)
+	return nil
+}
+
+func (s *appUserGroupUsecase) FindGroupUsers(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, pageNo, pageSize int) ([]*service.AppUserInfo, int, error) {
+	var appUsers []*service.AppUserInfo
+	var totalCount int
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := owner.FindAppUserGroupByID(ctx, appUserGroupID); err != nil {
+			return liberrors.Errorf("failed to FindAppUserGroupByID. err: %w", err)
+		}
+
+		tmpAppUsers, tmpTotalCount, err := owner.FindGroupUsers(ctx, appUserGroupID, pageNo, pageSize)
+		if err != nil {
+			return liberrors.Errorf("failed to FindGroupUsers. err: %w", err)
+		}
+
+		appUsers = tmpAppUsers
+		totalCount = tmpTotalCount
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return appUsers, totalCount, nil
+}
+
+func (s *appUserGroupUsecase) AddGroupUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error {
+	return s.updateAppUserGroup(ctx, organizationID, operatorID, appUserGroupID, func(owner service.Owner) error {
+		return owner.AddGroupUser(ctx, appUserGroupID, appUserID)
+	})
+}
+
+func (s *appUserGroupUsecase) RemoveGroupUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, appUserID domain.AppUserID) error {
+	return s.updateAppUserGroup(ctx, organizationID, operatorID, appUserGroupID, func(owner service.Owner) error {
+		return owner.RemoveGroupUser(ctx, appUserGroupID, appUserID)
+	})
+}
+
+// updateAppUserGroup rejects the operation on the public group because all the users belong to it
+func (s *appUserGroupUsecase) updateAppUserGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserGroupID domain.AppUserGroupID, fn func(owner service.Owner) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		appUserGroup, err := owner.FindAppUserGroupByID(ctx, appUserGroupID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserGroupByID. err: %w", err)
+		}
+		if appUserGroup.GetKey() == service.PublicGroupKey {
+			return liberrors.Errorf("the public group can't be changed. appUserGroupID: %d, err: %w", appUserGroupID, libD.ErrInvalidArgument)
+		}
+
+		return fn(owner)
+	})
+}
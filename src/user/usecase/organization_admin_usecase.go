@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+// the login IDs can't contain '#' which is the prefix of the login IDs of the removed users
+type OrganizationAddParameter struct {
+	Name               string `validate:"required,max=20"`
+	FirstOwnerLoginID  string `validate:"required,max=200,excludes=#"`
+	FirstOwnerUsername string `validate:"required,max=40"`
+	FirstOwnerPassword string `validate:"min=8,max=72"`
+}
+
+type AppUserInviteParameter struct {
+	LoginID  string `validate:"required,max=200,excludes=#"`
+	Username string `validate:"required,max=40"`
+}
+
+// AppUserInvitedCallback notifies the invited user of the way to set the password
+type AppUserInvitedCallback func(ctx context.Context, organizationName string, appUser domain.AppUserModel) error
+
+// AppUserSignedOutCallback revokes the tokens of the disabled or removed user
+type AppUserSignedOutCallback func(ctx context.Context, organizationID domain.OrganizationID, appUserID domain.AppUserID) error
+
+type OrganizationAdminUsecase interface {
+	// AddOrganization adds the organization and the first owner of it
+	AddOrganization(ctx context.Context, param *OrganizationAddParameter) (domain.OrganizationID, error)
+
+	FindAppUsers(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, pageNo, pageSize int) ([]*service.AppUserInfo, int, error)
+
+	FindAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) (*service.AppUserInfo, error)
+
+	// InviteAppUser adds the user without the password and notifies the user
+	InviteAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param *AppUserInviteParameter) (domain.AppUserID, error)
+
+	DisableAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error
+
+	EnableAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error
+
+	RemoveAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error
+}
+
+type organizationAdminUsecase struct {
+	db                       *gorm.DB
+	userRfFunc               service.RepositoryFactoryFunc
+	appUserInvitedCallback   AppUserInvitedCallback
+	appUserSignedOutCallback AppUserSignedOutCallback
+}
+
+func NewOrganizationAdminUsecase(db *gorm.DB, userRfFunc service.RepositoryFactoryFunc, appUserInvitedCallback AppUserInvitedCallback, appUserSignedOutCallback AppUserSignedOutCallback) OrganizationAdminUsecase {
+	return &organizationAdminUsecase{
+		db:                       db,
+		userRfFunc:               userRfFunc,
+		appUserInvitedCallback:   appUserInvitedCallback,
+		appUserSignedOutCallback: appUserSignedOutCallback,
+	}
+}
+
+func (s *organizationAdminUsecase) AddOrganization(ctx context.Context, param *OrganizationAddParameter) (domain.OrganizationID, error) {
+	logger := log.FromContext(ctx)
+
+	if err := libD.Validator.Struct(param); err != nil {
+		return 0, liberrors.Errorf("invalid OrganizationAddParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+	}
+
+	firstOwnerAddParam, err := service.NewFirstOwnerAddParameter(param.FirstOwnerLoginID, param.FirstOwnerUsername, param.FirstOwnerPassword)
+	if err != nil {
+		return 0, liberrors.Errorf("invalid FirstOwnerAddParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+	}
+
+	organizationAddParam, err := service.NewOrganizationAddParameter(param.Name, firstOwnerAddParam)
+	if err != nil {
+		return 0, liberrors.Errorf("invalid OrganizationAddParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+	}
+
+	var organizationID domain.OrganizationID
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		systemAdmin, err := service.NewSystemAdminFromDB(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		tmpOrganizationID, err := systemAdmin.AddOrganization(ctx, organizationAddParam)
+		if err != nil {
+			return liberrors.Errorf("failed to AddOrganization. err: %w", err)
+		}
+
+		organizationID = tmpOrganizationID
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	logger.Infof("organization added. organizationID: %d, name: %s", organizationID, param.Name)
+	return organizationID, nil
+}
+
+func (s *organizationAdminUsecase) FindAppUsers(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, pageNo, pageSize int) ([]*service.AppUserInfo, int, error) {
+	var appUsers []*service.AppUserInfo
+	var totalCount int
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpAppUsers, tmpTotalCount, err := owner.FindAppUserInfos(ctx, pageNo, pageSize)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserInfos. err: %w", err)
+		}
+
+		appUsers = tmpAppUsers
+		totalCount = tmpTotalCount
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	return appUsers, totalCount, nil
+}
+
+func (s *organizationAdminUsecase) FindAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) (*service.AppUserInfo, error) {
+	var appUser *service.AppUserInfo
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpAppUser, err := owner.FindAppUserInfo(ctx, appUserID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserInfo. err: %w", err)
+		}
+
+		appUser = tmpAppUser
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return appUser, nil
+}
+
+func (s *organizationAdminUsecase) InviteAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param *AppUserInviteParameter) (domain.AppUserID, error) {
+	logger := log.FromContext(ctx)
+
+	if err := libD.Validator.Struct(param); err != nil {
+		return 0, liberrors.Errorf("invalid AppUserInviteParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+	}
+
+	var appUser service.AppUser
+	var organization service.Organization
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		systemOwner, _, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		appUserAddParam, err := service.NewAppUserAddParameter(param.LoginID, param.Username, []string{domain.UserRole.GetName()}, map[string]string{})
+		if err != nil {
+			return liberrors.Errorf("invalid AppUserAddParameter. err: %v, %w", err, libD.ErrInvalidArgument)
+		}
+
+		// the personal space and the public group are given in the same way as the users who sign up
+		appUserID, err := systemOwner.AddAppUser(ctx, appUserAddParam)
+		if err != nil {
+			return liberrors.Errorf("failed to AddAppUser. err: %w", err)
+		}
+
+		tmpAppUser, err := systemOwner.FindAppUserByID(ctx, appUserID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+		}
+
+		tmpOrganization, err := systemOwner.GetOrganization(ctx)
+		if err != nil {
+			return liberrors.Errorf("failed to GetOrganization. err: %w", err)
+		}
+
+		appUser = tmpAppUser
+		organization = tmpOrganization
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	appUserID := domain.AppUserID(appUser.GetID())
+	logger.Infof("app user invited. organizationID: %d, operatorID: %d, appUserID: %d", organizationID, operatorID, appUserID)
+
+	if err := s.appUserInvitedCallback(ctx, organization.GetName(), appUser); err != nil {
+		return 0, liberrors.Errorf("failed to appUserInvitedCallback. err: %w", err)
+	}
+	return appUserID, nil
+}
+
+func (s *organizationAdminUsecase) DisableAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error {
+	return s.updateAppUser(ctx, organizationID, operatorID, appUserID, "disabled", true, func(owner service.Owner) error {
+		return owner.DisableAppUser(ctx, appUserID)
+	})
+}
+
+func (s *organizationAdminUsecase) EnableAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error {
+	return s.updateAppUser(ctx, organizationID, operatorID, appUserID, "enabled", false, func(owner service.Owner) error {
+		return owner.EnableAppUser(ctx, appUserID)
+	})
+}
+
+func (s *organizationAdminUsecase) RemoveAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID) error {
+	return s.updateAppUser(ctx, organizationID, operatorID, appUserID, "removed", true, func(owner service.Owner) error {
+		return owner.RemoveAppUser(ctx, appUserID)
+	})
+}
+
+// updateAppUser rejects the operation on the operator so that the organization doesn't lose the owner
+func (s *organizationAdminUsecase) updateAppUser(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, appUserID domain.AppUserID, operation string, revokeTokens bool, fn func(owner service.Owner) error) error {
+	logger := log.FromContext(ctx)
+
+	if appUserID == operatorID {
+		return liberrors.Errorf("the operator can't be %s. appUserID: %d, err: %w", operation, appUserID, libD.ErrInvalidArgument)
+	}
+
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		_, owner, err := findOwner(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		return fn(owner)
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("app user %s. organizationID: %d, operatorID: %d, appUserID: %d", operation, organizationID, operatorID, appUserID)
+
+	if !revokeTokens {
+		return nil
+	}
+	if err := s.appUserSignedOutCallback(ctx, organizationID, appUserID); err != nil {
+		return liberrors.Errorf("failed to appUserSignedOutCallback. err: %w", err)
+	}
+	return nil
+}
+
+// findOwner returns the system owner and the operator. The operator must have the owner role
+func findOwner(ctx context.Context, userRfFunc service.RepositoryFactoryFunc, tx *gorm.DB, organizationID domain.OrganizationID, operatorID domain.AppUserID) (service.SystemOwner, service.Owner, error) {
+	userRf, err := userRfFunc(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	systemAdmin := service.NewSystemAdmin(userRf)
+	systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+	}
+
+	operator, err := systemOwner.FindAppUserByID(ctx, operatorID)
+	if err != nil {
+		return nil, nil, liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+	}
+
+	// the role in the access token may be stale
+	for _, role := range operator.GetRoles() {
+		if role == domain.OwnerRole.GetName() {
+			return systemOwner, service.NewOwner(userRf, operator), nil
+		}
+	}
+	return nil, nil, liberrors.Errorf("the operator isn't the owner. operatorID: %d, err: %w", operatorID, service.ErrPermissionDenied)
+}
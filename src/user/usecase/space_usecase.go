@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	liberrors "github.com/kujilabo/cocotola-api/src/lib/errors"
+	"github.com/kujilabo/cocotola-api/src/lib/log"
+	"github.com/kujilabo/cocotola-api/src/user/domain"
+	"github.com/kujilabo/cocotola-api/src/user/service"
+)
+
+type SpaceUsecase interface {
+	// FindSharedSpaces returns the shared spaces which the operator is a member of
+	FindSharedSpaces(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID) ([]service.Space, error)
+
+	FindSharedSpaceByID(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID) (service.Space, error)
+
+	// AddSharedSpace adds the shared space. The operator becomes the admin of it
+	AddSharedSpace(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param service.SharedSpaceAddParameter) (domain.SpaceID, error)
+
+	FindSpaceMembers(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID) ([]*service.SpaceMember, error)
+
+	AddSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error
+
+	UpdateSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error
+
+	RemoveSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID) error
+
+	// SetSpaceGroupRole shares the shared space with all the users of the group
+	SetSpaceGroupRole(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID, role domain.SpaceRole) error
+
+	RemoveSpaceGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID) error
+}
+
+type spaceUsecase struct {
+	db         *gorm.DB
+	userRfFunc service.RepositoryFactoryFunc
+}
+
+func NewSpaceUsecase(db *gorm.DB, userRfFunc service.RepositoryFactoryFunc) SpaceUsecase {
+	return &spaceUsecase{
+		db:         db,
+		userRfFunc: userRfFunc,
+	}
+}
+
+func (s *spaceUsecase) FindSharedSpaces(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID) ([]service.Space, error) {
+	var spaces []service.Space
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		operator, err := findAppUser(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpSpaces, err := operator.FindSharedSpaces(ctx)
+		if err != nil {
+			return liberrors.Errorf("failed to FindSharedSpaces. err: %w", err)
+		}
+
+		spaces = tmpSpaces
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return spaces, nil
+}
+
+func (s *spaceUsecase) FindSharedSpaceByID(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID) (service.Space, error) {
+	var space service.Space
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		operator, err := findAppUser(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpSpace, err := operator.FindSharedSpaceByID(ctx, spaceID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindSharedSpaceByID. err: %w", err)
+		}
+
+		space = tmpSpace
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return space, nil
+}
+
+func (s *spaceUsecase) AddSharedSpace(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, param service.SharedSpaceAddParameter) (domain.SpaceID, error) {
+	logger := log.FromContext(ctx)
+
+	var spaceID domain.SpaceID
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		operator, err := findAppUser(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		tmpSpaceID, err := operator.AddSharedSpace(ctx, param)
+		if err != nil {
+			return liberrors.Errorf("failed to AddSharedSpace. err: %w", err)
+		}
+
+		spaceID = tmpSpaceID
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	logger.Infof("shared space added. organizationID: %d, operatorID: %d, spaceID: %d", organizationID, operatorID, spaceID)
+	return spaceID, nil
+}
+
+func (s *spaceUsecase) FindSpaceMembers(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID) ([]*service.SpaceMember, error) {
+	var members []*service.SpaceMember
+	if err := s.db.Transaction(func(tx *gorm.DB) error {
+		operator, err := findAppUser(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := operator.FindSharedSpaceByID(ctx, spaceID); err != nil {
+			return liberrors.Errorf("failed to FindSharedSpaceByID. err: %w", err)
+		}
+
+		tmpMembers, err := operator.FindSpaceMembers(ctx, spaceID)
+		if err != nil {
+			return liberrors.Errorf("failed to FindSpaceMembers. err: %w", err)
+		}
+
+		members = tmpMembers
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (s *spaceUsecase) AddSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	return s.updateSpaceMember(ctx, organizationID, operatorID, spaceID, appUserID, "added", func(operator service.AppUser) error {
+		return operator.AddSpaceMember(ctx, spaceID, appUserID, role)
+	})
+}
+
+func (s *spaceUsecase) UpdateSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID, role domain.SpaceRole) error {
+	return s.updateSpaceMember(ctx, organizationID, operatorID, spaceID, appUserID, "updated", func(operator service.AppUser) error {
+		return operator.UpdateSpaceMember(ctx, spaceID, appUserID, role)
+	})
+}
+
+func (s *spaceUsecase) RemoveSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID) error {
+	return s.updateSpaceMember(ctx, organizationID, operatorID, spaceID, appUserID, "removed", func(operator service.AppUser) error {
+		return operator.RemoveSpaceMember(ctx, spaceID, appUserID)
+	})
+}
+
+func (s *spaceUsecase) SetSpaceGroupRole(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID, role domain.SpaceRole) error {
+	logger := log.FromContext(ctx)
+
+	if err := s.updateSharedSpace(ctx, organizationID, operatorID, spaceID, func(operator service.AppUser) error {
+		return operator.SetSpaceGroupRole(ctx, spaceID, appUserGroupID, role)
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("space group set. organizationID: %d, operatorID: %d, spaceID: %d, appUserGroupID: %d, role: %s", organizationID, operatorID, spaceID, appUserGroupID, role)
+	return nil
+}
+
+func (s *spaceUsecase) RemoveSpaceGroup(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserGroupID domain.AppUserGroupID) error {
+	logger := log.FromContext(ctx)
+
+	if err := s.updateSharedSpace(ctx, organizationID, operatorID, spaceID, func(operator service.AppUser) error {
+		return operator.RemoveSpaceGroup(ctx, spaceID, appUserGroupID)
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("space group removed. organizationID: %d, operatorID: %d, spaceID: %d, appUserGroupID: %d", organizationID, operatorID, spaceID, appUserGroupID)
+	return nil
+}
+
+func (s *spaceUsecase) updateSpaceMember(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, appUserID domain.AppUserID, operation string, fn func(operator service.AppUser) error) error {
+	logger := log.FromContext(ctx)
+
+	if err := s.updateSharedSpace(ctx, organizationID, operatorID, spaceID, fn); err != nil {
+		return err
+	}
+
+	logger.Infof("space member %s. organizationID: %d, operatorID: %d, spaceID: %d, appUserID: %d", operation, organizationID, operatorID, spaceID, appUserID)
+	return nil
+}
+
+// updateSharedSpace accepts only the shared spaces. The members and the groups of the personal spaces can't be changed
+func (s *spaceUsecase) updateSharedSpace(ctx context.Context, organizationID domain.OrganizationID, operatorID domain.AppUserID, spaceID domain.SpaceID, fn func(operator service.AppUser) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		operator, err := findAppUser(ctx, s.userRfFunc, tx, organizationID, operatorID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := operator.FindSharedSpaceByID(ctx, spaceID); err != nil {
+			return liberrors.Errorf("failed to FindSharedSpaceByID. err: %w", err)
+		}
+
+		return fn(operator)
+	})
+}
+
+func findAppUser(ctx context.Context, userRfFunc service.RepositoryFactoryFunc, tx *gorm.DB, organizationID domain.OrganizationID, operatorID domain.AppUserID) (service.AppUser, error) {
+	userRf, err := userRfFunc(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	systemAdmin := service.NewSystemAdmin(userRf)
+	systemOwner, err := systemAdmin.FindSystemOwnerByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindSystemOwnerByOrganizationID. err: %w", err)
+	}
+
+	operator, err := systemOwner.FindAppUserByID(ctx, operatorID)
+	if err != nil {
+		return nil, liberrors.Errorf("failed to FindAppUserByID. err: %w", err)
+	}
+	return operator, nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+type translationFixture struct {
+	Text       string    `yaml:"text"`
+	Pos        int       `yaml:"pos"`
+	Lang2      string    `yaml:"lang2"`
+	Translated string    `yaml:"translated"`
+	Provider   string    `yaml:"provider"`
+	UpdatedAt  time.Time `yaml:"updatedAt"`
+}
+
+type audioFixture struct {
+	Lang2   string `yaml:"lang2"`
+	Text    string `yaml:"text"`
+	Content string `yaml:"content"`
+}
+
+type tatoebaSentenceFixture struct {
+	SentenceNumber int
+	Lang2          string
+	Text           string
+	Author         string
+	UpdatedAt      time.Time
+}
+
+type tatoebaLinkFixture struct {
+	From int
+	To   int
+}
+
+// loadTranslations reads `translations.yml`
+func loadTranslations(dir string) ([]translationFixture, error) {
+	translations := make([]translationFixture, 0)
+	if err := loadYAML(filepath.Join(dir, "translations.yml"), &translations); err != nil {
+		return nil, err
+	}
+
+	for i := range translations {
+		if translations[i].Lang2 == "" {
+			translations[i].Lang2 = "ja"
+		}
+		if translations[i].Provider == "" {
+			translations[i].Provider = "fake"
+		}
+	}
+	return translations, nil
+}
+
+// loadAudios reads `audios.yml`. The content is the base64 encoded audio
+func loadAudios(dir string) ([]audioFixture, error) {
+	audios := make([]audioFixture, 0)
+	if err := loadYAML(filepath.Join(dir, "audios.yml"), &audios); err != nil {
+		return nil, err
+	}
+	return audios, nil
+}
+
+// loadTatoebaSentences reads `tatoeba_sentences.csv` whose columns are sentenceNumber, lang2, text and author
+func loadTatoebaSentences(dir string) ([]tatoebaSentenceFixture, error) {
+	records, err := loadCSV(filepath.Join(dir, "tatoeba_sentences.csv"), 4)
+	if err != nil {
+		return nil, err
+	}
+
+	sentences := make([]tatoebaSentenceFixture, len(records))
+	for i, record := range records {
+		sentenceNumber, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid sentence number. line: %d, err: %w", i+2, err)
+		}
+		sentences[i] = tatoebaSentenceFixture{
+			SentenceNumber: sentenceNumber,
+			Lang2:          record[1],
+			Text:           record[2],
+			Author:         record[3],
+			UpdatedAt:      time.Now(),
+		}
+	}
+	return sentences, nil
+}
+
+// loadTatoebaLinks reads `tatoeba_links.csv` whose columns are the sentence numbers of the source and the destination
+func loadTatoebaLinks(dir string) ([]tatoebaLinkFixture, error) {
+	records, err := loadCSV(filepath.Join(dir, "tatoeba_links.csv"), 2)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]tatoebaLinkFixture, len(records))
+	for i, record := range records {
+		from, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid sentence number. line: %d, err: %w", i+2, err)
+		}
+		to, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid sentence number. line: %d, err: %w", i+2, err)
+		}
+		links[i] = tatoebaLinkFixture{From: from, To: to}
+	}
+	return links, nil
+}
+
+// loadYAML does nothing if the file doesn't exist
+func loadYAML(filePath string, out interface{}) error {
+	bytes, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(bytes, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s. err: %w", filePath, err)
+	}
+	return nil
+}
+
+// loadCSV returns the records except the header. It returns no records if the file doesn't exist
+func loadCSV(filePath string, numColumns int) ([][]string, error) {
+	file, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return [][]string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = numColumns
+	records := make([][]string, 0)
+	isHeader := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s. err: %w", filePath, err)
+		}
+		if isHeader {
+			isHeader = false
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
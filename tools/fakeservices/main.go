@@ -0,0 +1,168 @@
+// fakeservices runs the fake translator(gRPC and HTTP), tatoeba and synthesizer servers backed by the fixtures,
+// so that cocotola-api runs without the external services.
+//
+//	go run ./tools/fakeservices -env local -fixtures tools/fakeservices/fixtures
+//
+// The addresses and the credentials are read from the config of cocotola-api.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v2"
+
+	"github.com/kujilabo/cocotola-api/src/app/config"
+	libD "github.com/kujilabo/cocotola-api/src/lib/domain"
+)
+
+const shutdownTimeSec = 5
+
+type fakeServicesConfig struct {
+	Translator  *config.TranslatorConfig  `yaml:"translator" validate:"required"`
+	Tatoeba     *config.TatoebaConfig     `yaml:"tatoeba" validate:"required"`
+	Synthesizer *config.SynthesizerConfig `yaml:"synthesizer" validate:"required"`
+}
+
+// loadConfig reads only the configs of the external services, because config.LoadConfig requires the secrets of cocotola-api
+func loadConfig(env string) (*fakeServicesConfig, error) {
+	confContent, err := os.ReadFile("./configs/" + env + ".yml")
+	if err != nil {
+		return nil, err
+	}
+
+	confContent = []byte(os.ExpandEnv(string(confContent)))
+	conf := &fakeServicesConfig{}
+	if err := yaml.Unmarshal(confContent, conf); err != nil {
+		return nil, err
+	}
+
+	if err := libD.Validator.Struct(conf); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+func main() {
+	env := flag.String("env", "local", "the config of cocotola-api")
+	fixtureDir := flag.String("fixtures", "tools/fakeservices/fixtures", "the directory of the fixtures")
+	defaultLang2 := flag.String("lang2", "ja", "the language of the translations which the HTTP translator returns")
+	flag.Parse()
+
+	cfg, err := loadConfig(*env)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	translations, err := loadTranslations(*fixtureDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sentences, err := loadTatoebaSentences(*fixtureDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	links, err := loadTatoebaLinks(*fixtureDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	audios, err := loadAudios(*fixtureDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("translations: %d, sentences: %d, links: %d, audios: %d", len(translations), len(sentences), len(links), len(audios))
+
+	gin.SetMode(gin.ReleaseMode)
+	translationStore := newTranslationStore(translations)
+
+	translatorRouter := gin.New()
+	translatorRouter.Use(gin.Recovery())
+	initTranslatorRouter(translatorRouter, translationStore, cfg.Translator.Username, cfg.Translator.Password, *defaultLang2)
+
+	tatoebaRouter := gin.New()
+	tatoebaRouter.Use(gin.Recovery())
+	initTatoebaRouter(tatoebaRouter, newTatoebaStore(sentences, links), cfg.Tatoeba.Username, cfg.Tatoeba.Password)
+
+	synthesizerRouter := gin.New()
+	synthesizerRouter.Use(gin.Recovery())
+	initSynthesizerRouter(synthesizerRouter, newAudioStore(audios), cfg.Synthesizer.Username, cfg.Synthesizer.Password)
+
+	httpServers := make([]*http.Server, 0)
+	for _, s := range []struct {
+		endpoint string
+		handler  http.Handler
+	}{
+		{endpoint: cfg.Translator.Endpoint, handler: translatorRouter},
+		{endpoint: cfg.Tatoeba.Endpoint, handler: tatoebaRouter},
+		{endpoint: cfg.Synthesizer.Endpoint, handler: synthesizerRouter},
+	} {
+		u, err := url.Parse(s.endpoint)
+		if err != nil {
+			log.Fatal(err)
+		}
+		httpServers = append(httpServers, &http.Server{
+			Addr:              ":" + u.Port(),
+			Handler:           s.handler,
+			ReadHeaderTimeout: time.Duration(cfg.Translator.TimeoutSec) * time.Second,
+		})
+	}
+
+	grpcServer := newTranslatorGRPCServer(translationStore, cfg.Translator.Username, cfg.Translator.Password)
+	_, grpcPort, err := net.SplitHostPort(cfg.Translator.GRPCAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, server := range httpServers {
+		server := server
+		eg.Go(func() error {
+			log.Printf("http server listening at %v", server.Addr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		})
+	}
+	eg.Go(func() error {
+		log.Printf("grpc server listening at %v", listener.Addr())
+		return grpcServer.Serve(listener)
+	})
+	eg.Go(func() error {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeSec*time.Second)
+		defer cancel()
+
+		grpcServer.GracefulStop()
+		for _, server := range httpServers {
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
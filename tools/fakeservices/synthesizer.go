@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const silentAudioSampleRate = 8000
+
+// silentAudioContent is the base64 encoded WAV of the silence which is returned when the text is not in the fixtures
+var silentAudioContent = newSilentAudioContent(silentAudioSampleRate / 2)
+
+type audio struct {
+	ID      int    `json:"id"`
+	Lang2   string `json:"lang2"`
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// audioStore is an in-memory store of the synthesized audios
+type audioStore struct {
+	mu     sync.RWMutex
+	audios []audio
+}
+
+func newAudioStore(audios []audioFixture) *audioStore {
+	s := &audioStore{audios: make([]audio, 0, len(audios))}
+	for _, a := range audios {
+		s.add(a.Lang2, a.Text, a.Content)
+	}
+	return s
+}
+
+func (s *audioStore) findByID(id int) (audio, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if id < 1 || id > len(s.audios) {
+		return audio{}, false
+	}
+	return s.audios[id-1], true
+}
+
+// synthesize returns the audio of the text in the fixtures, or adds the silent audio
+func (s *audioStore) synthesize(lang2, text string) audio {
+	s.mu.RLock()
+	for _, a := range s.audios {
+		if a.Lang2 == lang2 && a.Text == text {
+			s.mu.RUnlock()
+			return a
+		}
+	}
+	s.mu.RUnlock()
+
+	return s.add(lang2, text, silentAudioContent)
+}
+
+func (s *audioStore) add(lang2, text, content string) audio {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := audio{ID: len(s.audios) + 1, Lang2: lang2, Text: text, Content: content}
+	s.audios = append(s.audios, a)
+	return a
+}
+
+func newSilentAudioContent(numSamples int) string {
+	data := make([]byte, numSamples*2)
+	buf := bytes.Buffer{}
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(36+len(data)))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(16))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1)) // mono
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(silentAudioSampleRate))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(silentAudioSampleRate*2))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(2))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(16))
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+type synthesizeParameter struct {
+	Lang2 string `json:"lang2" binding:"required"`
+	Text  string `json:"text" binding:"required"`
+}
+
+// initSynthesizerRouter registers the endpoints which synthesizerClient calls
+func initSynthesizerRouter(router *gin.Engine, store *audioStore, username, password string) {
+	authorized := router.Group("v1/user", gin.BasicAuth(gin.Accounts{username: password}))
+
+	authorized.POST("synthesize", func(c *gin.Context) {
+		param := synthesizeParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.JSON(http.StatusOK, store.synthesize(param.Lang2, param.Text))
+	})
+
+	authorized.GET("audio/:audioID", func(c *gin.Context) {
+		audioID, err := strconv.Atoi(c.Param("audioID"))
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		a, ok := store.findByID(audioID)
+		if !ok {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusOK, a)
+	})
+}
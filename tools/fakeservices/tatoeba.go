@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bufio"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var lang3ToLang2 = map[string]string{
+	"eng": "en",
+	"jpn": "ja",
+}
+
+// tatoebaStore is an in-memory store of the sentences and the links between them
+type tatoebaStore struct {
+	mu        sync.RWMutex
+	sentences map[int]tatoebaSentenceFixture
+	links     []tatoebaLinkFixture
+}
+
+func newTatoebaStore(sentences []tatoebaSentenceFixture, links []tatoebaLinkFixture) *tatoebaStore {
+	s := &tatoebaStore{
+		sentences: make(map[int]tatoebaSentenceFixture),
+		links:     links,
+	}
+	for _, sentence := range sentences {
+		s.sentences[sentence.SentenceNumber] = sentence
+	}
+	return s
+}
+
+func (s *tatoebaStore) findSentence(sentenceNumber int) (tatoebaSentenceFixture, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sentence, ok := s.sentences[sentenceNumber]
+	return sentence, ok
+}
+
+// findSentencePairs returns the pairs of the english sentences which contain `keyword` and the japanese sentences
+func (s *tatoebaStore) findSentencePairs(keyword string) [][2]tatoebaSentenceFixture {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	pairs := make([][2]tatoebaSentenceFixture, 0)
+	for _, link := range s.links {
+		src, ok := s.sentences[link.From]
+		if !ok || src.Lang2 != "en" || !strings.Contains(src.Text, keyword) {
+			continue
+		}
+		dst, ok := s.sentences[link.To]
+		if !ok || dst.Lang2 != "ja" {
+			continue
+		}
+		pairs = append(pairs, [2]tatoebaSentenceFixture{src, dst})
+	}
+	return pairs
+}
+
+func (s *tatoebaStore) addSentence(sentence tatoebaSentenceFixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sentences[sentence.SentenceNumber] = sentence
+}
+
+func (s *tatoebaStore) addLink(link tatoebaLinkFixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.links = append(s.links, link)
+}
+
+type tatoebaSentenceFindParameter struct {
+	PageNo   int    `json:"pageNo" binding:"required,gte=1"`
+	PageSize int    `json:"pageSize" binding:"required,gte=1"`
+	Keyword  string `json:"keyword"`
+	Random   bool   `json:"random"`
+}
+
+type tatoebaSentenceResponse struct {
+	SentenceNumber int       `json:"sentenceNumber"`
+	Lang2          string    `json:"lang2"`
+	Text           string    `json:"text"`
+	Author         string    `json:"author"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type tatoebaSentencePairResponse struct {
+	Src tatoebaSentenceResponse `json:"src"`
+	Dst tatoebaSentenceResponse `json:"dst"`
+}
+
+type tatoebaSentenceFindResponse struct {
+	TotalCount int64                         `json:"totalCount"`
+	Results    []tatoebaSentencePairResponse `json:"results"`
+}
+
+func toTatoebaSentenceResponse(s tatoebaSentenceFixture) tatoebaSentenceResponse {
+	return tatoebaSentenceResponse{
+		SentenceNumber: s.SentenceNumber,
+		Lang2:          s.Lang2,
+		Text:           s.Text,
+		Author:         s.Author,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+// importTatoebaFile reads the TSV file downloaded from tatoeba.
+// The file which has two columns is the links, otherwise it is the sentences
+func importTatoebaFile(store *tatoebaStore, scanner *bufio.Scanner) error {
+	for scanner.Scan() {
+		columns := strings.Split(scanner.Text(), "\t")
+		if len(columns) == 2 {
+			from, err := strconv.Atoi(columns[0])
+			if err != nil {
+				return err
+			}
+			to, err := strconv.Atoi(columns[1])
+			if err != nil {
+				return err
+			}
+			store.addLink(tatoebaLinkFixture{From: from, To: to})
+			continue
+		}
+
+		if len(columns) < 4 {
+			continue
+		}
+		lang2, ok := lang3ToLang2[columns[1]]
+		if !ok {
+			continue
+		}
+		sentenceNumber, err := strconv.Atoi(columns[0])
+		if err != nil {
+			return err
+		}
+		store.addSentence(tatoebaSentenceFixture{
+			SentenceNumber: sentenceNumber,
+			Lang2:          lang2,
+			Text:           columns[2],
+			Author:         columns[3],
+			UpdatedAt:      time.Now(),
+		})
+	}
+	return scanner.Err()
+}
+
+// initTatoebaRouter registers the endpoints which tatoebaClient calls
+func initTatoebaRouter(router *gin.Engine, store *tatoebaStore, username, password string) {
+	authorized := router.Group("v1", gin.BasicAuth(gin.Accounts{username: password}))
+
+	authorized.POST("user/sentence_pair/find", func(c *gin.Context) {
+		param := tatoebaSentenceFindParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		pairs := store.findSentencePairs(param.Keyword)
+		if param.Random {
+			rand.Shuffle(len(pairs), func(i, j int) { pairs[i], pairs[j] = pairs[j], pairs[i] })
+		}
+
+		results := make([]tatoebaSentencePairResponse, 0)
+		for i := (param.PageNo - 1) * param.PageSize; i < len(pairs) && len(results) < param.PageSize; i++ {
+			results = append(results, tatoebaSentencePairResponse{
+				Src: toTatoebaSentenceResponse(pairs[i][0]),
+				Dst: toTatoebaSentenceResponse(pairs[i][1]),
+			})
+		}
+
+		c.JSON(http.StatusOK, tatoebaSentenceFindResponse{
+			TotalCount: int64(len(pairs)),
+			Results:    results,
+		})
+	})
+
+	authorized.GET("user/sentence/:sentenceNumber", func(c *gin.Context) {
+		sentenceNumber, err := strconv.Atoi(c.Param("sentenceNumber"))
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		sentence, ok := store.findSentence(sentenceNumber)
+		if !ok {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.JSON(http.StatusOK, toTatoebaSentenceResponse(sentence))
+	})
+
+	authorized.POST("admin/sentence/import", func(c *gin.Context) {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		multipartFile, err := file.Open()
+		if err != nil {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		defer multipartFile.Close()
+
+		if err := importTatoebaFile(store, bufio.NewScanner(multipartFile)); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+}
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcMetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/kujilabo/cocotola-api/src/proto"
+)
+
+var (
+	errTranslationNotFound      = errors.New("translation not found")
+	errTranslationAlreadyExists = errors.New("translation already exists")
+)
+
+// translationStore is an in-memory dictionary shared by the gRPC and the HTTP translators
+type translationStore struct {
+	mu           sync.RWMutex
+	translations []translationFixture
+}
+
+func newTranslationStore(translations []translationFixture) *translationStore {
+	return &translationStore{translations: translations}
+}
+
+func (s *translationStore) find(match func(t *translationFixture) bool) []translationFixture {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := make([]translationFixture, 0)
+	for i := range s.translations {
+		if match(&s.translations[i]) {
+			results = append(results, s.translations[i])
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Text != results[j].Text {
+			return results[i].Text < results[j].Text
+		}
+		return results[i].Pos < results[j].Pos
+	})
+	return results
+}
+
+func (s *translationStore) findByText(lang2, text string) []translationFixture {
+	return s.find(func(t *translationFixture) bool {
+		return t.Lang2 == lang2 && t.Text == text
+	})
+}
+
+func (s *translationStore) findByTextAndPos(lang2, text string, pos int) (translationFixture, error) {
+	results := s.find(func(t *translationFixture) bool {
+		return t.Lang2 == lang2 && t.Text == text && t.Pos == pos
+	})
+	if len(results) == 0 {
+		return translationFixture{}, errTranslationNotFound
+	}
+	return results[0], nil
+}
+
+func (s *translationStore) findByFirstLetter(lang2, letter string) []translationFixture {
+	return s.find(func(t *translationFixture) bool {
+		return t.Lang2 == lang2 && strings.HasPrefix(t.Text, letter)
+	})
+}
+
+func (s *translationStore) add(translation translationFixture) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.translations {
+		if t.Lang2 == translation.Lang2 && t.Text == translation.Text && t.Pos == translation.Pos {
+			return errTranslationAlreadyExists
+		}
+	}
+	translation.UpdatedAt = time.Now()
+	s.translations = append(s.translations, translation)
+	return nil
+}
+
+func (s *translationStore) update(lang2, text string, pos int, translated string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.translations {
+		if t.Lang2 == lang2 && t.Text == text && t.Pos == pos {
+			s.translations[i].Translated = translated
+			s.translations[i].Provider = "custom"
+			s.translations[i].UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return errTranslationNotFound
+}
+
+func (s *translationStore) remove(lang2, text string, pos int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, t := range s.translations {
+		if t.Lang2 == lang2 && t.Text == text && t.Pos == pos {
+			s.translations = append(s.translations[:i], s.translations[i+1:]...)
+			return nil
+		}
+	}
+	return errTranslationNotFound
+}
+
+func toDictionaryResponse(t translationFixture) *pb.DictionaryResponse {
+	return &pb.DictionaryResponse{
+		Lang2:      t.Lang2,
+		Text:       t.Text,
+		Pos:        int32(t.Pos),
+		Translated: t.Translated,
+		Provider:   t.Provider,
+	}
+}
+
+func toTranslationResponse(t translationFixture) *pb.TranslationResponse {
+	return &pb.TranslationResponse{
+		Lang2:      t.Lang2,
+		Text:       t.Text,
+		Pos:        int32(t.Pos),
+		Translated: t.Translated,
+		Provider:   t.Provider,
+	}
+}
+
+func toGRPCError(err error) error {
+	if errors.Is(err, errTranslationNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	} else if errors.Is(err, errTranslationAlreadyExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// grpcAuthInterceptor checks the credentials which translatorGRPCClient appends to the metadata.
+// Both the username and password pair and the basic authorization header are accepted
+func grpcAuthInterceptor(username, password string) grpc.UnaryServerInterceptor {
+	basicAuth := "basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := grpcMetadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "metadata not found")
+		}
+
+		if authorizations := md.Get("authorization"); len(authorizations) > 0 && authorizations[0] == basicAuth {
+			return handler(ctx, req)
+		}
+
+		usernames := md.Get("username")
+		passwords := md.Get("password")
+		if len(usernames) == 0 || len(passwords) == 0 || usernames[0] != username || passwords[0] != password {
+			return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+type translatorUserServer struct {
+	pb.UnimplementedTranslatorUserServer
+	store *translationStore
+}
+
+func (s *translatorUserServer) DictionaryLookup(ctx context.Context, in *pb.DictionaryLookupParameter) (*pb.DictionaryLookupResponses, error) {
+	translations := s.store.findByText(in.ToLang2, in.Text)
+	results := make([]*pb.DictionaryResponse, len(translations))
+	for i, t := range translations {
+		results[i] = toDictionaryResponse(t)
+	}
+	return &pb.DictionaryLookupResponses{Results: results}, nil
+}
+
+func (s *translatorUserServer) DictionaryLookupWithPos(ctx context.Context, in *pb.DictionaryLookupWithPosParameter) (*pb.DictionaryLookupResponse, error) {
+	translation, err := s.store.findByTextAndPos(in.ToLang2, in.Text, int(in.Pos))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &pb.DictionaryLookupResponse{Result: toDictionaryResponse(translation)}, nil
+}
+
+type translatorAdminServer struct {
+	pb.UnimplementedTranslatorAdminServer
+	store *translationStore
+}
+
+func (s *translatorAdminServer) FindTranslationsByFirstLetter(ctx context.Context, in *pb.TranslationFindParameter) (*pb.TranslationFindResposne, error) {
+	return s.toFindResponse(s.store.findByFirstLetter(in.Lang2, in.Letter)), nil
+}
+
+func (s *translatorAdminServer) FindTranslationByTextAndPos(ctx context.Context, in *pb.TranslationFindByTextAndPosParameter) (*pb.TranslationResponse, error) {
+	translation, err := s.store.findByTextAndPos(in.Lang2, in.Text, int(in.Pos))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toTranslationResponse(translation), nil
+}
+
+func (s *translatorAdminServer) FindTranslationsByText(ctx context.Context, in *pb.TranslationFindByTextParameter) (*pb.TranslationFindResposne, error) {
+	return s.toFindResponse(s.store.findByText(in.Lang2, in.Text)), nil
+}
+
+func (s *translatorAdminServer) AddTranslation(ctx context.Context, in *pb.TranslationAddParameter) (*pb.TranslationAddResponse, error) {
+	if err := s.store.add(translationFixture{Lang2: in.Lang2, Text: in.Text, Pos: int(in.Pos), Translated: in.Translated, Provider: "custom"}); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &pb.TranslationAddResponse{}, nil
+}
+
+func (s *translatorAdminServer) UpdateTranslation(ctx context.Context, in *pb.TranslationUpdateParameter) (*pb.TranslationAddResponse, error) {
+	if err := s.store.update(in.Lang2, in.Text, int(in.Pos), in.Translated); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &pb.TranslationAddResponse{}, nil
+}
+
+func (s *translatorAdminServer) RemoveTranslation(ctx context.Context, in *pb.TranslationRemoveParameter) (*pb.TranslationRemoveResponse, error) {
+	if err := s.store.remove(in.Lang2, in.Text, int(in.Pos)); err != nil {
+		return nil, toGRPCError(err)
+	}
+	return &pb.TranslationRemoveResponse{}, nil
+}
+
+func (s *translatorAdminServer) toFindResponse(translations []translationFixture) *pb.TranslationFindResposne {
+	results := make([]*pb.TranslationResponse, len(translations))
+	for i, t := range translations {
+		results[i] = toTranslationResponse(t)
+	}
+	return &pb.TranslationFindResposne{Results: results}
+}
+
+func newTranslatorGRPCServer(store *translationStore, username, password string) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcAuthInterceptor(username, password)))
+	pb.RegisterTranslatorUserServer(server, &translatorUserServer{store: store})
+	pb.RegisterTranslatorAdminServer(server, &translatorAdminServer{store: store})
+	return server
+}
+
+type translationHTTPResponse struct {
+	Text       string    `json:"text"`
+	Pos        int       `json:"pos"`
+	Lang2      string    `json:"lang2"`
+	Translated string    `json:"translated"`
+	Provider   string    `json:"provider"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+type translationHTTPFindResponse struct {
+	Results []translationHTTPResponse `json:"results"`
+}
+
+type translationHTTPAddParameter struct {
+	Lang2      string `json:"lang2" binding:"required"`
+	Text       string `json:"text" binding:"required"`
+	Pos        int    `json:"pos" binding:"required"`
+	Translated string `json:"translated"`
+}
+
+type translationHTTPUpdateParameter struct {
+	Translated string `json:"translated" binding:"required"`
+}
+
+func toTranslationHTTPResponse(t translationFixture) translationHTTPResponse {
+	return translationHTTPResponse{
+		Text:       t.Text,
+		Pos:        t.Pos,
+		Lang2:      t.Lang2,
+		Translated: t.Translated,
+		Provider:   t.Provider,
+		UpdatedAt:  t.UpdatedAt,
+	}
+}
+
+func toTranslationHTTPFindResponse(translations []translationFixture) translationHTTPFindResponse {
+	results := make([]translationHTTPResponse, len(translations))
+	for i, t := range translations {
+		results[i] = toTranslationHTTPResponse(t)
+	}
+	return translationHTTPFindResponse{Results: results}
+}
+
+// initTranslatorRouter registers the endpoints which translatorHTTPClient calls.
+// translatorHTTPClient doesn't send the language of the translations, so `defaultLang2` is used unless `lang2` is specified in the query
+func initTranslatorRouter(router *gin.Engine, store *translationStore, username, password, defaultLang2 string) {
+	lang2 := func(c *gin.Context) string {
+		return c.DefaultQuery("lang2", defaultLang2)
+	}
+	pos := func(c *gin.Context) (int, bool) {
+		pos, err := strconv.Atoi(c.Param("pos"))
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return 0, false
+		}
+		return pos, true
+	}
+	errorHandle := func(c *gin.Context, err error) {
+		if errors.Is(err, errTranslationNotFound) {
+			c.Status(http.StatusNotFound)
+		} else if errors.Is(err, errTranslationAlreadyExists) {
+			c.Status(http.StatusConflict)
+		} else {
+			c.Status(http.StatusInternalServerError)
+		}
+	}
+
+	authorized := router.Group("", gin.BasicAuth(gin.Accounts{username: password}))
+
+	user := authorized.Group("v1/user/dictionary")
+	user.GET("lookup", func(c *gin.Context) {
+		text := c.Query("text")
+		if c.Query("pos") == "" {
+			c.JSON(http.StatusOK, toTranslationHTTPFindResponse(store.findByText(lang2(c), text)))
+			return
+		}
+
+		pos, err := strconv.Atoi(c.Query("pos"))
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		translation, err := store.findByTextAndPos(lang2(c), text, pos)
+		if err != nil {
+			errorHandle(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, toTranslationHTTPResponse(translation))
+	})
+
+	authorized.GET("find", func(c *gin.Context) {
+		param := map[string]string{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.JSON(http.StatusOK, toTranslationHTTPFindResponse(store.findByFirstLetter(lang2(c), param["letter"])))
+	})
+	authorized.GET("text/:text", func(c *gin.Context) {
+		c.JSON(http.StatusOK, toTranslationHTTPFindResponse(store.findByText(lang2(c), c.Param("text"))))
+	})
+	authorized.GET("text/:text/pos/:pos", func(c *gin.Context) {
+		pos, ok := pos(c)
+		if !ok {
+			return
+		}
+		translation, err := store.findByTextAndPos(lang2(c), c.Param("text"), pos)
+		if err != nil {
+			errorHandle(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, toTranslationHTTPResponse(translation))
+	})
+	authorized.POST("", func(c *gin.Context) {
+		param := translationHTTPAddParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		if err := store.add(translationFixture{Lang2: param.Lang2, Text: param.Text, Pos: param.Pos, Translated: param.Translated, Provider: "custom"}); err != nil {
+			errorHandle(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	authorized.PUT("text/:text/pos/:pos", func(c *gin.Context) {
+		pos, ok := pos(c)
+		if !ok {
+			return
+		}
+		param := translationHTTPUpdateParameter{}
+		if err := c.ShouldBindJSON(&param); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		if err := store.update(lang2(c), c.Param("text"), pos, param.Translated); err != nil {
+			errorHandle(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	authorized.DELETE("text/:text/pos/:pos", func(c *gin.Context) {
+		pos, ok := pos(c)
+		if !ok {
+			return
+		}
+		if err := store.remove(lang2(c), c.Param("text"), pos); err != nil {
+			errorHandle(c, err)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kujilabo/cocotola-api/src/plugin/english/domain"
+)
+
+// ngsl_migration prints the migration which tags the english words with the NGSL rank and the CEFR level in ngsl.csv.
+// Run it after ngsl.csv is changed and save the output to sqls/mysql and sqls/sqlite3.
+func main() {
+	for _, w := range domain.GetNGSLWords() {
+		text := strings.ReplaceAll(w.GetText(), "'", "''")
+		fmt.Printf("update `english_word_problem` set `ngsl_rank` = %d, `cefr_level` = '%s' where lower(`text`) = '%s';\n", w.GetRank(), w.GetCEFRLevel().String(), text)
+	}
+}
@@ -2,105 +2,222 @@ package main
 
 import (
 	"bytes"
-	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 
 	"github.com/kujilabo/cocotola-api/src/app/config"
 	authG "github.com/kujilabo/cocotola-api/src/auth/gateway"
-	userD "github.com/kujilabo/cocotola-api/src/user/domain"
 )
 
-var timeoutImportMin = 20
+var timeoutChunkMin = 5
+var maxRetries = 5
+
+type importJob struct {
+	ID             uint   `json:"id"`
+	FileSize       int64  `json:"fileSize"`
+	ReceivedSize   int64  `json:"receivedSize"`
+	ProcessedLines int    `json:"processedLines"`
+	Status         string `json:"status"`
+	ErrorMessage   string `json:"errorMessage"`
+}
+
+type importClient struct {
+	endpoint    string
+	accessToken string
+	client      http.Client
+}
 
 func main() {
-	ctx := context.Background()
+	endpoint := flag.String("endpoint", "http://localhost:8080/plugin/tatoeba/import/job", "endpoint of the import job")
+	kind := flag.String("kind", "sentence", "sentence or link")
+	filePath := flag.String("file", "../cocotola-data/datasource/tatoeba/eng_sentences_detailed.tsv", "tatoeba file")
+	chunkMB := flag.Int("chunkMB", 8, "size of a chunk")
+	jobID := flag.Uint("job", 0, "resume the job instead of creating a new one")
+	flag.Parse()
+
 	cfg, err := config.LoadConfig("local")
 	if err != nil {
 		panic(err)
 	}
 
-	signingKey := []byte(cfg.Auth.SigningKey)
-	signingMethod := jwt.SigningMethodHS256
-	authTokenManager := authG.NewAuthTokenManager(signingKey, signingMethod, time.Duration(cfg.Auth.AccessTokenTTLMin)*time.Minute, time.Duration(cfg.Auth.RefreshTokenTTLHour)*time.Hour)
-
-	modelU, err := userD.NewModel(1, 1, time.Now(), time.Now(), 1, 1)
+	// the import job only needs the access token, so the token is signed directly without issuing the refresh token
+	signingKey, err := authG.NewHMACSigningKey("", []byte(cfg.Auth.SigningKey), time.Time{}, time.Time{})
 	if err != nil {
 		panic(err)
 	}
-	appUserModel, err := userD.NewAppUserModel(modelU, userD.OrganizationID(1), "test", "Test", []string{"Owner"}, map[string]string{})
+	signingKeySet, err := authG.NewSigningKeySet([]*authG.SigningKey{signingKey})
 	if err != nil {
 		panic(err)
 	}
 
-	modelO, err := userD.NewModel(1, 1, time.Now(), time.Now(), 1, 1)
+	now := time.Now()
+	accessToken, err := signingKeySet.Sign(&authG.AppUserClaims{
+		LoginID:          "test",
+		AppUserID:        1,
+		Username:         "Test",
+		OrganizationID:   1,
+		OrganizationName: "Test",
+		Role:             "Owner",
+		TokenType:        "access",
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(time.Duration(cfg.Auth.AccessTokenTTLMin) * time.Minute).Unix(),
+		},
+	})
 	if err != nil {
 		panic(err)
 	}
-	organizationModel, err := userD.NewOrganizationModel(modelO, "Test")
-	if err != nil {
-		panic(err)
+
+	client := &importClient{
+		endpoint:    *endpoint,
+		accessToken: accessToken,
+		client: http.Client{
+			Timeout: time.Duration(timeoutChunkMin) * time.Minute,
+		},
 	}
-	tokenSet, err := authTokenManager.CreateTokenSet(ctx, appUserModel, organizationModel)
-	if err != nil {
+
+	if err := run(client, *kind, *filePath, int64(*chunkMB)*1024*1024, *jobID); err != nil {
 		panic(err)
 	}
+}
 
-	url := "http://localhost:8080/plugin/tatoeba/sentence/import"
-	fieldname := "file"
-	filename := "eng_sentences_detailed.tsv"
-
-	file, err := os.Open("../cocotola-data/datasource/tatoeba/" + filename)
+func run(client *importClient, kind, filePath string, chunkSize int64, jobID uint) error {
+	file, err := os.Open(filePath)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer file.Close()
 
-	body := bytes.Buffer{}
-
-	mw := multipart.NewWriter(&body)
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
 
-	fw, err := mw.CreateFormFile(fieldname, filename)
+	var job *importJob
+	if jobID == 0 {
+		job, err = client.startImport(kind, filepath.Base(filePath), stat.Size())
+	} else {
+		job, err = client.findJob(jobID)
+	}
 	if err != nil {
-		panic(err)
+		return err
+	}
+	fmt.Printf("job: %d\n", job.ID)
+
+	retries := 0
+	for job.Status == "uploading" && job.ReceivedSize < job.FileSize {
+		offset := job.ReceivedSize
+		size := chunkSize
+		if remaining := job.FileSize - offset; remaining < size {
+			size = remaining
+		}
+
+		next, err := client.uploadChunk(job.ID, offset, io.NewSectionReader(file, offset, size))
+		if err != nil {
+			retries++
+			if retries > maxRetries {
+				return err
+			}
+			fmt.Printf("failed to upload the chunk. retry from the received size. err: %v\n", err)
+			time.Sleep(time.Duration(retries) * time.Second)
+			if job, err = client.findJob(job.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		retries = 0
+		job = next
+		fmt.Printf("uploaded: %d / %d\n", job.ReceivedSize, job.FileSize)
 	}
 
-	if _, err := io.Copy(fw, file); err != nil {
-		panic(err)
+	if job.Status == "failed" {
+		fmt.Printf("resume the failed job. processedLines: %d\n", job.ProcessedLines)
+		if job, err = client.resumeJob(job.ID); err != nil {
+			return err
+		}
 	}
 
-	if err = mw.Close(); err != nil {
-		panic(err)
+	for job.Status == "processing" {
+		time.Sleep(5 * time.Second)
+		if job, err = client.findJob(job.ID); err != nil {
+			return err
+		}
+		fmt.Printf("processed lines: %d\n", job.ProcessedLines)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, url, &body)
+	fmt.Printf("status: %s\n", job.Status)
+	if job.Status != "completed" {
+		return fmt.Errorf("failed to import. message: %s", job.ErrorMessage)
+	}
+	return nil
+}
+
+func (c *importClient) startImport(kind, fileName string, fileSize int64) (*importJob, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"kind":     kind,
+		"fileName": fileName,
+		"fileSize": fileSize,
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+tokenSet.AccessToken)
-	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return c.do(http.MethodPost, c.endpoint, "application/json", bytes.NewReader(body))
+}
+
+func (c *importClient) uploadChunk(jobID uint, offset int64, reader io.Reader) (*importJob, error) {
+	url := c.endpoint + "/" + strconv.Itoa(int(jobID)) + "/chunk?offset=" + strconv.FormatInt(offset, 10)
+	return c.do(http.MethodPut, url, "application/octet-stream", reader)
+}
+
+func (c *importClient) findJob(jobID uint) (*importJob, error) {
+	return c.do(http.MethodGet, c.endpoint+"/"+strconv.Itoa(int(jobID)), "", nil)
+}
+
+func (c *importClient) resumeJob(jobID uint) (*importJob, error) {
+	return c.do(http.MethodPost, c.endpoint+"/"+strconv.Itoa(int(jobID))+"/resume", "", nil)
+}
+
+func (c *importClient) do(method, url, contentType string, body io.Reader) (*importJob, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
 
-	client := http.Client{
-		Timeout: time.Duration(timeoutImportMin) * time.Minute,
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
-	resp, err := client.Do(req)
+	resp, err := c.client.Do(req)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	fmt.Printf("status: %d\n", resp.StatusCode)
-	fmt.Printf("body: %s\n", string(respBody))
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("status: " + strconv.Itoa(resp.StatusCode) + ", body: " + string(respBody))
+	}
+
+	job := importJob{}
+	if err := json.Unmarshal(respBody, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
 }